@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/alnah/github-activity/internal/messages"
+	"github.com/alnah/github-activity/pkg/activity"
+	"github.com/alnah/github-activity/pkg/format"
+)
+
+// runSync handles `sync [-db=path] <username>`
+func (c *CLI) runSync(args []string) int {
+	flags, err := c.resolveFlags(append([]string{"github-activity sync"}, args...))
+	if err != nil {
+		return c.reportFlagsError(err)
+	}
+	if !c.hasUsername(flags) {
+		fmt.Println("Usage:")
+		fmt.Println("  github-activity sync [-db=path] <username>")
+		return 1
+	}
+	service, ok := c.service.(*activity.ActivityService)
+	if !ok {
+		fmt.Fprintln(os.Stderr, "Error: sync requires the activity service")
+		return 1
+	}
+	switch {
+	case flags.FromDB || flags.Offline:
+		fmt.Fprintln(os.Stderr, "Error: sync reads from GitHub and cannot use -from-db or -offline")
+		return 1
+	case flags.Received:
+		fmt.Fprintln(os.Stderr, "Error: the archive holds a user's own events and cannot store -received")
+		return 1
+	case flags.DB == "":
+		fmt.Fprintln(os.Stderr, "Error: no archive location; set -db")
+		return 1
+	}
+
+	// Archive everything the API still serves
+	flags.Limit = 0
+	run, err := c.setup(flags)
+	if err != nil {
+		return c.reportError(err)
+	}
+
+	archive := activity.NewEventArchive(flags.DB)
+	added, total, err := service.SyncArchive(run.username, archive)
+	if err != nil {
+		return c.reportError(err)
+	}
+	fmt.Printf("Archived %s for %s (%d in total) in %s.\n",
+		messages.Plural("new_events", added), run.username, total, archive.Path())
+	return 0
+}
+
+// runHistory handles `history [-since=date] [-until=date] [flags] <username>`,
+// listing archived events without contacting GitHub
+func (c *CLI) runHistory(args []string) int {
+	flags, err := c.resolveFlags(append([]string{"github-activity history"}, args...))
+	if err != nil {
+		return c.reportFlagsError(err)
+	}
+	if !c.hasUsername(flags) {
+		fmt.Println("Usage:")
+		fmt.Println("  github-activity history [-since=date] [-until=date] [flags] <username>")
+		return 1
+	}
+
+	flags.FromDB = true
+	// History is for long ranges; only an explicit -limit caps it
+	if !flags.explicit["limit"] {
+		flags.Limit = 0
+	}
+	run, err := c.setup(flags)
+	if err != nil {
+		return c.reportError(err)
+	}
+
+	if flags.Detailed || flags.Enrich || format.NeedsDetails(run.format) {
+		return c.displayDetailedActivities(run.username, run.filter)
+	}
+	return c.displayActivities(run.username, run.filter)
+}
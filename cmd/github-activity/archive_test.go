@@ -0,0 +1,133 @@
+package main
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/activity"
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// countingRepository counts FetchEvents calls per user
+type countingRepository struct {
+	events []github.GitHubEvent
+	err    error
+	calls  map[string]int
+}
+
+func (r *countingRepository) FetchEvents(username string) ([]github.GitHubEvent, error) {
+	if r.calls == nil {
+		r.calls = make(map[string]int)
+	}
+	r.calls[username]++
+	if r.err != nil {
+		return nil, r.err
+	}
+	return r.events, nil
+}
+
+func TestCLI_runSync(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "archive.json")
+	events := []github.GitHubEvent{
+		{ID: "1", Type: "WatchEvent", Repo: github.Repo{Name: "user/repo"}, CreatedAt: time.Now()},
+	}
+	next := &countingRepository{events: events}
+	cli := NewCLI(activity.NewActivityService(next))
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected int
+	}{
+		{"sync", []string{"github-activity", "sync", "-db=" + path, "octocat"}, 0},
+		{"sync again", []string{"github-activity", "sync", "-db=" + path, "octocat"}, 0},
+		{"sync from db", []string{"github-activity", "sync", "-db=" + path, "-from-db", "octocat"}, 1},
+		{"missing username", []string{"github-activity", "sync", "-db=" + path}, 1},
+		{"read archive", []string{"github-activity", "-db=" + path, "-from-db", "octocat"}, 0},
+		{"user not archived", []string{"github-activity", "-db=" + path, "-from-db", "someone"}, 1},
+		{"archive with session", []string{
+			"github-activity", "-db=" + path, "-from-db", "-session=" + path + ".session", "octocat",
+		}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := cli.Run(tt.args); code != tt.expected {
+				t.Errorf("Run() = %d, want %d", code, tt.expected)
+			}
+		})
+	}
+
+	if next.calls["octocat"] != 2 {
+		t.Errorf("Fetched octocat %d times, want 2 (once per sync)", next.calls["octocat"])
+	}
+	if archived, _, _ := activity.NewEventArchive(path).Events("octocat"); len(archived) != 1 {
+		t.Errorf("Archived %d events, want 1", len(archived))
+	}
+}
+
+func TestCLI_runHistory(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "archive.json")
+	archived := []github.GitHubEvent{
+		{ID: "1", Type: "WatchEvent", Repo: github.Repo{Name: "user/old"}, CreatedAt: time.Date(2023, 3, 10, 12, 0, 0, 0, time.UTC)},
+		{ID: "2", Type: "ForkEvent", Repo: github.Repo{Name: "user/new"}, CreatedAt: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)},
+	}
+	if _, _, err := activity.NewEventArchive(path).Append("octocat", archived, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected int
+		output   []string
+		excluded []string
+	}{
+		{
+			name:     "range",
+			args:     []string{"-since=2023-01-01", "-until=2023-12-31", "octocat"},
+			output:   []string{"user/old"},
+			excluded: []string{"user/new"},
+		},
+		{
+			name:     "type",
+			args:     []string{"-type=fork", "-format=csv", "octocat"},
+			output:   []string{"ForkEvent"},
+			excluded: []string{"WatchEvent"},
+		},
+		{name: "invalid since", args: []string{"-since=yesterday", "octocat"}, expected: 1},
+		{name: "not archived", args: []string{"someone"}, expected: 1},
+		{name: "missing username", expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// The API repository must never be reached
+			next := &countingRepository{err: errors.New("network used")}
+			cli := NewCLI(activity.NewActivityService(next))
+			args := append([]string{"github-activity", "history", "-db=" + path, "-tz=UTC"}, tt.args...)
+
+			var code int
+			output := captureStdout(t, func() { code = cli.Run(args) })
+			if code != tt.expected {
+				t.Errorf("Run() = %d, want %d", code, tt.expected)
+			}
+			for _, want := range tt.output {
+				if !strings.Contains(output, want) {
+					t.Errorf("Output missing %q:\n%s", want, output)
+				}
+			}
+			for _, unwanted := range tt.excluded {
+				if strings.Contains(output, unwanted) {
+					t.Errorf("Output should not contain %q:\n%s", unwanted, output)
+				}
+			}
+			if len(next.calls) != 0 {
+				t.Errorf("history fetched from the API: %v", next.calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/alnah/github-activity/internal/messages"
+)
+
+// Badge - The badge subcommand
+
+// Badge colors, as used by shields.io
+const (
+	badgeLabelColor  = "#555"
+	badgeActiveColor = "#4c1"
+	badgeIdleColor   = "#9f9f9f"
+)
+
+// badgeCharWidth approximates the width in pixels of one character of 11px
+// Verdana, enough to size a badge without font metrics
+const badgeCharWidth = 7
+
+// badgeMessage is the right-hand text of a badge counting events over days
+func badgeMessage(events, days int) string {
+	switch days {
+	case 1:
+		return messages.Plural("events", events) + " today"
+	case 7:
+		return messages.Plural("events", events) + " this week"
+	}
+	return fmt.Sprintf("%s in %d days", messages.Plural("events", events), days)
+}
+
+// renderBadge writes a flat SVG badge with label on grey and message on color
+func renderBadge(w io.Writer, label, message, color string) {
+	labelWidth := utf8.RuneCountInString(label)*badgeCharWidth + 10
+	messageWidth := utf8.RuneCountInString(message)*badgeCharWidth + 10
+	width := labelWidth + messageWidth
+	label, message = html.EscapeString(label), html.EscapeString(message)
+
+	lines := []string{
+		fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20" role="img" aria-label="%s: %s">`,
+			width, label, message),
+		fmt.Sprintf(`<title>%s: %s</title>`, label, message),
+		`<linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>`,
+		fmt.Sprintf(`<clipPath id="r"><rect width="%d" height="20" rx="3" fill="#fff"/></clipPath>`, width),
+		`<g clip-path="url(#r)">`,
+		fmt.Sprintf(`<rect width="%d" height="20" fill="%s"/>`, labelWidth, badgeLabelColor),
+		fmt.Sprintf(`<rect x="%d" width="%d" height="20" fill="%s"/>`, labelWidth, messageWidth, color),
+		fmt.Sprintf(`<rect width="%d" height="20" fill="url(#s)"/>`, width),
+		`</g>`,
+		`<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">`,
+	}
+	for _, text := range []struct {
+		x    int
+		text string
+	}{{labelWidth / 2, label}, {labelWidth + messageWidth/2, message}} {
+		// A dark copy one pixel lower gives the text its shadow
+		lines = append(lines,
+			fmt.Sprintf(`<text x="%d" y="15" fill="#010101" fill-opacity=".3">%s</text>`, text.x, text.text),
+			fmt.Sprintf(`<text x="%d" y="14">%s</text>`, text.x, text.text),
+		)
+	}
+	lines = append(lines, `</g>`, `</svg>`)
+	_, _ = fmt.Fprintln(w, strings.Join(lines, "\n"))
+}
+
+// runBadge handles `badge [-days=7] [-streak] <username>`
+func (c *CLI) runBadge(args []string) int {
+	flags, err := c.resolveFlags(append([]string{"github-activity badge"}, args...))
+	if err != nil {
+		return c.reportFlagsError(err)
+	}
+	if !c.hasUsername(flags) {
+		fmt.Println("Usage:")
+		fmt.Println("  github-activity badge [-days=7] [-streak] [-output=badge.svg] <username>")
+		return 1
+	}
+	if flags.Format != "" {
+		fmt.Fprintln(os.Stderr, "Error: badge always writes SVG and cannot use -format")
+		return 1
+	}
+	days := flags.Days
+	if days == 0 {
+		days = defaultSummaryDays
+	}
+
+	// The whole feed is needed
+	flags.Limit = 0
+	run, err := c.setup(flags)
+	if err != nil {
+		return c.reportError(err)
+	}
+	run.filter.MaxLimit = 0
+
+	if flags.Streak {
+		streaks, err := c.service.GetStreaks(run.username)
+		if err != nil {
+			return c.reportError(err)
+		}
+		color := badgeActiveColor
+		if streaks.Current == 0 {
+			color = badgeIdleColor
+		}
+		renderBadge(os.Stdout, "activity", fmt.Sprintf("%d-day streak", streaks.Current), color)
+		return 0
+	}
+
+	summary, err := c.service.GetPeriodSummary(run.username, run.filter, days)
+	failures, ok := partialFailures(err)
+	if !ok {
+		return c.reportError(err)
+	}
+	color := badgeActiveColor
+	if summary.Events == 0 {
+		color = badgeIdleColor
+	}
+	renderBadge(os.Stdout, "activity", badgeMessage(summary.Events, days), color)
+	printWarnings(os.Stderr, failures)
+	return 0
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/alnah/github-activity/pkg/activity"
+)
+
+func TestBadgeMessage(t *testing.T) {
+	tests := []struct {
+		events, days int
+		want         string
+	}{
+		{42, 7, "42 events this week"},
+		{1, 1, "1 event today"},
+		{0, 30, "0 events in 30 days"},
+	}
+	for _, tt := range tests {
+		if got := badgeMessage(tt.events, tt.days); got != tt.want {
+			t.Errorf("badgeMessage(%d, %d) = %q, want %q", tt.events, tt.days, got, tt.want)
+		}
+	}
+}
+
+func TestRenderBadge(t *testing.T) {
+	var buf bytes.Buffer
+	renderBadge(&buf, "activity", "R&D <3", badgeActiveColor)
+	svg := buf.String()
+
+	if err := xml.Unmarshal(buf.Bytes(), new(struct{})); err != nil {
+		t.Fatalf("Badge is not well-formed XML: %v\n%s", err, svg)
+	}
+	for _, want := range []string{
+		`width="118"`, // (8 + 6) characters of 7px plus 10px padding per side
+		`<title>activity: R&amp;D &lt;3</title>`,
+		`fill="#4c1"`,
+		`<text x="92" y="14">R&amp;D &lt;3</text>`,
+	} {
+		if !strings.Contains(svg, want) {
+			t.Errorf("Badge missing %q:\n%s", want, svg)
+		}
+	}
+}
+
+func TestCLI_runBadge(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected int
+		output   string
+	}{
+		{name: "events", args: []string{"alice"}, output: "1 event this week"},
+		{name: "streak", args: []string{"-streak", "alice"}, output: "1-day streak"},
+		{name: "format", args: []string{"-format=csv", "alice"}, expected: 1},
+		{name: "unknown user", args: []string{"ghost"}, expected: userNotFoundExitCode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli := NewCLI(activity.NewActivityService(usersRepository{}))
+
+			var code int
+			output := captureStdout(t, func() {
+				code = cli.Run(append([]string{"github-activity", "badge"}, tt.args...))
+			})
+			if code != tt.expected {
+				t.Errorf("Run() = %d, want %d", code, tt.expected)
+			}
+			if !strings.Contains(output, tt.output) {
+				t.Errorf("Output missing %q:\n%s", tt.output, output)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/activity"
+)
+
+// Changelog - The changelog subcommand
+
+// changelogRef names where an entry came from: the pull request number or the
+// short commit SHA, after the repository when the changelog spans several
+func changelogRef(entry activity.ChangelogEntry, showRepo bool) string {
+	ref := entry.Ref
+	if !strings.HasPrefix(ref, "#") {
+		ref = abbreviate(ref)
+		if showRepo {
+			return entry.Repo + "@" + ref
+		}
+		return ref
+	}
+	if showRepo {
+		return entry.Repo + ref
+	}
+	return ref
+}
+
+// renderChangelog writes the changelog draft as Markdown
+func renderChangelog(w io.Writer, changelog *activity.Changelog) {
+	// Until is exclusive, so the last day covered ends just before it
+	last := changelog.Until.Add(-time.Nanosecond).Format("2006-01-02")
+	period := "up to " + last
+	if !changelog.Since.IsZero() {
+		period = fmt.Sprintf("%s to %s", changelog.Since.Format("2006-01-02"), last)
+	}
+	_, _ = fmt.Fprintf(w, "# Changelog\n\n_%s_\n", period)
+	if len(changelog.Sections) == 0 {
+		_, _ = fmt.Fprintf(w, "\nNo merged pull requests or pushed commits.\n")
+		return
+	}
+
+	repos := make(map[string]bool)
+	for _, section := range changelog.Sections {
+		for _, entry := range section.Entries {
+			repos[entry.Repo] = true
+		}
+	}
+	for _, section := range changelog.Sections {
+		_, _ = fmt.Fprintf(w, "\n## %s\n\n", section.Title)
+		for _, entry := range section.Entries {
+			scope := ""
+			if entry.Scope != "" {
+				scope = "**" + entry.Scope + ":** "
+			}
+			_, _ = fmt.Fprintf(w, "- %s%s (%s)\n", scope, entry.Description, changelogRef(entry, len(repos) > 1))
+		}
+	}
+}
+
+// runChangelog handles `changelog [-repo=owner/name] [-since=date] [-until=date] <username>`
+func (c *CLI) runChangelog(args []string) int {
+	flags, err := c.resolveFlags(append([]string{"github-activity changelog"}, args...))
+	if err != nil {
+		return c.reportFlagsError(err)
+	}
+	if !c.hasUsername(flags) {
+		fmt.Println("Usage:")
+		fmt.Println("  github-activity changelog [-repo=owner/name] [-since=date] [-until=date] [-days=7] <username>")
+		return 1
+	}
+	if format := strings.ToLower(flags.Format); format != "" && format != "markdown" {
+		fmt.Fprintf(os.Stderr, "Error: changelog writes Markdown and cannot use -format=%s\n", flags.Format)
+		return 1
+	}
+
+	// The formatter is not used and the whole feed is needed
+	flags.Format = ""
+	flags.Limit = 0
+	run, err := c.setup(flags)
+	if err != nil {
+		return c.reportError(err)
+	}
+	run.filter.MaxLimit = 0
+	if flags.Since == "" {
+		days := flags.Days
+		if days == 0 {
+			days = defaultSummaryDays
+		}
+		run.filter.Since = c.now().AddDate(0, 0, -days)
+	}
+
+	changelog, err := c.service.GetChangelog(run.username, run.filter)
+	failures, ok := partialFailures(err)
+	if !ok {
+		return c.reportError(err)
+	}
+
+	renderChangelog(os.Stdout, changelog)
+	printWarnings(os.Stderr, failures)
+	return 0
+}
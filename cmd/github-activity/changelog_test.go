@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/activity"
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+func TestRenderChangelog(t *testing.T) {
+	since := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+	sections := []activity.ChangelogSection{
+		{Title: activity.SectionFeatures, Entries: []activity.ChangelogEntry{
+			{Scope: "cli", Description: "add changelog", Repo: "alnah/api", Ref: "#7"},
+		}},
+		{Title: activity.SectionFixes, Entries: []activity.ChangelogEntry{
+			{Description: "handle empty pages", Repo: "alnah/api", Ref: "9f2c1ab5d3e"},
+		}},
+	}
+
+	tests := []struct {
+		name      string
+		changelog *activity.Changelog
+		expected  string
+	}{
+		{
+			"one repository",
+			&activity.Changelog{Since: since, Until: since.AddDate(0, 0, 8), Sections: sections},
+			"# Changelog\n\n_2024-01-08 to 2024-01-15_\n\n" +
+				"## Features\n\n- **cli:** add changelog (#7)\n\n" +
+				"## Fixes\n\n- handle empty pages (9f2c1ab)\n",
+		},
+		{
+			"several repositories",
+			&activity.Changelog{Until: since, Sections: []activity.ChangelogSection{
+				{Title: activity.SectionOther, Entries: []activity.ChangelogEntry{
+					{Description: "Update README", Repo: "alnah/docs", Ref: "4e8d0c2"},
+					{Description: "Bump version", Repo: "alnah/api", Ref: "#9"},
+				}},
+			}},
+			"# Changelog\n\n_up to 2024-01-07_\n\n" +
+				"## Other Changes\n\n- Update README (alnah/docs@4e8d0c2)\n- Bump version (alnah/api#9)\n",
+		},
+		{
+			"empty",
+			&activity.Changelog{Since: since, Until: since.AddDate(0, 0, 1)},
+			"# Changelog\n\n_2024-01-08 to 2024-01-08_\n\nNo merged pull requests or pushed commits.\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			renderChangelog(&buf, tt.changelog)
+			if buf.String() != tt.expected {
+				t.Errorf("Output = %q, want %q", buf.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestCLI_runChangelog(t *testing.T) {
+	events := []github.GitHubEvent{
+		{
+			ID:        "1",
+			Type:      "PushEvent",
+			Repo:      github.Repo{Name: "user/repo"},
+			Payload:   json.RawMessage(`{"size":1,"commits":[{"sha":"abc1234","message":"feat: add it"}]}`),
+			CreatedAt: time.Now(),
+		},
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected int
+	}{
+		{"default window", []string{"testuser"}, 0},
+		{"date range", []string{"-repo=user/repo", "-since=2024-01-01", "-until=2024-01-31", "testuser"}, 0},
+		{"markdown", []string{"-format=markdown", "testuser"}, 0},
+		{"unsupported format", []string{"-format=csv", "testuser"}, 1},
+		{"invalid range", []string{"-since=2024-02-01", "-until=2024-01-01", "testuser"}, 1},
+		{"missing username", nil, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli := NewCLI(activity.NewActivityService(github.NewMockEventRepository(events, nil)))
+			args := append([]string{"github-activity", "changelog"}, tt.args...)
+			if code := cli.Run(args); code != tt.expected {
+				t.Errorf("Run() = %d, want %d", code, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,1436 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/alnah/github-activity/internal/messages"
+	"github.com/alnah/github-activity/pkg/activity"
+	"github.com/alnah/github-activity/pkg/format"
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// CLI Layer - User interface and presentation
+
+// CLI handles command-line interface
+type CLI struct {
+	service     activity.ActivityProvider
+	output      format.OutputFormatter
+	formats     format.FormatterRegistry
+	repository  *github.GitHubAPIRepository
+	configPath  string
+	workDir     string
+	ghConfigDir string
+	keyring     Keyring
+
+	contributions github.ContributionRepository // Defaults to the repository's GraphQL API
+	gists         github.GistRepository         // Defaults to the repository
+	stars         github.StarRepository         // Defaults to the repository
+	notifications github.NotificationRepository // Defaults to the repository, which needs a token
+	teams         github.TeamRepository         // Defaults to the repository, which needs a token
+
+	outputFile *outputFile    // Set while -output captures standard output
+	profile    *profile       // Set while -pprof records the run
+	sparkline  *time.Location // Zone of the days of the sparkline heading the activity list, when shown
+}
+
+// CLIOption configures a CLI
+type CLIOption func(*CLI)
+
+// WithFormatters replaces the registry used to resolve -format
+func WithFormatters(formats format.FormatterRegistry) CLIOption {
+	return func(c *CLI) {
+		c.formats = formats
+	}
+}
+
+// NewCLI creates a new CLI instance
+func NewCLI(service activity.ActivityProvider, options ...CLIOption) *CLI {
+	cli := &CLI{
+		service: service,
+		output:  &format.ConsoleOutputFormatter{},
+		formats: format.Formats,
+	}
+	for _, option := range options {
+		option(cli)
+	}
+	return cli
+}
+
+// SetRepository lets the CLI apply repository-level flags such as retries
+func (c *CLI) SetRepository(repository *github.GitHubAPIRepository) {
+	c.repository = repository
+}
+
+// SetContributionRepository sets where the contributions subcommand reads from
+func (c *CLI) SetContributionRepository(repository github.ContributionRepository) {
+	c.contributions = repository
+}
+
+// SetGistRepository sets where the gists subcommand reads from
+func (c *CLI) SetGistRepository(repository github.GistRepository) {
+	c.gists = repository
+}
+
+// SetStarRepository sets where the stars subcommand reads from
+func (c *CLI) SetStarRepository(repository github.StarRepository) {
+	c.stars = repository
+}
+
+// SetNotificationRepository sets where the notifications subcommand reads from
+func (c *CLI) SetNotificationRepository(repository github.NotificationRepository) {
+	c.notifications = repository
+}
+
+// SetTeamRepository sets where the team subcommand lists members from
+func (c *CLI) SetTeamRepository(repository github.TeamRepository) {
+	c.teams = repository
+}
+
+// SetConfigPath sets the config file used for defaults when -config is not given
+func (c *CLI) SetConfigPath(path string) {
+	c.configPath = path
+}
+
+// SetWorkDir sets where workspace config discovery starts
+func (c *CLI) SetWorkDir(dir string) {
+	c.workDir = dir
+}
+
+// SetGHConfigDir lets the CLI fall back to the gh CLI's token when
+// GITHUB_TOKEN is unset; an empty dir disables the fallback
+func (c *CLI) SetGHConfigDir(dir string) {
+	c.ghConfigDir = dir
+}
+
+// CLIFlags represents command-line flags. Secrets are tagged json:"-" so a
+// snapshot's config.json never records them.
+type CLIFlags struct {
+	EventType       string
+	Limit           int
+	Detailed        bool
+	ListTypes       bool
+	ReviewDebt      bool
+	Heatmap         bool
+	Histogram       bool
+	TUI             bool
+	Received        bool
+	GroupBy         string
+	Streak          bool
+	Authors         bool
+	ByLanguage      bool
+	ByOwner         bool
+	Addr            string
+	SlackWebhook    string `json:"-"`
+	DiscordWebhook  string `json:"-"`
+	EmailTo         string
+	EmailFrom       string                      // From config only
+	SMTP            SMTPSettings                // From config only
+	Descriptions    map[github.EventType]string // Description templates, from config only
+	Token           string                      `json:"-"` // From GITHUB_TOKEN, the keyring or the gh CLI, never a flag so it stays out of shell history
+	AbsoluteTime    bool
+	Width           int    // Columns to fit console and table output to; 0 detects the terminal
+	Output          string // File replacing stdout, written once the run succeeds
+	Update          string // README whose activity block `readme` rewrites
+	PProf           string // cpu or mem; hidden, for performance work
+	Quiet           bool
+	Verbose         bool
+	Emoji           bool
+	EmojiMap        string // Emoji overrides, from config only
+	Collapse        bool
+	Timezone        string
+	Spikes          string
+	Repo            string
+	Actor           string
+	Action          string
+	User            string // Default username from config
+	Speed           string
+	MaxGap          time.Duration
+	MarkRead        bool
+	Days            int
+	Format          string
+	Template        string
+	Explain         bool
+	Retries         int
+	Concurrency     int
+	Enrich          bool
+	NoLimit         bool
+	ConfigPath      string
+	Profile         string
+	CacheTTL        time.Duration
+	Refresh         bool
+	APIURL          string
+	Session         string
+	Since           string
+	Until           string
+	DB              string // Event archive written by sync
+	FromDB          bool
+	Offline         bool
+	ArchiveFallback bool
+	Args            []string // Non-flag arguments
+
+	explicit map[string]bool // Flags set on the command line
+}
+
+// Run executes the CLI
+func (c *CLI) Run(args []string) int {
+	return c.finishOutput(c.finishProfile(c.dispatch(args)))
+}
+
+// dispatch runs the subcommand or listing args ask for
+func (c *CLI) dispatch(args []string) int {
+	// Dispatch subcommands
+	if len(args) > 1 {
+		switch args[1] {
+		case "snapshot":
+			return c.runSnapshot(args[2:])
+		case "config":
+			return c.runConfig(args[2:])
+		case "replay":
+			return c.runReplay(args[2:])
+		case "summary":
+			return c.runSummary(args[2:])
+		case "commits":
+			return c.runCommits(args[2:])
+		case "changelog":
+			return c.runChangelog(args[2:])
+		case "serve":
+			return c.runServe(args[2:])
+		case "listen":
+			return c.runListen(args[2:])
+		case "digest":
+			return c.runDigest(args[2:])
+		case "sync":
+			return c.runSync(args[2:])
+		case "history":
+			return c.runHistory(args[2:])
+		case "contributions":
+			return c.runContributions(args[2:])
+		case "gists":
+			return c.runGists(args[2:])
+		case "stars":
+			return c.runStars(args[2:])
+		case "notifications":
+			return c.runNotifications(args[2:])
+		case "team":
+			return c.runTeam(args[2:])
+		case "badge":
+			return c.runBadge(args[2:])
+		case "readme":
+			return c.runReadme(args[2:])
+		case "auth":
+			return c.runAuth(args[2:], os.Stdin)
+		}
+	}
+
+	flags, err := c.resolveFlags(args)
+	if err != nil {
+		return c.reportFlagsError(err)
+	}
+
+	// Handle list-types flag
+	if flags.ListTypes {
+		c.listEventTypes()
+		return 0
+	}
+
+	// Check if username is provided
+	if !c.hasUsername(flags) {
+		c.printUsage()
+		return 1
+	}
+
+	run, err := c.setup(flags)
+	if err != nil {
+		return c.reportError(err)
+	}
+	username, filter, outputFormat := run.username, run.filter, run.format
+
+	if len(flags.Args) > 1 {
+		return c.displayUsers(flags.Args, run, flags)
+	}
+
+	if flags.TUI {
+		return c.runTUI(username, filter)
+	}
+
+	if sink, ok := selectedChatSink(flags); ok {
+		if flags.Spikes != "" {
+			return c.displaySpikes(username, flags.Spikes, sink)
+		}
+		return c.notify(sink.URL, sink.Name, run, flags)
+	}
+
+	// Fetch and display activities
+	header := (outputFormat == "" || outputFormat == "console") && !flags.Explain && !flags.Quiet && flags.Output == ""
+	if header {
+		fmt.Printf("Fetching GitHub activity for user: %s\n\n", username)
+	}
+
+	if flags.ReviewDebt {
+		return c.displayReviewBurndown(username, time.Duration(flags.Days)*24*time.Hour)
+	}
+
+	if flags.Spikes != "" {
+		return c.displaySpikes(username, flags.Spikes, chatSink{})
+	}
+
+	if flags.Histogram {
+		return c.displayHistogram(username)
+	}
+
+	if flags.Streak {
+		return c.displayStreaks(username)
+	}
+
+	if flags.Authors {
+		return c.displayAuthors(username, filter)
+	}
+
+	if flags.ByLanguage {
+		return c.displayLanguages(username, filter)
+	}
+
+	if flags.ByOwner {
+		return c.displayOwners(username, filter)
+	}
+
+	if flags.Heatmap {
+		return c.displayHeatmap(username, filter, flags.Days, run.location)
+	}
+
+	if header {
+		c.sparkline = run.location
+		if c.sparkline == nil {
+			c.sparkline = time.Local
+		}
+		defer func() { c.sparkline = nil }()
+	}
+
+	if flags.Detailed || flags.Enrich || format.NeedsDetails(outputFormat) {
+		return c.displayDetailedActivities(username, filter)
+	}
+
+	if outputFormat == "ndjson" && !flags.Explain {
+		return c.streamActivities(username, filter)
+	}
+
+	return c.displayActivities(username, filter)
+}
+
+// hasUsername reports whether a username was given, configured, or can be
+// resolved from the token
+func (c *CLI) hasUsername(flags CLIFlags) bool {
+	return len(flags.Args) > 0 || flags.User != "" || (flags.Token != "" && c.repository != nil)
+}
+
+// runSetup is the validated state shared by the main command and replay
+type runSetup struct {
+	username string
+	filter   activity.EventFilter
+	format   string
+	location *time.Location
+}
+
+// setup validates flags, applies them to the repository, service and output
+// formatter, and resolves the username
+func (c *CLI) setup(flags CLIFlags) (*runSetup, error) {
+	run := &runSetup{
+		username: flags.User,
+		format:   strings.ToLower(flags.Format),
+	}
+	if len(flags.Args) > 0 {
+		run.username = flags.Args[0]
+	}
+
+	// Create filter
+	run.filter = activity.EventFilter{
+		Type:     flags.EventType,
+		Repo:     flags.Repo,
+		Actor:    flags.Actor,
+		Action:   flags.Action,
+		MaxLimit: flags.Limit,
+		Collapse: flags.Collapse,
+	}
+
+	// Validate options
+	options := activity.ActivityOptions{
+		EventType:    flags.EventType,
+		Limit:        flags.Limit,
+		ShowDetailed: flags.Detailed,
+		Days:         flags.Days,
+		Retries:      flags.Retries,
+		Timezone:     flags.Timezone,
+		Spikes:       flags.Spikes,
+		Repo:         flags.Repo,
+		Actor:        flags.Actor,
+		Action:       flags.Action,
+		GroupBy:      flags.GroupBy,
+		Since:        flags.Since,
+		Until:        flags.Until,
+	}
+
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+	// Sessions store one dataset per username, whichever feed it came from
+	if flags.Received && flags.Session != "" {
+		return nil, fmt.Errorf("-received cannot be combined with -session")
+	}
+	if flags.FromDB {
+		if flags.Received || flags.Session != "" {
+			return nil, fmt.Errorf("-from-db cannot be combined with -received or -session")
+		}
+		if flags.DB == "" {
+			return nil, fmt.Errorf("no archive location; set -db")
+		}
+	}
+	if flags.ArchiveFallback && (flags.FromDB || flags.Offline || flags.DB == "") {
+		return nil, fmt.Errorf("-archive-fallback needs the -db archive and cannot be combined with -from-db or -offline")
+	}
+	if err := validateOffline(flags); err != nil {
+		return nil, err
+	}
+	if flags.Quiet && flags.Verbose {
+		return nil, fmt.Errorf("-quiet and -verbose cannot be used together")
+	}
+	if flags.Concurrency < 1 {
+		return nil, fmt.Errorf("concurrency must be at least 1")
+	}
+	if err := validateProfile(flags.PProf); err != nil {
+		return nil, err
+	}
+	run.location, _ = activity.LoadTimezone(flags.Timezone)
+	run.filter.Since, run.filter.Until, _ = activity.ParseDateRange(flags.Since, flags.Until, run.location)
+	// -since names the start of the range itself, so -days only fills it in
+	if flags.Days > 0 && flags.Since == "" {
+		run.filter.Since = c.now().AddDate(0, 0, -flags.Days)
+	}
+
+	// Apply repository settings
+	c.applyRepositorySettings(flags)
+	if run.username == "" && !flags.Offline && c.repository != nil && c.repository.HasToken() {
+		login, err := c.repository.AuthenticatedUser()
+		if err != nil {
+			return nil, err
+		}
+		run.username = login
+	}
+	if service, ok := c.service.(*activity.ActivityService); ok {
+		switch {
+		case flags.Offline:
+			service.UseOffline(flags.Session, activity.NewEventArchive(flags.DB))
+		case flags.FromDB:
+			service.UseArchive(activity.NewEventArchive(flags.DB))
+		case flags.Session != "":
+			session := service.UseSession(flags.Session)
+			session.SetMaxAge(flags.CacheTTL)
+			if flags.Refresh {
+				session.Refresh()
+			}
+		}
+		if flags.ArchiveFallback {
+			service.UseArchiveFallback(activity.NewEventArchive(flags.DB))
+		}
+		if run.location != nil {
+			service.SetLocation(run.location)
+		}
+		if flags.Verbose {
+			service.SetLogger(github.NewTextLogger(os.Stderr))
+		}
+		if len(flags.Descriptions) > 0 {
+			renderers, err := github.NewTemplateRenderers(flags.Descriptions)
+			if err != nil {
+				return nil, err
+			}
+			service.SetDescriptions(renderers)
+		}
+		if flags.Emoji {
+			emoji, err := emojiMapping(flags.EmojiMap)
+			if err != nil {
+				return nil, err
+			}
+			service.SetEmoji(emoji)
+		}
+	}
+
+	if err := c.configureOutput(flags, "GitHub activity for "+run.username); err != nil {
+		return nil, err
+	}
+	if flags.Output != "" {
+		if flags.TUI {
+			return nil, fmt.Errorf("-tui draws on the terminal and cannot use -output")
+		}
+		if err := c.redirectOutput(flags.Output); err != nil {
+			return nil, err
+		}
+	}
+	if flags.PProf != "" {
+		if err := c.startProfile(flags.PProf); err != nil {
+			return nil, err
+		}
+	}
+	return run, nil
+}
+
+// configureOutput selects the output formatter and applies console options.
+// title heads formats that have one, such as slack.
+func (c *CLI) configureOutput(flags CLIFlags, title string) error {
+	if name := strings.ToLower(flags.Format); name != "" {
+		formatter, err := c.formats.New(name, format.FormatOptions{
+			Template: flags.Template,
+			Title:    title,
+			Width:    terminalWidth(flags),
+		})
+		if err != nil {
+			return err
+		}
+		c.output = formatter
+	}
+	if flags.Explain {
+		c.output = &format.ExplainOutputFormatter{}
+	}
+	console, ok := c.output.(*format.ConsoleOutputFormatter)
+	if ok {
+		console.AbsoluteTime = flags.AbsoluteTime
+		console.Width = terminalWidth(flags)
+		console.ShowActor = flags.Received
+		console.GroupByDay = flags.GroupBy == "day"
+	} else if flags.GroupBy != "" {
+		return fmt.Errorf("-group-by only applies to console output")
+	}
+	return nil
+}
+
+// Enrichment settings
+const (
+	enrichWorkers  = 4
+	enrichCacheTTL = 24 * time.Hour
+)
+
+// pagesForLimit returns the page size and page count that fetch limit events
+// in as few requests as possible; a limit of 0 means every available page.
+// When filters drop or merge events, paging goes on until the limit is
+// filled, through pages of at least the default size.
+func pagesForLimit(limit int, narrowed bool) (perPage, pages int) {
+	switch {
+	case limit <= 0:
+		return github.MaxPageSize, 0
+	case narrowed:
+		return min(max(limit, github.DefaultPageSize), github.MaxPageSize), 0
+	}
+	perPage = min(limit, github.MaxPageSize)
+	return perPage, (limit + perPage - 1) / perPage
+}
+
+// narrowsFeed reports whether flags drop or merge events, so showing limit
+// activities can take more than limit events
+func narrowsFeed(flags CLIFlags) bool {
+	return flags.EventType != "" || flags.Repo != "" || flags.Actor != "" || flags.Action != "" ||
+		flags.Since != "" || flags.Until != "" || flags.Collapse
+}
+
+// applyRepositorySettings applies repository-level flags when a repository is set
+func (c *CLI) applyRepositorySettings(flags CLIFlags) {
+	if c.repository == nil {
+		return
+	}
+	c.repository.SetMaxRetries(flags.Retries)
+	c.repository.SetToken(flags.Token)
+	if flags.Verbose {
+		c.repository.SetLogger(github.NewTextLogger(os.Stderr))
+	}
+	if showProgress(flags) {
+		c.repository.SetProgress(NewSpinner(os.Stderr))
+	} else {
+		c.repository.SetProgress(nil)
+	}
+	if flags.Received {
+		c.repository.SetFeed(github.FeedReceived)
+	} else {
+		c.repository.SetFeed(github.FeedEvents)
+	}
+	// Aggregate views count every event, not just the displayed ones
+	if flags.Spikes != "" || flags.Heatmap || flags.Histogram || flags.Streak || flags.Authors ||
+		flags.ByLanguage || flags.ByOwner {
+		c.repository.SetPerPage(github.MaxPageSize)
+		c.repository.SetMaxPages(0)
+	} else {
+		perPage, pages := pagesForLimit(flags.Limit, narrowsFeed(flags))
+		c.repository.SetPerPage(perPage)
+		c.repository.SetMaxPages(pages)
+	}
+	if flags.APIURL != "" {
+		c.repository.SetBaseURL(flags.APIURL)
+	}
+	if flags.CacheTTL > 0 {
+		c.repository.SetCacheTTL(flags.CacheTTL)
+	}
+	if flags.Refresh {
+		c.repository.Refresh()
+	}
+
+	if service, ok := c.service.(*activity.ActivityService); ok && (flags.Enrich || flags.ByLanguage) {
+		var cache *github.DiskCache
+		if dir, err := github.DefaultCacheDir(); err == nil {
+			cache = github.NewDiskCache(filepath.Join(dir, "enrich"), enrichCacheTTL)
+		}
+		service.SetEnricher(activity.NewEnricher(c.repository, cache, enrichWorkers))
+	}
+	if service, ok := c.service.(*activity.ActivityService); ok && flags.ReviewDebt {
+		service.SetReviewSearcher(c.repository)
+	}
+}
+
+// APIURLEnvVar points the CLI at a GitHub Enterprise Server API
+const APIURLEnvVar = "GITHUB_API_URL"
+
+// TokenEnvVar holds a GitHub token used to authenticate API requests
+const TokenEnvVar = "GITHUB_TOKEN"
+
+// resolveFlags parses args and fills in config defaults and flag shorthands.
+// The API URL comes from -api-url, then GITHUB_API_URL, then the config file;
+// the token from GITHUB_TOKEN, then the keyring, then the gh CLI login for
+// that server.
+func (c *CLI) resolveFlags(args []string) (CLIFlags, error) {
+	flags, err := c.parseFlags(args)
+	if err != nil {
+		return flags, err
+	}
+	if err := c.applyConfig(&flags); err != nil {
+		return flags, err
+	}
+	if env := os.Getenv(APIURLEnvVar); env != "" && !flags.explicit["api-url"] {
+		flags.APIURL = env
+	}
+	if flags.APIURL != "" {
+		if err := validateConfigURL(flags.APIURL); err != nil {
+			return flags, fmt.Errorf("invalid API URL: %w", err)
+		}
+	}
+	if flags.NoLimit {
+		flags.Limit = 0
+	}
+	flags.EventType = github.ResolveEventType(flags.EventType)
+	flags.Token = os.Getenv(TokenEnvVar)
+	if flags.Token == "" {
+		flags.Token = c.keyringToken(flags.APIURL)
+	}
+	if flags.Token == "" && c.ghConfigDir != "" {
+		flags.Token = ghToken(c.ghConfigDir, flags.APIURL)
+	}
+	if err := applyChatSink(&flags); err != nil {
+		return flags, err
+	}
+	return flags, nil
+}
+
+// chatSink is a chat service activity can be posted to
+type chatSink struct {
+	Name   string // Shown to the user, e.g. "Slack"
+	Flag   string
+	Format string // Output format the service's webhook accepts
+	URL    string
+}
+
+// chatSinks returns the chat services with their webhook URL from flags
+func chatSinks(flags CLIFlags) []chatSink {
+	return []chatSink{
+		{Name: "Slack", Flag: "slack-webhook-url", Format: "slack", URL: flags.SlackWebhook},
+		{Name: "Discord", Flag: "discord-webhook-url", Format: "discord", URL: flags.DiscordWebhook},
+	}
+}
+
+// selectedChatSink returns the chat service a webhook URL was given for
+func selectedChatSink(flags CLIFlags) (chatSink, bool) {
+	for _, sink := range chatSinks(flags) {
+		if sink.URL != "" {
+			return sink, true
+		}
+	}
+	return chatSink{}, false
+}
+
+// applyChatSink validates the chat webhook URL, if any, and selects the
+// format that service accepts
+func applyChatSink(flags *CLIFlags) error {
+	var selected []chatSink
+	for _, sink := range chatSinks(*flags) {
+		if sink.URL != "" {
+			selected = append(selected, sink)
+		}
+	}
+	if len(selected) == 0 {
+		return nil
+	}
+	if len(selected) > 1 {
+		return fmt.Errorf("-%s and -%s cannot be used together", selected[0].Flag, selected[1].Flag)
+	}
+
+	sink := selected[0]
+	if err := validateConfigURL(sink.URL); err != nil {
+		return fmt.Errorf("invalid %s webhook URL: %w", sink.Name, err)
+	}
+	if flags.Format == "" {
+		flags.Format = sink.Format
+	} else if !strings.EqualFold(flags.Format, sink.Format) {
+		return fmt.Errorf("-%s posts %s messages and cannot use -format=%s",
+			sink.Flag, sink.Name, flags.Format)
+	}
+	return nil
+}
+
+// flagParseError is a flag parse failure, which the flag package has already
+// reported along with the usage
+type flagParseError struct {
+	err error
+}
+
+func (e *flagParseError) Error() string { return e.err.Error() }
+func (e *flagParseError) Unwrap() error { return e.err }
+
+// reportFlagsError prints an error from resolveFlags and returns the exit
+// code: 0 after -h printed the usage, 1 otherwise
+func (c *CLI) reportFlagsError(err error) int {
+	if errors.Is(err, flag.ErrHelp) {
+		return 0
+	}
+	var parseErr *flagParseError
+	if !errors.As(err, &parseErr) {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	}
+	return 1
+}
+
+// parseFlags parses command-line flags
+func (c *CLI) parseFlags(args []string) (CLIFlags, error) {
+	flags := CLIFlags{}
+
+	flagSet := flag.NewFlagSet("github-activity", flag.ContinueOnError)
+	flagSet.StringVar(
+		&flags.EventType,
+		"type",
+		"",
+		"Filter by event type or alias (e.g., PushEvent, push, pr)",
+	)
+	flagSet.IntVar(
+		&flags.Limit,
+		"limit",
+		30,
+		"Limit the number of events displayed (0 for no limit)",
+	)
+	flagSet.BoolVar(&flags.NoLimit, "no-limit", false, "Show every available event")
+	flagSet.BoolVar(&flags.Detailed, "detailed", false, "Show detailed information for each event")
+	flagSet.BoolVar(&flags.ListTypes, "list-types", false, "List all available event types")
+	flagSet.BoolVar(
+		&flags.ReviewDebt,
+		"review-debt",
+		false,
+		"Show review requests that have not been reviewed yet",
+	)
+	flagSet.BoolVar(&flags.Heatmap, "heatmap", false, "Show a calendar of activity per day")
+	flagSet.BoolVar(
+		&flags.Histogram,
+		"histogram",
+		false,
+		"Show activity by day of week and hour of day",
+	)
+	flagSet.BoolVar(
+		&flags.Received,
+		"received",
+		false,
+		"Show events from people and repositories the user follows",
+	)
+	flagSet.BoolVar(&flags.Streak, "streak", false, "Show current and longest streaks of active days")
+	flagSet.BoolVar(&flags.Authors, "authors", false, "Count pushed commits per author across the feed")
+	flagSet.BoolVar(
+		&flags.ByLanguage,
+		"by-language",
+		false,
+		"Count events by the primary language of their repository across the feed",
+	)
+	flagSet.BoolVar(
+		&flags.ByOwner,
+		"by-owner",
+		false,
+		"Count events by the user or organization owning their repository across the feed",
+	)
+	flagSet.StringVar(&flags.GroupBy, "group-by", "", "Insert date headers between events: day")
+	flagSet.BoolVar(&flags.TUI, "tui", false, "Browse events in an interactive terminal dashboard")
+	flagSet.BoolVar(
+		&flags.AbsoluteTime,
+		"absolute-time",
+		false,
+		"Show timestamps instead of relative times like \"3 days ago\"",
+	)
+	flagSet.IntVar(&flags.Width, "width", 0, "Wrap or cut console and table lines to this many columns (default: the terminal's width)")
+	flagSet.BoolVar(&flags.Quiet, "quiet", false, "Only print the results: no banner or fetch progress")
+	flagSet.BoolVar(&flags.Verbose, "verbose", false, "Log requests, responses, rate limits and cache use to stderr")
+	flagSet.BoolVar(&flags.Verbose, "debug", false, "Same as -verbose")
+	flagSet.BoolVar(&flags.Emoji, "emoji", false, "Prefix each description with an emoji for its event type")
+	flagSet.BoolVar(&flags.Collapse, "collapse", false, "Merge consecutive pushes to the same branch into one line")
+	flagSet.StringVar(
+		&flags.Timezone,
+		"tz",
+		"",
+		"Show times in this zone (e.g. America/New_York, UTC, local)",
+	)
+	flagSet.StringVar(
+		&flags.Repo,
+		"repo",
+		"",
+		"Only show events in this repository (owner/repo) or organization (owner)",
+	)
+	flagSet.StringVar(&flags.Actor, "actor", "", "Only show events performed by this login, e.g. in the -received feed")
+	flagSet.StringVar(
+		&flags.Action,
+		"action",
+		"",
+		"Only show events with this payload action: opened, closed, merged, created, published...",
+	)
+	flagSet.StringVar(
+		&flags.Spikes,
+		"spikes",
+		"",
+		"Report unusual activity in the current hour or day (hour, day)",
+	)
+	flagSet.IntVar(&flags.Days, "days", 0, "Only consider events from the last N days")
+	flagSet.StringVar(
+		&flags.Format,
+		"format",
+		"",
+		"Output format (console, table, csv, tsv, ndjson, yaml, template, slack, discord, gha)",
+	)
+	flagSet.StringVar(&flags.Output, "output", "", "Write the output to this file, replaced only once the run succeeds")
+	// Hidden from the usage text: a development aid rather than a feature
+	flagSet.StringVar(&flags.PProf, "pprof", "", "Write a cpu or mem pprof profile of the run to cpu.pprof or mem.pprof")
+	flagSet.StringVar(&flags.Update, "update", "", "With readme, rewrite the activity block between the markers in this file")
+	flagSet.StringVar(
+		&flags.Template,
+		"template",
+		"",
+		"Go template applied to each event with -format=template",
+	)
+	flagSet.BoolVar(
+		&flags.Explain,
+		"explain",
+		false,
+		"Print the payload fields behind each description as JSON",
+	)
+	flagSet.IntVar(
+		&flags.Retries,
+		"retries",
+		github.DefaultRetryPolicy().MaxRetries,
+		"Retry transient API failures this many times",
+	)
+	flagSet.IntVar(
+		&flags.Concurrency,
+		"concurrency",
+		activity.DefaultConcurrency,
+		"Fetch this many users' feeds at once when several are given (team)",
+	)
+	flagSet.BoolVar(
+		&flags.Enrich,
+		"enrich",
+		false,
+		"Add repository, pull request and commit status details (implies -detailed)",
+	)
+	flagSet.StringVar(
+		&flags.APIURL,
+		"api-url",
+		"",
+		"GitHub API base URL, e.g. https://github.example.com/api/v3 (env: GITHUB_API_URL)",
+	)
+	flagSet.StringVar(&flags.Speed, "speed", "1x", "Replay speed, e.g. 10x (replay)")
+	flagSet.DurationVar(
+		&flags.MaxGap,
+		"max-gap",
+		5*time.Second,
+		"Longest wait between replayed events, 0 for none (replay)",
+	)
+	flagSet.BoolVar(&flags.MarkRead, "mark-read", false, "Mark the listed notifications as read (notifications)")
+	flagSet.StringVar(&flags.Addr, "addr", defaultServeAddr, "Address to listen on (serve, listen)")
+	flagSet.StringVar(
+		&flags.SlackWebhook,
+		"slack-webhook-url",
+		"",
+		"Post the activity to this Slack incoming webhook (implies -format=slack)",
+	)
+	flagSet.StringVar(
+		&flags.DiscordWebhook,
+		"discord-webhook-url",
+		"",
+		"Post the activity to this Discord webhook (implies -format=discord)",
+	)
+	flagSet.StringVar(
+		&flags.EmailTo,
+		"email-to",
+		"",
+		"Comma-separated recipients of the digest email",
+	)
+	flagSet.StringVar(&flags.ConfigPath, "config", "", "Path to the config file")
+	flagSet.StringVar(&flags.Profile, "profile", "", "Config profile to apply")
+	flagSet.DurationVar(
+		&flags.CacheTTL,
+		"cache-ttl",
+		0,
+		"Reuse fetched events for this long, e.g. 10m (default 5m; sessions never expire)",
+	)
+	flagSet.BoolVar(&flags.Refresh, "refresh", false, "Fetch from GitHub even if cached or session events are fresh")
+	flagSet.StringVar(
+		&flags.Session,
+		"session",
+		"",
+		"Reuse events stored in this session file across invocations",
+	)
+	flagSet.StringVar(&flags.Since, "since", "", "Only show events from this date (YYYY-MM-DD or RFC 3339)")
+	flagSet.StringVar(&flags.Until, "until", "", "Only show events up to and including this date")
+	flagSet.StringVar(&flags.DB, "db", activity.DefaultArchivePath(), "Event archive written by sync")
+	flagSet.BoolVar(&flags.FromDB, "from-db", false, "Read events from the archive instead of GitHub")
+	flagSet.BoolVar(&flags.Offline, "offline", false, "Never contact GitHub; read the -session file or the archive")
+	flagSet.BoolVar(
+		&flags.ArchiveFallback,
+		"archive-fallback",
+		false,
+		"Read events older than GitHub's 300-event / 90-day window from the archive",
+	)
+
+	flagSet.Usage = c.printUsage
+
+	// Parse flags
+	if err := flagSet.Parse(args[1:]); err != nil {
+		return flags, &flagParseError{err: err}
+	}
+
+	// Store remaining arguments
+	flags.Args = flagSet.Args()
+
+	// Remember which flags were given so config defaults do not override them
+	flags.explicit = make(map[string]bool)
+	flagSet.Visit(func(f *flag.Flag) {
+		flags.explicit[f.Name] = true
+	})
+
+	return flags, nil
+}
+
+// displayActivities displays activities in summary format, followed by a
+// warning when only part of the feed could be fetched
+func (c *CLI) displayActivities(username string, filter activity.EventFilter) int {
+	activities, err := c.service.GetUserActivity(username, filter)
+	failures, ok := partialFailures(err)
+	if !ok {
+		return c.reportError(err)
+	}
+	defer printWarnings(os.Stderr, failures)
+
+	if len(activities) == 0 {
+		printNoActivity(filter)
+		if !c.consoleOutput() {
+			c.output.FormatActivities(os.Stdout, activities)
+		}
+		return 0
+	}
+
+	c.printSparkline(activities)
+	c.output.FormatActivities(os.Stdout, activities)
+	if len(failures) == 0 {
+		c.printTimelineGap(username, len(activities), filter.MaxLimit)
+	}
+	return 0
+}
+
+// printNoActivity tells on stderr that nothing matched the filter, so the
+// standard output of other formats stays a document
+func printNoActivity(filter activity.EventFilter) {
+	if filter.Type != "" {
+		fmt.Fprintf(os.Stderr, "No '%s' events found.\n", filter.Type)
+	} else {
+		fmt.Fprintln(os.Stderr, "No recent activity found.")
+	}
+}
+
+// consoleOutput reports whether activities are listed for a person rather
+// than as a document, which must be written even when empty
+func (c *CLI) consoleOutput() bool {
+	_, ok := c.output.(*format.ConsoleOutputFormatter)
+	return ok
+}
+
+// displayDetailedActivities displays activities with detailed information
+func (c *CLI) displayDetailedActivities(username string, filter activity.EventFilter) int {
+	activities, err := c.service.GetUserActivityDetailed(username, filter)
+	failures, ok := partialFailures(err)
+	if !ok {
+		return c.reportError(err)
+	}
+	defer printWarnings(os.Stderr, failures)
+
+	if len(activities) == 0 {
+		printNoActivity(filter)
+		if !c.consoleOutput() {
+			c.output.FormatDetailedActivities(os.Stdout, activities)
+		}
+		return 0
+	}
+
+	summaries := make([]activity.ActivitySummary, len(activities))
+	for i, detailed := range activities {
+		summaries[i] = detailed.ActivitySummary
+	}
+	c.printSparkline(summaries)
+	c.output.FormatDetailedActivities(os.Stdout, activities)
+	if len(failures) == 0 {
+		c.printTimelineGap(username, len(activities), filter.MaxLimit)
+	}
+	return 0
+}
+
+// streamActivities writes each activity as soon as the page holding it is
+// read, for formats that write a line per event. Providers that cannot
+// stream are listed once fetched.
+func (c *CLI) streamActivities(username string, filter activity.EventFilter) int {
+	streamer, ok := c.service.(activity.ActivityStreamer)
+	if !ok {
+		return c.displayActivities(username, filter)
+	}
+	summaries, errs := streamer.StreamUserActivity(context.Background(), username, filter)
+	for summary := range summaries {
+		c.output.FormatActivities(os.Stdout, []activity.ActivitySummary{summary})
+	}
+	if err := <-errs; err != nil {
+		return c.reportError(err)
+	}
+	return 0
+}
+
+// printTimelineGap warns on stderr when older history was likely truncated
+// and fewer than limit activities could be shown because of it
+func (c *CLI) printTimelineGap(username string, shown, limit int) {
+	if limit > 0 && shown >= limit {
+		return
+	}
+	gap, err := c.service.GetTimelineGap(username)
+	if err != nil || gap == nil {
+		return
+	}
+	fmt.Fprintf(
+		os.Stderr,
+		"\nNote: history may be incomplete before %s: %s.\n",
+		gap.OldestEvent.Format("2006-01-02"),
+		gap.Reason,
+	)
+}
+
+// displayReviewBurndown displays outstanding review requests
+func (c *CLI) displayReviewBurndown(username string, window time.Duration) int {
+	burndown, err := c.service.GetReviewBurndown(username, window)
+	if err != nil {
+		return c.reportError(err)
+	}
+
+	fmt.Printf("Review requests:   %d\n", burndown.Requested)
+	fmt.Printf("Reviews submitted: %d\n", burndown.Reviewed)
+	fmt.Printf("Outstanding:       %d\n", len(burndown.Outstanding))
+
+	for _, request := range burndown.Outstanding {
+		fmt.Printf("  - %s#%d: %s (updated %s)\n",
+			request.Repository,
+			request.Number,
+			request.Title,
+			request.UpdatedAt.Format("2006-01-02"))
+	}
+	return 0
+}
+
+// spikeExitCode is returned when -spikes finds unusual activity, so cron
+// jobs and scripts can trigger their own notifications
+const spikeExitCode = 2
+
+// Exit codes for GitHub failures scripts may want to tell apart; other
+// errors exit with 1
+const (
+	userNotFoundExitCode = 3
+	unauthorizedExitCode = 4
+	rateLimitExitCode    = 5
+	networkExitCode      = 6
+)
+
+// now returns the time on the service's clock, so windows and relative times
+// follow a clock injected with activity.WithClock
+func (c *CLI) now() time.Time {
+	if service, ok := c.service.(*activity.ActivityService); ok {
+		return service.Now()
+	}
+	return time.Now()
+}
+
+// reportError prints err with guidance for known repository failures and
+// returns the matching exit code
+func (c *CLI) reportError(err error) int {
+	fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+
+	var hint string
+	code := 1
+	switch {
+	case errors.Is(err, github.ErrUserNotFound):
+		hint = "check the spelling; GitHub usernames are not case-sensitive but must exist"
+		code = userNotFoundExitCode
+	case errors.Is(err, github.ErrUnauthorized):
+		hint = "the token is invalid or expired; create a new one at https://github.com/settings/tokens " +
+			"and set GITHUB_TOKEN or run `github-activity auth login`"
+		code = unauthorizedExitCode
+	case errors.Is(err, github.ErrRateLimitExceeded):
+		hint = "set GITHUB_TOKEN or run `github-activity auth login` to raise the limit from 60 to 5,000 requests per hour"
+		var repoErr *github.RepositoryError
+		if errors.As(err, &repoErr) && repoErr.RetryAfter > 0 {
+			hint = fmt.Sprintf("GitHub limits bursts of requests; wait %s, and lower -concurrency if it keeps happening",
+				repoErr.RetryAfter)
+		} else if c.repository != nil && c.repository.HasToken() {
+			hint = "wait for the limit to reset, or reuse fetched events with -session"
+		}
+		code = rateLimitExitCode
+	case errors.Is(err, github.ErrNetworkError):
+		hint = "check your connection and -api-url; -offline reads events stored earlier"
+		code = networkExitCode
+	case errors.Is(err, github.ErrDataPending):
+		hint = "the data is being prepared; try again in a minute"
+	}
+	if hint != "" {
+		fmt.Fprintf(os.Stderr, "Hint: %s\n", hint)
+	}
+	return code
+}
+
+// displaySpikes reports activity spikes for the current hour or day. When
+// sink has a webhook URL, the spikes are also posted there as an alert, so a
+// -spikes cron job notifies the configured chat service.
+func (c *CLI) displaySpikes(username, period string, sink chatSink) int {
+	spikes, err := c.service.GetActivitySpikes(username, period)
+	if err != nil {
+		return c.reportError(err)
+	}
+
+	if len(spikes) == 0 {
+		fmt.Printf("No unusual activity in the last %s.\n", period)
+		return 0
+	}
+
+	alerts := make([]activity.ActivitySummary, 0, len(spikes))
+	now := c.now()
+	for _, spike := range spikes {
+		scope := spike.Repository
+		if scope == "" {
+			scope = "all repositories"
+		}
+		line := fmt.Sprintf("Spike: %s in %s in the last %s (baseline %.1f per %s)",
+			messages.Plural("events", spike.Count), scope, period, spike.Baseline, period)
+		fmt.Println(line)
+		alerts = append(alerts, activity.ActivitySummary{
+			Description: line,
+			Repository:  spike.Repository,
+			Actor:       username,
+			Timestamp:   now.Format("2006-01-02 15:04:05"),
+			CreatedAt:   now,
+		})
+	}
+
+	if sink.URL != "" {
+		var alert bytes.Buffer
+		c.output.FormatActivities(&alert, alerts)
+		if err := postMessages(notifyClient, sink.URL, alert.Bytes()); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		fmt.Printf("Posted %s to %s.\n", messages.Plural("spikes", len(spikes)), sink.Name)
+	}
+	return spikeExitCode
+}
+
+// displayStreaks displays streaks of active days and how many days a week
+// the user is active
+func (c *CLI) displayStreaks(username string) int {
+	streaks, err := c.service.GetStreaks(username)
+	if err != nil {
+		return c.reportError(err)
+	}
+
+	if streaks.ActiveDays == 0 {
+		fmt.Println("No recent activity found.")
+		return 0
+	}
+
+	fmt.Printf("Current streak: %s\n", messages.Plural("days", streaks.Current))
+	end := streaks.LongestStart.AddDate(0, 0, streaks.Longest-1)
+	fmt.Printf("Longest streak: %s (%s to %s)\n",
+		messages.Plural("days", streaks.Longest),
+		streaks.LongestStart.Format("2006-01-02"),
+		end.Format("2006-01-02"))
+	fmt.Printf("Active days:    %d in the last %s (%.1f per week)\n",
+		streaks.ActiveDays, messages.Plural("weeks", streaks.Weeks), streaks.ActiveDaysPerWeek)
+	return 0
+}
+
+// displayAuthors displays how many pushed commits each author wrote
+func (c *CLI) displayAuthors(username string, filter activity.EventFilter) int {
+	authors, err := c.service.GetCommitAuthors(username, filter)
+	if err != nil {
+		return c.reportError(err)
+	}
+
+	if len(authors) == 0 {
+		fmt.Println("No pushed commits found.")
+		return 0
+	}
+
+	width := 0
+	for _, author := range authors {
+		width = max(width, utf8.RuneCountInString(author.Author))
+	}
+	for _, author := range authors {
+		fmt.Printf("%-*s  %s\n", width, author.Author, messages.Plural("commits", author.Commits))
+	}
+	return 0
+}
+
+// displayLanguages displays how the matching events split across the primary
+// languages of their repositories
+func (c *CLI) displayLanguages(username string, filter activity.EventFilter) int {
+	languages, err := c.service.GetLanguageBreakdown(username, filter)
+	if err != nil {
+		return c.reportError(err)
+	}
+
+	if len(languages) == 0 {
+		fmt.Println("No recent activity found.")
+		return 0
+	}
+
+	width, total := 0, 0
+	for _, language := range languages {
+		width = max(width, utf8.RuneCountInString(language.Language))
+		total += language.Events
+	}
+	for _, language := range languages {
+		fmt.Printf("%-*s  %s in %s (%.0f%%)\n", width, language.Language,
+			messages.Plural("events", language.Events), messages.Plural("repos", language.Repos),
+			100*float64(language.Events)/float64(total))
+	}
+	return 0
+}
+
+// displayOwners displays how the matching events split across the users and
+// organizations owning their repositories, marking the user's own
+func (c *CLI) displayOwners(username string, filter activity.EventFilter) int {
+	owners, err := c.service.GetOwnerBreakdown(username, filter)
+	if err != nil {
+		return c.reportError(err)
+	}
+
+	if len(owners) == 0 {
+		fmt.Println("No recent activity found.")
+		return 0
+	}
+
+	names := make([]string, len(owners))
+	width, total := 0, 0
+	for i, owner := range owners {
+		names[i] = owner.Owner
+		if owner.Own {
+			names[i] += " (own)"
+		}
+		width = max(width, utf8.RuneCountInString(names[i]))
+		total += owner.Events
+	}
+	for i, owner := range owners {
+		fmt.Printf("%-*s  %s in %s (%.0f%%)\n", width, names[i],
+			messages.Plural("events", owner.Events), messages.Plural("repos", owner.Repos),
+			100*float64(owner.Events)/float64(total))
+	}
+	return 0
+}
+
+// listEventTypes displays available event types
+func (c *CLI) listEventTypes() {
+	eventTypes := github.GetAvailableEventTypes()
+
+	aliases := make(map[github.EventType][]string)
+	for alias, eventType := range github.GetEventTypeAliases() {
+		aliases[eventType] = append(aliases[eventType], alias)
+	}
+
+	fmt.Println("Available event types:")
+	names := make([]string, 0, len(eventTypes))
+	maxTypeLen := 0
+	for eventType := range eventTypes {
+		names = append(names, string(eventType))
+		if len(eventType) > maxTypeLen {
+			maxTypeLen = len(eventType)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		eventType := github.EventType(name)
+		line := fmt.Sprintf("  %-*s - %s", maxTypeLen+2, name, eventTypes[eventType])
+		if shorthands := aliases[eventType]; len(shorthands) > 0 {
+			sort.Strings(shorthands)
+			line += fmt.Sprintf(" (alias: %s)", strings.Join(shorthands, ", "))
+		}
+		fmt.Println(line)
+	}
+}
+
+// printUsage prints usage information
+func (c *CLI) printUsage() {
+	fmt.Println("GitHub Activity CLI")
+	fmt.Println()
+	fmt.Println("Usage:")
+	fmt.Println("  github-activity [flags] [username...]   (username defaults to the config's user, then the GITHUB_TOKEN owner)")
+	fmt.Println("  github-activity snapshot [flags] <username> [archive]")
+	fmt.Println("  github-activity config validate|init [-config file]")
+	fmt.Println("  github-activity replay [-speed=10x] [-max-gap=5s] [flags] <username>")
+	fmt.Println("  github-activity summary [-days=7] [-format=console|markdown] <username>")
+	fmt.Println("  github-activity commits [-days=7] [-format=console|markdown] <username>")
+	fmt.Println("  github-activity changelog [-repo=owner/name] [-since=date] [-until=date] [-days=7] <username>")
+	fmt.Println("  github-activity serve [-addr=localhost:8080] [flags]")
+	fmt.Println("  github-activity listen [-addr=localhost:8080] [flags]")
+	fmt.Println("  github-activity digest [-days=7] [-email-to=addresses] <username>")
+	fmt.Println("  github-activity sync [-db=path] <username>")
+	fmt.Println("  github-activity history [-since=date] [-until=date] [flags] <username>")
+	fmt.Println("  github-activity contributions [-days=365] <username>")
+	fmt.Println("  github-activity gists [-limit=N] [-detailed] [-format=...] <username>")
+	fmt.Println("  github-activity stars [-limit=N] [-absolute-time] <username>")
+	fmt.Println("  github-activity notifications [-limit=N] [-mark-read]")
+	fmt.Println("  github-activity team [-days=7] [-format=console|markdown] <org>/<team-slug>")
+	fmt.Println("  github-activity badge [-days=7] [-streak] [-output=badge.svg] <username>")
+	fmt.Println("  github-activity readme [-limit=5] [-update=README.md] <username>")
+	fmt.Println("  github-activity auth login|logout [-api-url=url]")
+	fmt.Println()
+	fmt.Println("Flags:")
+	fmt.Println("  -type string")
+	fmt.Println("        Filter by event type or alias (e.g., PushEvent, push, pr)")
+	fmt.Println("  -repo string")
+	fmt.Println("        Only show events in this repository (owner/repo) or organization (owner)")
+	fmt.Println("  -actor string")
+	fmt.Println("        Only show events performed by this login, e.g. in the -received feed")
+	fmt.Println("  -action string")
+	fmt.Println("        Only show events with this payload action: opened, closed, merged, created, published...")
+	fmt.Println("  -limit int")
+	fmt.Println("        Limit the number of events displayed, 0 for no limit (default 30)")
+	fmt.Println("  -no-limit")
+	fmt.Println("        Show every available event (same as -limit=0)")
+	fmt.Println("  -detailed")
+	fmt.Println("        Show detailed information for each event")
+	fmt.Println("  -list-types")
+	fmt.Println("        List all available event types")
+	fmt.Println("  -review-debt")
+	fmt.Println("        Show review requests that have not been reviewed yet")
+	fmt.Println("  -heatmap")
+	fmt.Println("        Show a calendar of activity per day")
+	fmt.Println("  -histogram")
+	fmt.Println("        Show activity by day of week and hour of day")
+	fmt.Println("  -received")
+	fmt.Println("        Show events from people and repositories the user follows")
+	fmt.Println("  -streak")
+	fmt.Println("        Show current and longest streaks of active days")
+	fmt.Println("  -authors")
+	fmt.Println("        Count pushed commits per author across the feed")
+	fmt.Println("  -by-language")
+	fmt.Println("        Count events by the primary language of their repository across the feed")
+	fmt.Println("  -by-owner")
+	fmt.Println("        Count events by the user or organization owning their repository across the feed")
+	fmt.Println("  -group-by string")
+	fmt.Println("        Insert date headers between events: day")
+	fmt.Println("  -tui")
+	fmt.Println("        Browse events in an interactive terminal dashboard")
+	fmt.Println("  -absolute-time")
+	fmt.Println("        Show timestamps instead of relative times like \"3 days ago\"")
+	fmt.Println("  -width int")
+	fmt.Println("        Wrap or cut console and table lines to this many columns (default: the terminal's width)")
+	fmt.Println("  -quiet")
+	fmt.Println("        Only print the results: no banner or fetch progress")
+	fmt.Println("  -verbose, -debug")
+	fmt.Println("        Log requests, responses, rate limits and cache use to stderr")
+	fmt.Println("  -emoji")
+	fmt.Println("        Prefix each description with an emoji for its event type")
+	fmt.Println("  -collapse")
+	fmt.Println("        Merge consecutive pushes to the same branch into one line")
+	fmt.Println("  -tz string")
+	fmt.Println("        Show times in this zone (e.g. America/New_York, UTC, local)")
+	fmt.Println("  -spikes string")
+	fmt.Println("        Report unusual activity in the current hour or day (hour, day)")
+	fmt.Println("  -days int")
+	fmt.Println("        Only consider events from the last N days")
+	fmt.Println("  -format string")
+	fmt.Println("        Output format (console, table, csv, tsv, ndjson, yaml, template, slack, discord, gha)")
+	fmt.Println("  -output string")
+	fmt.Println("        Write the output to this file, replaced only once the run succeeds")
+	fmt.Println("  -update string")
+	fmt.Println("        With readme, rewrite the activity block between the markers in this file")
+	fmt.Println("  -template string")
+	fmt.Println("        Go template applied to each event with -format=template")
+	fmt.Println("  -explain")
+	fmt.Println("        Print the payload fields behind each description as JSON")
+	fmt.Println("  -retries int")
+	fmt.Println("        Retry transient API failures this many times (default 2)")
+	fmt.Println("  -concurrency int")
+	fmt.Println("        Fetch this many users' feeds at once when several are given (team) (default 4)")
+	fmt.Println("  -enrich")
+	fmt.Println("        Add repository, pull request and commit status details (implies -detailed)")
+	fmt.Println("  -api-url string")
+	fmt.Println("        GitHub API base URL, e.g. https://github.example.com/api/v3 (env: GITHUB_API_URL)")
+	fmt.Println("  -speed string")
+	fmt.Println("        Replay speed, e.g. 10x (replay) (default \"1x\")")
+	fmt.Println("  -max-gap duration")
+	fmt.Println("        Longest wait between replayed events, 0 for none (replay) (default 5s)")
+	fmt.Println("  -mark-read")
+	fmt.Println("        Mark the listed notifications as read (notifications)")
+	fmt.Println("  -addr string")
+	fmt.Println("        Address to listen on (serve, listen) (default \"localhost:8080\")")
+	fmt.Println("  -slack-webhook-url string")
+	fmt.Println("        Post the activity to this Slack incoming webhook (implies -format=slack)")
+	fmt.Println("  -discord-webhook-url string")
+	fmt.Println("        Post the activity to this Discord webhook (implies -format=discord)")
+	fmt.Println("  -email-to string")
+	fmt.Println("        Comma-separated recipients of the digest email")
+	fmt.Println("  -config string")
+	fmt.Println("        Path to the config file")
+	fmt.Println("  -profile string")
+	fmt.Println("        Config profile to apply")
+	fmt.Println("  -cache-ttl duration")
+	fmt.Println("        Reuse fetched events for this long, e.g. 10m (default 5m; sessions never expire)")
+	fmt.Println("  -refresh")
+	fmt.Println("        Fetch from GitHub even if cached or session events are fresh")
+	fmt.Println("  -session string")
+	fmt.Println("        Reuse events stored in this session file across invocations")
+	fmt.Println("  -since string")
+	fmt.Println("        Only show events from this date (YYYY-MM-DD or RFC 3339)")
+	fmt.Println("  -until string")
+	fmt.Println("        Only show events up to and including this date")
+	fmt.Println("  -db string")
+	fmt.Println("        Event archive written by sync (default: archive.json in the config directory)")
+	fmt.Println("  -from-db")
+	fmt.Println("        Read events from the archive instead of GitHub")
+	fmt.Println("  -offline")
+	fmt.Println("        Never contact GitHub; read the -session file or the archive")
+	fmt.Println("  -archive-fallback")
+	fmt.Println("        Read events older than GitHub's 300-event / 90-day window from the archive")
+	fmt.Println()
+	fmt.Println("Examples:")
+	fmt.Println("  github-activity kamranahmedse")
+	fmt.Println("  github-activity -type=PushEvent -limit=5 torvalds")
+	fmt.Println("  github-activity -detailed octocat")
+	fmt.Println("  github-activity -list-types")
+	fmt.Println("  github-activity -format=csv octocat > activity.csv")
+	fmt.Println("  github-activity -format=template -template='{{.Timestamp}} {{.Description}}' octocat")
+}
@@ -0,0 +1,1044 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/activity"
+	"github.com/alnah/github-activity/pkg/format"
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// MockOutputFormatter for testing CLI output
+type MockOutputFormatter struct {
+	activities         []activity.ActivitySummary
+	detailedActivities []activity.DetailedActivity
+}
+
+func (m *MockOutputFormatter) FormatActivities(w io.Writer, activities []activity.ActivitySummary) {
+	m.activities = activities
+}
+
+func (m *MockOutputFormatter) FormatDetailedActivities(w io.Writer, activities []activity.DetailedActivity) {
+	m.detailedActivities = activities
+}
+
+// fakeActivityProvider serves canned summaries; unimplemented queries panic
+type fakeActivityProvider struct {
+	activity.ActivityProvider
+	summaries []activity.ActivitySummary
+	users     []string
+}
+
+func (f *fakeActivityProvider) GetUserActivity(
+	username string,
+	filter activity.EventFilter,
+) ([]activity.ActivitySummary, error) {
+	f.users = append(f.users, username)
+	return f.summaries, nil
+}
+
+func (f *fakeActivityProvider) GetTimelineGap(username string) (*activity.TimelineGap, error) {
+	return nil, nil
+}
+
+func TestCLI_Run_WithFakeProvider(t *testing.T) {
+	provider := &fakeActivityProvider{
+		summaries: []activity.ActivitySummary{{Description: "Starred user/repo"}},
+	}
+	mockOutput := &MockOutputFormatter{}
+	cli := NewCLI(provider, WithFormatters(format.FormatterRegistry{
+		"mock": func(format.FormatOptions) (format.OutputFormatter, error) { return mockOutput, nil },
+	}))
+
+	if code := cli.Run([]string{"github-activity", "-format=mock", "octocat"}); code != 0 {
+		t.Fatalf("Run() = %d, want 0", code)
+	}
+	if len(provider.users) != 1 || provider.users[0] != "octocat" {
+		t.Errorf("provider queried for %v, want [octocat]", provider.users)
+	}
+	if len(mockOutput.activities) != 1 {
+		t.Errorf("Expected 1 activity to be formatted, got %d", len(mockOutput.activities))
+	}
+
+	// Built-in formats are not available unless registered
+	if code := cli.Run([]string{"github-activity", "-format=csv", "octocat"}); code != 1 {
+		t.Errorf("Run() with unregistered format = %d, want 1", code)
+	}
+}
+
+func TestCLI_Run_WorkspaceUser(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, WorkspaceConfigName), []byte("user: alnah\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	provider := &fakeActivityProvider{summaries: []activity.ActivitySummary{{Description: "Starred user/repo"}}}
+	cli := NewCLI(provider, WithFormatters(format.FormatterRegistry{
+		"mock": func(format.FormatOptions) (format.OutputFormatter, error) { return &MockOutputFormatter{}, nil },
+	}))
+	cli.SetWorkDir(dir)
+
+	if code := cli.Run([]string{"github-activity", "-format=mock"}); code != 0 {
+		t.Fatalf("Run() = %d, want 0", code)
+	}
+	if len(provider.users) != 1 || provider.users[0] != "alnah" {
+		t.Errorf("provider queried for %v, want [alnah]", provider.users)
+	}
+}
+
+func TestCLI_parseFlags(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected CLIFlags
+	}{
+		{
+			name: "default flags",
+			args: []string{"github-activity", "testuser"},
+			expected: CLIFlags{
+				EventType: "",
+				Limit:     30,
+				Detailed:  false,
+				ListTypes: false,
+			},
+		},
+		{
+			name: "with type filter",
+			args: []string{"github-activity", "-type=PushEvent", "testuser"},
+			expected: CLIFlags{
+				EventType: "PushEvent",
+				Limit:     30,
+				Detailed:  false,
+				ListTypes: false,
+			},
+		},
+		{
+			name: "with limit",
+			args: []string{"github-activity", "-limit=5", "testuser"},
+			expected: CLIFlags{
+				EventType: "",
+				Limit:     5,
+				Detailed:  false,
+				ListTypes: false,
+			},
+		},
+		{
+			name: "with detailed flag",
+			args: []string{"github-activity", "-detailed", "testuser"},
+			expected: CLIFlags{
+				EventType: "",
+				Limit:     30,
+				Detailed:  true,
+				ListTypes: false,
+			},
+		},
+		{
+			name: "list types flag",
+			args: []string{"github-activity", "-list-types"},
+			expected: CLIFlags{
+				EventType: "",
+				Limit:     30,
+				Detailed:  false,
+				ListTypes: true,
+			},
+		},
+		{
+			name: "all flags",
+			args: []string{
+				"github-activity",
+				"-type=IssuesEvent",
+				"-limit=10",
+				"-detailed",
+				"testuser",
+			},
+			expected: CLIFlags{
+				EventType: "IssuesEvent",
+				Limit:     10,
+				Detailed:  true,
+				ListTypes: false,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Reset flag.CommandLine for each test
+			flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+			flag.CommandLine.SetOutput(io.Discard)
+
+			cli := NewCLI(nil)
+			flags, err := cli.parseFlags(tt.args)
+			if err != nil {
+				t.Fatalf("parseFlags() error = %v", err)
+			}
+
+			if flags.EventType != tt.expected.EventType {
+				t.Errorf("EventType = %v, want %v", flags.EventType, tt.expected.EventType)
+			}
+			if flags.Limit != tt.expected.Limit {
+				t.Errorf("Limit = %v, want %v", flags.Limit, tt.expected.Limit)
+			}
+			if flags.Detailed != tt.expected.Detailed {
+				t.Errorf("Detailed = %v, want %v", flags.Detailed, tt.expected.Detailed)
+			}
+			if flags.ListTypes != tt.expected.ListTypes {
+				t.Errorf("ListTypes = %v, want %v", flags.ListTypes, tt.expected.ListTypes)
+			}
+		})
+	}
+}
+
+func TestPagesForLimit(t *testing.T) {
+	tests := []struct {
+		limit    int
+		narrowed bool
+		perPage  int
+		pages    int
+	}{
+		{0, false, 100, 0},
+		{5, false, 5, 1},
+		{30, false, 30, 1},
+		{31, false, 31, 1},
+		{150, false, 100, 2},
+		{300, false, 100, 3},
+		{5, true, 30, 0},
+		{50, true, 50, 0},
+		{0, true, 100, 0},
+	}
+
+	for _, tt := range tests {
+		perPage, pages := pagesForLimit(tt.limit, tt.narrowed)
+		if perPage != tt.perPage || pages != tt.pages {
+			t.Errorf("pagesForLimit(%d, %v) = %d, %d, want %d, %d",
+				tt.limit, tt.narrowed, perPage, pages, tt.perPage, tt.pages)
+		}
+	}
+}
+
+func TestCLI_resolveFlags_NoLimit(t *testing.T) {
+	cli := NewCLI(nil)
+	flags, err := cli.resolveFlags([]string{"github-activity", "-no-limit", "testuser"})
+	if err != nil {
+		t.Fatalf("resolveFlags() error = %v", err)
+	}
+	if flags.Limit != 0 {
+		t.Errorf("Limit = %d, want 0", flags.Limit)
+	}
+}
+
+func TestCLI_resolveFlags_TypeAlias(t *testing.T) {
+	cli := NewCLI(nil)
+	flags, err := cli.resolveFlags([]string{"github-activity", "-type=pr", "testuser"})
+	if err != nil {
+		t.Fatalf("resolveFlags() error = %v", err)
+	}
+	if flags.EventType != "PullRequestEvent" {
+		t.Errorf("EventType = %q, want %q", flags.EventType, "PullRequestEvent")
+	}
+}
+
+func TestCLI_resolveFlags_APIURL(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("api_url: https://config.example.com/api/v3\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		args        []string
+		env         string
+		expected    string
+		expectError bool
+	}{
+		{"config file", nil, "", "https://config.example.com/api/v3", false},
+		{"env overrides config", nil, "https://env.example.com/api/v3", "https://env.example.com/api/v3", false},
+		{
+			"flag overrides env",
+			[]string{"-api-url=https://flag.example.com/api/v3"},
+			"https://env.example.com/api/v3",
+			"https://flag.example.com/api/v3",
+			false,
+		},
+		{"invalid url", []string{"-api-url=github.example.com"}, "", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(APIURLEnvVar, tt.env)
+			cli := NewCLI(nil)
+			cli.SetConfigPath(path)
+
+			args := append([]string{"github-activity"}, tt.args...)
+			flags, err := cli.resolveFlags(append(args, "testuser"))
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("resolveFlags() error = %v", err)
+			}
+			if flags.APIURL != tt.expected {
+				t.Errorf("APIURL = %q, want %q", flags.APIURL, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCLI_Run_AuthenticatedUser(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		paths = append(paths, r.URL.Path)
+		if r.URL.Path == "/user" {
+			_, _ = w.Write([]byte(`{"login":"octocat"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`[{"id":"1","type":"WatchEvent","repo":{"name":"user/repo"}}]`))
+	}))
+	defer server.Close()
+
+	t.Setenv(TokenEnvVar, "secret")
+	repo := github.NewGitHubAPIRepository()
+	repo.SetBaseURL(server.URL)
+	cli := NewCLI(activity.NewActivityService(repo))
+	cli.SetRepository(repo)
+
+	if code := cli.Run([]string{"github-activity"}); code != 0 {
+		t.Fatalf("Run() = %d, want 0", code)
+	}
+	expected := []string{"/user", "/users/octocat/events"}
+	if strings.Join(paths, ",") != strings.Join(expected, ",") {
+		t.Errorf("Requested %v, want %v", paths, expected)
+	}
+}
+
+func TestCLI_Run_FlagErrors(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`{"login":"octocat"}`))
+	}))
+	defer server.Close()
+
+	t.Setenv(TokenEnvVar, "secret")
+	tests := []struct {
+		name     string
+		args     []string
+		expected int
+	}{
+		{"help", []string{"github-activity", "-h"}, 0},
+		{"unknown flag", []string{"github-activity", "-limt=3", "torvalds"}, 1},
+		{"subcommand help", []string{"github-activity", "stars", "-h"}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requests = 0
+			repo := github.NewGitHubAPIRepository()
+			repo.SetBaseURL(server.URL)
+			cli := NewCLI(activity.NewActivityService(repo))
+			cli.SetRepository(repo)
+
+			stderr := os.Stderr
+			os.Stderr, _ = os.Open(os.DevNull)
+			var code int
+			captureStdout(t, func() { code = cli.Run(tt.args) })
+			os.Stderr = stderr
+
+			if code != tt.expected {
+				t.Errorf("Run() = %d, want %d", code, tt.expected)
+			}
+			if requests != 0 {
+				t.Errorf("Made %d requests after a flag error, want none", requests)
+			}
+		})
+	}
+}
+
+func TestCLI_Run(t *testing.T) {
+	tests := []struct {
+		name         string
+		args         []string
+		setupService func() *activity.ActivityService
+		expectedCode int
+		checkOutput  func(t *testing.T, output string)
+	}{
+		{
+			name: "no arguments shows usage",
+			args: []string{"github-activity"},
+			setupService: func() *activity.ActivityService {
+				return activity.NewActivityService(github.NewMockEventRepository(nil, nil))
+			},
+			expectedCode: 1,
+			checkOutput: func(t *testing.T, output string) {
+				if !strings.Contains(output, "Usage:") {
+					t.Error("Expected usage information in output")
+				}
+			},
+		},
+		{
+			name: "list types",
+			args: []string{"github-activity", "-list-types"},
+			setupService: func() *activity.ActivityService {
+				return activity.NewActivityService(github.NewMockEventRepository(nil, nil))
+			},
+			expectedCode: 0,
+			checkOutput: func(t *testing.T, output string) {
+				if !strings.Contains(output, "Available event types:") {
+					t.Error("Expected event types list in output")
+				}
+			},
+		},
+		{
+			name: "successful activity fetch",
+			args: []string{"github-activity", "testuser"},
+			setupService: func() *activity.ActivityService {
+				events := []github.GitHubEvent{
+					{
+						ID:        "1",
+						Type:      "PushEvent",
+						Repo:      github.Repo{Name: "user/repo"},
+						CreatedAt: time.Now(),
+						Payload:   json.RawMessage(`{"size": 1, "ref": "refs/heads/main"}`),
+					},
+				}
+				repo := github.NewMockEventRepository(events, nil)
+				return activity.NewActivityService(repo)
+			},
+			expectedCode: 0,
+			checkOutput: func(t *testing.T, output string) {
+				if !strings.Contains(output, "Fetching GitHub activity for user: testuser") {
+					t.Error("Expected fetch message in output")
+				}
+				if !strings.Contains(output, "Last 14 days  ▁▁▁▁▁▁▁▁▁▁▁▁▁█  1 event") {
+					t.Errorf("Expected a sparkline above the activities, got %q", output)
+				}
+			},
+		},
+		{
+			name: "error from service",
+			args: []string{"github-activity", "nonexistent"},
+			setupService: func() *activity.ActivityService {
+				repo := github.NewMockEventRepository(nil, &github.RepositoryError{
+					Code:    "USER_NOT_FOUND",
+					Message: "User not found",
+				})
+				return activity.NewActivityService(repo)
+			},
+			expectedCode: userNotFoundExitCode,
+			checkOutput: func(t *testing.T, output string) {
+				if !strings.Contains(output, "Error:") {
+					t.Error("Expected error message in output")
+				}
+				if !strings.Contains(output, "Hint:") {
+					t.Error("Expected guidance for a missing user")
+				}
+			},
+		},
+		{
+			name: "no activities found",
+			args: []string{"github-activity", "emptyuser"},
+			setupService: func() *activity.ActivityService {
+				// Return empty events array
+				repo := github.NewMockEventRepository([]github.GitHubEvent{}, nil)
+				return activity.NewActivityService(repo)
+			},
+			expectedCode: 0,
+			checkOutput: func(t *testing.T, output string) {
+				if !strings.Contains(output, "No recent activity found") {
+					t.Error("Expected 'no activity' message in output")
+				}
+			},
+		},
+		{
+			name: "with type filter no matches",
+			args: []string{"github-activity", "-type=IssuesEvent", "testuser"},
+			setupService: func() *activity.ActivityService {
+				events := []github.GitHubEvent{
+					{
+						ID:   "1",
+						Type: "PushEvent", // Different type
+						Repo: github.Repo{Name: "user/repo"},
+					},
+				}
+				repo := github.NewMockEventRepository(events, nil)
+				return activity.NewActivityService(repo)
+			},
+			expectedCode: 0,
+			checkOutput: func(t *testing.T, output string) {
+				if !strings.Contains(output, "No 'IssuesEvent' events found") {
+					t.Error("Expected filtered no events message")
+				}
+			},
+		},
+		{
+			name: "invalid event type",
+			args: []string{"github-activity", "-type=InvalidEvent", "testuser"},
+			setupService: func() *activity.ActivityService {
+				return activity.NewActivityService(github.NewMockEventRepository(nil, nil))
+			},
+			expectedCode: 1,
+			checkOutput: func(t *testing.T, output string) {
+				if !strings.Contains(output, "Error:") &&
+					!strings.Contains(output, "invalid event type") {
+					t.Error("Expected invalid event type error")
+				}
+			},
+		},
+		{
+			name: "invalid output format",
+			args: []string{"github-activity", "-format=xml", "testuser"},
+			setupService: func() *activity.ActivityService {
+				return activity.NewActivityService(github.NewMockEventRepository(nil, nil))
+			},
+			expectedCode: 1,
+			checkOutput: func(t *testing.T, output string) {
+				if !strings.Contains(output, "invalid output format") {
+					t.Error("Expected invalid output format error")
+				}
+			},
+		},
+		{
+			name: "csv output skips banner",
+			args: []string{"github-activity", "-format=csv", "testuser"},
+			setupService: func() *activity.ActivityService {
+				events := []github.GitHubEvent{
+					{ID: "1", Type: "WatchEvent", Repo: github.Repo{Name: "user/repo"}},
+				}
+				return activity.NewActivityService(github.NewMockEventRepository(events, nil))
+			},
+			expectedCode: 0,
+			checkOutput: func(t *testing.T, output string) {
+				if strings.Contains(output, "Fetching GitHub activity") {
+					t.Error("CSV output should not include the banner")
+				}
+				if strings.Contains(output, "Last 14 days") {
+					t.Error("CSV output should not include the sparkline")
+				}
+				if !strings.Contains(output, "timestamp,type,repo,description,actor,commits") {
+					t.Error("Expected CSV header in output")
+				}
+			},
+		},
+		{
+			name: "quiet skips banner",
+			args: []string{"github-activity", "-quiet", "testuser"},
+			setupService: func() *activity.ActivityService {
+				events := []github.GitHubEvent{
+					{ID: "1", Type: "WatchEvent", Repo: github.Repo{Name: "user/repo"}},
+				}
+				return activity.NewActivityService(github.NewMockEventRepository(events, nil))
+			},
+			expectedCode: 0,
+			checkOutput: func(t *testing.T, output string) {
+				if strings.Contains(output, "Fetching GitHub activity") {
+					t.Error("-quiet should not print the banner")
+				}
+				if strings.Contains(output, "Last 14 days") {
+					t.Error("-quiet should not print the sparkline")
+				}
+				if !strings.Contains(output, "Starred user/repo") {
+					t.Error("Expected the activity in output")
+				}
+			},
+		},
+		{
+			name: "quiet with verbose",
+			args: []string{"github-activity", "-quiet", "-debug", "testuser"},
+			setupService: func() *activity.ActivityService {
+				return activity.NewActivityService(github.NewMockEventRepository(nil, nil))
+			},
+			expectedCode: 1,
+		},
+		{
+			name: "negative limit",
+			args: []string{"github-activity", "-limit=-5", "testuser"},
+			setupService: func() *activity.ActivityService {
+				return activity.NewActivityService(github.NewMockEventRepository(nil, nil))
+			},
+			expectedCode: 1,
+			checkOutput: func(t *testing.T, output string) {
+				if !strings.Contains(output, "Error:") &&
+					!strings.Contains(output, "limit cannot be negative") {
+					t.Error("Expected negative limit error")
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Reset flags for each test
+			flag.CommandLine = flag.NewFlagSet(os.Args[0], flag.ContinueOnError)
+
+			// Create a buffer to capture output
+			var outputBuf bytes.Buffer
+
+			// Create a custom CLI with mocked output
+			service := tt.setupService()
+			cli := NewCLI(service)
+
+			// Temporarily redirect fmt output for testing
+			// Save original stdout and stderr
+			oldStdout := os.Stdout
+			oldStderr := os.Stderr
+
+			// Create a pipe
+			r, w, _ := os.Pipe()
+			os.Stdout = w
+			os.Stderr = w
+
+			// Run the CLI in a goroutine
+			done := make(chan struct{})
+			var code int
+			go func() {
+				code = cli.Run(tt.args)
+				_ = w.Close()
+				close(done)
+			}()
+
+			// Read from the pipe
+			_, _ = io.Copy(&outputBuf, r)
+			<-done
+
+			// Restore stdout and stderr
+			os.Stdout = oldStdout
+			os.Stderr = oldStderr
+
+			output := outputBuf.String()
+
+			// Check exit code
+			if code != tt.expectedCode {
+				t.Errorf("Exit code = %v, want %v\nOutput: %s", code, tt.expectedCode, output)
+			}
+
+			// Check output
+			if tt.checkOutput != nil {
+				tt.checkOutput(t, output)
+			}
+		})
+	}
+}
+
+func TestCLI_displayStreaks(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name   string
+		events []github.GitHubEvent
+	}{
+		{"no activity", nil},
+		{"active", []github.GitHubEvent{{ID: "1", CreatedAt: now}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli := NewCLI(activity.NewActivityService(github.NewMockEventRepository(tt.events, nil)))
+			if code := cli.displayStreaks("testuser"); code != 0 {
+				t.Errorf("displayStreaks() = %d, want 0", code)
+			}
+		})
+	}
+
+	t.Run("error", func(t *testing.T) {
+		cli := NewCLI(activity.NewActivityService(github.NewMockEventRepository(nil, github.ErrUserNotFound)))
+		if code := cli.displayStreaks("testuser"); code != userNotFoundExitCode {
+			t.Errorf("displayStreaks() = %d, want %d", code, userNotFoundExitCode)
+		}
+	})
+}
+
+func TestCLI_displayAuthors(t *testing.T) {
+	events := []github.GitHubEvent{
+		{
+			ID:   "2",
+			Type: "PushEvent",
+			Repo: github.Repo{Name: "user/repo"},
+			Payload: json.RawMessage(`{"commits": [
+				{"sha": "a", "author": {"name": "Jane"}},
+				{"sha": "b", "author": {"name": "Jo"}}
+			]}`),
+		},
+		{
+			ID:      "1",
+			Type:    "PushEvent",
+			Repo:    github.Repo{Name: "user/other"},
+			Payload: json.RawMessage(`{"commits": [{"sha": "c", "author": {"name": "Jane"}}]}`),
+		},
+	}
+
+	tests := []struct {
+		name     string
+		filter   activity.EventFilter
+		expected string
+	}{
+		{"whole feed", activity.EventFilter{}, "Jane  2 commits\nJo    1 commit\n"},
+		{"one repository", activity.EventFilter{Repo: "user/other"}, "Jane  1 commit\n"},
+		{"nothing pushed", activity.EventFilter{Type: "WatchEvent"}, "No pushed commits found.\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli := NewCLI(activity.NewActivityService(github.NewMockEventRepository(events, nil)))
+			output := captureStdout(t, func() {
+				if code := cli.displayAuthors("testuser", tt.filter); code != 0 {
+					t.Errorf("displayAuthors() = %d, want 0", code)
+				}
+			})
+			if output != tt.expected {
+				t.Errorf("displayAuthors() printed %q, want %q", output, tt.expected)
+			}
+		})
+	}
+}
+
+// resourceFunc serves API resources from a function
+type resourceFunc func(path string) ([]byte, error)
+
+func (f resourceFunc) FetchResource(path string) ([]byte, error) {
+	return f(path)
+}
+
+func TestCLI_displayLanguages(t *testing.T) {
+	events := []github.GitHubEvent{
+		{ID: "3", Type: "PushEvent", Repo: github.Repo{Name: "user/api"}},
+		{ID: "2", Type: "PushEvent", Repo: github.Repo{Name: "user/api"}},
+		{ID: "1", Type: "WatchEvent", Repo: github.Repo{Name: "user/notes"}},
+	}
+	fetcher := resourceFunc(func(path string) ([]byte, error) {
+		if path == "/repos/user/api" {
+			return []byte(`{"language": "Go"}`), nil
+		}
+		return nil, errors.New("not found")
+	})
+
+	tests := []struct {
+		name     string
+		filter   activity.EventFilter
+		expected string
+	}{
+		{"whole feed", activity.EventFilter{}, "Go       2 events in 1 repo (67%)\nUnknown  1 event in 1 repo (33%)\n"},
+		{"nothing matched", activity.EventFilter{Type: "IssuesEvent"}, "No recent activity found.\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := activity.NewActivityService(github.NewMockEventRepository(events, nil))
+			service.SetEnricher(activity.NewEnricher(fetcher, nil, 2))
+			cli := NewCLI(service)
+			output := captureStdout(t, func() {
+				if code := cli.displayLanguages("testuser", tt.filter); code != 0 {
+					t.Errorf("displayLanguages() = %d, want 0", code)
+				}
+			})
+			if output != tt.expected {
+				t.Errorf("displayLanguages() printed %q, want %q", output, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCLI_displayOwners(t *testing.T) {
+	events := []github.GitHubEvent{
+		{ID: "4", Type: "PushEvent", Repo: github.Repo{Name: "acme/api"}},
+		{ID: "3", Type: "PushEvent", Repo: github.Repo{Name: "acme/web"}},
+		{ID: "2", Type: "PushEvent", Repo: github.Repo{Name: "testuser/notes"}},
+		{ID: "1", Type: "WatchEvent", Repo: github.Repo{Name: "golang/go"}},
+	}
+
+	tests := []struct {
+		name     string
+		filter   activity.EventFilter
+		expected string
+	}{
+		{
+			"whole feed",
+			activity.EventFilter{},
+			"acme            2 events in 2 repos (50%)\n" +
+				"golang          1 event in 1 repo (25%)\n" +
+				"testuser (own)  1 event in 1 repo (25%)\n",
+		},
+		{"nothing matched", activity.EventFilter{Type: "IssuesEvent"}, "No recent activity found.\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli := NewCLI(activity.NewActivityService(github.NewMockEventRepository(events, nil)))
+			output := captureStdout(t, func() {
+				if code := cli.displayOwners("testuser", tt.filter); code != 0 {
+					t.Errorf("displayOwners() = %d, want 0", code)
+				}
+			})
+			if output != tt.expected {
+				t.Errorf("displayOwners() printed %q, want %q", output, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCLI_displaySpikes(t *testing.T) {
+	now := time.Now()
+	burst := make([]github.GitHubEvent, 0)
+	for i := 0; i < activity.SpikeMinEvents; i++ {
+		burst = append(burst, github.GitHubEvent{Repo: github.Repo{Name: "bot/repo"}, CreatedAt: now.Add(-time.Minute)})
+	}
+
+	tests := []struct {
+		name     string
+		events   []github.GitHubEvent
+		expected int
+	}{
+		{"quiet", nil, 0},
+		{"spike", burst, spikeExitCode},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli := NewCLI(activity.NewActivityService(github.NewMockEventRepository(tt.events, nil)))
+			if code := cli.displaySpikes("testuser", "hour", chatSink{}); code != tt.expected {
+				t.Errorf("displaySpikes() = %d, want %d", code, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCLI_displayActivities(t *testing.T) {
+	t.Run("no activities", func(t *testing.T) {
+		service := activity.NewActivityService(github.NewMockEventRepository([]github.GitHubEvent{}, nil))
+		cli := NewCLI(service)
+		cli.output = &MockOutputFormatter{}
+
+		code := cli.displayActivities("testuser", activity.EventFilter{})
+		if code != 0 {
+			t.Errorf("Expected exit code 0, got %d", code)
+		}
+	})
+
+	t.Run("with activities", func(t *testing.T) {
+		events := []github.GitHubEvent{
+			{ID: "1", Type: "PushEvent", Repo: github.Repo{Name: "user/repo"}},
+		}
+		service := activity.NewActivityService(github.NewMockEventRepository(events, nil))
+
+		mockOutput := &MockOutputFormatter{}
+		cli := NewCLI(service)
+		cli.output = mockOutput
+
+		code := cli.displayActivities("testuser", activity.EventFilter{})
+		if code != 0 {
+			t.Errorf("Expected exit code 0, got %d", code)
+		}
+
+		if len(mockOutput.activities) != 1 {
+			t.Errorf("Expected 1 activity to be formatted, got %d", len(mockOutput.activities))
+		}
+	})
+}
+
+func TestCLI_streamActivities(t *testing.T) {
+	cli := NewCLI(activity.NewActivityService(usersRepository{}))
+
+	var code int
+	output := captureStdout(t, func() {
+		code = cli.Run([]string{"github-activity", "-format=ndjson", "alice"})
+	})
+	if code != 0 {
+		t.Fatalf("Run() = %d, want 0", code)
+	}
+	var decoded activity.ActivitySummary
+	if err := json.Unmarshal([]byte(output), &decoded); err != nil {
+		t.Fatalf("Output is not one JSON line: %v\n%s", err, output)
+	}
+	if decoded.Repository != "alice/repo" || decoded.Type != "PushEvent" {
+		t.Errorf("Decoded = %+v", decoded)
+	}
+
+	// Providers that cannot stream are listed once fetched
+	provider := &fakeActivityProvider{summaries: []activity.ActivitySummary{{Description: "Did something"}}}
+	cli = NewCLI(provider)
+	cli.output = &format.NDJSONOutputFormatter{}
+	output = captureStdout(t, func() {
+		code = cli.streamActivities("alice", activity.EventFilter{})
+	})
+	if code != 0 || !strings.Contains(output, `"description":"Did something"`) {
+		t.Errorf("streamActivities() = %d, output %q", code, output)
+	}
+}
+
+func TestCLI_displayDetailedActivities(t *testing.T) {
+	events := []github.GitHubEvent{
+		{
+			ID:    "1",
+			Type:  "PushEvent",
+			Repo:  github.Repo{Name: "user/repo"},
+			Actor: github.Actor{Login: "testuser"},
+		},
+	}
+	service := activity.NewActivityService(github.NewMockEventRepository(events, nil))
+
+	mockOutput := &MockOutputFormatter{}
+	cli := NewCLI(service)
+	cli.output = mockOutput
+
+	code := cli.displayDetailedActivities("testuser", activity.EventFilter{})
+	if code != 0 {
+		t.Errorf("Expected exit code 0, got %d", code)
+	}
+
+	if len(mockOutput.detailedActivities) != 1 {
+		t.Errorf("Expected 1 detailed activity to be formatted, got %d",
+			len(mockOutput.detailedActivities))
+	}
+}
+
+// captureStdout returns what fn prints to standard output
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	output := make(chan string)
+	go func() {
+		var buf bytes.Buffer
+		_, _ = io.Copy(&buf, r)
+		output <- buf.String()
+	}()
+	fn()
+	_ = w.Close()
+	return <-output
+}
+
+func TestCLI_reportError(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"user not found", fmt.Errorf("failed to fetch events: %w", github.ErrUserNotFound), userNotFoundExitCode},
+		{"unauthorized", github.ErrUnauthorized, unauthorizedExitCode},
+		{"rate limit", github.ErrRateLimitExceeded, rateLimitExitCode},
+		{"secondary rate limit", &github.RepositoryError{Code: github.ErrRateLimitExceeded.Code, RetryAfter: 5 * time.Minute}, rateLimitExitCode},
+		{"network", &github.RepositoryError{Code: github.ErrNetworkError.Code, Message: "dial failed"}, networkExitCode},
+		{"data pending", fmt.Errorf("giving up after 2 retries: %w", github.ErrDataPending), 1},
+		{"other", errors.New("invalid limit"), 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli := NewCLI(nil)
+			if code := cli.reportError(tt.err); code != tt.expected {
+				t.Errorf("reportError() = %d, want %d", code, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCLI_Days(t *testing.T) {
+	events := []github.GitHubEvent{
+		{ID: "2", Type: "WatchEvent", Repo: github.Repo{Name: "user/recent"}, CreatedAt: time.Now().Add(-time.Hour)},
+		{ID: "1", Type: "WatchEvent", Repo: github.Repo{Name: "user/stale"}, CreatedAt: time.Now().AddDate(0, 0, -3)},
+	}
+	cli := NewCLI(activity.NewActivityService(&countingRepository{events: events}))
+
+	var code int
+	output := captureStdout(t, func() { code = cli.Run([]string{"github-activity", "-days=1", "-format=csv", "octocat"}) })
+	if code != 0 {
+		t.Fatalf("Run() = %d, want 0", code)
+	}
+	if !strings.Contains(output, "user/recent") || strings.Contains(output, "user/stale") {
+		t.Errorf("Output should only list the last day:\n%s", output)
+	}
+}
+
+func TestCLI_Days_ServiceClock(t *testing.T) {
+	now := time.Date(2024, 1, 10, 12, 0, 0, 0, time.UTC)
+	events := []github.GitHubEvent{
+		{ID: "2", Type: "WatchEvent", Repo: github.Repo{Name: "user/recent"}, CreatedAt: now.Add(-time.Hour)},
+		{ID: "1", Type: "WatchEvent", Repo: github.Repo{Name: "user/stale"}, CreatedAt: now.AddDate(0, 0, -3)},
+	}
+	clock := github.NewFakeClock(now)
+	service := activity.NewActivityService(&countingRepository{events: events}, activity.WithClock(clock.Now))
+	cli := NewCLI(service)
+
+	var code int
+	output := captureStdout(t, func() { code = cli.Run([]string{"github-activity", "-days=1", "-format=csv", "octocat"}) })
+	if code != 0 {
+		t.Fatalf("Run() = %d, want 0", code)
+	}
+	if !strings.Contains(output, "user/recent") || strings.Contains(output, "user/stale") {
+		t.Errorf("Output should only list the day before the service clock:\n%s", output)
+	}
+}
+
+func TestCLI_TemplateError(t *testing.T) {
+	events := []github.GitHubEvent{
+		{ID: "1", Type: "WatchEvent", Repo: github.Repo{Name: "user/repo"}, CreatedAt: time.Now()},
+	}
+	cli := NewCLI(activity.NewActivityService(github.NewMockEventRepository(events, nil)))
+
+	stderr := os.Stderr
+	os.Stderr, _ = os.Open(os.DevNull)
+	var code int
+	output := captureStdout(t, func() {
+		code = cli.Run([]string{"github-activity", "-format=template", "-template={{.Descripton}}", "octocat"})
+	})
+	os.Stderr = stderr
+
+	if code == 0 {
+		t.Error("Run() = 0, want a failure for an unknown template field")
+	}
+	if output != "" {
+		t.Errorf("Stdout = %q, want nothing", output)
+	}
+}
+
+func TestCLI_EmptyDocument(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{"csv header", []string{"-format=csv"}, "timestamp,type,repo,description,actor,commits\n"},
+		{"tsv header", []string{"-format=tsv"}, "timestamp\ttype\trepo\tdescription\tactor\tcommits\n"},
+		{"yaml sequence", []string{"-format=yaml"}, "[]\n"},
+		{"detailed yaml", []string{"-format=yaml", "-detailed"}, "[]\n"},
+		{"console", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli := NewCLI(activity.NewActivityService(github.NewMockEventRepository(nil, nil)))
+			args := append(append([]string{"github-activity", "-quiet"}, tt.args...), "emptyuser")
+
+			var code int
+			output := captureStdout(t, func() { code = cli.Run(args) })
+			if code != 0 {
+				t.Fatalf("Run() = %d, want 0", code)
+			}
+			if output != tt.expected {
+				t.Errorf("Stdout = %q, want %q", output, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/alnah/github-activity/internal/messages"
+	"github.com/alnah/github-activity/pkg/activity"
+)
+
+// Commits - The commits subcommand
+
+// shortSHA is how many characters of a commit SHA are shown, as git does
+const shortSHA = 7
+
+// abbreviate shortens a commit SHA for display
+func abbreviate(sha string) string {
+	return sha[:min(len(sha), shortSHA)]
+}
+
+// renderCommits writes the per-repository and per-day counts followed by the
+// commits, as plain text or as a Markdown section ready for a standup
+func renderCommits(w io.Writer, report *activity.CommitReport, markdown bool) {
+	period := fmt.Sprintf("%s to %s",
+		report.Since.Format("2006-01-02"), report.Until.Format("2006-01-02"))
+	total := fmt.Sprintf("%s in %s",
+		messages.Plural("commits", len(report.Commits)), messages.Plural("repos", len(report.ByRepo)))
+
+	if markdown {
+		_, _ = fmt.Fprintf(w, "## Commits by %s\n\n_%s: %s_\n", report.Username, period, total)
+		if len(report.Commits) == 0 {
+			return
+		}
+		_, _ = fmt.Fprintf(w, "\n### By repository\n\n")
+		for _, count := range report.ByRepo {
+			_, _ = fmt.Fprintf(w, "- %s: %s\n", count.Key, messages.Plural("commits", count.Commits))
+		}
+		_, _ = fmt.Fprintf(w, "\n### By day\n\n")
+		for _, count := range report.ByDay {
+			_, _ = fmt.Fprintf(w, "- %s: %s\n", count.Key, messages.Plural("commits", count.Commits))
+		}
+		_, _ = fmt.Fprintf(w, "\n### Commits\n\n")
+		for _, commit := range report.Commits {
+			_, _ = fmt.Fprintf(w, "- `%s` %s (%s)\n", abbreviate(commit.SHA), commit.Message, commit.Repo)
+		}
+		return
+	}
+
+	_, _ = fmt.Fprintf(w, "Commits by %s, %s: %s\n", report.Username, period, total)
+	if len(report.Commits) == 0 {
+		return
+	}
+	width := 0
+	for _, count := range report.ByRepo {
+		width = max(width, utf8.RuneCountInString(count.Key))
+	}
+	_, _ = fmt.Fprintf(w, "\nBy repository:\n")
+	for _, count := range report.ByRepo {
+		_, _ = fmt.Fprintf(w, "  %-*s  %s\n", width, count.Key, messages.Plural("commits", count.Commits))
+	}
+	_, _ = fmt.Fprintf(w, "\nBy day:\n")
+	for _, count := range report.ByDay {
+		_, _ = fmt.Fprintf(w, "  %s  %s\n", count.Key, messages.Plural("commits", count.Commits))
+	}
+	_, _ = fmt.Fprintln(w)
+	for _, commit := range report.Commits {
+		line := fmt.Sprintf("%s  %-*s  %s", abbreviate(commit.SHA), width, commit.Repo, commit.Message)
+		_, _ = fmt.Fprintln(w, strings.TrimRight(line, " "))
+	}
+}
+
+// runCommits handles `commits [-days=7] [-format=console|markdown] <username>`
+func (c *CLI) runCommits(args []string) int {
+	flags, err := c.resolveFlags(append([]string{"github-activity commits"}, args...))
+	if err != nil {
+		return c.reportFlagsError(err)
+	}
+	if !c.hasUsername(flags) {
+		fmt.Println("Usage:")
+		fmt.Println("  github-activity commits [-days=7] [-format=console|markdown] <username>")
+		return 1
+	}
+
+	format := strings.ToLower(flags.Format)
+	if format != "" && format != "console" && format != "markdown" {
+		fmt.Fprintf(os.Stderr, "Error: commits supports -format=console or markdown, not %s\n", format)
+		return 1
+	}
+	days := flags.Days
+	if days == 0 {
+		days = defaultSummaryDays
+	}
+
+	// The formatter is not used and the whole feed is needed
+	flags.Format = ""
+	flags.Limit = 0
+	run, err := c.setup(flags)
+	if err != nil {
+		return c.reportError(err)
+	}
+	run.filter.MaxLimit = 0
+
+	report, err := c.service.GetCommitReport(run.username, run.filter, days)
+	failures, ok := partialFailures(err)
+	if !ok {
+		return c.reportError(err)
+	}
+
+	renderCommits(os.Stdout, report, format == "markdown")
+	printWarnings(os.Stderr, failures)
+	return 0
+}
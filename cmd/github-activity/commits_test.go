@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/activity"
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+func TestRenderCommits(t *testing.T) {
+	since := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+	report := &activity.CommitReport{
+		Username: "alnah",
+		Since:    since,
+		Until:    since.AddDate(0, 0, 7),
+		Commits: []activity.PushedCommit{
+			{SHA: "9f2c1ab5d3e", Message: "Retry rate-limited requests", Repo: "alnah/api"},
+			{SHA: "4e8d0c2", Message: "Document commits", Repo: "alnah/docs"},
+		},
+		ByRepo: []activity.CommitCount{{Key: "alnah/api", Commits: 1}, {Key: "alnah/docs", Commits: 1}},
+		ByDay:  []activity.CommitCount{{Key: "2024-01-15", Commits: 2}},
+	}
+	empty := &activity.CommitReport{Username: "alnah", Since: since, Until: since.AddDate(0, 0, 7)}
+
+	tests := []struct {
+		name     string
+		report   *activity.CommitReport
+		markdown bool
+		expected string
+	}{
+		{
+			"console",
+			report,
+			false,
+			"Commits by alnah, 2024-01-08 to 2024-01-15: 2 commits in 2 repos\n\n" +
+				"By repository:\n  alnah/api   1 commit\n  alnah/docs  1 commit\n\n" +
+				"By day:\n  2024-01-15  2 commits\n\n" +
+				"9f2c1ab  alnah/api   Retry rate-limited requests\n" +
+				"4e8d0c2  alnah/docs  Document commits\n",
+		},
+		{
+			"markdown",
+			report,
+			true,
+			"## Commits by alnah\n\n_2024-01-08 to 2024-01-15: 2 commits in 2 repos_\n\n" +
+				"### By repository\n\n- alnah/api: 1 commit\n- alnah/docs: 1 commit\n\n" +
+				"### By day\n\n- 2024-01-15: 2 commits\n\n" +
+				"### Commits\n\n- `9f2c1ab` Retry rate-limited requests (alnah/api)\n" +
+				"- `4e8d0c2` Document commits (alnah/docs)\n",
+		},
+		{
+			"no commits",
+			empty,
+			false,
+			"Commits by alnah, 2024-01-08 to 2024-01-15: 0 commits in 0 repos\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			renderCommits(&buf, tt.report, tt.markdown)
+			if buf.String() != tt.expected {
+				t.Errorf("Output = %q, want %q", buf.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestCLI_runCommits(t *testing.T) {
+	events := []github.GitHubEvent{
+		{
+			ID:        "1",
+			Type:      "PushEvent",
+			Repo:      github.Repo{Name: "user/repo"},
+			Payload:   json.RawMessage(`{"size":1,"commits":[{"sha":"abc1234","message":"Fix"}]}`),
+			CreatedAt: time.Now(),
+		},
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected int
+	}{
+		{"console", []string{"testuser"}, 0},
+		{"markdown", []string{"-format=markdown", "-days=1", "testuser"}, 0},
+		{"unsupported format", []string{"-format=csv", "testuser"}, 1},
+		{"missing username", nil, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli := NewCLI(activity.NewActivityService(github.NewMockEventRepository(events, nil)))
+			args := append([]string{"github-activity", "commits"}, tt.args...)
+			if code := cli.Run(args); code != tt.expected {
+				t.Errorf("Run() = %d, want %d", code, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,857 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/mail"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alnah/github-activity/internal/messages"
+	"github.com/alnah/github-activity/pkg/activity"
+	"github.com/alnah/github-activity/pkg/format"
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// Configuration - Defaults loaded from a config file with optional profiles
+
+// ConfigEnvVar overrides the config file location
+const ConfigEnvVar = "GITHUB_ACTIVITY_CONFIG"
+
+// WorkspaceConfigName is the per-directory config discovered by walking up
+// from the working directory
+const WorkspaceConfigName = ".github-activity.yaml"
+
+// workspaceKeys are the settings a workspace config may set. A workspace file
+// usually comes with a cloned repository, so it is limited to filters and
+// display options and can never redirect requests, notifications or the token.
+var workspaceKeys = map[string]bool{
+	"user":     true,
+	"repo":     true,
+	"type":     true,
+	"actor":    true,
+	"action":   true,
+	"limit":    true,
+	"days":     true,
+	"detailed": true,
+	"format":   true,
+	"group_by": true,
+	"tz":       true,
+	"emoji":    true,
+	"collapse": true,
+}
+
+// configKeys lists the supported settings and how their values are validated
+var configKeys = map[string]func(value string) error{
+	"type":                validateConfigEventType,
+	"limit":               validateConfigCount,
+	"days":                validateConfigCount,
+	"detailed":            validateConfigBool,
+	"format":              validateConfigFormat,
+	"template":            func(string) error { return nil },
+	"retries":             validateConfigCount,
+	"concurrency":         validateConfigPositive,
+	"cache_ttl":           validateConfigDuration,
+	"api_url":             validateConfigURL,
+	"profile":             func(string) error { return nil },
+	"tz":                  validateConfigTimezone,
+	"user":                validateConfigUser,
+	"repo":                validateConfigRepo,
+	"actor":               validateConfigActor,
+	"action":              validateConfigAction,
+	"group_by":            validateConfigGroupBy,
+	"slack_webhook_url":   validateConfigURL,
+	"discord_webhook_url": validateConfigURL,
+	"smtp_host":           validateConfigHost,
+	"smtp_port":           validateConfigPort,
+	"smtp_username":       func(string) error { return nil },
+	"smtp_password":       func(string) error { return nil },
+	"email_from":          validateConfigEmail,
+	"email_to":            validateConfigEmails,
+	"db":                  func(string) error { return nil },
+	"emoji":               validateConfigBool,
+	"emoji_map":           validateConfigEmojiMap,
+	"collapse":            validateConfigBool,
+	"archive_fallback":    validateConfigBool,
+}
+
+// configStarter is written by `config init`; the format list comes from the
+// registry so it follows the formats that exist
+var configStarter = `# github-activity configuration
+#
+# Top-level settings are defaults for every run. Command-line flags always win.
+
+# Filter by event type (e.g. PushEvent, IssuesEvent)
+# type: PushEvent
+
+# Number of events displayed
+# limit: 30
+
+# Only consider events from the last N days (0 = no limit)
+# days: 0
+
+# Show detailed information for each event
+# detailed: false
+
+# Output format: ` + strings.Join(format.Formats.Names(), ", ") + `
+# format: console
+
+# Go template used with format: template
+# template: "{{.Timestamp}} {{.Description}}"
+
+# Retries for transient API failures
+# retries: 2
+
+# Users fetched at once when several are given, and by the team subcommand
+# concurrency: 4
+
+# How long fetched events are reused (Go duration, e.g. 5m, 1h)
+# cache_ttl: 5m
+
+# Time zone for displayed times: an IANA name such as America/New_York, or local
+# tz: UTC
+
+# GitHub API base URL (GitHub Enterprise: https://github.example.com/api/v3)
+# api_url: https://api.github.com
+
+# Default user when none is given on the command line
+# user: octocat
+
+# Only show events in this repository (owner/repo) or organization (owner)
+# repo: octocat/hello-world
+
+# Only show events performed by this login, e.g. in the received feed
+# actor: hubot
+
+# Only show events with this payload action; closed includes merged
+# action: merged
+
+# Insert date headers between events: day
+# group_by: day
+
+# Slack incoming webhook that receives the activity instead of the terminal
+# slack_webhook_url: https://hooks.slack.com/services/...
+
+# Discord webhook that receives the activity instead of the terminal
+# discord_webhook_url: https://discord.com/api/webhooks/...
+
+# Mail server and addresses for the digest subcommand. The password can be
+# left out and given in SMTP_PASSWORD instead.
+# smtp_host: smtp.example.com
+# smtp_port: 587
+# smtp_username: octocat
+# smtp_password: ...
+# email_from: GitHub Activity <activity@example.com>
+# email_to: team@example.com, lead@example.com
+
+# Description templates per event type (Go text/template). Fields are the ones
+# -explain prints, capitalized: .Repo, .Branch, .Size, .Number, .Title...
+# PushEvent: "🚀 {{.Size}} commits → {{.Repo}}@{{.Branch}}"
+
+# Prefix descriptions with an emoji per event type, and override the defaults
+# emoji: true
+# emoji_map: push=🚀, star=🌟, release=
+
+# Merge consecutive pushes to the same branch into one line
+# collapse: true
+
+# Event archive kept by the sync subcommand and read with -from-db
+# db: /home/octocat/github-activity/archive.json
+
+# Read events older than GitHub's 300-event / 90-day window from the archive
+# archive_fallback: true
+
+# Profile applied by default; override with -profile
+# profile: work
+
+# Named profiles override top-level settings
+# profiles:
+#   work:
+#     api_url: https://github.example.com/api/v3
+#     limit: 50
+`
+
+// ConfigEntry is a single key/value setting with its source line
+type ConfigEntry struct {
+	Key   string
+	Value string
+	Line  int
+}
+
+// ConfigProfile is a named group of settings
+type ConfigProfile struct {
+	Name    string
+	Line    int
+	Entries []ConfigEntry
+}
+
+// Config holds the parsed contents of a config file
+type Config struct {
+	Path     string
+	Entries  []ConfigEntry
+	Profiles []ConfigProfile
+}
+
+// ConfigIssue is a problem found in a config file, tied to a line
+type ConfigIssue struct {
+	Path    string
+	Line    int
+	Message string
+}
+
+func (i ConfigIssue) Error() string {
+	if i.Line > 0 {
+		return fmt.Sprintf("%s:%d: %s", i.Path, i.Line, i.Message)
+	}
+	return fmt.Sprintf("%s: %s", i.Path, i.Message)
+}
+
+// DefaultConfigPath returns the config file location, honoring GITHUB_ACTIVITY_CONFIG
+func DefaultConfigPath() string {
+	if path := os.Getenv(ConfigEnvVar); path != "" {
+		return path
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "github-activity", "config.yaml")
+}
+
+// LoadConfig reads and validates a config file without network checks.
+// A missing file is not an error and returns a nil config.
+func LoadConfig(path string) (*Config, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open config: %w", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	config, issues := ParseConfig(path, file)
+	if len(issues) == 0 {
+		issues = config.Validate(nil)
+	}
+	if len(issues) > 0 {
+		return nil, issues[0]
+	}
+	return config, nil
+}
+
+// ParseConfig parses the YAML subset used by config files: top-level
+// "key: value" pairs and a "profiles:" section of named key/value maps
+func ParseConfig(path string, r io.Reader) (*Config, []ConfigIssue) {
+	config := &Config{Path: path}
+	issues := make([]ConfigIssue, 0)
+	issue := func(line int, format string, args ...any) {
+		issues = append(issues, ConfigIssue{Path: path, Line: line, Message: fmt.Sprintf(format, args...)})
+	}
+
+	inProfiles := false
+	profileIndent, keyIndent := -1, -1
+	var profile *ConfigProfile
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		raw := stripConfigComment(scanner.Text())
+		if strings.TrimSpace(raw) == "" {
+			continue
+		}
+
+		indentText := raw[:len(raw)-len(strings.TrimLeft(raw, " \t"))]
+		if strings.Contains(indentText, "\t") {
+			issue(lineNumber, "tabs are not allowed for indentation")
+			continue
+		}
+		indent := len(indentText)
+
+		key, value, ok := strings.Cut(strings.TrimSpace(raw), ":")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			issue(lineNumber, "expected \"key: value\"")
+			continue
+		}
+		value, err := unquoteConfigValue(strings.TrimSpace(value))
+		if err != nil {
+			issue(lineNumber, "invalid quoted value for %q", key)
+			continue
+		}
+
+		switch {
+		case indent == 0:
+			profile = nil
+			inProfiles = key == "profiles"
+			if inProfiles {
+				if value != "" {
+					issue(lineNumber, "profiles must be a section, not a value")
+				}
+				continue
+			}
+			config.Entries = append(config.Entries, ConfigEntry{Key: key, Value: value, Line: lineNumber})
+
+		case !inProfiles:
+			issue(lineNumber, "unexpected indentation")
+
+		case profileIndent == -1 || indent == profileIndent:
+			profileIndent = indent
+			if value != "" {
+				issue(lineNumber, "profile %q must be a section, not a value", key)
+				continue
+			}
+			config.Profiles = append(config.Profiles, ConfigProfile{Name: key, Line: lineNumber})
+			profile = &config.Profiles[len(config.Profiles)-1]
+
+		case profile != nil && indent > profileIndent && (keyIndent == -1 || indent == keyIndent):
+			keyIndent = indent
+			profile.Entries = append(profile.Entries, ConfigEntry{Key: key, Value: value, Line: lineNumber})
+
+		default:
+			issue(lineNumber, "unexpected indentation")
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		issue(0, "failed to read config: %v", err)
+	}
+
+	return config, issues
+}
+
+// stripConfigComment removes a trailing # comment outside of quotes
+func stripConfigComment(line string) string {
+	var quote rune
+	for i, r := range line {
+		switch {
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '"' || r == '\'':
+			quote = r
+		case r == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
+
+// unquoteConfigValue removes single or double quotes around a value
+func unquoteConfigValue(value string) (string, error) {
+	if len(value) >= 2 && value[0] == '"' && value[len(value)-1] == '"' {
+		return strconv.Unquote(value)
+	}
+	if len(value) >= 2 && value[0] == '\'' && value[len(value)-1] == '\'' {
+		return strings.ReplaceAll(value[1:len(value)-1], "''", "'"), nil
+	}
+	if strings.HasPrefix(value, "\"") || strings.HasPrefix(value, "'") {
+		return "", fmt.Errorf("unterminated quote")
+	}
+	return value, nil
+}
+
+// Validate checks keys, values and profiles. When client is not nil, api_url
+// values are also checked for reachability.
+func (c *Config) Validate(client *http.Client) []ConfigIssue {
+	issues := make([]ConfigIssue, 0)
+	issue := func(line int, format string, args ...any) {
+		issues = append(issues, ConfigIssue{Path: c.Path, Line: line, Message: fmt.Sprintf(format, args...)})
+	}
+
+	checkEntries := func(entries []ConfigEntry, scope string) {
+		seen := make(map[string]int)
+		for _, entry := range entries {
+			validate, known := configKeys[entry.Key]
+			if !known && isDescriptionKey(entry.Key) {
+				validate, known = validateConfigDescription, true
+			}
+			if !known {
+				issue(entry.Line, "unknown key %q%s", entry.Key, scope)
+				continue
+			}
+			if scope != "" && entry.Key == "profile" {
+				issue(entry.Line, "profiles cannot select another profile%s", scope)
+				continue
+			}
+			if first, duplicate := seen[entry.Key]; duplicate {
+				issue(entry.Line, "duplicate key %q%s (first set on line %d)", entry.Key, scope, first)
+				continue
+			}
+			seen[entry.Key] = entry.Line
+			if err := validate(entry.Value); err != nil {
+				issue(entry.Line, "invalid %s: %v", entry.Key, err)
+				continue
+			}
+			if entry.Key == "api_url" && client != nil {
+				if err := checkAPIURL(client, entry.Value); err != nil {
+					issue(entry.Line, "api_url is unreachable: %v", err)
+				}
+			}
+		}
+	}
+
+	checkEntries(c.Entries, "")
+
+	profiles := make(map[string]int)
+	for _, profile := range c.Profiles {
+		if first, duplicate := profiles[profile.Name]; duplicate {
+			issue(profile.Line, "profile %q is defined twice (first on line %d)", profile.Name, first)
+		}
+		profiles[profile.Name] = profile.Line
+		checkEntries(profile.Entries, fmt.Sprintf(" in profile %q", profile.Name))
+	}
+
+	for _, entry := range c.Entries {
+		if entry.Key == "profile" {
+			if _, exists := profiles[entry.Value]; !exists {
+				issue(entry.Line, "profile %q is not defined", entry.Value)
+			}
+		}
+	}
+
+	for _, name := range append([]string{""}, c.profileNames()...) {
+		settings, err := c.Settings(name)
+		if err != nil {
+			continue
+		}
+		if strings.EqualFold(settings["format"], "template") && settings["template"] == "" {
+			line := c.lineOf(name, "format")
+			if name == "" {
+				issue(line, "format is template but no template is set")
+			} else {
+				issue(line, "format is template but no template is set in profile %q", name)
+			}
+		}
+	}
+
+	return issues
+}
+
+// profileNames returns the names of all defined profiles
+func (c *Config) profileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for _, profile := range c.Profiles {
+		names = append(names, profile.Name)
+	}
+	return names
+}
+
+// lineOf returns the line where key is set for the given profile
+func (c *Config) lineOf(profile, key string) int {
+	for _, p := range c.Profiles {
+		if p.Name == profile {
+			for _, entry := range p.Entries {
+				if entry.Key == key {
+					return entry.Line
+				}
+			}
+		}
+	}
+	for _, entry := range c.Entries {
+		if entry.Key == key {
+			return entry.Line
+		}
+	}
+	return 0
+}
+
+// Settings returns the effective settings for a profile. An empty name uses the
+// profile selected by the config file, if any.
+func (c *Config) Settings(profile string) (map[string]string, error) {
+	settings := make(map[string]string)
+	for _, entry := range c.Entries {
+		settings[entry.Key] = entry.Value
+	}
+
+	if profile == "" {
+		profile = settings["profile"]
+	}
+	delete(settings, "profile")
+	if profile == "" {
+		return settings, nil
+	}
+
+	for _, p := range c.Profiles {
+		if p.Name == profile {
+			for _, entry := range p.Entries {
+				settings[entry.Key] = entry.Value
+			}
+			return settings, nil
+		}
+	}
+	return nil, fmt.Errorf("profile %q is not defined in %s", profile, c.Path)
+}
+
+// checkAPIURL verifies that an API URL answers HTTP requests
+func checkAPIURL(client *http.Client, apiURL string) error {
+	resp, err := client.Get(apiURL)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+	return nil
+}
+
+// isDescriptionKey reports whether a key names an event type, such as
+// PushEvent, whose value is a description template
+func isDescriptionKey(key string) bool {
+	name, ok := strings.CutSuffix(key, "Event")
+	if !ok || name == "" || name[0] < 'A' || name[0] > 'Z' {
+		return false
+	}
+	return !strings.ContainsAny(name, "_- ")
+}
+
+func validateConfigDescription(value string) error {
+	_, err := github.NewTemplateRenderer(value)
+	return err
+}
+
+func validateConfigEmojiMap(value string) error {
+	_, err := activity.ParseEmojiMap(value)
+	return err
+}
+
+func validateConfigEventType(value string) error {
+	options := activity.ActivityOptions{EventType: github.ResolveEventType(value)}
+	return options.Validate()
+}
+
+func validateConfigCount(value string) error {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("%q is not a number", value)
+	}
+	if n < 0 {
+		return fmt.Errorf("%d cannot be negative", n)
+	}
+	return nil
+}
+
+func validateConfigPositive(value string) error {
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("%q is not a number", value)
+	}
+	if n < 1 {
+		return fmt.Errorf("%d must be at least 1", n)
+	}
+	return nil
+}
+
+func validateConfigBool(value string) error {
+	if _, err := strconv.ParseBool(value); err != nil {
+		return fmt.Errorf("%q is not true or false", value)
+	}
+	return nil
+}
+
+func validateConfigFormat(value string) error {
+	if _, known := format.Formats[strings.ToLower(value)]; !known {
+		return fmt.Errorf("unknown format %q", value)
+	}
+	return nil
+}
+
+func validateConfigDuration(value string) error {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return fmt.Errorf("%q is not a duration (e.g. 30s, 5m, 1h)", value)
+	}
+	if duration < 0 {
+		return fmt.Errorf("%s cannot be negative", value)
+	}
+	return nil
+}
+
+func validateConfigTimezone(value string) error {
+	_, err := activity.LoadTimezone(value)
+	return err
+}
+
+func validateConfigUser(value string) error {
+	if value == "" || strings.ContainsAny(value, "/ ") {
+		return fmt.Errorf("%q is not a GitHub username", value)
+	}
+	return nil
+}
+
+func validateConfigRepo(value string) error {
+	options := activity.ActivityOptions{Repo: value}
+	return options.Validate()
+}
+
+func validateConfigActor(value string) error {
+	options := activity.ActivityOptions{Actor: value}
+	return options.Validate()
+}
+
+func validateConfigAction(value string) error {
+	options := activity.ActivityOptions{Action: value}
+	return options.Validate()
+}
+
+func validateConfigGroupBy(value string) error {
+	options := activity.ActivityOptions{GroupBy: value}
+	return options.Validate()
+}
+
+func validateConfigURL(value string) error {
+	parsed, err := url.Parse(value)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("%q is not an http(s) URL", value)
+	}
+	return nil
+}
+
+func validateConfigHost(value string) error {
+	if value == "" || strings.ContainsAny(value, "/: ") {
+		return fmt.Errorf("%q is not a host name", value)
+	}
+	return nil
+}
+
+func validateConfigPort(value string) error {
+	port, err := strconv.Atoi(value)
+	if err != nil || port < 1 || port > 65535 {
+		return fmt.Errorf("%q is not a port number", value)
+	}
+	return nil
+}
+
+func validateConfigEmail(value string) error {
+	if _, err := mail.ParseAddress(value); err != nil {
+		return fmt.Errorf("%q is not an email address", value)
+	}
+	return nil
+}
+
+func validateConfigEmails(value string) error {
+	addresses := splitAddresses(value)
+	if len(addresses) == 0 {
+		return fmt.Errorf("no email address given")
+	}
+	for _, address := range addresses {
+		if err := validateConfigEmail(address); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteConfigStarter writes a commented starter config file, refusing to
+// overwrite an existing one unless force is set
+func WriteConfigStarter(path string, force bool) error {
+	if !force {
+		if _, err := os.Stat(path); err == nil {
+			return fmt.Errorf("%s already exists (use -force to overwrite)", path)
+		}
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(configStarter), 0o644); err != nil {
+		return fmt.Errorf("failed to write config: %w", err)
+	}
+	return nil
+}
+
+// configSetters apply a validated config value to the corresponding flag
+var configSetters = map[string]func(flags *CLIFlags, value string){
+	"type":     func(flags *CLIFlags, value string) { flags.EventType = value },
+	"limit":    func(flags *CLIFlags, value string) { flags.Limit, _ = strconv.Atoi(value) },
+	"days":     func(flags *CLIFlags, value string) { flags.Days, _ = strconv.Atoi(value) },
+	"detailed": func(flags *CLIFlags, value string) { flags.Detailed, _ = strconv.ParseBool(value) },
+	"format":   func(flags *CLIFlags, value string) { flags.Format = value },
+	"template": func(flags *CLIFlags, value string) { flags.Template = value },
+	"retries":  func(flags *CLIFlags, value string) { flags.Retries, _ = strconv.Atoi(value) },
+	"concurrency": func(flags *CLIFlags, value string) {
+		flags.Concurrency, _ = strconv.Atoi(value)
+	},
+	"cache_ttl": func(flags *CLIFlags, value string) {
+		flags.CacheTTL, _ = time.ParseDuration(value)
+	},
+	"api_url":  func(flags *CLIFlags, value string) { flags.APIURL = value },
+	"tz":       func(flags *CLIFlags, value string) { flags.Timezone = value },
+	"user":     func(flags *CLIFlags, value string) { flags.User = value },
+	"repo":     func(flags *CLIFlags, value string) { flags.Repo = value },
+	"actor":    func(flags *CLIFlags, value string) { flags.Actor = value },
+	"action":   func(flags *CLIFlags, value string) { flags.Action = value },
+	"group_by": func(flags *CLIFlags, value string) { flags.GroupBy = value },
+	"slack_webhook_url": func(flags *CLIFlags, value string) {
+		flags.SlackWebhook = value
+	},
+	"discord_webhook_url": func(flags *CLIFlags, value string) {
+		flags.DiscordWebhook = value
+	},
+	"smtp_host":     func(flags *CLIFlags, value string) { flags.SMTP.Host = value },
+	"smtp_port":     func(flags *CLIFlags, value string) { flags.SMTP.Port, _ = strconv.Atoi(value) },
+	"smtp_username": func(flags *CLIFlags, value string) { flags.SMTP.Username = value },
+	"smtp_password": func(flags *CLIFlags, value string) { flags.SMTP.Password = value },
+	"email_from":    func(flags *CLIFlags, value string) { flags.EmailFrom = value },
+	"email_to":      func(flags *CLIFlags, value string) { flags.EmailTo = value },
+	"db":            func(flags *CLIFlags, value string) { flags.DB = value },
+	"emoji":         func(flags *CLIFlags, value string) { flags.Emoji, _ = strconv.ParseBool(value) },
+	"emoji_map":     func(flags *CLIFlags, value string) { flags.EmojiMap = value },
+	"collapse":      func(flags *CLIFlags, value string) { flags.Collapse, _ = strconv.ParseBool(value) },
+	"archive_fallback": func(flags *CLIFlags, value string) {
+		flags.ArchiveFallback, _ = strconv.ParseBool(value)
+	},
+}
+
+// FindWorkspaceConfig returns the nearest .github-activity.yaml in dir or
+// one of its parents, or an empty string when there is none
+func FindWorkspaceConfig(dir string) string {
+	for {
+		path := filepath.Join(dir, WorkspaceConfigName)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// applyConfig fills flags that were not given on the command line from the
+// config file, the selected profile and the workspace config
+func (c *CLI) applyConfig(flags *CLIFlags) error {
+	path := flags.ConfigPath
+	if path == "" {
+		path = c.configPath
+	} else if _, err := os.Stat(path); err != nil {
+		return fmt.Errorf("config file not found: %s", path)
+	}
+
+	config, err := LoadConfig(path)
+	if err != nil {
+		return err
+	}
+
+	settings := make(map[string]string)
+	if config != nil {
+		if settings, err = config.Settings(flags.Profile); err != nil {
+			return err
+		}
+	} else if flags.Profile != "" {
+		return fmt.Errorf("profile %q requires a config file", flags.Profile)
+	}
+
+	// Workspace settings are more specific than the user's config
+	if c.workDir != "" {
+		workspace, err := LoadConfig(FindWorkspaceConfig(c.workDir))
+		if err != nil {
+			return err
+		}
+		if workspace != nil {
+			for _, entry := range workspace.Entries {
+				if !workspaceKeys[entry.Key] {
+					return ConfigIssue{
+						Path:    workspace.Path,
+						Line:    entry.Line,
+						Message: fmt.Sprintf("%q cannot be set in a workspace config", entry.Key),
+					}
+				}
+				settings[entry.Key] = entry.Value
+			}
+		}
+	}
+
+	for key, value := range settings {
+		if flags.explicit[strings.ReplaceAll(key, "_", "-")] {
+			continue
+		}
+		if set, ok := configSetters[key]; ok {
+			set(flags, value)
+		} else if isDescriptionKey(key) {
+			if flags.Descriptions == nil {
+				flags.Descriptions = make(map[github.EventType]string)
+			}
+			flags.Descriptions[github.EventType(key)] = value
+		}
+	}
+	return nil
+}
+
+// runConfig handles the config subcommands
+func (c *CLI) runConfig(args []string) int {
+	if len(args) < 1 {
+		fmt.Println("Usage:")
+		fmt.Println("  github-activity config validate [-config file] [-offline]")
+		fmt.Println("  github-activity config init [-config file] [-force]")
+		return 1
+	}
+
+	flagSet := flag.NewFlagSet("github-activity config", flag.ContinueOnError)
+	path := flagSet.String("config", c.configPath, "Path to the config file")
+	offline := flagSet.Bool("offline", false, "Skip checking that API URLs are reachable")
+	force := flagSet.Bool("force", false, "Overwrite an existing config file")
+	if err := flagSet.Parse(args[1:]); err != nil {
+		return 1
+	}
+
+	if *path == "" {
+		fmt.Fprintln(os.Stderr, "Error: could not determine the config file location")
+		return 1
+	}
+
+	switch args[0] {
+	case "init":
+		if err := WriteConfigStarter(*path, *force); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		fmt.Printf("Wrote starter config to %s\n", *path)
+		return 0
+
+	case "validate":
+		file, err := os.Open(*path)
+		if err != nil {
+			fmt.Fprintf(
+				os.Stderr,
+				"Error: no config file at %s (run 'github-activity config init')\n",
+				*path,
+			)
+			return 1
+		}
+		defer func() { _ = file.Close() }()
+
+		config, issues := ParseConfig(*path, file)
+		if len(issues) == 0 {
+			var client *http.Client
+			if !*offline {
+				client = &http.Client{Timeout: 5 * time.Second}
+			}
+			issues = config.Validate(client)
+		}
+
+		if len(issues) > 0 {
+			for _, issue := range issues {
+				fmt.Fprintln(os.Stderr, issue.Error())
+			}
+			fmt.Fprintf(os.Stderr, "%s found\n", messages.Plural("problems", len(issues)))
+			return 1
+		}
+		fmt.Printf("%s is valid\n", *path)
+		return 0
+	}
+
+	fmt.Fprintf(os.Stderr, "Error: unknown config command: %s\n", args[0])
+	return 1
+}
@@ -0,0 +1,390 @@
+package main
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/format"
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+const validConfig = `# defaults
+limit: 10
+format: csv # trailing comment
+template: "{{.Description}} # not a comment"
+cache_ttl: 10m
+profile: work
+
+profiles:
+  work:
+    api_url: https://github.example.com/api/v3
+    limit: 50
+  oss:
+    type: PushEvent
+`
+
+func TestParseConfig(t *testing.T) {
+	config, issues := ParseConfig("config.yaml", strings.NewReader(validConfig))
+	if len(issues) > 0 {
+		t.Fatalf("ParseConfig() issues = %v", issues)
+	}
+
+	if len(config.Entries) != 5 {
+		t.Errorf("Got %d top-level entries, want 5", len(config.Entries))
+	}
+	if config.Entries[1].Value != "csv" || config.Entries[1].Line != 3 {
+		t.Errorf("Entries[1] = %+v, want csv on line 3", config.Entries[1])
+	}
+	if config.Entries[2].Value != "{{.Description}} # not a comment" {
+		t.Errorf("Quoted value = %q", config.Entries[2].Value)
+	}
+
+	if len(config.Profiles) != 2 {
+		t.Fatalf("Got %d profiles, want 2", len(config.Profiles))
+	}
+	if config.Profiles[0].Name != "work" || len(config.Profiles[0].Entries) != 2 {
+		t.Errorf("Profiles[0] = %+v", config.Profiles[0])
+	}
+}
+
+func TestParseConfig_SyntaxErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		line    int
+		message string
+	}{
+		{"missing colon", "limit 10\n", 1, "expected"},
+		{"tab indentation", "profiles:\n\twork:\n", 2, "tabs"},
+		{"unexpected indentation", "limit: 10\n  days: 3\n", 2, "unexpected indentation"},
+		{"unterminated quote", "template: \"{{.Type}}\n", 1, "quoted"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, issues := ParseConfig("config.yaml", strings.NewReader(tt.content))
+			if len(issues) != 1 {
+				t.Fatalf("Got %d issues, want 1: %v", len(issues), issues)
+			}
+			if issues[0].Line != tt.line || !strings.Contains(issues[0].Message, tt.message) {
+				t.Errorf("Issue = %v, want line %d containing %q", issues[0], tt.line, tt.message)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		line    int
+		message string
+	}{
+		{"unknown key", "limt: 10\n", 1, `unknown key "limt"`},
+		{"bad duration", "cache_ttl: 5 minutes\n", 1, "not a duration"},
+		{"bad event type", "type: NopeEvent\n", 1, "invalid event type"},
+		{"bad url", "api_url: github.example.com\n", 1, "not an http(s) URL"},
+		{"negative limit", "limit: -1\n", 1, "cannot be negative"},
+		{"no concurrency", "concurrency: 0\n", 1, "must be at least 1"},
+		{"bad timezone", "tz: Nowhere/Land\n", 1, "invalid timezone"},
+		{"bad repo", "repo: /github-activity\n", 1, "invalid repo filter"},
+		{"bad grouping", "group_by: week\n", 1, "invalid grouping"},
+		{"bad user", "user: alnah/dotfiles\n", 1, "not a GitHub username"},
+		{"duplicate key", "limit: 1\nlimit: 2\n", 2, "duplicate key"},
+		{"bad emoji map", "emoji_map: push:🚀\n", 1, "not type=emoji"},
+		{"bad description", "PushEvent: \"{{.Size\"\n", 1, "invalid description template"},
+		{"missing profile", "profile: work\n", 1, `profile "work" is not defined`},
+		{
+			"duplicate profile",
+			"profiles:\n  work:\n    limit: 1\n  work:\n    limit: 2\n",
+			4,
+			"defined twice",
+		},
+		{
+			"profile selecting profile",
+			"profiles:\n  work:\n    profile: oss\n",
+			3,
+			"cannot select another profile",
+		},
+		{
+			"template conflict in profile",
+			"profiles:\n  work:\n    format: template\n",
+			3,
+			"no template is set",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config, issues := ParseConfig("config.yaml", strings.NewReader(tt.content))
+			if len(issues) > 0 {
+				t.Fatalf("ParseConfig() issues = %v", issues)
+			}
+
+			issues = config.Validate(nil)
+			if len(issues) != 1 {
+				t.Fatalf("Got %d issues, want 1: %v", len(issues), issues)
+			}
+			if issues[0].Line != tt.line || !strings.Contains(issues[0].Message, tt.message) {
+				t.Errorf("Issue = %v, want line %d containing %q", issues[0], tt.line, tt.message)
+			}
+		})
+	}
+
+	t.Run("valid config", func(t *testing.T) {
+		config, _ := ParseConfig("config.yaml", strings.NewReader(validConfig))
+		if issues := config.Validate(nil); len(issues) > 0 {
+			t.Errorf("Validate() issues = %v", issues)
+		}
+	})
+
+	t.Run("event type alias", func(t *testing.T) {
+		config, _ := ParseConfig("config.yaml", strings.NewReader("type: push\n"))
+		if issues := config.Validate(nil); len(issues) > 0 {
+			t.Errorf("Validate() issues = %v", issues)
+		}
+	})
+
+	t.Run("unreachable api url", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		reachable := server.URL
+		server.Close()
+
+		config, _ := ParseConfig("config.yaml", strings.NewReader("api_url: "+reachable+"\n"))
+		issues := config.Validate(&http.Client{Timeout: time.Second})
+		if len(issues) != 1 || !strings.Contains(issues[0].Message, "unreachable") {
+			t.Errorf("Validate() issues = %v, want unreachable api_url", issues)
+		}
+	})
+}
+
+func TestConfig_Settings(t *testing.T) {
+	config, _ := ParseConfig("config.yaml", strings.NewReader(validConfig))
+
+	t.Run("default profile", func(t *testing.T) {
+		settings, err := config.Settings("")
+		if err != nil {
+			t.Fatalf("Settings() error = %v", err)
+		}
+		if settings["limit"] != "50" || settings["api_url"] == "" || settings["format"] != "csv" {
+			t.Errorf("Settings() = %v", settings)
+		}
+		if _, exists := settings["profile"]; exists {
+			t.Error("Settings() should not include the profile selector")
+		}
+	})
+
+	t.Run("explicit profile", func(t *testing.T) {
+		settings, err := config.Settings("oss")
+		if err != nil {
+			t.Fatalf("Settings() error = %v", err)
+		}
+		if settings["limit"] != "10" || settings["type"] != "PushEvent" {
+			t.Errorf("Settings() = %v", settings)
+		}
+	})
+
+	t.Run("unknown profile", func(t *testing.T) {
+		if _, err := config.Settings("missing"); err == nil {
+			t.Error("Expected error for unknown profile")
+		}
+	})
+}
+
+func TestCLI_applyConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	content := "limit: 5\nformat: tsv\ncache_ttl: 1m\nPushEvent: \"{{.Size}} commits to {{.Repo}}\"\n"
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cli := NewCLI(nil)
+	cli.SetConfigPath(path)
+
+	flags, _ := cli.parseFlags([]string{"github-activity", "-format=csv", "testuser"})
+	if err := cli.applyConfig(&flags); err != nil {
+		t.Fatalf("applyConfig() error = %v", err)
+	}
+
+	if flags.Limit != 5 {
+		t.Errorf("Limit = %d, want 5 from config", flags.Limit)
+	}
+	if flags.Format != "csv" {
+		t.Errorf("Format = %s, want csv from command line", flags.Format)
+	}
+	if flags.CacheTTL != time.Minute {
+		t.Errorf("CacheTTL = %v, want 1m", flags.CacheTTL)
+	}
+	if flags.Descriptions[github.EventTypePush] != "{{.Size}} commits to {{.Repo}}" {
+		t.Errorf("Descriptions = %v, want the PushEvent template", flags.Descriptions)
+	}
+
+	t.Run("cache-ttl flag overrides cache_ttl", func(t *testing.T) {
+		flags, _ := cli.parseFlags([]string{"github-activity", "-cache-ttl=10m", "testuser"})
+		if err := cli.applyConfig(&flags); err != nil {
+			t.Fatalf("applyConfig() error = %v", err)
+		}
+		if flags.CacheTTL != 10*time.Minute {
+			t.Errorf("CacheTTL = %v, want 10m from command line", flags.CacheTTL)
+		}
+	})
+
+	t.Run("missing explicit config", func(t *testing.T) {
+		flags, _ := cli.parseFlags([]string{"github-activity", "-config=/nonexistent.yaml", "testuser"})
+		if err := cli.applyConfig(&flags); err == nil {
+			t.Error("Expected error for missing config file")
+		}
+	})
+}
+
+func TestFindWorkspaceConfig(t *testing.T) {
+	root := t.TempDir()
+	project := filepath.Join(root, "project")
+	nested := filepath.Join(project, "cmd", "tool")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(project, WorkspaceConfigName)
+	if err := os.WriteFile(path, []byte("repo: alnah/github-activity\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		dir      string
+		expected string
+	}{
+		{"same directory", project, path},
+		{"nested directory", nested, path},
+		{"outside the project", root, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FindWorkspaceConfig(tt.dir); got != tt.expected {
+				t.Errorf("FindWorkspaceConfig(%s) = %q, want %q", tt.dir, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCLI_applyConfig_Workspace(t *testing.T) {
+	dir := t.TempDir()
+	userConfig := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(userConfig, []byte("limit: 5\nformat: tsv\nuser: someone\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	project := filepath.Join(dir, "project")
+	if err := os.MkdirAll(filepath.Join(project, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	workspace := "user: alnah\nrepo: alnah/github-activity\nlimit: 7\n"
+	if err := os.WriteFile(filepath.Join(project, WorkspaceConfigName), []byte(workspace), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cli := NewCLI(nil)
+	cli.SetConfigPath(userConfig)
+	cli.SetWorkDir(filepath.Join(project, "sub"))
+
+	flags, _ := cli.parseFlags([]string{"github-activity", "-limit=3"})
+	if err := cli.applyConfig(&flags); err != nil {
+		t.Fatalf("applyConfig() error = %v", err)
+	}
+
+	if flags.User != "alnah" || flags.Repo != "alnah/github-activity" {
+		t.Errorf("User = %q, Repo = %q, want workspace values", flags.User, flags.Repo)
+	}
+	if flags.Format != "tsv" {
+		t.Errorf("Format = %q, want tsv from the user config", flags.Format)
+	}
+	if flags.Limit != 3 {
+		t.Errorf("Limit = %d, want 3 from the command line", flags.Limit)
+	}
+
+	t.Run("invalid workspace config", func(t *testing.T) {
+		bad := filepath.Join(dir, "bad")
+		if err := os.MkdirAll(bad, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(filepath.Join(bad, WorkspaceConfigName), []byte("limt: 1\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		cli.SetWorkDir(bad)
+		flags, _ := cli.parseFlags([]string{"github-activity"})
+		if err := cli.applyConfig(&flags); err == nil {
+			t.Error("Expected error for an invalid workspace config")
+		}
+	})
+
+	t.Run("workspace api_url is refused", func(t *testing.T) {
+		hostile := filepath.Join(dir, "hostile")
+		if err := os.MkdirAll(hostile, 0o755); err != nil {
+			t.Fatal(err)
+		}
+		content := "user: alnah\napi_url: http://127.0.0.1:1\n"
+		if err := os.WriteFile(filepath.Join(hostile, WorkspaceConfigName), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		cli.SetWorkDir(hostile)
+		flags, _ := cli.parseFlags([]string{"github-activity"})
+		err := cli.applyConfig(&flags)
+
+		var issue ConfigIssue
+		if !errors.As(err, &issue) {
+			t.Fatalf("applyConfig() error = %v, want a ConfigIssue", err)
+		}
+		if issue.Line != 2 || !strings.Contains(issue.Message, "api_url") {
+			t.Errorf("issue = %+v, want api_url on line 2", issue)
+		}
+		if flags.APIURL == "http://127.0.0.1:1" {
+			t.Error("APIURL was taken from the workspace config")
+		}
+	})
+}
+
+func TestCLI_runConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "github-activity", "config.yaml")
+	cli := NewCLI(nil)
+	cli.SetConfigPath(path)
+
+	stdout, stderr := os.Stdout, os.Stderr
+	os.Stdout, _ = os.Open(os.DevNull)
+	os.Stderr = os.Stdout
+	defer func() { os.Stdout, os.Stderr = stdout, stderr }()
+
+	if code := cli.Run([]string{"github-activity", "config", "validate"}); code != 1 {
+		t.Errorf("validate without file: exit code = %d, want 1", code)
+	}
+	if code := cli.Run([]string{"github-activity", "config", "init"}); code != 0 {
+		t.Fatalf("init: exit code = %d, want 0", code)
+	}
+	if code := cli.Run([]string{"github-activity", "config", "init"}); code != 1 {
+		t.Errorf("init over existing file: exit code = %d, want 1", code)
+	}
+	if code := cli.Run([]string{"github-activity", "config", "validate", "-offline"}); code != 0 {
+		t.Errorf("validate starter: exit code = %d, want 0", code)
+	}
+	starter, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range format.Formats.Names() {
+		if !strings.Contains(string(starter), name) {
+			t.Errorf("Starter config does not list the %s format", name)
+		}
+	}
+
+	if err := os.WriteFile(path, []byte("limt: 5\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if code := cli.Run([]string{"github-activity", "config", "validate", "-offline"}); code != 1 {
+		t.Errorf("validate invalid file: exit code = %d, want 1", code)
+	}
+}
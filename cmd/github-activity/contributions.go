@@ -0,0 +1,91 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// Contributions - The contributions subcommand
+
+// maxContributionDays is the longest range contributionsCollection accepts
+const maxContributionDays = 365
+
+// renderContributions prints the totals followed by the calendar
+func renderContributions(contributions *github.Contributions, color bool) {
+	fmt.Printf("Contributions for %s, %s to %s\n\n",
+		contributions.Username,
+		contributions.From.Format("2006-01-02"),
+		contributions.To.Format("2006-01-02"))
+	fmt.Printf("  Total:         %d", contributions.Total)
+	if contributions.Private > 0 {
+		fmt.Printf(" (%d private)", contributions.Private)
+	}
+	fmt.Println()
+	fmt.Printf("  Commits:       %d\n", contributions.Commits)
+	fmt.Printf("  Pull requests: %d\n", contributions.PullRequests)
+	fmt.Printf("  Reviews:       %d\n", contributions.Reviews)
+	fmt.Printf("  Issues:        %d\n", contributions.Issues)
+	fmt.Printf("  Repositories:  %d\n\n", contributions.Repositories)
+
+	days := int(contributions.To.Sub(contributions.From).Hours()/24) + 1
+	renderHeatmap(os.Stdout, contributions.Days, contributions.To, heatmapWeeks(days), "contributions", color)
+}
+
+// runContributions handles `contributions [-days=365] <username>`
+func (c *CLI) runContributions(args []string) int {
+	flags, err := c.resolveFlags(append([]string{"github-activity contributions"}, args...))
+	if err != nil {
+		return c.reportFlagsError(err)
+	}
+	if !c.hasUsername(flags) {
+		fmt.Println("Usage:")
+		fmt.Println("  github-activity contributions [-days=365] <username>")
+		return 1
+	}
+	days := flags.Days
+	if days == 0 {
+		days = maxContributionDays
+	}
+	if days > maxContributionDays {
+		fmt.Fprintf(os.Stderr, "Error: GitHub reports contributions for at most %d days\n", maxContributionDays)
+		return 1
+	}
+	switch {
+	case flags.Format != "":
+		fmt.Fprintf(os.Stderr, "Error: contributions cannot use -format=%s\n", flags.Format)
+		return 1
+	case flags.FromDB || flags.Offline || flags.Session != "":
+		fmt.Fprintln(os.Stderr, "Error: contributions are read from GitHub and cannot use -from-db, -offline or -session")
+		return 1
+	}
+
+	run, err := c.setup(flags)
+	if err != nil {
+		return c.reportError(err)
+	}
+	contributions := c.contributions
+	if contributions == nil {
+		if c.repository == nil {
+			return c.reportError(errors.New("contributions require the GitHub API"))
+		}
+		contributions = c.repository.GraphQL()
+	}
+
+	location := run.location
+	if location == nil {
+		location = time.Local
+	}
+	to := c.now().In(location)
+	from := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, location).AddDate(0, 0, 1-days)
+
+	result, err := contributions.FetchContributions(run.username, from, to)
+	if err != nil {
+		return c.reportError(err)
+	}
+	renderContributions(result, useColor(os.Stdout))
+	return 0
+}
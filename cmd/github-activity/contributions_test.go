@@ -0,0 +1,75 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/activity"
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// fakeContributions records the range it was asked for
+type fakeContributions struct {
+	from, to time.Time
+	err      error
+}
+
+func (f *fakeContributions) FetchContributions(username string, from, to time.Time) (*github.Contributions, error) {
+	f.from, f.to = from, to
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &github.Contributions{
+		Username: username,
+		From:     from,
+		To:       to,
+		Total:    12,
+		Commits:  10,
+		Days:     map[string]int{to.Format("2006-01-02"): 12},
+	}, nil
+}
+
+func TestCLI_runContributions(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		err      error
+		expected int
+		output   string
+	}{
+		{name: "year", args: []string{"octocat"}, output: "12 contributions in the last"},
+		{name: "days", args: []string{"-days=30", "octocat"}, output: "Commits:       10"},
+		{name: "too many days", args: []string{"-days=400", "octocat"}, expected: 1},
+		{name: "format", args: []string{"-format=csv", "octocat"}, expected: 1},
+		{name: "user not found", args: []string{"ghost"}, err: github.ErrUserNotFound, expected: userNotFoundExitCode},
+		{name: "missing username", expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeContributions{err: tt.err}
+			cli := NewCLI(activity.NewActivityService(github.NewMockEventRepository(nil, nil)))
+			cli.SetContributionRepository(fake)
+
+			var code int
+			output := captureStdout(t, func() {
+				code = cli.Run(append([]string{"github-activity", "contributions", "-tz=UTC"}, tt.args...))
+			})
+			if code != tt.expected {
+				t.Errorf("Run() = %d, want %d", code, tt.expected)
+			}
+			if !strings.Contains(output, tt.output) {
+				t.Errorf("Output missing %q:\n%s", tt.output, output)
+			}
+		})
+	}
+
+	fake := &fakeContributions{}
+	cli := NewCLI(activity.NewActivityService(github.NewMockEventRepository(nil, nil)))
+	cli.SetContributionRepository(fake)
+	captureStdout(t, func() { cli.Run([]string{"github-activity", "contributions", "-days=7", "-tz=UTC", "octocat"}) })
+	if days := fake.to.Sub(fake.from); days < 6*24*time.Hour || days > 7*24*time.Hour {
+		t.Errorf("Requested %v, want the last 7 calendar days", days)
+	}
+}
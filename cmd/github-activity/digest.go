@@ -0,0 +1,291 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alnah/github-activity/internal/messages"
+	"github.com/alnah/github-activity/pkg/activity"
+)
+
+// SMTPPasswordEnvVar supplies the SMTP password when the config file has none
+const SMTPPasswordEnvVar = "SMTP_PASSWORD"
+
+// defaultSMTPPort is the mail submission port, which upgrades to TLS
+const defaultSMTPPort = 587
+
+// sendMail delivers a message over SMTP; replaced in tests
+var sendMail = smtp.SendMail
+
+// SMTPSettings is the mail server digests are sent through
+type SMTPSettings struct {
+	Host     string
+	Port     int
+	Username string // Empty to send without authentication
+	Password string `json:"-"`
+}
+
+// DigestEmail is an activity digest with plain text and HTML bodies
+type DigestEmail struct {
+	From    string
+	To      []string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// digestHTML renders the HTML body of a digest
+var digestHTML = template.Must(template.New("digest").Parse(`<!DOCTYPE html>
+<html>
+<body>
+<h2>Activity summary for {{.Username}}</h2>
+<p><em>{{.Period}}</em></p>
+{{- if .Clauses}}
+<ul>
+{{- range .Clauses}}
+<li>{{.}}</li>
+{{- end}}
+</ul>
+{{- else}}
+<p>No activity ({{.Events}}).</p>
+{{- end}}
+{{- if .Activities}}
+<h3>Events</h3>
+<ul>
+{{- range .Activities}}
+<li>{{.Description}} <small>{{.Timestamp}}</small></li>
+{{- end}}
+</ul>
+{{- end}}
+</body>
+</html>
+`))
+
+// composeDigest writes the period summary and its events as a text and an
+// HTML body
+func composeDigest(summary *activity.PeriodSummary, activities []activity.ActivitySummary) (DigestEmail, error) {
+	period := fmt.Sprintf("%s to %s",
+		summary.Since.Format("2006-01-02"), summary.Until.Format("2006-01-02"))
+	clauses := summaryClauses(summary)
+	for i, clause := range clauses {
+		clauses[i] = capitalize(clause)
+	}
+
+	var text bytes.Buffer
+	renderSummary(&text, summary, false)
+	if len(activities) > 0 {
+		_, _ = fmt.Fprintln(&text, "\nEvents:")
+		for _, activity := range activities {
+			_, _ = fmt.Fprintf(&text, "- %s (%s)\n", activity.Description, activity.Timestamp)
+		}
+	}
+
+	var html bytes.Buffer
+	err := digestHTML.Execute(&html, map[string]any{
+		"Username":   summary.Username,
+		"Period":     period,
+		"Clauses":    clauses,
+		"Events":     messages.Plural("events", summary.Events),
+		"Activities": activities,
+	})
+	if err != nil {
+		return DigestEmail{}, fmt.Errorf("failed to render digest: %w", err)
+	}
+
+	return DigestEmail{
+		Subject: fmt.Sprintf("GitHub activity for %s, %s", summary.Username, period),
+		Text:    text.String(),
+		HTML:    html.String(),
+	}, nil
+}
+
+// Message encodes the email as a multipart/alternative message, so mail
+// clients show the HTML body and fall back to the text one
+func (e DigestEmail) Message(date time.Time) ([]byte, error) {
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+	for _, part := range []struct{ contentType, content string }{
+		{"text/plain; charset=utf-8", e.Text},
+		{"text/html; charset=utf-8", e.HTML},
+	} {
+		writer, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		encoder := quotedprintable.NewWriter(writer)
+		if _, err := io.WriteString(encoder, part.content); err != nil {
+			return nil, err
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	var message bytes.Buffer
+	headers := [][2]string{
+		{"From", e.From},
+		{"To", strings.Join(e.To, ", ")},
+		{"Subject", mime.QEncoding.Encode("utf-8", e.Subject)},
+		{"Date", date.Format(time.RFC1123Z)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", fmt.Sprintf("multipart/alternative; boundary=%q", parts.Boundary())},
+	}
+	for _, header := range headers {
+		if strings.ContainsAny(header[1], "\r\n") {
+			return nil, fmt.Errorf("invalid %s header", header[0])
+		}
+		_, _ = fmt.Fprintf(&message, "%s: %s\r\n", header[0], header[1])
+	}
+	message.WriteString("\r\n")
+	message.Write(body.Bytes())
+	return message.Bytes(), nil
+}
+
+// sendDigest delivers the email through the SMTP server. The standard
+// library upgrades to TLS when the server offers it and only sends
+// credentials over TLS or to localhost.
+func sendDigest(settings SMTPSettings, email DigestEmail, date time.Time) error {
+	from, err := mail.ParseAddress(email.From)
+	if err != nil {
+		return fmt.Errorf("invalid sender %q: %w", email.From, err)
+	}
+	recipients := make([]string, 0, len(email.To))
+	for _, to := range email.To {
+		address, err := mail.ParseAddress(to)
+		if err != nil {
+			return fmt.Errorf("invalid recipient %q: %w", to, err)
+		}
+		recipients = append(recipients, address.Address)
+	}
+
+	message, err := email.Message(date)
+	if err != nil {
+		return fmt.Errorf("failed to compose email: %w", err)
+	}
+
+	var auth smtp.Auth
+	if settings.Username != "" {
+		auth = smtp.PlainAuth("", settings.Username, settings.Password, settings.Host)
+	}
+	addr := net.JoinHostPort(settings.Host, strconv.Itoa(settings.Port))
+	if err := sendMail(addr, auth, from.Address, recipients, message); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+	return nil
+}
+
+// splitAddresses splits a comma-separated recipient list
+func splitAddresses(value string) []string {
+	addresses := make([]string, 0)
+	for _, address := range strings.Split(value, ",") {
+		if address = strings.TrimSpace(address); address != "" {
+			addresses = append(addresses, address)
+		}
+	}
+	return addresses
+}
+
+// digestSettings checks that the mail settings a digest needs are present
+func digestSettings(flags CLIFlags) (SMTPSettings, error) {
+	settings := flags.SMTP
+	switch {
+	case settings.Host == "":
+		return settings, errors.New("digest needs smtp_host in the config file")
+	case flags.EmailFrom == "":
+		return settings, errors.New("digest needs email_from in the config file")
+	case len(splitAddresses(flags.EmailTo)) == 0:
+		return settings, errors.New("digest needs email_to in the config file or -email-to")
+	}
+	if settings.Port == 0 {
+		settings.Port = defaultSMTPPort
+	}
+	if settings.Password == "" {
+		settings.Password = os.Getenv(SMTPPasswordEnvVar)
+	}
+	return settings, nil
+}
+
+// runDigest handles `digest [-days=7] [-email-to=addresses] <username>`
+func (c *CLI) runDigest(args []string) int {
+	flags, err := c.resolveFlags(append([]string{"github-activity digest"}, args...))
+	if err != nil {
+		return c.reportFlagsError(err)
+	}
+	if !c.hasUsername(flags) {
+		fmt.Println("Usage:")
+		fmt.Println("  github-activity digest [-days=7] [-email-to=addresses] <username>")
+		return 1
+	}
+	if flags.Format != "" {
+		fmt.Fprintf(os.Stderr, "Error: digest sends an email and cannot use -format=%s\n", flags.Format)
+		return 1
+	}
+	settings, err := digestSettings(flags)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	days := flags.Days
+	if days == 0 {
+		days = defaultSummaryDays
+	}
+
+	run, err := c.setup(flags)
+	if err != nil {
+		return c.reportError(err)
+	}
+	filter := run.filter
+	filter.MaxLimit = 0
+
+	summary, err := c.service.GetPeriodSummary(run.username, filter, days)
+	if err != nil {
+		return c.reportError(err)
+	}
+	all, err := c.service.GetUserActivity(run.username, filter)
+	if err != nil {
+		return c.reportError(err)
+	}
+	activities := make([]activity.ActivitySummary, 0)
+	for _, activity := range all {
+		if flags.Limit > 0 && len(activities) == flags.Limit {
+			break
+		}
+		if !activity.CreatedAt.Before(summary.Since) {
+			activities = append(activities, activity)
+		}
+	}
+
+	email, err := composeDigest(summary, activities)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	email.From = flags.EmailFrom
+	email.To = splitAddresses(flags.EmailTo)
+	if err := sendDigest(settings, email, c.now()); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	fmt.Printf("Sent activity digest for %s to %s.\n", run.username, strings.Join(email.To, ", "))
+	return 0
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"net/smtp"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/activity"
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+func TestComposeDigest(t *testing.T) {
+	since := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+	summary := &activity.PeriodSummary{
+		Username:    "alnah",
+		Since:       since,
+		Until:       since.AddDate(0, 0, 7),
+		Commits:     2,
+		PushedRepos: 1,
+	}
+	activities := []activity.ActivitySummary{
+		{Description: "Pushed 2 commits to user/<repo>", Timestamp: "2024-01-10 09:00:00"},
+	}
+
+	email, err := composeDigest(summary, activities)
+	if err != nil {
+		t.Fatalf("composeDigest() error = %v", err)
+	}
+
+	if email.Subject != "GitHub activity for alnah, 2024-01-08 to 2024-01-15" {
+		t.Errorf("Subject = %q", email.Subject)
+	}
+	for _, want := range []string{
+		"Pushed 2 commits to 1 repo.",
+		"Events:\n- Pushed 2 commits to user/<repo> (2024-01-10 09:00:00)",
+	} {
+		if !strings.Contains(email.Text, want) {
+			t.Errorf("Text missing %q:\n%s", want, email.Text)
+		}
+	}
+	for _, want := range []string{"<li>Pushed 2 commits to 1 repo</li>", "user/&lt;repo&gt;"} {
+		if !strings.Contains(email.HTML, want) {
+			t.Errorf("HTML missing %q:\n%s", want, email.HTML)
+		}
+	}
+}
+
+func TestDigestEmail_Message(t *testing.T) {
+	email := DigestEmail{
+		From:    "Activity <activity@example.com>",
+		To:      []string{"a@example.com", "b@example.com"},
+		Subject: "Activité",
+		Text:    "plain body",
+		HTML:    "<p>html body</p>",
+	}
+
+	raw, err := email.Message(time.Date(2024, 1, 15, 8, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Message() error = %v", err)
+	}
+	message, err := mail.ReadMessage(bytes.NewReader(raw))
+	if err != nil {
+		t.Fatalf("Message is not valid: %v", err)
+	}
+
+	subject, _ := new(mime.WordDecoder).DecodeHeader(message.Header.Get("Subject"))
+	if subject != "Activité" {
+		t.Errorf("Subject = %q", subject)
+	}
+	if message.Header.Get("To") != "a@example.com, b@example.com" {
+		t.Errorf("To = %q", message.Header.Get("To"))
+	}
+
+	mediaType, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("Content-Type = %q", message.Header.Get("Content-Type"))
+	}
+	parts := multipart.NewReader(message.Body, params["boundary"])
+	for _, want := range []string{"plain body", "<p>html body</p>"} {
+		part, err := parts.NextPart()
+		if err != nil {
+			t.Fatalf("Missing part %q: %v", want, err)
+		}
+		body, _ := io.ReadAll(part)
+		if string(body) != want {
+			t.Errorf("Part = %q, want %q", body, want)
+		}
+	}
+
+	email.To = []string{"a@example.com\r\nBcc: x@example.com"}
+	if _, err := email.Message(time.Now()); err == nil {
+		t.Error("Expected an error for a header with a line break")
+	}
+}
+
+func TestCLI_runDigest(t *testing.T) {
+	events := []github.GitHubEvent{
+		{
+			ID:        "1",
+			Type:      "PushEvent",
+			Repo:      github.Repo{Name: "user/repo"},
+			Payload:   json.RawMessage(`{"size":2}`),
+			CreatedAt: time.Now(),
+		},
+		{
+			ID:        "2",
+			Type:      "WatchEvent",
+			Repo:      github.Repo{Name: "user/old"},
+			CreatedAt: time.Now().AddDate(0, 0, -30),
+		},
+	}
+	config := "smtp_host: smtp.example.com\nsmtp_username: bot\nsmtp_password: secret\n" +
+		"email_from: Activity <activity@example.com>\nemail_to: team@example.com\n"
+
+	var sent struct {
+		addr, from string
+		to         []string
+		message    []byte
+		calls      int
+	}
+	original := sendMail
+	sendMail = func(addr string, _ smtp.Auth, from string, to []string, message []byte) error {
+		sent.addr, sent.from, sent.to, sent.message = addr, from, to, message
+		sent.calls++
+		return nil
+	}
+	defer func() { sendMail = original }()
+
+	tests := []struct {
+		name     string
+		config   string
+		args     []string
+		expected int
+		sends    int
+	}{
+		{"sends", config, []string{"testuser"}, 0, 1},
+		{"recipient flag", config, []string{"-email-to=me@example.com", "testuser"}, 0, 1},
+		{"no smtp host", "email_to: team@example.com\n", []string{"testuser"}, 1, 0},
+		{"format", config, []string{"-format=csv", "testuser"}, 1, 0},
+		{"missing username", config, nil, 1, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sent.calls = 0
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(path, []byte(tt.config), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			cli := NewCLI(activity.NewActivityService(github.NewMockEventRepository(events, nil)))
+			cli.SetConfigPath(path)
+
+			args := append([]string{"github-activity", "digest"}, tt.args...)
+			if code := cli.Run(args); code != tt.expected {
+				t.Errorf("Run() = %d, want %d", code, tt.expected)
+			}
+			if sent.calls != tt.sends {
+				t.Errorf("Sent %d emails, want %d", sent.calls, tt.sends)
+			}
+		})
+	}
+
+	if sent.addr != "smtp.example.com:587" || sent.from != "activity@example.com" {
+		t.Errorf("Sent via %s from %s", sent.addr, sent.from)
+	}
+	if len(sent.to) != 1 || sent.to[0] != "me@example.com" {
+		t.Errorf("Recipients = %v, want the -email-to address", sent.to)
+	}
+	if bytes.Contains(sent.message, []byte("user/old")) {
+		t.Error("Digest should leave out events before the period")
+	}
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/alnah/github-activity/pkg/activity"
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// emojiMapping returns DefaultEmoji with the config overrides applied
+func emojiMapping(overrides string) (map[github.EventType]string, error) {
+	custom, err := activity.ParseEmojiMap(overrides)
+	if err != nil {
+		return nil, fmt.Errorf("invalid emoji_map: %w", err)
+	}
+	emoji := make(map[github.EventType]string, len(activity.DefaultEmoji)+len(custom))
+	for eventType, symbol := range activity.DefaultEmoji {
+		emoji[eventType] = symbol
+	}
+	for eventType, symbol := range custom {
+		emoji[eventType] = symbol
+	}
+	return emoji, nil
+}
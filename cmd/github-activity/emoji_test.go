@@ -0,0 +1,21 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/alnah/github-activity/pkg/activity"
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+func TestEmojiMapping(t *testing.T) {
+	merged, err := emojiMapping("push=🚀")
+	if err != nil {
+		t.Fatalf("emojiMapping() error = %v", err)
+	}
+	if merged[github.EventTypePush] != "🚀" || merged[github.EventTypeWatch] != activity.DefaultEmoji[github.EventTypeWatch] {
+		t.Errorf("emojiMapping() = %v, want overrides on top of the defaults", merged)
+	}
+	if activity.DefaultEmoji[github.EventTypePush] != "⬆️" {
+		t.Error("emojiMapping() modified activity.DefaultEmoji")
+	}
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"bufio"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// gh CLI Authentication - Reusing the token of GitHub's official CLI
+
+// ghAuthToken asks the gh CLI for its token, which recent versions keep in
+// the system keyring rather than hosts.yml; replaced in tests
+var ghAuthToken = func(host string) string {
+	if _, err := exec.LookPath("gh"); err != nil {
+		return ""
+	}
+	output, err := exec.Command("gh", "auth", "token", "--hostname", host).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(output))
+}
+
+// DefaultGHConfigDir returns where the gh CLI keeps hosts.yml, following
+// gh's own lookup: GH_CONFIG_DIR, then XDG_CONFIG_HOME, then the platform
+// default
+func DefaultGHConfigDir() string {
+	if dir := os.Getenv("GH_CONFIG_DIR"); dir != "" {
+		return dir
+	}
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "gh")
+	}
+	if runtime.GOOS == "windows" {
+		if dir := os.Getenv("AppData"); dir != "" {
+			return filepath.Join(dir, "GitHub CLI")
+		}
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "gh")
+}
+
+// ghHost returns the host gh knows an API base URL by: github.com for the
+// public API, the server name for GitHub Enterprise Server
+func ghHost(apiURL string) string {
+	if apiURL == "" {
+		return "github.com"
+	}
+	parsed, err := url.Parse(apiURL)
+	if err != nil || parsed.Hostname() == "" {
+		return "github.com"
+	}
+	host := parsed.Hostname()
+	if host == "api.github.com" {
+		return "github.com"
+	}
+	return strings.TrimPrefix(host, "api.")
+}
+
+// ghHostsToken reads the oauth_token stored for host in a gh hosts.yml. The
+// host's own token wins over the per-user ones nested below it.
+func ghHostsToken(path, host string) string {
+	file, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer func() { _ = file.Close() }()
+
+	token, tokenIndent := "", -1
+	inHost := false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := stripConfigComment(scanner.Text())
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+		key, value, _ := strings.Cut(trimmed, ":")
+		key = strings.TrimSpace(key)
+
+		if indent == 0 {
+			if inHost {
+				break
+			}
+			inHost = strings.Trim(key, `"'`) == host
+			continue
+		}
+		if inHost && key == "oauth_token" && (tokenIndent == -1 || indent < tokenIndent) {
+			if value, err := unquoteConfigValue(strings.TrimSpace(value)); err == nil && value != "" {
+				token, tokenIndent = value, indent
+			}
+		}
+	}
+	return token
+}
+
+// ghToken returns the token the gh CLI is logged in with for the API URL,
+// or an empty string
+func ghToken(configDir, apiURL string) string {
+	host := ghHost(apiURL)
+	if token := ghHostsToken(filepath.Join(configDir, "hosts.yml"), host); token != "" {
+		return token
+	}
+	return ghAuthToken(host)
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGHHost(t *testing.T) {
+	tests := map[string]string{
+		"":                                  "github.com",
+		"https://api.github.com":            "github.com",
+		"https://github.example.com/api/v3": "github.example.com",
+		"https://api.acme.ghe.com":          "acme.ghe.com",
+	}
+	for apiURL, expected := range tests {
+		if got := ghHost(apiURL); got != expected {
+			t.Errorf("ghHost(%q) = %q, want %q", apiURL, got, expected)
+		}
+	}
+}
+
+func TestGHHostsToken(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hosts.yml")
+	hosts := `github.com:
+    users:
+        alnah:
+            oauth_token: gho_user
+    git_protocol: https
+    oauth_token: gho_host # active account
+    user: alnah
+"github.example.com":
+    oauth_token: 'ghe_token'
+keyring.example.com:
+    user: alnah
+`
+	if err := os.WriteFile(path, []byte(hosts), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]string{
+		"github.com":          "gho_host",
+		"github.example.com":  "ghe_token",
+		"keyring.example.com": "",
+		"unknown.example.com": "",
+	}
+	for host, expected := range tests {
+		if got := ghHostsToken(path, host); got != expected {
+			t.Errorf("ghHostsToken(%q) = %q, want %q", host, got, expected)
+		}
+	}
+	if got := ghHostsToken(filepath.Join(t.TempDir(), "missing.yml"), "github.com"); got != "" {
+		t.Errorf("Missing hosts.yml gave %q", got)
+	}
+}
+
+func TestCLI_resolveFlags_GHToken(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "hosts.yml"), []byte("github.com:\n    oauth_token: gho_file\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	var asked []string
+	original := ghAuthToken
+	ghAuthToken = func(host string) string {
+		asked = append(asked, host)
+		return "gho_keyring"
+	}
+	defer func() { ghAuthToken = original }()
+	t.Setenv(TokenEnvVar, "")
+
+	tests := []struct {
+		name     string
+		dir      string
+		env      string
+		args     []string
+		expected string
+	}{
+		{"hosts file", dir, "", nil, "gho_file"},
+		{"keyring", dir, "", []string{"-api-url=https://github.example.com/api/v3"}, "gho_keyring"},
+		{"environment wins", dir, "ghp_env", nil, "ghp_env"},
+		{"disabled", "", "", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv(TokenEnvVar, tt.env)
+			cli := NewCLI(nil)
+			cli.SetGHConfigDir(tt.dir)
+
+			flags, err := cli.resolveFlags(append([]string{"github-activity"}, tt.args...))
+			if err != nil {
+				t.Fatalf("resolveFlags() error = %v", err)
+			}
+			if flags.Token != tt.expected {
+				t.Errorf("Token = %q, want %q", flags.Token, tt.expected)
+			}
+		})
+	}
+
+	if len(asked) != 1 || asked[0] != "github.example.com" {
+		t.Errorf("gh auth token asked for %v, want only github.example.com", asked)
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/alnah/github-activity/pkg/activity"
+	"github.com/alnah/github-activity/pkg/format"
+)
+
+// Gists - The gists subcommand
+
+// runGists handles `gists [-limit=N] [-detailed] [-format=...] <username>`
+func (c *CLI) runGists(args []string) int {
+	flags, err := c.resolveFlags(append([]string{"github-activity gists"}, args...))
+	if err != nil {
+		return c.reportFlagsError(err)
+	}
+	if !c.hasUsername(flags) {
+		fmt.Println("Usage:")
+		fmt.Println("  github-activity gists [-limit=N] [-detailed] [-format=...] <username>")
+		return 1
+	}
+	switch {
+	case flags.FromDB || flags.Offline || flags.Session != "":
+		fmt.Fprintln(os.Stderr, "Error: gists are read from GitHub and cannot use -from-db, -offline or -session")
+		return 1
+	case flags.EventType != "" || flags.Repo != "" || flags.Actor != "" || flags.Action != "":
+		fmt.Fprintln(os.Stderr, "Error: gists cannot be filtered with -type, -repo, -actor or -action")
+		return 1
+	}
+
+	run, err := c.setup(flags)
+	if err != nil {
+		return c.reportError(err)
+	}
+	gists := c.gists
+	if gists == nil {
+		if c.repository == nil {
+			return c.reportError(errors.New("gists require the GitHub API"))
+		}
+		gists = c.repository
+	}
+
+	result, err := gists.FetchGists(run.username)
+	if err != nil {
+		return c.reportError(err)
+	}
+	activities := activity.GistActivities(result, flags.Limit, run.location)
+	if len(activities) == 0 {
+		fmt.Println("No gists found.")
+		return 0
+	}
+
+	if flags.Detailed || format.NeedsDetails(run.format) {
+		c.output.FormatDetailedActivities(os.Stdout, activities)
+		return 0
+	}
+	summaries := make([]activity.ActivitySummary, 0, len(activities))
+	for _, gist := range activities {
+		summaries = append(summaries, gist.ActivitySummary)
+	}
+	c.output.FormatActivities(os.Stdout, summaries)
+	return 0
+}
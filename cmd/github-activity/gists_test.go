@@ -0,0 +1,69 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/activity"
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// fakeGists returns the same gists for every user
+type fakeGists struct {
+	gists []github.Gist
+	err   error
+}
+
+func (f *fakeGists) FetchGists(username string) ([]github.Gist, error) {
+	return f.gists, f.err
+}
+
+func TestCLI_runGists(t *testing.T) {
+	created := time.Now().Add(-48 * time.Hour)
+	gists := []github.Gist{{
+		ID:          "aa5a315d",
+		Description: "Hello world",
+		HTMLURL:     "https://gist.github.com/aa5a315d",
+		Public:      true,
+		Files:       map[string]github.GistFile{"hello.go": {Filename: "hello.go"}},
+		CreatedAt:   created,
+		UpdatedAt:   created,
+	}}
+
+	tests := []struct {
+		name     string
+		args     []string
+		gists    []github.Gist
+		err      error
+		expected int
+		output   string
+	}{
+		{name: "summary", args: []string{"octocat"}, gists: gists, output: `- Created gist "Hello world" (1 file)`},
+		{name: "detailed", args: []string{"-detailed", "octocat"}, gists: gists, output: "Url: https://gist.github.com/aa5a315d"},
+		{name: "csv", args: []string{"-format=csv", "octocat"}, gists: gists, output: ",GistEvent,"},
+		{name: "none", args: []string{"octocat"}, output: "No gists found."},
+		{name: "type filter", args: []string{"-type=push", "octocat"}, expected: 1},
+		{name: "offline", args: []string{"-offline", "octocat"}, expected: 1},
+		{name: "user not found", args: []string{"ghost"}, err: github.ErrUserNotFound, expected: userNotFoundExitCode},
+		{name: "missing username", expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli := NewCLI(activity.NewActivityService(github.NewMockEventRepository(nil, nil)))
+			cli.SetGistRepository(&fakeGists{gists: tt.gists, err: tt.err})
+
+			var code int
+			output := captureStdout(t, func() {
+				code = cli.Run(append([]string{"github-activity", "gists"}, tt.args...))
+			})
+			if code != tt.expected {
+				t.Errorf("Run() = %d, want %d", code, tt.expected)
+			}
+			if !strings.Contains(output, tt.output) {
+				t.Errorf("Output missing %q:\n%s", tt.output, output)
+			}
+		})
+	}
+}
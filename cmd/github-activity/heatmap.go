@@ -0,0 +1,139 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/alnah/github-activity/internal/messages"
+	"github.com/alnah/github-activity/pkg/activity"
+)
+
+// Heatmap cells from no activity (level 0) to the busiest days (level 4)
+var (
+	heatmapSymbols = []string{"·", "░", "▒", "▓", "█"}
+	heatmapColors  = []string{"\033[38;5;240m", "\033[38;5;22m", "\033[38;5;28m", "\033[38;5;34m", "\033[38;5;40m"}
+)
+
+const (
+	heatmapColorReset   = "\033[0m"
+	defaultHeatmapWeeks = 13 // GitHub's feed covers about 90 days
+)
+
+// heatmapLevel scales count against the busiest day into levels 0-4
+func heatmapLevel(count, busiest int) int {
+	if count <= 0 || busiest <= 0 {
+		return 0
+	}
+	level := (count*4 + busiest - 1) / busiest
+	if level > 4 {
+		level = 4
+	}
+	return level
+}
+
+// heatmapWeeks converts a -days window into calendar columns
+func heatmapWeeks(days int) int {
+	if days <= 0 {
+		return defaultHeatmapWeeks
+	}
+	return (days + 6) / 7
+}
+
+// useColor reports whether w is a terminal that accepts ANSI colors
+func useColor(w io.Writer) bool {
+	return os.Getenv("NO_COLOR") == "" && isTerminal(w)
+}
+
+// renderHeatmap draws counts as a calendar grid with one column per week,
+// Sunday to Saturday from top to bottom, ending with the week of end. unit is
+// the plural message id of what is counted, such as "events".
+func renderHeatmap(w io.Writer, counts map[string]int, end time.Time, weeks int, unit string, color bool) {
+	end = time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, end.Location())
+	start := end.AddDate(0, 0, -int(end.Weekday())-7*(weeks-1))
+
+	// Find the busiest day inside the grid
+	total, busiest := 0, 0
+	busiestDay := ""
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		key := day.Format("2006-01-02")
+		count := counts[key]
+		total += count
+		if count > busiest {
+			busiest, busiestDay = count, key
+		}
+	}
+
+	cell := func(level int) string {
+		if color {
+			return heatmapColors[level] + heatmapSymbols[level] + heatmapColorReset
+		}
+		return heatmapSymbols[level]
+	}
+
+	// Month labels above the first week of each month
+	header := []byte(strings.Repeat(" ", weeks*2))
+	nextFree := 0
+	for week := 0; week < weeks; week++ {
+		day := start.AddDate(0, 0, 7*week)
+		if week > 0 && day.AddDate(0, 0, -7).Month() == day.Month() {
+			continue
+		}
+		pos := week * 2
+		if pos < nextFree || pos+3 > len(header) {
+			continue
+		}
+		copy(header[pos:], day.Format("Jan"))
+		nextFree = pos + 4
+	}
+	_, _ = fmt.Fprintf(w, "    %s\n", strings.TrimRight(string(header), " "))
+
+	for weekday := 0; weekday < 7; weekday++ {
+		var row strings.Builder
+		for week := 0; week < weeks; week++ {
+			day := start.AddDate(0, 0, 7*week+weekday)
+			if day.After(end) {
+				break
+			}
+			row.WriteString(cell(heatmapLevel(counts[day.Format("2006-01-02")], busiest)))
+			row.WriteString(" ")
+		}
+		label := time.Weekday(weekday).String()[:3]
+		_, _ = fmt.Fprintf(w, "%s %s\n", label, strings.TrimRight(row.String(), " "))
+	}
+
+	legend := make([]string, len(heatmapSymbols))
+	for level := range heatmapSymbols {
+		legend[level] = cell(level)
+	}
+	_, _ = fmt.Fprintf(w, "\n    Less %s More\n\n", strings.Join(legend, " "))
+
+	_, _ = fmt.Fprintf(w, "%s in the last %s", messages.Plural(unit, total), messages.Plural("weeks", weeks))
+	if busiest > 0 {
+		_, _ = fmt.Fprintf(w, "; busiest day %s (%d)", busiestDay, busiest)
+	}
+	_, _ = fmt.Fprintln(w)
+}
+
+// displayHeatmap renders the contribution calendar for username, ending
+// today in location (the local zone when nil)
+func (c *CLI) displayHeatmap(
+	username string,
+	filter activity.EventFilter,
+	days int,
+	location *time.Location,
+) int {
+	counts, err := c.service.GetDailyActivity(username, filter)
+	if err != nil {
+		return c.reportError(err)
+	}
+
+	if location == nil {
+		location = time.Local
+	}
+	now := c.now().In(location)
+	renderHeatmap(os.Stdout, counts, now, heatmapWeeks(days), "events", useColor(os.Stdout))
+	return 0
+}
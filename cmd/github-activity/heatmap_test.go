@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHeatmapLevel(t *testing.T) {
+	tests := []struct {
+		count, busiest, expected int
+	}{
+		{0, 10, 0},
+		{1, 10, 1},
+		{3, 10, 2},
+		{5, 10, 2},
+		{6, 10, 3},
+		{10, 10, 4},
+		{1, 1, 4},
+		{3, 0, 0},
+	}
+
+	for _, tt := range tests {
+		if got := heatmapLevel(tt.count, tt.busiest); got != tt.expected {
+			t.Errorf("heatmapLevel(%d, %d) = %d, want %d", tt.count, tt.busiest, got, tt.expected)
+		}
+	}
+}
+
+func TestHeatmapWeeks(t *testing.T) {
+	tests := []struct {
+		days, expected int
+	}{
+		{0, defaultHeatmapWeeks},
+		{7, 1},
+		{8, 2},
+		{30, 5},
+	}
+
+	for _, tt := range tests {
+		if got := heatmapWeeks(tt.days); got != tt.expected {
+			t.Errorf("heatmapWeeks(%d) = %d, want %d", tt.days, got, tt.expected)
+		}
+	}
+}
+
+func TestRenderHeatmap(t *testing.T) {
+	// Wednesday 2024-03-13, so the last column stops after Wednesday
+	end := time.Date(2024, 3, 13, 15, 0, 0, 0, time.UTC)
+	counts := map[string]int{
+		"2024-03-03": 4, // Sunday of the last week
+		"2024-03-13": 1, // end day
+		"2024-02-25": 2, // Sunday of an earlier week
+		"2024-01-01": 9, // outside the grid
+	}
+
+	var buf bytes.Buffer
+	renderHeatmap(&buf, counts, end, 6, "events", false)
+	lines := strings.Split(buf.String(), "\n")
+
+	if lines[0] != "    Feb     Mar" {
+		t.Errorf("month header = %q", lines[0])
+	}
+	if lines[1] != "Sun · · · ▒ █ ·" {
+		t.Errorf("Sunday row = %q, want %q", lines[1], "Sun · · · ▒ █ ·")
+	}
+	if lines[4] != "Wed · · · · · ░" {
+		t.Errorf("Wednesday row = %q, want %q", lines[4], "Wed · · · · · ░")
+	}
+	if lines[5] != "Thu · · · · ·" {
+		t.Errorf("Thursday row = %q, want %q", lines[5], "Thu · · · · ·")
+	}
+
+	output := buf.String()
+	if !strings.Contains(output, "7 events in the last 6 weeks; busiest day 2024-03-03 (4)") {
+		t.Errorf("summary missing from output:\n%s", output)
+	}
+	if strings.Contains(output, "\033[") {
+		t.Error("output should not contain colors when disabled")
+	}
+
+	buf.Reset()
+	renderHeatmap(&buf, counts, end, 6, "events", true)
+	if !strings.Contains(buf.String(), heatmapColors[4]+"█"+heatmapColorReset) {
+		t.Error("colored output should wrap cells in ANSI colors")
+	}
+}
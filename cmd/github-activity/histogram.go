@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/alnah/github-activity/internal/messages"
+	"github.com/alnah/github-activity/pkg/activity"
+)
+
+// histogramWidth is the length of the longest bar
+const histogramWidth = 40
+
+// renderBars draws one labeled bar per count, scaled to the largest count.
+// Non-zero counts always get at least one block so they stay visible.
+func renderBars(w io.Writer, labels []string, counts []int) {
+	busiest := 0
+	for _, count := range counts {
+		if count > busiest {
+			busiest = count
+		}
+	}
+
+	for i, count := range counts {
+		length := 0
+		if busiest > 0 {
+			length = count * histogramWidth / busiest
+		}
+		if count > 0 && length == 0 {
+			length = 1
+		}
+		_, _ = fmt.Fprintf(w, "%s %s %d\n", labels[i], strings.Repeat("█", length), count)
+	}
+}
+
+// renderHistogram draws weekday and hour-of-day bar charts
+func renderHistogram(w io.Writer, histogram *activity.ActivityHistogram) {
+	_, _ = fmt.Fprintf(w, "By day of week (%s):\n", messages.Plural("events", histogram.Total))
+	weekdays := make([]string, 7)
+	for day := range weekdays {
+		weekdays[day] = time.Weekday(day).String()[:3]
+	}
+	renderBars(w, weekdays, histogram.ByWeekday[:])
+
+	_, _ = fmt.Fprintln(w)
+	zone := histogram.Zone
+	if zone == "" || zone == "Local" {
+		zone = "local time"
+	}
+	_, _ = fmt.Fprintf(w, "By hour of day (%s):\n", zone)
+	hours := make([]string, 24)
+	for hour := range hours {
+		hours[hour] = fmt.Sprintf("%02d", hour)
+	}
+	renderBars(w, hours, histogram.ByHour[:])
+}
+
+// displayHistogram renders when username is most active
+func (c *CLI) displayHistogram(username string) int {
+	histogram, err := c.service.GetActivityHistogram(username)
+	if err != nil {
+		return c.reportError(err)
+	}
+
+	renderHistogram(os.Stdout, histogram)
+	return 0
+}
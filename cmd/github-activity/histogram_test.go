@@ -0,0 +1,49 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/activity"
+)
+
+func TestRenderBars(t *testing.T) {
+	var buf bytes.Buffer
+	renderBars(&buf, []string{"a", "b", "c"}, []int{0, 1, 80})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	expected := []string{
+		"a  0",
+		"b █ 1",
+		"c " + strings.Repeat("█", histogramWidth) + " 80",
+	}
+	for i, want := range expected {
+		if lines[i] != want {
+			t.Errorf("line %d = %q, want %q", i, lines[i], want)
+		}
+	}
+}
+
+func TestRenderHistogram(t *testing.T) {
+	histogram := &activity.ActivityHistogram{Total: 2}
+	histogram.ByWeekday[time.Monday] = 2
+	histogram.ByHour[9] = 2
+
+	var buf bytes.Buffer
+	renderHistogram(&buf, histogram)
+	output := buf.String()
+
+	for _, want := range []string{
+		"By day of week (2 events):",
+		"Mon " + strings.Repeat("█", histogramWidth) + " 2",
+		"Sun  0",
+		"09 " + strings.Repeat("█", histogramWidth) + " 2",
+		"23  0",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("output missing %q:\n%s", want, output)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+)
+
+// Keyring - Token storage in the operating system's credential store
+
+// keyringService names the tokens this tool stores in the keyring
+const keyringService = "github-activity"
+
+// ErrKeyringNotFound reports that the keyring holds no token for an account
+var ErrKeyringNotFound = errors.New("no token stored in the keyring")
+
+// Keyring stores secrets per account in the OS credential store: the macOS
+// Keychain, the Secret Service on Linux and BSD, or the Windows Credential
+// Manager
+type Keyring interface {
+	Get(account string) (string, error)
+	Set(account, secret string) error
+	Delete(account string) error
+}
+
+// SystemKeyring returns the credential store of the current platform
+func SystemKeyring() Keyring {
+	return systemKeyring{}
+}
+
+// validToken rejects input that cannot be a GitHub token, such as a pasted
+// line with spaces or quotes
+func validToken(token string) error {
+	if token == "" {
+		return errors.New("no token given")
+	}
+	if strings.ContainsAny(token, " \t\r\n\"'\\") {
+		return errors.New("the token contains spaces or quotes")
+	}
+	return nil
+}
+
+// readToken reads a token from the first line of r
+func readToken(r io.Reader) (string, error) {
+	line, err := bufio.NewReader(r).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("failed to read the token: %w", err)
+	}
+	token := strings.TrimSpace(line)
+	return token, validToken(token)
+}
+
+// readSecret reads a token from stdin. A terminal does not echo it while it
+// is typed; where stty cannot turn echo off, a typed token is refused rather
+// than shown, and piping it in still works.
+func readSecret(stdin io.Reader) (string, error) {
+	tty, ok := stdin.(*os.File)
+	if !ok || !isTerminal(tty) {
+		return readToken(stdin)
+	}
+
+	refused := errors.New("cannot hide the token as it is typed; pipe it in instead")
+	state, err := stty(tty, "-g")
+	if err != nil {
+		return "", refused
+	}
+	if _, err := stty(tty, "-echo"); err != nil {
+		return "", refused
+	}
+	restore := func() { _, _ = stty(tty, strings.TrimSpace(state)) }
+	defer restore()
+
+	// Ctrl-C must not leave the terminal without echo
+	interrupted := make(chan os.Signal, 1)
+	done := make(chan struct{})
+	signal.Notify(interrupted, os.Interrupt)
+	defer signal.Stop(interrupted)
+	defer close(done)
+	go func() {
+		select {
+		case <-interrupted:
+			restore()
+			fmt.Fprintln(os.Stderr)
+			os.Exit(130)
+		case <-done:
+		}
+	}()
+
+	return readToken(stdin)
+}
+
+// SetKeyring lets the CLI read tokens from, and auth store them in, keyring;
+// nil disables the keyring
+func (c *CLI) SetKeyring(keyring Keyring) {
+	c.keyring = keyring
+}
+
+// keyringToken returns the stored token for the API URL's host, if any.
+// An unavailable keyring is the same as an empty one.
+func (c *CLI) keyringToken(apiURL string) string {
+	if c.keyring == nil {
+		return ""
+	}
+	token, err := c.keyring.Get(ghHost(apiURL))
+	if err != nil {
+		return ""
+	}
+	return token
+}
+
+// runAuth handles `auth login` and `auth logout`
+func (c *CLI) runAuth(args []string, stdin io.Reader) int {
+	if len(args) < 1 || (args[0] != "login" && args[0] != "logout") {
+		fmt.Println("Usage:")
+		fmt.Println("  github-activity auth login [-api-url=url] < token")
+		fmt.Println("  github-activity auth logout [-api-url=url]")
+		return 1
+	}
+	if c.keyring == nil {
+		fmt.Fprintln(os.Stderr, "Error: no keyring is available")
+		return 1
+	}
+
+	flagSet := flag.NewFlagSet("github-activity auth "+args[0], flag.ContinueOnError)
+	apiURL := flagSet.String("api-url", os.Getenv(APIURLEnvVar), "GitHub API base URL")
+	if err := flagSet.Parse(args[1:]); err != nil {
+		return 1
+	}
+	if *apiURL != "" {
+		if err := validateConfigURL(*apiURL); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid API URL: %v\n", err)
+			return 1
+		}
+	}
+	host := ghHost(*apiURL)
+
+	if args[0] == "logout" {
+		err := c.keyring.Delete(host)
+		if errors.Is(err, ErrKeyringNotFound) {
+			fmt.Printf("No token stored for %s.\n", host)
+			return 0
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			return 1
+		}
+		fmt.Printf("Removed the token for %s from the keyring.\n", host)
+		return 0
+	}
+
+	fmt.Fprintf(os.Stderr, "Paste a personal access token for %s: ", host)
+	token, err := readSecret(stdin)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	// Check the token before storing it
+	login := ""
+	if c.repository != nil {
+		if *apiURL != "" {
+			c.repository.SetBaseURL(*apiURL)
+		}
+		c.repository.SetToken(token)
+		if login, err = c.repository.AuthenticatedUser(); err != nil {
+			return c.reportError(err)
+		}
+	}
+	if err := c.keyring.Set(host, token); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	if login != "" {
+		fmt.Printf("Logged in to %s as %s; the token is stored in the keyring.\n", host, login)
+	} else {
+		fmt.Printf("Stored the token for %s in the keyring.\n", host)
+	}
+	return 0
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// securityNotFound is the exit status of `security` for a missing item
+const securityNotFound = 44
+
+// systemKeyring stores tokens in the macOS Keychain with the security tool
+type systemKeyring struct{}
+
+// Get returns the Keychain password for account
+func (systemKeyring) Get(account string) (string, error) {
+	output, err := exec.Command(
+		"security", "find-generic-password", "-s", keyringService, "-a", account, "-w",
+	).Output()
+	if err != nil {
+		return "", keychainError(err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// Set adds or updates the Keychain password for account. The command is
+// written to `security -i` so the token never appears in the process list.
+func (systemKeyring) Set(account, secret string) error {
+	cmd := exec.Command("security", "-i")
+	cmd.Stdin = strings.NewReader(fmt.Sprintf(
+		"add-generic-password -U -s %q -a %q -w %q\n", keyringService, account, secret,
+	))
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("failed to store the token in the Keychain: %s", strings.TrimSpace(string(output)))
+	}
+	return nil
+}
+
+// Delete removes the Keychain password for account
+func (systemKeyring) Delete(account string) error {
+	err := exec.Command("security", "delete-generic-password", "-s", keyringService, "-a", account).Run()
+	if err != nil {
+		return keychainError(err)
+	}
+	return nil
+}
+
+// keychainError maps the security tool's missing-item status to ErrKeyringNotFound
+func keychainError(err error) error {
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == securityNotFound {
+		return ErrKeyringNotFound
+	}
+	return fmt.Errorf("keychain: %w", err)
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/alnah/github-activity/pkg/activity"
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// memoryKeyring is an in-memory Keyring
+type memoryKeyring map[string]string
+
+func (k memoryKeyring) Get(account string) (string, error) {
+	secret, ok := k[account]
+	if !ok {
+		return "", ErrKeyringNotFound
+	}
+	return secret, nil
+}
+
+func (k memoryKeyring) Set(account, secret string) error {
+	k[account] = secret
+	return nil
+}
+
+func (k memoryKeyring) Delete(account string) error {
+	if _, ok := k[account]; !ok {
+		return ErrKeyringNotFound
+	}
+	delete(k, account)
+	return nil
+}
+
+func TestReadToken(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		valid    bool
+	}{
+		{"ghp_abc\n", "ghp_abc", true},
+		{"  ghp_abc  ", "ghp_abc", true},
+		{"\n", "", false},
+		{"ghp abc\n", "", false},
+	}
+	for _, tt := range tests {
+		token, err := readToken(strings.NewReader(tt.input))
+		if (err == nil) != tt.valid || (tt.valid && token != tt.expected) {
+			t.Errorf("readToken(%q) = %q, %v", tt.input, token, err)
+		}
+	}
+}
+
+func TestReadSecret(t *testing.T) {
+	// A piped token is read as is; only terminals have their echo turned off
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = r.Close() }()
+	_, _ = w.WriteString("ghp_piped\n")
+	_ = w.Close()
+
+	if token, err := readSecret(r); err != nil || token != "ghp_piped" {
+		t.Errorf("readSecret() = %q, %v, want ghp_piped", token, err)
+	}
+}
+
+func TestCLI_runAuth(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer ghp_good" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"login":"octocat"}`))
+	}))
+	defer server.Close()
+
+	keyring := memoryKeyring{}
+	newCLI := func() *CLI {
+		repo := github.NewGitHubAPIRepository()
+		repo.SetBaseURL(server.URL)
+		cli := NewCLI(activity.NewActivityService(repo))
+		cli.SetRepository(repo)
+		cli.SetKeyring(keyring)
+		return cli
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		stdin    string
+		expected int
+		stored   string
+	}{
+		{"rejected token", []string{"login"}, "ghp_bad\n", unauthorizedExitCode, ""},
+		{"empty token", []string{"login"}, "", 1, ""},
+		{"login", []string{"login"}, "ghp_good\n", 0, "ghp_good"},
+		{"logout", []string{"logout"}, "", 0, ""},
+		{"logout again", []string{"logout"}, "", 0, ""},
+		{"unknown command", []string{"status"}, "", 1, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var code int
+			captureStdout(t, func() { code = newCLI().runAuth(tt.args, strings.NewReader(tt.stdin)) })
+			if code != tt.expected {
+				t.Errorf("runAuth() = %d, want %d", code, tt.expected)
+			}
+			if keyring["github.com"] != tt.stored {
+				t.Errorf("Stored %q, want %q", keyring["github.com"], tt.stored)
+			}
+		})
+	}
+
+	t.Run("enterprise host", func(t *testing.T) {
+		cli := newCLI()
+		args := []string{"login", "-api-url=" + server.URL + "/api/v3"}
+		captureStdout(t, func() { cli.runAuth(args, strings.NewReader("ghp_good\n")) })
+		if _, ok := keyring["github.com"]; ok {
+			t.Error("Enterprise token stored for github.com")
+		}
+		if keyring["127.0.0.1"] != "ghp_good" {
+			t.Errorf("Keyring = %v, want the token under the server's host", keyring)
+		}
+	})
+}
+
+func TestCLI_resolveFlags_KeyringToken(t *testing.T) {
+	t.Setenv(TokenEnvVar, "")
+	cli := NewCLI(nil)
+	cli.SetKeyring(memoryKeyring{"github.com": "ghp_stored"})
+
+	flags, err := cli.resolveFlags([]string{"github-activity"})
+	if err != nil {
+		t.Fatalf("resolveFlags() error = %v", err)
+	}
+	if flags.Token != "ghp_stored" {
+		t.Errorf("Token = %q, want the keyring token", flags.Token)
+	}
+
+	t.Setenv(TokenEnvVar, "ghp_env")
+	if flags, _ := cli.resolveFlags([]string{"github-activity"}); flags.Token != "ghp_env" {
+		t.Errorf("Token = %q, want GITHUB_TOKEN to win", flags.Token)
+	}
+}
@@ -0,0 +1,64 @@
+//go:build !darwin && !windows
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// systemKeyring stores tokens in the Secret Service (GNOME Keyring, KWallet)
+// through secret-tool, from libsecret
+type systemKeyring struct{}
+
+// secretTool runs secret-tool with the attributes identifying account
+func secretTool(command, account string, extra ...string) *exec.Cmd {
+	args := append([]string{command}, extra...)
+	args = append(args, "service", keyringService, "account", account)
+	return exec.Command("secret-tool", args...)
+}
+
+// Get returns the stored secret for account
+func (systemKeyring) Get(account string) (string, error) {
+	output, err := secretTool("lookup", account).Output()
+	if err != nil {
+		// lookup exits with 1 and prints nothing for a missing item
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && len(output) == 0 && len(exitErr.Stderr) == 0 {
+			return "", ErrKeyringNotFound
+		}
+		return "", secretToolError(err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// Set stores the secret for account; secret-tool reads it from stdin
+func (systemKeyring) Set(account, secret string) error {
+	cmd := secretTool("store", account, "--label=github-activity token for "+account)
+	cmd.Stdin = strings.NewReader(secret)
+	if err := cmd.Run(); err != nil {
+		return secretToolError(err)
+	}
+	return nil
+}
+
+// Delete removes the secret for account
+func (k systemKeyring) Delete(account string) error {
+	if _, err := k.Get(account); err != nil {
+		return err
+	}
+	if err := secretTool("clear", account).Run(); err != nil {
+		return secretToolError(err)
+	}
+	return nil
+}
+
+// secretToolError explains a missing secret-tool
+func secretToolError(err error) error {
+	if errors.Is(err, exec.ErrNotFound) {
+		return errors.New("secret-tool is not installed (install libsecret-tools or libsecret)")
+	}
+	return fmt.Errorf("secret service: %w", err)
+}
@@ -0,0 +1,16 @@
+//go:build !darwin && !windows
+
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSecretTool(t *testing.T) {
+	cmd := secretTool("store", "github.com", "--label=token")
+	expected := "secret-tool store --label=token service github-activity account github.com"
+	if got := strings.Join(cmd.Args, " "); got != expected {
+		t.Errorf("Args = %q, want %q", got, expected)
+	}
+}
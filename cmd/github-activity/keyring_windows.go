@@ -0,0 +1,114 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"syscall"
+	"unsafe"
+)
+
+// Credential Manager functions from advapi32
+var (
+	advapi32        = syscall.NewLazyDLL("advapi32.dll")
+	procCredReadW   = advapi32.NewProc("CredReadW")
+	procCredWriteW  = advapi32.NewProc("CredWriteW")
+	procCredDeleteW = advapi32.NewProc("CredDeleteW")
+	procCredFree    = advapi32.NewProc("CredFree")
+)
+
+const (
+	credTypeGeneric         = 1
+	credPersistLocalMachine = 2
+	errorNotFound           = syscall.Errno(1168)
+)
+
+// winCredential mirrors the CREDENTIALW structure
+type winCredential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        syscall.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+// systemKeyring stores tokens as generic credentials in the Windows
+// Credential Manager
+type systemKeyring struct{}
+
+// credentialTarget names the credential for account
+func credentialTarget(account string) (*uint16, error) {
+	return syscall.UTF16PtrFromString(keyringService + ":" + account)
+}
+
+// Get returns the stored secret for account
+func (systemKeyring) Get(account string) (string, error) {
+	target, err := credentialTarget(account)
+	if err != nil {
+		return "", err
+	}
+	var credential *winCredential
+	ok, _, err := procCredReadW.Call(
+		uintptr(unsafe.Pointer(target)), credTypeGeneric, 0, uintptr(unsafe.Pointer(&credential)),
+	)
+	if ok == 0 {
+		return "", credentialError(err)
+	}
+	defer func() { _, _, _ = procCredFree.Call(uintptr(unsafe.Pointer(credential))) }()
+
+	blob := unsafe.Slice(credential.CredentialBlob, credential.CredentialBlobSize)
+	return string(blob), nil
+}
+
+// Set stores the secret for account
+func (systemKeyring) Set(account, secret string) error {
+	target, err := credentialTarget(account)
+	if err != nil {
+		return err
+	}
+	user, err := syscall.UTF16PtrFromString(account)
+	if err != nil {
+		return err
+	}
+	blob := []byte(secret)
+	credential := winCredential{
+		Type:               credTypeGeneric,
+		TargetName:         target,
+		CredentialBlobSize: uint32(len(blob)),
+		CredentialBlob:     &blob[0],
+		Persist:            credPersistLocalMachine,
+		UserName:           user,
+	}
+	ok, _, err := procCredWriteW.Call(uintptr(unsafe.Pointer(&credential)), 0)
+	if ok == 0 {
+		return credentialError(err)
+	}
+	return nil
+}
+
+// Delete removes the secret for account
+func (systemKeyring) Delete(account string) error {
+	target, err := credentialTarget(account)
+	if err != nil {
+		return err
+	}
+	ok, _, err := procCredDeleteW.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0)
+	if ok == 0 {
+		return credentialError(err)
+	}
+	return nil
+}
+
+// credentialError maps ERROR_NOT_FOUND to ErrKeyringNotFound
+func credentialError(err error) error {
+	if errors.Is(err, errorNotFound) {
+		return ErrKeyringNotFound
+	}
+	return fmt.Errorf("credential manager: %w", err)
+}
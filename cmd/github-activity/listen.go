@@ -0,0 +1,265 @@
+package main
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/activity"
+	"github.com/alnah/github-activity/pkg/format"
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// WebhookSecretEnvVar holds the secret configured on the GitHub webhook
+const WebhookSecretEnvVar = "GITHUB_WEBHOOK_SECRET"
+
+// maxWebhookBody bounds delivery bodies; GitHub caps payloads at 25 MB
+const maxWebhookBody = 25 << 20
+
+// WebhookListener accepts GitHub webhook deliveries, verifies their
+// signature and hands each one to Handle as a GitHubEvent
+type WebhookListener struct {
+	Secret string
+	Handle func(github.GitHubEvent)
+	now    func() time.Time
+}
+
+// NewWebhookListener creates a listener that verifies deliveries with secret
+func NewWebhookListener(secret string, handle func(github.GitHubEvent)) *WebhookListener {
+	return &WebhookListener{
+		Secret: secret,
+		Handle: handle,
+		now:    time.Now,
+	}
+}
+
+// ServeHTTP handles one webhook delivery
+func (l *WebhookListener) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("webhooks are delivered with POST"))
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxWebhookBody))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("failed to read delivery: %w", err))
+		return
+	}
+	if !VerifyWebhookSignature(l.Secret, body, r.Header.Get("X-Hub-Signature-256")) {
+		writeError(w, http.StatusUnauthorized, errors.New("invalid signature"))
+		return
+	}
+
+	name := r.Header.Get("X-GitHub-Event")
+	if name == "ping" {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	event, err := WebhookEvent(name, r.Header.Get("X-GitHub-Delivery"), body, l.now())
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	l.Handle(event)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// VerifyWebhookSignature checks an X-Hub-Signature-256 header against the
+// HMAC-SHA256 of body
+func VerifyWebhookSignature(secret string, body []byte, signature string) bool {
+	digest, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return false
+	}
+	expected, err := hex.DecodeString(digest)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// webhookEventTypes maps webhook names whose event type is not simply the
+// name in CamelCase followed by "Event"
+var webhookEventTypes = map[string]github.EventType{
+	"star": github.EventTypeWatch,
+}
+
+// webhookEventType maps a webhook name such as pull_request_review to its
+// events API type, PullRequestReviewEvent
+func webhookEventType(name string) string {
+	if eventType, ok := webhookEventTypes[name]; ok {
+		return string(eventType)
+	}
+	var b strings.Builder
+	for _, word := range strings.Split(name, "_") {
+		b.WriteString(titleCase(word))
+	}
+	return b.String() + "Event"
+}
+
+// webhookPush is the part of a push delivery that differs from PushPayload
+type webhookPush struct {
+	Ref     string `json:"ref"`
+	After   string `json:"after"`
+	Commits []struct {
+		ID      string `json:"id"`
+		Message string `json:"message"`
+		Author  struct {
+			Name  string `json:"name"`
+			Email string `json:"email"`
+		} `json:"author"`
+	} `json:"commits"`
+}
+
+// WebhookEvent converts a webhook delivery to the GitHubEvent the events API
+// would report, so it can go through the same filters and formatters
+func WebhookEvent(name, delivery string, body []byte, now time.Time) (github.GitHubEvent, error) {
+	if name == "" {
+		return github.GitHubEvent{}, errors.New("missing X-GitHub-Event header")
+	}
+
+	var envelope struct {
+		Sender     github.Actor `json:"sender"`
+		Repository struct {
+			ID       int    `json:"id"`
+			FullName string `json:"full_name"`
+			URL      string `json:"url"`
+		} `json:"repository"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return github.GitHubEvent{}, fmt.Errorf("invalid delivery payload: %w", err)
+	}
+
+	event := github.GitHubEvent{
+		ID:    delivery,
+		Type:  webhookEventType(name),
+		Actor: envelope.Sender,
+		Repo: github.Repo{
+			ID:   envelope.Repository.ID,
+			Name: envelope.Repository.FullName,
+			URL:  envelope.Repository.URL,
+		},
+		Payload:   json.RawMessage(body),
+		CreatedAt: now.UTC(),
+	}
+
+	// Push deliveries name commits by id and carry no size
+	if event.Type == string(github.EventTypePush) {
+		var push webhookPush
+		if err := json.Unmarshal(body, &push); err != nil {
+			return github.GitHubEvent{}, fmt.Errorf("invalid push payload: %w", err)
+		}
+		payload := github.PushPayload{Size: len(push.Commits), Ref: push.Ref, Head: push.After}
+		for _, c := range push.Commits {
+			commit := github.Commit{SHA: c.ID, Message: c.Message}
+			commit.Author.Name, commit.Author.Email = c.Author.Name, c.Author.Email
+			payload.Commits = append(payload.Commits, commit)
+		}
+		converted, err := json.Marshal(payload)
+		if err != nil {
+			return github.GitHubEvent{}, err
+		}
+		event.Payload = converted
+	}
+	return event, nil
+}
+
+// runListen handles `listen [-addr=host:port] [flags]`, printing matching
+// webhook deliveries as they arrive
+func (c *CLI) runListen(args []string) int {
+	flags, err := c.resolveFlags(append([]string{"github-activity listen"}, args...))
+	if err != nil {
+		return c.reportFlagsError(err)
+	}
+
+	secret := os.Getenv(WebhookSecretEnvVar)
+	if secret == "" {
+		fmt.Fprintf(os.Stderr, "Error: listen needs the webhook secret in %s\n", WebhookSecretEnvVar)
+		return 1
+	}
+
+	listener, err := c.webhookListener(flags, secret, os.Stdout)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	return runServer(listener, flags.Addr, "Listening for GitHub webhooks")
+}
+
+// webhookListener validates flags and returns a listener that writes
+// deliveries matching -type, -repo, -actor and -action to w with the selected formatter
+func (c *CLI) webhookListener(flags CLIFlags, secret string, w io.Writer) (*WebhookListener, error) {
+	options := activity.ActivityOptions{
+		EventType: flags.EventType,
+		Timezone:  flags.Timezone,
+		Repo:      flags.Repo,
+		Actor:     flags.Actor,
+		Action:    flags.Action,
+		GroupBy:   flags.GroupBy,
+	}
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+
+	service, ok := c.service.(*activity.ActivityService)
+	if !ok {
+		return nil, errors.New("listen requires the activity service")
+	}
+	if location, _ := activity.LoadTimezone(flags.Timezone); location != nil {
+		service.SetLocation(location)
+	}
+	if err := c.configureOutput(flags, "GitHub webhook delivery"); err != nil {
+		return nil, err
+	}
+
+	filter := activity.EventFilter{
+		Type:   flags.EventType,
+		Repo:   flags.Repo,
+		Actor:  flags.Actor,
+		Action: flags.Action,
+	}
+	detailed := flags.Detailed || format.NeedsDetails(flags.Format)
+
+	// Deliveries arrive concurrently; keep each one's output together
+	var mu sync.Mutex
+	return NewWebhookListener(secret, func(event github.GitHubEvent) {
+		if !filter.Matches(event) {
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		if detailed {
+			c.output.FormatDetailedActivities(w, []activity.DetailedActivity{service.SummarizeDetailed(event)})
+			return
+		}
+		c.output.FormatActivities(w, []activity.ActivitySummary{service.Summarize(event)})
+	}), nil
+}
+
+// titleCase converts the first letter to uppercase
+func titleCase(s string) string {
+	if len(s) == 0 {
+		return s
+	}
+	return strings.ToUpper(string(s[0])) + strings.ToLower(s[1:])
+}
+
+// writeError writes {"error": "..."} with the given status
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
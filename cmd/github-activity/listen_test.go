@@ -0,0 +1,173 @@
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/activity"
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// signWebhook returns the X-Hub-Signature-256 header GitHub would send
+func signWebhook(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	body := []byte(`{"zen":"Keep it logically awesome."}`)
+
+	tests := []struct {
+		name      string
+		signature string
+		expected  bool
+	}{
+		{"valid", signWebhook("secret", body), true},
+		{"wrong secret", signWebhook("other", body), false},
+		{"missing prefix", strings.TrimPrefix(signWebhook("secret", body), "sha256="), false},
+		{"not hex", "sha256=zz", false},
+		{"empty", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := VerifyWebhookSignature("secret", body, tt.signature); got != tt.expected {
+				t.Errorf("VerifyWebhookSignature() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestWebhookEvent(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		name        string
+		event       string
+		body        string
+		expected    string
+		expectError bool
+	}{
+		{
+			"push",
+			"push",
+			`{"ref":"refs/heads/main","after":"abc","sender":{"login":"octocat"},
+			  "repository":{"full_name":"user/repo"},
+			  "commits":[{"id":"abc1234","message":"Fix bug"},{"id":"def5678","message":"Add test"}]}`,
+			"Pushed 2 commits to user/repo (branch: main)",
+			false,
+		},
+		{
+			"pull request",
+			"pull_request",
+			`{"action":"opened","pull_request":{"number":7,"title":"Add feature"},
+			  "repository":{"full_name":"user/repo"}}`,
+			"Opened pull request #7 in user/repo: Add feature",
+			false,
+		},
+		{"star", "star", `{"action":"created","repository":{"full_name":"user/repo"}}`, "Starred user/repo", false},
+		{"missing event name", "", `{}`, "", true},
+		{"invalid json", "push", `{`, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event, err := WebhookEvent(tt.event, "delivery-1", []byte(tt.body), now)
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("WebhookEvent() error = %v", err)
+			}
+			if got := event.FormatDescription(); got != tt.expected {
+				t.Errorf("Description = %q, want %q", got, tt.expected)
+			}
+			if event.ID != "delivery-1" || !event.CreatedAt.Equal(now) {
+				t.Errorf("Event ID/time = %s/%v, want delivery-1/%v", event.ID, event.CreatedAt, now)
+			}
+		})
+	}
+}
+
+func TestWebhookListener(t *testing.T) {
+	var received []github.GitHubEvent
+	listener := NewWebhookListener("secret", func(event github.GitHubEvent) {
+		received = append(received, event)
+	})
+	body := []byte(`{"action":"started","repository":{"full_name":"user/repo"}}`)
+
+	tests := []struct {
+		name           string
+		method         string
+		event          string
+		signature      string
+		expectedStatus int
+	}{
+		{"delivery", http.MethodPost, "watch", signWebhook("secret", body), http.StatusNoContent},
+		{"ping", http.MethodPost, "ping", signWebhook("secret", body), http.StatusNoContent},
+		{"bad signature", http.MethodPost, "watch", signWebhook("other", body), http.StatusUnauthorized},
+		{"wrong method", http.MethodGet, "watch", signWebhook("secret", body), http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(tt.method, "/", bytes.NewReader(body))
+			request.Header.Set("X-GitHub-Event", tt.event)
+			request.Header.Set("X-Hub-Signature-256", tt.signature)
+			recorder := httptest.NewRecorder()
+			listener.ServeHTTP(recorder, request)
+
+			if recorder.Code != tt.expectedStatus {
+				t.Errorf("Status = %d, want %d", recorder.Code, tt.expectedStatus)
+			}
+		})
+	}
+
+	if len(received) != 1 || received[0].Type != "WatchEvent" {
+		t.Errorf("Received %v, want one WatchEvent", received)
+	}
+}
+
+func TestCLI_webhookListener(t *testing.T) {
+	cli := NewCLI(activity.NewActivityService(github.NewMockEventRepository(nil, nil)))
+	flags, err := cli.resolveFlags([]string{"github-activity listen", "-type=star", "-repo=user"})
+	if err != nil {
+		t.Fatalf("resolveFlags() error = %v", err)
+	}
+
+	var out bytes.Buffer
+	listener, err := cli.webhookListener(flags, "secret", &out)
+	if err != nil {
+		t.Fatalf("webhookListener() error = %v", err)
+	}
+
+	deliveries := []struct {
+		event string
+		body  string
+	}{
+		{"watch", `{"action":"started","repository":{"full_name":"user/repo"}}`},
+		{"watch", `{"action":"started","repository":{"full_name":"other/repo"}}`},
+		{"fork", `{"forkee":{"full_name":"me/repo"},"repository":{"full_name":"user/repo"}}`},
+	}
+	for _, delivery := range deliveries {
+		request := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(delivery.body))
+		request.Header.Set("X-GitHub-Event", delivery.event)
+		request.Header.Set("X-Hub-Signature-256", signWebhook("secret", []byte(delivery.body)))
+		listener.ServeHTTP(httptest.NewRecorder(), request)
+	}
+
+	if out.String() != "- Starred user/repo\n" {
+		t.Errorf("Output = %q, want only the matching star", out.String())
+	}
+}
@@ -0,0 +1,34 @@
+// Command github-activity prints a GitHub user's recent activity.
+package main
+
+import (
+	"os"
+
+	"github.com/alnah/github-activity/pkg/activity"
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// version is set at build time with -ldflags "-X main.version=..."
+var version = "dev"
+
+func main() {
+	// Initialize repository
+	repository := github.NewGitHubAPIRepository()
+
+	// Initialize service
+	service := activity.NewActivityService(repository)
+
+	// Initialize CLI
+	cli := NewCLI(service)
+	cli.SetRepository(repository)
+	cli.SetConfigPath(DefaultConfigPath())
+	cli.SetKeyring(SystemKeyring())
+	cli.SetGHConfigDir(DefaultGHConfigDir())
+	if dir, err := os.Getwd(); err == nil {
+		cli.SetWorkDir(dir)
+	}
+
+	// Run CLI and exit with appropriate code
+	exitCode := cli.Run(os.Args)
+	os.Exit(exitCode)
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/activity"
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// Notifications - The notifications subcommand
+
+// renderNotifications lists notifications as "[reason] repo: subject (type)"
+// with when each was last updated, counted from now
+func renderNotifications(
+	w io.Writer,
+	notifications []github.Notification,
+	now time.Time,
+	absolute bool,
+	location *time.Location,
+) {
+	_, _ = fmt.Fprintf(w, "Unread notifications (%d):\n\n", len(notifications))
+	for _, notification := range notifications {
+		when := activity.HumanizeTime(notification.UpdatedAt, now)
+		if absolute {
+			updatedAt := notification.UpdatedAt
+			if location != nil {
+				updatedAt = updatedAt.In(location)
+			}
+			when = updatedAt.Format("2006-01-02 15:04:05")
+		}
+		_, _ = fmt.Fprintf(w, "- [%s] %s: %s (%s), %s\n",
+			notification.Reason,
+			notification.Repository.FullName,
+			notification.Subject.Title,
+			notification.Subject.Type,
+			when)
+	}
+}
+
+// runNotifications handles `notifications [-limit=N] [-mark-read]`
+func (c *CLI) runNotifications(args []string) int {
+	flags, err := c.resolveFlags(append([]string{"github-activity notifications"}, args...))
+	if err != nil {
+		return c.reportFlagsError(err)
+	}
+	switch {
+	case len(flags.Args) > 0:
+		fmt.Println("Usage:")
+		fmt.Println("  github-activity notifications [-limit=N] [-mark-read]")
+		return 1
+	case flags.Format != "":
+		fmt.Fprintf(os.Stderr, "Error: notifications cannot use -format=%s\n", flags.Format)
+		return 1
+	case flags.FromDB || flags.Offline || flags.Session != "":
+		fmt.Fprintln(os.Stderr, "Error: notifications are read from GitHub and cannot use -from-db, -offline or -session")
+		return 1
+	}
+
+	options := activity.ActivityOptions{Limit: flags.Limit, Retries: flags.Retries, Timezone: flags.Timezone}
+	if err := options.Validate(); err != nil {
+		return c.reportError(err)
+	}
+	location, _ := activity.LoadTimezone(flags.Timezone)
+	c.applyRepositorySettings(flags)
+
+	notifications := c.notifications
+	if notifications == nil {
+		if c.repository == nil || !c.repository.HasToken() {
+			return c.reportError(fmt.Errorf("notifications require a token: %w", github.ErrUnauthorized))
+		}
+		notifications = c.repository
+	}
+
+	result, err := notifications.FetchNotifications(flags.Limit)
+	if err != nil {
+		return c.reportError(err)
+	}
+	if len(result) == 0 {
+		fmt.Println("No unread notifications.")
+		return 0
+	}
+	renderNotifications(os.Stdout, result, c.now(), flags.AbsoluteTime, location)
+
+	if flags.MarkRead {
+		for _, notification := range result {
+			if err := notifications.MarkNotificationRead(notification.ID); err != nil {
+				return c.reportError(err)
+			}
+		}
+		fmt.Printf("\nMarked %d as read.\n", len(result))
+	}
+	return 0
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/activity"
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// fakeNotifications records the threads marked as read
+type fakeNotifications struct {
+	notifications []github.Notification
+	err           error
+	marked        []string
+}
+
+func (f *fakeNotifications) FetchNotifications(limit int) ([]github.Notification, error) {
+	if limit > 0 && len(f.notifications) > limit {
+		return f.notifications[:limit], f.err
+	}
+	return f.notifications, f.err
+}
+
+func (f *fakeNotifications) MarkNotificationRead(id string) error {
+	f.marked = append(f.marked, id)
+	return nil
+}
+
+func testNotifications() []github.Notification {
+	return []github.Notification{
+		{
+			ID: "1", Reason: "review_requested", UpdatedAt: time.Now().Add(-2 * time.Hour),
+			Subject:    github.NotificationSubject{Title: "Fix the build", Type: "PullRequest"},
+			Repository: github.NotificationRepo{FullName: "octo/repo"},
+		},
+		{
+			ID: "2", Reason: "mention", UpdatedAt: time.Now().Add(-48 * time.Hour),
+			Subject:    github.NotificationSubject{Title: "Crash on start", Type: "Issue"},
+			Repository: github.NotificationRepo{FullName: "octo/other"},
+		},
+	}
+}
+
+func TestRenderNotifications(t *testing.T) {
+	notifications := testNotifications()
+	notifications[0].UpdatedAt = time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	var buf bytes.Buffer
+	renderNotifications(&buf, notifications, time.Now(), true, time.UTC)
+	output := buf.String()
+
+	for _, want := range []string{
+		"Unread notifications (2):",
+		"- [review_requested] octo/repo: Fix the build (PullRequest), 2024-01-02 03:04:05",
+		"- [mention] octo/other: Crash on start (Issue), ",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Output missing %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestCLI_runNotifications(t *testing.T) {
+	tests := []struct {
+		name          string
+		args          []string
+		notifications []github.Notification
+		err           error
+		expected      int
+		output        string
+		marked        []string
+	}{
+		{name: "list", notifications: testNotifications(), output: "Crash on start (Issue), 2 days ago"},
+		{
+			name:          "mark read",
+			args:          []string{"-limit=1", "-mark-read"},
+			notifications: testNotifications(),
+			output:        "Marked 1 as read.",
+			marked:        []string{"1"},
+		},
+		{name: "none", output: "No unread notifications."},
+		{name: "unauthorized", err: github.ErrUnauthorized, expected: unauthorizedExitCode},
+		{name: "username", args: []string{"octocat"}, expected: 1, output: "Usage:"},
+		{name: "format", args: []string{"-format=csv"}, expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeNotifications{notifications: tt.notifications, err: tt.err}
+			cli := NewCLI(activity.NewActivityService(github.NewMockEventRepository(nil, nil)))
+			cli.SetNotificationRepository(fake)
+
+			var code int
+			output := captureStdout(t, func() {
+				code = cli.Run(append([]string{"github-activity", "notifications"}, tt.args...))
+			})
+			if code != tt.expected {
+				t.Errorf("Run() = %d, want %d", code, tt.expected)
+			}
+			if !strings.Contains(output, tt.output) {
+				t.Errorf("Output missing %q:\n%s", tt.output, output)
+			}
+			if strings.Join(fake.marked, ",") != strings.Join(tt.marked, ",") {
+				t.Errorf("Marked %v as read, want %v", fake.marked, tt.marked)
+			}
+		})
+	}
+
+	t.Run("without a token", func(t *testing.T) {
+		t.Setenv(TokenEnvVar, "")
+		cli := NewCLI(activity.NewActivityService(github.NewMockEventRepository(nil, nil)))
+		cli.SetRepository(github.NewGitHubAPIRepository())
+		cli.SetGHConfigDir(t.TempDir())
+		cli.SetKeyring(nil)
+
+		var code int
+		captureStdout(t, func() { code = cli.Run([]string{"github-activity", "notifications"}) })
+		if code != unauthorizedExitCode {
+			t.Errorf("Run() = %d, want %d", code, unauthorizedExitCode)
+		}
+	})
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+)
+
+// notifyClient posts formatted activity to chat webhooks
+var notifyClient = &http.Client{Timeout: 10 * time.Second}
+
+// postMessages posts each line of messages to url as a JSON body. Formats
+// that need several messages, because of per-message limits, write one per line.
+func postMessages(client *http.Client, url string, messages []byte) error {
+	for _, message := range bytes.Split(messages, []byte("\n")) {
+		if len(bytes.TrimSpace(message)) == 0 {
+			continue
+		}
+		resp, err := client.Post(url, "application/json", bytes.NewReader(message))
+		if err != nil {
+			return fmt.Errorf("failed to post to webhook: %w", err)
+		}
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		_ = resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return fmt.Errorf("webhook returned status code %d: %s", resp.StatusCode, bytes.TrimSpace(body))
+		}
+	}
+	return nil
+}
+
+// notify formats the user's activity with the configured formatter and posts
+// it to a chat webhook instead of printing it
+func (c *CLI) notify(url, sink string, run *runSetup, flags CLIFlags) int {
+	var messages bytes.Buffer
+	if flags.Detailed || flags.Enrich {
+		activities, err := c.service.GetUserActivityDetailed(run.username, run.filter)
+		if err != nil {
+			return c.reportError(err)
+		}
+		c.output.FormatDetailedActivities(&messages, activities)
+	} else {
+		activities, err := c.service.GetUserActivity(run.username, run.filter)
+		if err != nil {
+			return c.reportError(err)
+		}
+		c.output.FormatActivities(&messages, activities)
+	}
+
+	if err := postMessages(notifyClient, url, messages.Bytes()); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	fmt.Printf("Posted activity for %s to %s.\n", run.username, sink)
+	return 0
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/activity"
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+func TestPostMessages(t *testing.T) {
+	tests := []struct {
+		name        string
+		messages    string
+		status      int
+		posts       int
+		expectError bool
+	}{
+		{"one message", `{"text":"a"}` + "\n", http.StatusOK, 1, false},
+		{"one per line", `{"text":"a"}` + "\n\n" + `{"text":"b"}` + "\n", http.StatusNoContent, 2, false},
+		{"rejected", `{"text":"a"}` + "\n", http.StatusBadRequest, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			posts := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				posts++
+				if r.Header.Get("Content-Type") != "application/json" {
+					t.Errorf("Content-Type = %q", r.Header.Get("Content-Type"))
+				}
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			err := postMessages(server.Client(), server.URL, []byte(tt.messages))
+			if tt.expectError != (err != nil) {
+				t.Errorf("postMessages() error = %v, expectError %v", err, tt.expectError)
+			}
+			if posts != tt.posts {
+				t.Errorf("Posted %d messages, want %d", posts, tt.posts)
+			}
+		})
+	}
+}
+
+func TestCLI_Run_SlackWebhook(t *testing.T) {
+	var message struct {
+		Text   string            `json:"text"`
+		Blocks []json.RawMessage `json:"blocks"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &message); err != nil {
+			t.Errorf("Posted body is not valid JSON: %v", err)
+		}
+	}))
+	defer server.Close()
+
+	events := []github.GitHubEvent{{ID: "1", Type: "WatchEvent", Repo: github.Repo{Name: "user/repo"}}}
+	cli := NewCLI(activity.NewActivityService(github.NewMockEventRepository(events, nil)))
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected int
+	}{
+		{"posts", []string{"-slack-webhook-url=" + server.URL, "octocat"}, 0},
+		{"conflicting format", []string{"-slack-webhook-url=" + server.URL, "-format=csv", "octocat"}, 1},
+		{"invalid url", []string{"-slack-webhook-url=hooks.slack.com", "octocat"}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := cli.Run(append([]string{"github-activity"}, tt.args...)); code != tt.expected {
+				t.Errorf("Run() = %d, want %d", code, tt.expected)
+			}
+		})
+	}
+
+	if message.Text != "GitHub activity for octocat" || len(message.Blocks) != 2 {
+		t.Errorf("Posted message = %+v", message)
+	}
+}
+
+func TestCLI_Run_DiscordWebhook(t *testing.T) {
+	var message struct {
+		Content string            `json:"content"`
+		Embeds  []json.RawMessage `json:"embeds"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if err := json.Unmarshal(body, &message); err != nil {
+			t.Errorf("Posted body is not valid JSON: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	events := []github.GitHubEvent{{ID: "1", Type: "WatchEvent", Repo: github.Repo{Name: "user/repo"}}}
+	cli := NewCLI(activity.NewActivityService(github.NewMockEventRepository(events, nil)))
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected int
+	}{
+		{"posts", []string{"-discord-webhook-url=" + server.URL, "octocat"}, 0},
+		{"conflicting format", []string{"-discord-webhook-url=" + server.URL, "-format=slack", "octocat"}, 1},
+		{"both webhooks", []string{
+			"-discord-webhook-url=" + server.URL, "-slack-webhook-url=" + server.URL, "octocat",
+		}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := cli.Run(append([]string{"github-activity"}, tt.args...)); code != tt.expected {
+				t.Errorf("Run() = %d, want %d", code, tt.expected)
+			}
+		})
+	}
+
+	if message.Content != "**GitHub activity for octocat**" || len(message.Embeds) != 1 {
+		t.Errorf("Posted message = %+v", message)
+	}
+}
+
+func TestCLI_Run_SpikesWebhook(t *testing.T) {
+	var posted []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		posted = append(posted, string(body))
+	}))
+	defer server.Close()
+
+	now := time.Now()
+	burst := make([]github.GitHubEvent, 0, activity.SpikeMinEvents)
+	for i := 0; i < activity.SpikeMinEvents; i++ {
+		burst = append(burst, github.GitHubEvent{
+			ID: strconv.Itoa(i), Type: "PushEvent", Repo: github.Repo{Name: "bot/repo"}, CreatedAt: now.Add(-time.Minute),
+		})
+	}
+
+	tests := []struct {
+		name     string
+		events   []github.GitHubEvent
+		expected int
+		posts    int
+	}{
+		{"quiet", nil, 0, 0},
+		{"spike", burst, spikeExitCode, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			posted = nil
+			cli := NewCLI(activity.NewActivityService(github.NewMockEventRepository(tt.events, nil)))
+			var code int
+			captureStdout(t, func() {
+				code = cli.Run([]string{"github-activity", "-spikes=hour", "-slack-webhook-url=" + server.URL, "octocat"})
+			})
+			if code != tt.expected {
+				t.Errorf("Run() = %d, want %d", code, tt.expected)
+			}
+			if len(posted) != tt.posts {
+				t.Fatalf("Posted %d messages, want %d", len(posted), tt.posts)
+			}
+			if tt.posts > 0 && !strings.Contains(posted[0], "Spike: 10 events in bot/repo") {
+				t.Errorf("Posted message = %s, want the spike", posted[0])
+			}
+		})
+	}
+}
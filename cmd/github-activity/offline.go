@@ -0,0 +1,24 @@
+package main
+
+import "errors"
+
+// validateOffline rejects flags that need GitHub when -offline is set
+func validateOffline(flags CLIFlags) error {
+	switch {
+	case !flags.Offline:
+		return nil
+	case flags.Enrich:
+		return errors.New("-enrich fetches from GitHub and cannot be used with -offline")
+	case flags.ByLanguage:
+		return errors.New("-by-language looks repositories up on GitHub and cannot be used with -offline")
+	case flags.Received:
+		return errors.New("-received is not stored offline")
+	case flags.Refresh:
+		return errors.New("-refresh fetches from GitHub and cannot be used with -offline")
+	case len(flags.Args) == 0 && flags.User == "":
+		return errors.New("-offline needs a username; the token owner cannot be looked up offline")
+	case flags.Session == "" && flags.DB == "":
+		return errors.New("-offline reads the -session file or the archive; set one of them")
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/activity"
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+func TestCLI_Run_Offline(t *testing.T) {
+	dir := t.TempDir()
+	archive := filepath.Join(dir, "archive.json")
+	events := []github.GitHubEvent{{ID: "1", Type: "WatchEvent", Repo: github.Repo{Name: "user/repo"}, CreatedAt: time.Now()}}
+	if _, _, err := activity.NewEventArchive(archive).Append("octocat", events, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected int
+	}{
+		{"archive", []string{"-offline", "-db=" + archive, "octocat"}, 0},
+		{"not archived", []string{"-offline", "-db=" + archive, "someone"}, 1},
+		{"empty session", []string{"-offline", "-session=" + filepath.Join(dir, "session.json"), "octocat"}, 1},
+		{"enrich", []string{"-offline", "-db=" + archive, "-enrich", "octocat"}, 1},
+		{"by language", []string{"-offline", "-db=" + archive, "-by-language", "octocat"}, 1},
+		{"received", []string{"-offline", "-db=" + archive, "-received", "octocat"}, 1},
+		{"refresh", []string{"-offline", "-db=" + archive, "-refresh", "octocat"}, 1},
+		{"archive fallback", []string{"-offline", "-db=" + archive, "-archive-fallback", "octocat"}, 1},
+		{"no source", []string{"-offline", "-db=", "octocat"}, 1},
+		{"sync", []string{"sync", "-offline", "-db=" + archive, "octocat"}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := &countingRepository{err: errors.New("network used")}
+			cli := NewCLI(activity.NewActivityService(next))
+
+			if code := cli.Run(append([]string{"github-activity"}, tt.args...)); code != tt.expected {
+				t.Errorf("Run() = %d, want %d", code, tt.expected)
+			}
+			if len(next.calls) != 0 {
+				t.Errorf("Offline run fetched from the API: %v", next.calls)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Output File - Writing the results to a file instead of stdout
+
+// outputFile is an -output destination being written through a temporary file
+type outputFile struct {
+	path   string
+	tmp    *os.File
+	stdout *os.File
+}
+
+// redirectOutput sends standard output to a temporary file next to path
+// until finishOutput, so a report is never seen half written
+func (c *CLI) redirectOutput(path string) error {
+	if c.outputFile != nil {
+		return nil
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	c.outputFile = &outputFile{path: path, tmp: tmp, stdout: os.Stdout}
+	os.Stdout = tmp
+	return nil
+}
+
+// reportedExitCode reports whether a run exiting with code wrote its
+// results: success, or a status that flags what they show, as -spikes does
+func reportedExitCode(code int) bool {
+	return code == 0 || code == spikeExitCode
+}
+
+// finishOutput restores standard output and, when the run wrote its results,
+// replaces the -output file with them. A failed run leaves any earlier file
+// in place.
+func (c *CLI) finishOutput(code int) int {
+	output := c.outputFile
+	if output == nil {
+		return code
+	}
+	c.outputFile = nil
+	os.Stdout = output.stdout
+	defer func() { _ = os.Remove(output.tmp.Name()) }()
+
+	err := output.tmp.Chmod(0o644)
+	if closeErr := output.tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if !reportedExitCode(code) {
+		return code
+	}
+	if err == nil {
+		err = os.Rename(output.tmp.Name(), output.path)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to write %s: %v\n", output.path, err)
+		return 1
+	}
+	return code
+}
+
+// replaceFile writes data over the existing file at path through a temporary
+// file, keeping its permissions, so the file is never seen half written
+func replaceFile(path string, data []byte) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Chmod(info.Mode().Perm())
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
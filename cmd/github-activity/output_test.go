@@ -0,0 +1,96 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alnah/github-activity/pkg/activity"
+)
+
+func TestCLI_Run_Output(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "report.csv")
+	if err := os.WriteFile(path, []byte("previous report\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(username string) (int, string) {
+		cli := NewCLI(activity.NewActivityService(usersRepository{}))
+		var code int
+		stdout := captureStdout(t, func() {
+			code = cli.Run([]string{"github-activity", "-format=csv", "-output=" + path, username})
+		})
+		return code, stdout
+	}
+
+	// A failed run keeps the earlier report
+	if code, _ := run("ghost"); code != userNotFoundExitCode {
+		t.Fatalf("Run(ghost) = %d, want %d", code, userNotFoundExitCode)
+	}
+	if data, _ := os.ReadFile(path); string(data) != "previous report\n" {
+		t.Errorf("Failed run replaced the report with %q", data)
+	}
+
+	code, stdout := run("alice")
+	if code != 0 {
+		t.Fatalf("Run(alice) = %d, want 0", code)
+	}
+	if stdout != "" {
+		t.Errorf("Stdout = %q, want everything in the file", stdout)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "timestamp,type,repo") || !strings.Contains(string(data), "alice/repo") {
+		t.Errorf("Report = %q", data)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 {
+		t.Errorf("Output directory holds %d files, want no temporary files left", len(entries))
+	}
+}
+
+func TestCLI_finishOutput(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     int
+		expected string
+	}{
+		{"success", 0, "report\n"},
+		{"spike found", spikeExitCode, "report\n"},
+		{"failure", 1, "previous report\n"},
+		{"network failure", networkExitCode, "previous report\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "report.txt")
+			if err := os.WriteFile(path, []byte("previous report\n"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			cli := NewCLI(activity.NewActivityService(usersRepository{}))
+			stdout := os.Stdout
+			if err := cli.redirectOutput(path); err != nil {
+				t.Fatal(err)
+			}
+			fmt.Println("report")
+			if code := cli.finishOutput(tt.code); code != tt.code {
+				t.Errorf("finishOutput() = %d, want %d", code, tt.code)
+			}
+			if os.Stdout != stdout {
+				t.Error("Standard output was not restored")
+			}
+			if data, _ := os.ReadFile(path); string(data) != tt.expected {
+				t.Errorf("Report = %q, want %q", data, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+)
+
+// Profiling - The hidden -pprof flag for performance work, named so because
+// -profile selects a config profile
+
+// profileKinds maps each -pprof value to the file the profile is written to
+var profileKinds = map[string]string{
+	"cpu": "cpu.pprof",
+	"mem": "mem.pprof",
+}
+
+// profile is a -pprof run being recorded
+type profile struct {
+	kind string
+	file *os.File
+}
+
+// validateProfile rejects -pprof values other than cpu and mem
+func validateProfile(kind string) error {
+	if _, ok := profileKinds[kind]; kind != "" && !ok {
+		return fmt.Errorf("invalid profile %q; use cpu or mem", kind)
+	}
+	return nil
+}
+
+// startProfile creates the profile file and, for cpu, starts sampling until
+// finishProfile
+func (c *CLI) startProfile(kind string) error {
+	if c.profile != nil {
+		return nil
+	}
+	file, err := os.Create(profileKinds[kind])
+	if err != nil {
+		return fmt.Errorf("failed to create profile: %w", err)
+	}
+	if kind == "cpu" {
+		if err := pprof.StartCPUProfile(file); err != nil {
+			_ = file.Close()
+			return fmt.Errorf("failed to start CPU profile: %w", err)
+		}
+	}
+	c.profile = &profile{kind: kind, file: file}
+	return nil
+}
+
+// finishProfile stops the CPU profile, or writes the allocations made
+// during the run, and says where the profile went
+func (c *CLI) finishProfile(code int) int {
+	profile := c.profile
+	if profile == nil {
+		return code
+	}
+	c.profile = nil
+
+	var err error
+	if profile.kind == "cpu" {
+		pprof.StopCPUProfile()
+	} else {
+		runtime.GC()
+		err = pprof.Lookup("allocs").WriteTo(profile.file, 0)
+	}
+	if closeErr := profile.file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to write %s: %v\n", profile.file.Name(), err)
+		if code == 0 {
+			code = 1
+		}
+		return code
+	}
+	fmt.Fprintf(os.Stderr, "Wrote %s profile to %s; inspect it with go tool pprof\n", profile.kind, profile.file.Name())
+	return code
+}
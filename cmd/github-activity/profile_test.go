@@ -0,0 +1,43 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/alnah/github-activity/pkg/activity"
+)
+
+func TestCLI_Run_PProf(t *testing.T) {
+	t.Chdir(t.TempDir())
+
+	for _, kind := range []string{"cpu", "mem"} {
+		t.Run(kind, func(t *testing.T) {
+			cli := NewCLI(activity.NewActivityService(usersRepository{}))
+			var code int
+			captureStdout(t, func() {
+				code = cli.Run([]string{"github-activity", "-pprof=" + kind, "alice"})
+			})
+			if code != 0 {
+				t.Fatalf("Run() = %d, want 0", code)
+			}
+			info, err := os.Stat(profileKinds[kind])
+			if err != nil || info.Size() == 0 {
+				t.Errorf("Profile %s = %v, %v; want a non-empty file", profileKinds[kind], info, err)
+			}
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		cli := NewCLI(activity.NewActivityService(usersRepository{}))
+		var code int
+		captureStdout(t, func() {
+			code = cli.Run([]string{"github-activity", "-pprof=block", "alice"})
+		})
+		if code != 1 {
+			t.Errorf("Run() = %d, want 1", code)
+		}
+		if _, err := os.Stat("block.pprof"); !os.IsNotExist(err) {
+			t.Error("An invalid -pprof should not write a profile")
+		}
+	})
+}
@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// Progress - Fetch status on the terminal while pages download
+
+// spinnerFrames animate the progress line
+var spinnerFrames = []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
+
+// spinnerInterval is how often the spinner redraws
+const spinnerInterval = 100 * time.Millisecond
+
+// Spinner draws an animated status line such as "⠹ Fetching page 3/10…" and
+// erases it when the fetch finishes, so it never mixes with the output
+type Spinner struct {
+	w io.Writer
+
+	mu      sync.Mutex
+	message string
+	frame   int
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+// NewSpinner returns a spinner drawing on w, normally stderr
+func NewSpinner(w io.Writer) *Spinner {
+	return &Spinner{w: w}
+}
+
+// FetchStarted starts the animation
+func (s *Spinner) FetchStarted(username string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.message = fmt.Sprintf("Fetching events for %s…", username)
+	if s.stop != nil {
+		return
+	}
+	s.stop, s.done = make(chan struct{}), make(chan struct{})
+	s.draw()
+	go s.animate(s.stop, s.done)
+}
+
+// PageFetched shows which page is being fetched
+func (s *Spinner) PageFetched(done, pages int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if pages > 0 {
+		s.message = fmt.Sprintf("Fetching page %d/%d…", min(done+1, pages), pages)
+	} else {
+		s.message = fmt.Sprintf("Fetching page %d…", done+1)
+	}
+	s.draw()
+}
+
+// RetryWaiting shows that the fetch pauses because GitHub asked it to
+func (s *Spinner) RetryWaiting(wait time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.message = fmt.Sprintf("GitHub asked to slow down, retrying in %s…", wait)
+	s.draw()
+}
+
+// FetchFinished stops the animation and erases the line
+func (s *Spinner) FetchFinished() {
+	s.mu.Lock()
+	stop, done := s.stop, s.done
+	s.stop, s.done = nil, nil
+	s.mu.Unlock()
+	if stop == nil {
+		return
+	}
+	close(stop)
+	<-done
+	_, _ = fmt.Fprint(s.w, "\r\033[K")
+}
+
+// animate redraws the line until stop is closed
+func (s *Spinner) animate(stop, done chan struct{}) {
+	defer close(done)
+	ticker := time.NewTicker(spinnerInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			s.mu.Lock()
+			s.frame++
+			s.draw()
+			s.mu.Unlock()
+		}
+	}
+}
+
+// draw rewrites the status line; s.mu must be held
+func (s *Spinner) draw() {
+	frame := spinnerFrames[s.frame%len(spinnerFrames)]
+	_, _ = fmt.Fprintf(s.w, "\r\033[K%s %s", frame, s.message)
+}
+
+// isTerminal reports whether w is an interactive terminal
+func isTerminal(w io.Writer) bool {
+	file, ok := w.(*os.File)
+	if !ok {
+		return false
+	}
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// showProgress reports whether a progress line belongs on stderr: not with
+// -quiet or -verbose, whose log lines it would overwrite, and only when both
+// stdout and stderr are terminals
+func showProgress(flags CLIFlags) bool {
+	return !flags.Quiet && !flags.Verbose && isTerminal(os.Stdout) && isTerminal(os.Stderr)
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// The spinner shows pauses asked for by GitHub
+var _ github.RetryProgress = (*Spinner)(nil)
+
+func TestSpinner(t *testing.T) {
+	var buf bytes.Buffer
+	spinner := NewSpinner(&buf)
+
+	spinner.FetchStarted("octocat")
+	spinner.PageFetched(1, 3)
+	spinner.PageFetched(3, 3)
+	spinner.PageFetched(2, 0)
+	spinner.RetryWaiting(20 * time.Second)
+	spinner.FetchFinished()
+	spinner.FetchFinished() // A second stop is harmless
+
+	output := buf.String()
+	for _, want := range []string{
+		"Fetching events for octocat…",
+		"Fetching page 2/3…",
+		"Fetching page 3/3…",
+		"Fetching page 3…",
+		"GitHub asked to slow down, retrying in 20s…",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Output missing %q: %q", want, output)
+		}
+	}
+	if !strings.HasSuffix(output, "\r\033[K") {
+		t.Errorf("Expected the line to be erased at the end: %q", output)
+	}
+}
+
+func TestShowProgress(t *testing.T) {
+	// Test output is captured, so stdout is never a terminal here
+	if showProgress(CLIFlags{}) && !isTerminal(os.Stdout) {
+		t.Error("Progress shown when stdout is not a terminal")
+	}
+	if showProgress(CLIFlags{Quiet: true}) {
+		t.Error("Progress shown with -quiet")
+	}
+	if isTerminal(&bytes.Buffer{}) {
+		t.Error("A buffer is not a terminal")
+	}
+}
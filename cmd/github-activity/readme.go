@@ -0,0 +1,142 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/alnah/github-activity/internal/messages"
+	"github.com/alnah/github-activity/pkg/activity"
+)
+
+// README Snippet - The readme subcommand
+
+// Markers around the generated block in a profile README, as used by other
+// activity README generators so existing READMEs work unchanged
+const (
+	readmeStartMarker = "<!--START_SECTION:activity-->"
+	readmeEndMarker   = "<!--END_SECTION:activity-->"
+)
+
+// readmeDefaultLimit is how many activities `readme` lists without -limit
+const readmeDefaultLimit = 5
+
+// errNoMarkers is returned by spliceReadme when a README has no place for the block
+var errNoMarkers = errors.New("no " + readmeStartMarker + " and " + readmeEndMarker + " markers")
+
+// markdownEscaper escapes the characters Markdown would read as formatting
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", "*", `\*`, "_", `\_`, "[", `\[`, "]", `\]`, "<", `\<`, ">", `\>`,
+)
+
+// readmeLine is an activity as a Markdown list item with its repository linked
+func readmeLine(activity activity.ActivitySummary) string {
+	line := markdownEscaper.Replace(activity.Description)
+	if activity.Repository != "" {
+		repo := markdownEscaper.Replace(activity.Repository)
+		line = strings.Replace(line, repo, fmt.Sprintf("[%s](https://github.com/%s)", repo, activity.Repository), 1)
+	}
+	if !activity.CreatedAt.IsZero() {
+		line += " (" + activity.CreatedAt.Format("2006-01-02") + ")"
+	}
+	return "1. " + line
+}
+
+// renderReadme writes the activities between the README markers
+func renderReadme(w io.Writer, activities []activity.ActivitySummary) {
+	_, _ = fmt.Fprintln(w, readmeStartMarker)
+	if len(activities) == 0 {
+		_, _ = fmt.Fprintln(w, "No recent activity.")
+	}
+	for _, activity := range activities {
+		_, _ = fmt.Fprintln(w, readmeLine(activity))
+	}
+	_, _ = fmt.Fprintln(w, readmeEndMarker)
+}
+
+// spliceReadme replaces the block between the first pair of markers in
+// readme with block, which carries its own markers
+func spliceReadme(readme, block string) (string, error) {
+	start := strings.Index(readme, readmeStartMarker)
+	if start < 0 {
+		return "", errNoMarkers
+	}
+	end := strings.Index(readme[start:], readmeEndMarker)
+	if end < 0 {
+		return "", errNoMarkers
+	}
+	end += start + len(readmeEndMarker)
+	return readme[:start] + strings.TrimSuffix(block, "\n") + readme[end:], nil
+}
+
+// updateReadme splices the block into the README at path and reports
+// whether it changed
+func updateReadme(path, block string) (bool, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+	updated, err := spliceReadme(string(data), block)
+	if err != nil {
+		return false, fmt.Errorf("%s has %w; add them where the activity should go", path, err)
+	}
+	if updated == string(data) {
+		return false, nil
+	}
+	return true, replaceFile(path, []byte(updated))
+}
+
+// runReadme handles `readme [-limit=5] [-update=README.md] <username>`
+func (c *CLI) runReadme(args []string) int {
+	flags, err := c.resolveFlags(append([]string{"github-activity readme"}, args...))
+	if err != nil {
+		return c.reportFlagsError(err)
+	}
+	if !c.hasUsername(flags) {
+		fmt.Println("Usage:")
+		fmt.Println("  github-activity readme [-limit=5] [-update=README.md] <username>")
+		return 1
+	}
+	if flags.Format != "" {
+		fmt.Fprintln(os.Stderr, "Error: readme always writes Markdown and cannot use -format")
+		return 1
+	}
+	if flags.Update != "" && flags.Output != "" {
+		fmt.Fprintln(os.Stderr, "Error: -update rewrites its file in place and cannot use -output")
+		return 1
+	}
+	if !flags.explicit["limit"] {
+		flags.Limit = readmeDefaultLimit
+	}
+
+	run, err := c.setup(flags)
+	if err != nil {
+		return c.reportError(err)
+	}
+	activities, err := c.service.GetUserActivity(run.username, run.filter)
+	failures, ok := partialFailures(err)
+	if !ok {
+		return c.reportError(err)
+	}
+	defer printWarnings(os.Stderr, failures)
+
+	if flags.Update == "" {
+		renderReadme(os.Stdout, activities)
+		return 0
+	}
+	var block strings.Builder
+	renderReadme(&block, activities)
+	changed, err := updateReadme(flags.Update, block.String())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	if changed {
+		fmt.Printf("Updated %s with %s.\n", flags.Update, messages.Plural("events", len(activities)))
+	} else {
+		fmt.Printf("%s is up to date.\n", flags.Update)
+	}
+	return 0
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/activity"
+)
+
+func TestReadmeLine(t *testing.T) {
+	got := readmeLine(activity.ActivitySummary{
+		Description: "Opened issue #3 in octo/my_repo: Fix *bold* [links]",
+		Repository:  "octo/my_repo",
+		CreatedAt:   time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC),
+	})
+	want := `1. Opened issue #3 in [octo/my\_repo](https://github.com/octo/my_repo): Fix \*bold\* \[links\] (2024-01-15)`
+	if got != want {
+		t.Errorf("readmeLine() = %q, want %q", got, want)
+	}
+}
+
+func TestSpliceReadme(t *testing.T) {
+	block := readmeStartMarker + "\n1. New\n" + readmeEndMarker + "\n"
+	readme := "# Hi\n\n" + readmeStartMarker + "\n1. Old\n1. Older\n" + readmeEndMarker + "\n\nBye\n"
+
+	got, err := spliceReadme(readme, block)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "# Hi\n\n" + readmeStartMarker + "\n1. New\n" + readmeEndMarker + "\n\nBye\n"; got != want {
+		t.Errorf("spliceReadme() = %q, want %q", got, want)
+	}
+
+	for _, readme := range []string{"# Hi\n", readmeStartMarker + "\nno end\n", readmeEndMarker + "\n" + readmeStartMarker} {
+		if _, err := spliceReadme(readme, block); !errors.Is(err, errNoMarkers) {
+			t.Errorf("spliceReadme(%q) error = %v, want errNoMarkers", readme, err)
+		}
+	}
+}
+
+func TestCLI_runReadme(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "README.md")
+	if err := os.WriteFile(path, []byte("# Hi\n"+readmeStartMarker+"\n"+readmeEndMarker+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(args ...string) (int, string) {
+		cli := NewCLI(activity.NewActivityService(usersRepository{}))
+		var code int
+		output := captureStdout(t, func() {
+			code = cli.Run(append([]string{"github-activity", "readme"}, args...))
+		})
+		return code, output
+	}
+
+	code, output := run("alice")
+	if code != 0 || !strings.HasPrefix(output, readmeStartMarker+"\n1. Pushed 1 commit to [alice/repo](https://github.com/alice/repo)") {
+		t.Errorf("readme = %d, %q", code, output)
+	}
+
+	if code, output := run("-update="+path, "alice"); code != 0 || output != "Updated "+path+" with 1 event.\n" {
+		t.Errorf("readme -update = %d, %q", code, output)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "alice/repo") || !strings.HasPrefix(string(data), "# Hi\n") {
+		t.Errorf("README = %q", data)
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("README mode = %v, %v, want it kept at 0600", info.Mode().Perm(), err)
+	}
+	if code, output := run("-update="+path, "alice"); code != 0 || !strings.HasSuffix(output, "is up to date.\n") {
+		t.Errorf("second readme -update = %d, %q", code, output)
+	}
+
+	if code, _ := run("-update="+filepath.Join(t.TempDir(), "missing.md"), "alice"); code != 1 {
+		t.Errorf("readme -update of a missing file = %d, want 1", code)
+	}
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/activity"
+	"github.com/alnah/github-activity/pkg/format"
+)
+
+// Replayer emits activities oldest first, waiting between them for their
+// original gap divided by Speed, capped at MaxGap (0 = no cap)
+type Replayer struct {
+	Speed  float64
+	MaxGap time.Duration
+	sleep  func(time.Duration)
+}
+
+// NewReplayer creates a replayer that sleeps in real time
+func NewReplayer(speed float64, maxGap time.Duration) *Replayer {
+	return &Replayer{
+		Speed:  speed,
+		MaxGap: maxGap,
+		sleep:  time.Sleep,
+	}
+}
+
+// Replay calls emit for each activity in chronological order. Activities
+// are expected newest first, as the service returns them.
+func (r *Replayer) Replay(activities []activity.ActivitySummary, emit func(activity.ActivitySummary)) {
+	var previous time.Time
+	for i := len(activities) - 1; i >= 0; i-- {
+		activity := activities[i]
+		if !previous.IsZero() && activity.CreatedAt.After(previous) {
+			r.sleep(r.delay(activity.CreatedAt.Sub(previous)))
+		}
+		emit(activity)
+		previous = activity.CreatedAt
+	}
+}
+
+// delay scales an original gap by the replay speed
+func (r *Replayer) delay(gap time.Duration) time.Duration {
+	delay := time.Duration(float64(gap) / r.Speed)
+	if r.MaxGap > 0 && delay > r.MaxGap {
+		delay = r.MaxGap
+	}
+	return delay
+}
+
+// ParseSpeed parses a replay speed such as "10x", "0.5x" or "2"
+func ParseSpeed(value string) (float64, error) {
+	speed, err := strconv.ParseFloat(strings.TrimSuffix(strings.ToLower(value), "x"), 64)
+	if err != nil || speed <= 0 {
+		return 0, fmt.Errorf("invalid replay speed: %s (e.g. 1x, 10x, 0.5x)", value)
+	}
+	return speed, nil
+}
+
+// runReplay handles `replay [flags] <username>`
+func (c *CLI) runReplay(args []string) int {
+	flags, err := c.resolveFlags(append([]string{"github-activity replay"}, args...))
+	if err != nil {
+		return c.reportFlagsError(err)
+	}
+	if !c.hasUsername(flags) {
+		fmt.Println("Usage:")
+		fmt.Println("  github-activity replay [-speed=10x] [-max-gap=5s] [flags] <username>")
+		return 1
+	}
+
+	speed, err := ParseSpeed(flags.Speed)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	if format := strings.ToLower(flags.Format); format == "csv" || format == "tsv" {
+		fmt.Fprintf(os.Stderr, "Error: replay does not support -format=%s\n", format)
+		return 1
+	}
+
+	run, err := c.setup(flags)
+	if err != nil {
+		return c.reportError(err)
+	}
+
+	activities, err := c.service.GetUserActivity(run.username, run.filter)
+	if err != nil {
+		return c.reportError(err)
+	}
+
+	_, console := c.output.(*format.ConsoleOutputFormatter)
+	NewReplayer(speed, flags.MaxGap).Replay(activities, func(summary activity.ActivitySummary) {
+		if console {
+			fmt.Printf("%s %s\n", summary.Timestamp, summary.Description)
+			return
+		}
+		c.output.FormatActivities(os.Stdout, []activity.ActivitySummary{summary})
+	})
+	return 0
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/activity"
+)
+
+func TestParseSpeed(t *testing.T) {
+	tests := []struct {
+		value       string
+		expected    float64
+		expectError bool
+	}{
+		{"1x", 1, false},
+		{"10x", 10, false},
+		{"10X", 10, false},
+		{"0.5x", 0.5, false},
+		{"2", 2, false},
+		{"0x", 0, true},
+		{"-2x", 0, true},
+		{"fast", 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			speed, err := ParseSpeed(tt.value)
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if speed != tt.expected {
+				t.Errorf("ParseSpeed(%q) = %v, want %v", tt.value, speed, tt.expected)
+			}
+		})
+	}
+}
+
+func TestReplayer_Replay(t *testing.T) {
+	start := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	// Newest first, as the service returns them
+	activities := []activity.ActivitySummary{
+		{Description: "third", CreatedAt: start.Add(2 * time.Hour)},
+		{Description: "second", CreatedAt: start.Add(10 * time.Second)},
+		{Description: "first", CreatedAt: start},
+	}
+
+	tests := []struct {
+		name     string
+		speed    float64
+		maxGap   time.Duration
+		expected []time.Duration
+	}{
+		{"real time", 1, 0, []time.Duration{10 * time.Second, 2*time.Hour - 10*time.Second}},
+		{"ten times faster", 10, 0, []time.Duration{time.Second, 12*time.Minute - time.Second}},
+		{"capped", 10, 5 * time.Second, []time.Duration{time.Second, 5 * time.Second}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var waits []time.Duration
+			var emitted []string
+			replayer := NewReplayer(tt.speed, tt.maxGap)
+			replayer.sleep = func(d time.Duration) { waits = append(waits, d) }
+
+			replayer.Replay(activities, func(a activity.ActivitySummary) {
+				emitted = append(emitted, a.Description)
+			})
+
+			if !reflect.DeepEqual(emitted, []string{"first", "second", "third"}) {
+				t.Errorf("Emitted %v, want oldest first", emitted)
+			}
+			if !reflect.DeepEqual(waits, tt.expected) {
+				t.Errorf("Waits = %v, want %v", waits, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/activity"
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// Serve mode settings
+const (
+	defaultServeAddr     = "localhost:8080"
+	defaultServeCacheTTL = 5 * time.Minute
+	serveShutdownTimeout = 5 * time.Second
+)
+
+// serveHandler applies the shared flags to the repository and service and
+// returns the API handler with /metrics
+func (c *CLI) serveHandler(flags CLIFlags) (http.Handler, error) {
+	options := activity.ActivityOptions{
+		Limit:    flags.Limit,
+		Retries:  flags.Retries,
+		Timezone: flags.Timezone,
+	}
+	if err := options.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Cache whole feeds so any limit a request asks for can be served
+	limit := flags.Limit
+	flags.Limit = 0
+	c.applyRepositorySettings(flags)
+
+	service, ok := c.service.(*activity.ActivityService)
+	if !ok {
+		return nil, errors.New("serve requires the activity service")
+	}
+	var rateLimit func() (github.RateLimit, bool)
+	if c.repository != nil {
+		rateLimit = c.repository.LastRateLimit
+	}
+	metrics := activity.NewMetrics(rateLimit)
+	service.UseMetrics(metrics)
+
+	ttl := flags.CacheTTL
+	if ttl <= 0 {
+		ttl = defaultServeCacheTTL
+	}
+	service.UseSharedCache(ttl)
+	if location, _ := activity.LoadTimezone(flags.Timezone); location != nil {
+		service.SetLocation(location)
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", metrics)
+	mux.Handle("/", activity.NewHandler(service, activity.HandlerOptions{DefaultLimit: limit}))
+	return mux, nil
+}
+
+// runServe handles `serve [-addr=host:port] [flags]`, running the HTTP API
+// until interrupted
+func (c *CLI) runServe(args []string) int {
+	flags, err := c.resolveFlags(append([]string{"github-activity serve"}, args...))
+	if err != nil {
+		return c.reportFlagsError(err)
+	}
+
+	handler, err := c.serveHandler(flags)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	return runServer(handler, flags.Addr, "Serving GitHub activity")
+}
+
+// runServer serves handler on addr until interrupted, then shuts down gracefully
+func runServer(handler http.Handler, addr, banner string) int {
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- server.ListenAndServe()
+	}()
+	fmt.Fprintf(os.Stderr, "%s on http://%s\n", banner, addr)
+
+	select {
+	case err := <-errs:
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	case <-ctx.Done():
+	}
+
+	shutdown, cancel := context.WithTimeout(context.Background(), serveShutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdown); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
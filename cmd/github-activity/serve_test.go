@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/alnah/github-activity/pkg/activity"
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+func TestCLI_serveHandler(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		_, _ = w.Write([]byte(`[
+			{"id":"2","type":"WatchEvent","repo":{"name":"user/a"}},
+			{"id":"1","type":"ForkEvent","repo":{"name":"user/b"}}
+		]`))
+	}))
+	defer server.Close()
+
+	repo := github.NewGitHubAPIRepository()
+	cli := NewCLI(activity.NewActivityService(repo))
+	cli.SetRepository(repo)
+
+	flags, err := cli.resolveFlags([]string{"github-activity serve", "-api-url=" + server.URL, "-limit=1"})
+	if err != nil {
+		t.Fatalf("resolveFlags() error = %v", err)
+	}
+	handler, err := cli.serveHandler(flags)
+	if err != nil {
+		t.Fatalf("serveHandler() error = %v", err)
+	}
+
+	// Concurrent requests for several users share one cache
+	var wg sync.WaitGroup
+	for _, path := range []string{"/activity/alice", "/stats/alice", "/repos/bob", "/activity/bob"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+			if recorder.Code != http.StatusOK {
+				t.Errorf("%s: status = %d, want 200", path, recorder.Code)
+			}
+		}()
+	}
+	wg.Wait()
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/activity/alice", nil))
+	var activities []activity.ActivitySummary
+	if err := json.Unmarshal(recorder.Body.Bytes(), &activities); err != nil {
+		t.Fatalf("Response is not valid JSON: %v", err)
+	}
+	if len(activities) != 1 {
+		t.Errorf("Got %d activities, want the -limit default of 1", len(activities))
+	}
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	if !strings.Contains(recorder.Body.String(), `github_activity_events_total{user="bob",type="ForkEvent"} 1`) {
+		t.Errorf("Metrics missing bob's events:\n%s", recorder.Body.String())
+	}
+
+	before := requests.Load()
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/repos/alice", nil))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/repos/bob", nil))
+	if requests.Load() != before {
+		t.Errorf("Cached users were fetched again")
+	}
+}
+
+func TestCLI_serveHandler_InvalidFlags(t *testing.T) {
+	cli := NewCLI(activity.NewActivityService(github.NewMockEventRepository(nil, nil)))
+	if _, err := cli.serveHandler(CLIFlags{Timezone: "Nowhere/Land"}); err == nil {
+		t.Error("Expected error for an invalid time zone")
+	}
+}
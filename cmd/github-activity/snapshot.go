@@ -0,0 +1,252 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"regexp"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/activity"
+	"github.com/alnah/github-activity/pkg/format"
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// Snapshot - Reproducible bug report bundles
+
+// snapshotHeaders lists the response headers kept in a snapshot
+var snapshotHeaders = []string{
+	"Content-Type",
+	"Date",
+	"ETag",
+	"Last-Modified",
+	"Link",
+	"X-Poll-Interval",
+	"X-RateLimit-Limit",
+	"X-RateLimit-Remaining",
+	"X-RateLimit-Reset",
+}
+
+// emailPattern matches email addresses, which are redacted from raw responses
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+
+// Snapshot bundles everything needed to reproduce a run
+type Snapshot struct {
+	Username  string
+	Flags     CLIFlags
+	Responses []github.RecordedResponse
+	Output    []byte
+	CreatedAt time.Time
+}
+
+// snapshotVersion describes the build that produced a snapshot
+type snapshotVersion struct {
+	Version   string    `json:"version"`
+	GoVersion string    `json:"go_version"`
+	OS        string    `json:"os"`
+	Arch      string    `json:"arch"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// snapshotResponseMeta describes a recorded response without its body
+type snapshotResponseMeta struct {
+	URL        string            `json:"url"`
+	StatusCode int               `json:"status_code"`
+	Header     map[string]string `json:"header"`
+}
+
+// sanitizeHeader keeps only the allowlisted response headers
+func sanitizeHeader(header http.Header) map[string]string {
+	sanitized := make(map[string]string)
+	for _, name := range snapshotHeaders {
+		if value := header.Get(name); value != "" {
+			sanitized[name] = value
+		}
+	}
+	return sanitized
+}
+
+// sanitizeBody redacts email addresses from a raw response body
+func sanitizeBody(body []byte) []byte {
+	return emailPattern.ReplaceAll(body, []byte("redacted@example.com"))
+}
+
+// Write writes the snapshot as a gzipped tar archive
+func (s *Snapshot) Write(w io.Writer) error {
+	gzipWriter := gzip.NewWriter(w)
+	tarWriter := tar.NewWriter(gzipWriter)
+
+	files := []struct {
+		name string
+		data any
+	}{
+		{"version.json", snapshotVersion{
+			Version:   version,
+			GoVersion: runtime.Version(),
+			OS:        runtime.GOOS,
+			Arch:      runtime.GOARCH,
+			CreatedAt: s.CreatedAt,
+		}},
+		{"config.json", struct {
+			Username string
+			CLIFlags
+		}{s.Username, s.Flags}},
+	}
+
+	for _, file := range files {
+		data, err := json.MarshalIndent(file.data, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode %s: %w", file.name, err)
+		}
+		if err := s.writeFile(tarWriter, file.name, data); err != nil {
+			return err
+		}
+	}
+
+	for i, response := range s.Responses {
+		meta, err := json.MarshalIndent(snapshotResponseMeta{
+			URL:        response.URL,
+			StatusCode: response.StatusCode,
+			Header:     sanitizeHeader(response.Header),
+		}, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode response metadata: %w", err)
+		}
+
+		name := fmt.Sprintf("responses/%03d", i+1)
+		if err := s.writeFile(tarWriter, name+".meta.json", meta); err != nil {
+			return err
+		}
+		if err := s.writeFile(tarWriter, name+".json", sanitizeBody(response.Body)); err != nil {
+			return err
+		}
+	}
+
+	if err := s.writeFile(tarWriter, "output.txt", s.Output); err != nil {
+		return err
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return fmt.Errorf("failed to finish archive: %w", err)
+	}
+	return gzipWriter.Close()
+}
+
+// writeFile adds a single file to the archive
+func (s *Snapshot) writeFile(tarWriter *tar.Writer, name string, data []byte) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0o644,
+		Size:    int64(len(data)),
+		ModTime: s.CreatedAt,
+	}
+	if err := tarWriter.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if _, err := tarWriter.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+// runSnapshot fetches activity and bundles the run into an archive file
+func (c *CLI) runSnapshot(args []string) int {
+	flags, err := c.resolveFlags(append([]string{"github-activity snapshot"}, args...))
+	if err != nil {
+		return c.reportFlagsError(err)
+	}
+	if len(flags.Args) < 1 {
+		fmt.Println("Usage:")
+		fmt.Println("  github-activity snapshot [flags] <username> [archive]")
+		return 1
+	}
+
+	if c.repository == nil {
+		fmt.Fprintln(os.Stderr, "Error: snapshot requires the GitHub API repository")
+		return 1
+	}
+
+	username := flags.Args[0]
+	path := fmt.Sprintf("github-activity-snapshot-%s.tar.gz", username)
+	if len(flags.Args) > 1 {
+		path = flags.Args[1]
+	}
+
+	c.applyRepositorySettings(flags)
+
+	snapshot := &Snapshot{
+		Username:  username,
+		Flags:     flags,
+		CreatedAt: c.now().UTC(),
+	}
+	c.repository.SetRecorder(func(response github.RecordedResponse) {
+		snapshot.Responses = append(snapshot.Responses, response)
+	})
+	defer c.repository.SetRecorder(nil)
+
+	output := &bytes.Buffer{}
+	if err := c.renderActivities(output, username, flags); err != nil {
+		_, _ = fmt.Fprintf(output, "Error: %v\n", err)
+	}
+	snapshot.Output = output.Bytes()
+
+	file, err := os.Create(path)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to create snapshot: %v\n", err)
+		return 1
+	}
+	defer func() { _ = file.Close() }()
+
+	if err := snapshot.Write(file); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: failed to write snapshot: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("Snapshot written to %s\n", path)
+	return 0
+}
+
+// renderActivities fetches and formats activities into w using the flags' format
+func (c *CLI) renderActivities(w io.Writer, username string, flags CLIFlags) error {
+	outputFormat := strings.ToLower(flags.Format)
+	formatter := c.output
+	if outputFormat != "" {
+		var err error
+		formatter, err = c.formats.New(outputFormat, format.FormatOptions{Template: flags.Template})
+		if err != nil {
+			return err
+		}
+	}
+
+	filter := activity.EventFilter{
+		Type:     flags.EventType,
+		Repo:     flags.Repo,
+		Actor:    flags.Actor,
+		Action:   flags.Action,
+		MaxLimit: flags.Limit,
+		Collapse: flags.Collapse,
+	}
+
+	if flags.Detailed || flags.Enrich || format.NeedsDetails(outputFormat) {
+		activities, err := c.service.GetUserActivityDetailed(username, filter)
+		if err != nil {
+			return err
+		}
+		formatter.FormatDetailedActivities(w, activities)
+		return nil
+	}
+
+	activities, err := c.service.GetUserActivity(username, filter)
+	if err != nil {
+		return err
+	}
+	formatter.FormatActivities(w, activities)
+	return nil
+}
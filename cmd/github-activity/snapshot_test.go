@@ -0,0 +1,182 @@
+package main
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/alnah/github-activity/pkg/activity"
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+func TestSanitizeHeader(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-RateLimit-Remaining", "42")
+	header.Set("Set-Cookie", "session=secret")
+
+	sanitized := sanitizeHeader(header)
+
+	if sanitized["X-RateLimit-Remaining"] != "42" {
+		t.Error("Expected rate limit header to be kept")
+	}
+	if _, exists := sanitized["Set-Cookie"]; exists {
+		t.Error("Expected Set-Cookie header to be dropped")
+	}
+}
+
+func TestSanitizeBody(t *testing.T) {
+	body := []byte(`{"author": {"name": "John", "email": "john.doe@example.org"}}`)
+
+	sanitized := string(sanitizeBody(body))
+
+	if strings.Contains(sanitized, "john.doe@example.org") {
+		t.Error("Email address should be redacted")
+	}
+	if !strings.Contains(sanitized, "redacted@example.com") {
+		t.Error("Expected redaction placeholder")
+	}
+}
+
+func TestSnapshot_Write_OmitsSecrets(t *testing.T) {
+	secrets := []string{
+		"ghp_secret-token",
+		"https://hooks.slack.com/services/secret",
+		"https://discord.com/api/webhooks/secret",
+		"smtp-secret-password",
+	}
+	snapshot := &Snapshot{
+		Username: "testuser",
+		Flags: CLIFlags{
+			Limit:          5,
+			Token:          secrets[0],
+			SlackWebhook:   secrets[1],
+			DiscordWebhook: secrets[2],
+			SMTP:           SMTPSettings{Host: "smtp.example.com", Password: secrets[3]},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := snapshot.Write(&buf); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+
+	gzipReader, err := gzip.NewReader(&buf)
+	if err != nil {
+		t.Fatalf("Snapshot is not gzipped: %v", err)
+	}
+	var config string
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Failed to read archive: %v", err)
+		}
+		if header.Name == "config.json" {
+			data, _ := io.ReadAll(tarReader)
+			config = string(data)
+		}
+	}
+
+	if !strings.Contains(config, "smtp.example.com") {
+		t.Fatalf("config.json = %s, want the non-secret settings", config)
+	}
+	for _, secret := range secrets {
+		if strings.Contains(config, secret) {
+			t.Errorf("config.json contains %q", secret)
+		}
+	}
+}
+
+func TestCLI_runSnapshot(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "59")
+		_, _ = fmt.Fprint(w, `[{
+			"id": "1",
+			"type": "PushEvent",
+			"repo": {"name": "user/repo"},
+			"payload": {
+				"size": 1,
+				"ref": "refs/heads/main",
+				"commits": [{"sha": "abc", "author": {"email": "dev@example.org"}}]
+			}
+		}]`)
+	}))
+	defer server.Close()
+
+	repository := github.NewGitHubAPIRepository()
+	repository.SetBaseURL(server.URL)
+	cli := NewCLI(activity.NewActivityService(repository))
+	cli.SetRepository(repository)
+
+	path := filepath.Join(t.TempDir(), "snapshot.tar.gz")
+
+	stdout := os.Stdout
+	os.Stdout, _ = os.Open(os.DevNull)
+	code := cli.Run([]string{"github-activity", "snapshot", "testuser", path})
+	os.Stdout = stdout
+
+	if code != 0 {
+		t.Fatalf("Exit code = %d, want 0", code)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("Failed to open snapshot: %v", err)
+	}
+	defer func() { _ = file.Close() }()
+
+	gzipReader, err := gzip.NewReader(file)
+	if err != nil {
+		t.Fatalf("Snapshot is not gzipped: %v", err)
+	}
+
+	contents := make(map[string]string)
+	tarReader := tar.NewReader(gzipReader)
+	for {
+		header, err := tarReader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("Failed to read archive: %v", err)
+		}
+		data, _ := io.ReadAll(tarReader)
+		contents[header.Name] = string(data)
+	}
+
+	for _, name := range []string{
+		"version.json",
+		"config.json",
+		"responses/001.json",
+		"responses/001.meta.json",
+		"output.txt",
+	} {
+		if _, exists := contents[name]; !exists {
+			t.Errorf("Snapshot missing %s", name)
+		}
+	}
+
+	if strings.Contains(contents["responses/001.json"], "dev@example.org") {
+		t.Error("Raw response should be sanitized")
+	}
+	if !strings.Contains(contents["responses/001.meta.json"], `"X-RateLimit-Remaining": "59"`) {
+		t.Error("Response metadata should keep rate limit headers")
+	}
+	if !strings.Contains(contents["output.txt"], "Pushed 1 commit to user/repo") {
+		t.Errorf("Rendered output missing, got %q", contents["output.txt"])
+	}
+	if !strings.Contains(contents["config.json"], `"Username": "testuser"`) {
+		t.Error("Config should record the username")
+	}
+}
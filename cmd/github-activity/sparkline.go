@@ -0,0 +1,85 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alnah/github-activity/internal/messages"
+	"github.com/alnah/github-activity/pkg/activity"
+)
+
+// Sparkline - Events per day over the last two weeks, above the activity list
+
+// sparklineBlocks are the bars of a sparkline from no activity (level 0) to
+// the busiest day (level 7)
+var sparklineBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// sparklineDays is how many days the sparkline covers, ending today
+const sparklineDays = 14
+
+// sparklineLevel scales count against the busiest day into levels 0-7, so
+// any activity at all shows above an idle day
+func sparklineLevel(count, busiest int) int {
+	if count <= 0 || busiest <= 0 {
+		return 0
+	}
+	last := len(sparklineBlocks) - 1
+	return min((count*last+busiest-1)/busiest, last)
+}
+
+// renderSparkline writes one bar per day of the days days ending with end,
+// oldest first, followed by their total. Nothing is written when none of
+// those days had activity.
+func renderSparkline(w io.Writer, counts map[string]int, end time.Time, days int) {
+	end = time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, end.Location())
+	daily := make([]int, days)
+	total, busiest := 0, 0
+	for i := range daily {
+		daily[i] = counts[end.AddDate(0, 0, i-days+1).Format("2006-01-02")]
+		total += daily[i]
+		busiest = max(busiest, daily[i])
+	}
+	if total == 0 {
+		return
+	}
+
+	var bars strings.Builder
+	for _, count := range daily {
+		bars.WriteRune(sparklineBlocks[sparklineLevel(count, busiest)])
+	}
+	_, _ = fmt.Fprintf(w, "Last %s  %s  %s\n\n",
+		messages.Plural("days", days), bars.String(), messages.Plural("events", total))
+}
+
+// sparklineCounts counts listed activities per day in location. A run of
+// pushes collapsed into one activity counts once per push, on the day of
+// its newest push.
+func sparklineCounts(activities []activity.ActivitySummary, location *time.Location) map[string]int {
+	counts := make(map[string]int)
+	for _, activity := range activities {
+		if activity.CreatedAt.IsZero() {
+			continue
+		}
+		events := 1
+		if pushes, err := strconv.Atoi(activity.Fields["pushes"]); err == nil && pushes > 1 {
+			events = pushes
+		}
+		counts[activity.CreatedAt.In(location).Format("2006-01-02")] += events
+	}
+	return counts
+}
+
+// printSparkline heads the activity list with its activities of the last
+// sparklineDays days, when the console list asks for one. It counts the
+// activities already fetched for the list, so with a -limit it covers only
+// those, and costs no further request.
+func (c *CLI) printSparkline(activities []activity.ActivitySummary) {
+	if c.sparkline == nil {
+		return
+	}
+	renderSparkline(os.Stdout, sparklineCounts(activities, c.sparkline), c.now().In(c.sparkline), sparklineDays)
+}
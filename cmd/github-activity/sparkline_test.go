@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/activity"
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+func TestSparklineLevel(t *testing.T) {
+	tests := []struct {
+		count, busiest, expected int
+	}{
+		{0, 10, 0},
+		{1, 10, 1},
+		{5, 10, 4},
+		{10, 10, 7},
+		{3, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := sparklineLevel(tt.count, tt.busiest); got != tt.expected {
+			t.Errorf("sparklineLevel(%d, %d) = %d, want %d", tt.count, tt.busiest, got, tt.expected)
+		}
+	}
+}
+
+func TestRenderSparkline(t *testing.T) {
+	end := time.Date(2024, 1, 14, 18, 30, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		counts   map[string]int
+		expected string
+	}{
+		{
+			"busy",
+			map[string]int{
+				"2024-01-01": 7, // Oldest day shown
+				"2024-01-05": 2,
+				"2024-01-10": 14,
+				"2024-01-14": 1,
+				"2023-12-31": 50, // Before the window
+			},
+			"Last 14 days  ▅▁▁▁▂▁▁▁▁█▁▁▁▂  24 events\n\n",
+		},
+		{"idle", map[string]int{"2023-12-31": 3}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			renderSparkline(&buf, tt.counts, end, 14)
+			if buf.String() != tt.expected {
+				t.Errorf("Output = %q, want %q", buf.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestSparklineCounts(t *testing.T) {
+	day := time.Date(2024, 1, 14, 23, 30, 0, 0, time.UTC)
+	activities := []activity.ActivitySummary{
+		{CreatedAt: day},
+		{CreatedAt: day.Add(-time.Hour), Fields: map[string]string{"pushes": "3"}},
+		{CreatedAt: day.AddDate(0, 0, -1)},
+		{Timestamp: "unknown"},
+	}
+
+	want := map[string]int{"2024-01-14": 4, "2024-01-13": 1}
+	if got := sparklineCounts(activities, time.UTC); !reflect.DeepEqual(got, want) {
+		t.Errorf("sparklineCounts() = %v, want %v", got, want)
+	}
+	tokyo := time.FixedZone("JST", 9*60*60)
+	want = map[string]int{"2024-01-15": 4, "2024-01-14": 1}
+	if got := sparklineCounts(activities, tokyo); !reflect.DeepEqual(got, want) {
+		t.Errorf("sparklineCounts() in JST = %v, want %v", got, want)
+	}
+}
+
+func TestCLI_SparklineFetchesOnce(t *testing.T) {
+	now := time.Now()
+	repo := &countingRepository{events: []github.GitHubEvent{
+		{ID: "3", Type: "WatchEvent", Repo: github.Repo{Name: "user/a"}, CreatedAt: now},
+		{ID: "2", Type: "WatchEvent", Repo: github.Repo{Name: "user/b"}, CreatedAt: now},
+		{ID: "1", Type: "WatchEvent", Repo: github.Repo{Name: "user/c"}, CreatedAt: now},
+	}}
+	cli := NewCLI(activity.NewActivityService(repo))
+
+	output := captureStdout(t, func() {
+		if code := cli.Run([]string{"github-activity", "-limit=2", "testuser"}); code != 0 {
+			t.Errorf("Run() = %d, want 0", code)
+		}
+	})
+	if !strings.Contains(output, "Last 14 days  ▁▁▁▁▁▁▁▁▁▁▁▁▁█  2 events") {
+		t.Errorf("Output = %q, want a sparkline of the 2 listed events", output)
+	}
+	if repo.calls["testuser"] != 1 {
+		t.Errorf("Feed fetched %d times, want 1", repo.calls["testuser"])
+	}
+}
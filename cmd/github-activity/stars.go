@@ -0,0 +1,97 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/alnah/github-activity/internal/messages"
+	"github.com/alnah/github-activity/pkg/activity"
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// Stars - The stars subcommand
+
+// starDescriptionLength is how much of a repository description is shown
+const starDescriptionLength = 72
+
+// renderStars lists starred repositories, one per line with their language
+// and star count, followed by their description, with relative times
+// counted from now
+func renderStars(
+	w io.Writer,
+	username string,
+	stars []github.Star,
+	now time.Time,
+	absolute bool,
+	location *time.Location,
+) {
+	_, _ = fmt.Fprintf(w, "Repositories starred by %s:\n\n", username)
+	for _, star := range stars {
+		details := messages.Plural("stars", star.Repo.StargazersCount)
+		if star.Repo.Language != "" {
+			details = star.Repo.Language + ", " + details
+		}
+		when := activity.HumanizeTime(star.StarredAt, now)
+		if absolute {
+			starredAt := star.StarredAt
+			if location != nil {
+				starredAt = starredAt.In(location)
+			}
+			when = starredAt.Format("2006-01-02 15:04:05")
+		}
+		_, _ = fmt.Fprintf(w, "- %s (%s), starred %s\n", star.Repo.FullName, details, when)
+		if star.Repo.Description != "" {
+			_, _ = fmt.Fprintf(w, "  %s\n", github.TruncateMessage(star.Repo.Description, starDescriptionLength))
+		}
+	}
+}
+
+// runStars handles `stars [-limit=N] <username>`
+func (c *CLI) runStars(args []string) int {
+	flags, err := c.resolveFlags(append([]string{"github-activity stars"}, args...))
+	if err != nil {
+		return c.reportFlagsError(err)
+	}
+	if !c.hasUsername(flags) {
+		fmt.Println("Usage:")
+		fmt.Println("  github-activity stars [-limit=N] [-absolute-time] <username>")
+		return 1
+	}
+	switch {
+	case flags.Format != "":
+		fmt.Fprintf(os.Stderr, "Error: stars cannot use -format=%s\n", flags.Format)
+		return 1
+	case flags.FromDB || flags.Offline || flags.Session != "":
+		fmt.Fprintln(os.Stderr, "Error: stars are read from GitHub and cannot use -from-db, -offline or -session")
+		return 1
+	case flags.EventType != "" || flags.Repo != "" || flags.Actor != "" || flags.Action != "":
+		fmt.Fprintln(os.Stderr, "Error: stars cannot be filtered with -type, -repo, -actor or -action")
+		return 1
+	}
+
+	run, err := c.setup(flags)
+	if err != nil {
+		return c.reportError(err)
+	}
+	stars := c.stars
+	if stars == nil {
+		if c.repository == nil {
+			return c.reportError(errors.New("stars require the GitHub API"))
+		}
+		stars = c.repository
+	}
+
+	result, err := stars.FetchStars(run.username, flags.Limit)
+	if err != nil {
+		return c.reportError(err)
+	}
+	if len(result) == 0 {
+		fmt.Println("No starred repositories found.")
+		return 0
+	}
+	renderStars(os.Stdout, run.username, result, c.now(), flags.AbsoluteTime, run.location)
+	return 0
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/activity"
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// fakeStars records the limit it was asked for
+type fakeStars struct {
+	stars []github.Star
+	err   error
+	limit int
+}
+
+func (f *fakeStars) FetchStars(username string, limit int) ([]github.Star, error) {
+	f.limit = limit
+	return f.stars, f.err
+}
+
+func TestRenderStars(t *testing.T) {
+	starredAt := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	stars := []github.Star{
+		{StarredAt: starredAt, Repo: github.StarredRepo{
+			FullName: "golang/go", Language: "Go", StargazersCount: 1, Description: strings.Repeat("x", 100),
+		}},
+		{StarredAt: starredAt, Repo: github.StarredRepo{FullName: "octo/empty"}},
+	}
+
+	var buf bytes.Buffer
+	renderStars(&buf, "octocat", stars, time.Now(), true, time.UTC)
+	output := buf.String()
+
+	for _, want := range []string{
+		"Repositories starred by octocat:",
+		"- golang/go (Go, 1 star), starred 2024-01-02 03:04:05",
+		"  " + strings.Repeat("x", starDescriptionLength-3) + "...\n",
+		"- octo/empty (0 stars), starred 2024-01-02 03:04:05\n",
+	} {
+		if !strings.Contains(output, want) {
+			t.Errorf("Output missing %q:\n%s", want, output)
+		}
+	}
+}
+
+func TestCLI_runStars(t *testing.T) {
+	stars := []github.Star{{
+		StarredAt: time.Now().Add(-72 * time.Hour),
+		Repo:      github.StarredRepo{FullName: "golang/go", Language: "Go", StargazersCount: 120000},
+	}}
+
+	tests := []struct {
+		name     string
+		args     []string
+		stars    []github.Star
+		err      error
+		expected int
+		output   string
+		limit    int
+	}{
+		{name: "list", args: []string{"octocat"}, stars: stars, output: "- golang/go (Go, 120000 stars), starred 3 days ago", limit: 30},
+		{name: "limit", args: []string{"-limit=5", "octocat"}, stars: stars, limit: 5},
+		{name: "no limit", args: []string{"-no-limit", "octocat"}, stars: stars, limit: 0},
+		{name: "none", args: []string{"octocat"}, output: "No starred repositories found.", limit: 30},
+		{name: "format", args: []string{"-format=csv", "octocat"}, expected: 1},
+		{name: "user not found", args: []string{"ghost"}, err: github.ErrUserNotFound, expected: userNotFoundExitCode, limit: 30},
+		{name: "missing username", expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := &fakeStars{stars: tt.stars, err: tt.err}
+			cli := NewCLI(activity.NewActivityService(github.NewMockEventRepository(nil, nil)))
+			cli.SetStarRepository(fake)
+
+			var code int
+			output := captureStdout(t, func() {
+				code = cli.Run(append([]string{"github-activity", "stars"}, tt.args...))
+			})
+			if code != tt.expected {
+				t.Errorf("Run() = %d, want %d", code, tt.expected)
+			}
+			if !strings.Contains(output, tt.output) {
+				t.Errorf("Output missing %q:\n%s", tt.output, output)
+			}
+			if fake.limit != tt.limit {
+				t.Errorf("Fetched with limit %d, want %d", fake.limit, tt.limit)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/alnah/github-activity/internal/messages"
+	"github.com/alnah/github-activity/pkg/activity"
+)
+
+// defaultSummaryDays is the period `summary` covers without -days
+const defaultSummaryDays = 7
+
+// summaryClauses describes each kind of contribution in a summary, in the
+// order they are reported
+func summaryClauses(summary *activity.PeriodSummary) []string {
+	clauses := make([]string, 0)
+	if summary.Commits > 0 {
+		clauses = append(clauses, fmt.Sprintf("pushed %s to %s",
+			messages.Plural("commits", summary.Commits), messages.Plural("repos", summary.PushedRepos)))
+	}
+	if summary.PullRequestsOpened > 0 || summary.PullRequestsMerged > 0 {
+		clause := "opened " + messages.Plural("prs", summary.PullRequestsOpened)
+		if summary.PullRequestsMerged > 0 {
+			clause += fmt.Sprintf(" (%d merged)", summary.PullRequestsMerged)
+		}
+		clauses = append(clauses, clause)
+	}
+	if summary.PullRequestsReviewed > 0 {
+		clauses = append(clauses, "reviewed "+messages.Plural("prs", summary.PullRequestsReviewed))
+	}
+	if summary.IssuesOpened > 0 {
+		clauses = append(clauses, "opened "+messages.Plural("issues", summary.IssuesOpened))
+	}
+	if summary.IssuesClosed > 0 {
+		clauses = append(clauses, "closed "+messages.Plural("issues", summary.IssuesClosed))
+	}
+	if summary.Releases > 0 {
+		clauses = append(clauses, "published "+messages.Plural("releases", summary.Releases))
+	}
+	return clauses
+}
+
+// capitalize upper-cases the first letter of a clause
+func capitalize(clause string) string {
+	if clause == "" {
+		return clause
+	}
+	return strings.ToUpper(clause[:1]) + clause[1:]
+}
+
+// renderSummary writes the digest as a sentence, or as a Markdown section
+// ready to paste into a status update
+func renderSummary(w io.Writer, summary *activity.PeriodSummary, markdown bool) {
+	period := fmt.Sprintf("%s to %s",
+		summary.Since.Format("2006-01-02"), summary.Until.Format("2006-01-02"))
+	clauses := summaryClauses(summary)
+
+	if markdown {
+		_, _ = fmt.Fprintf(w, "## Activity summary for %s\n\n_%s_\n\n", summary.Username, period)
+		if len(clauses) == 0 {
+			_, _ = fmt.Fprintf(w, "No activity (%s).\n", messages.Plural("events", summary.Events))
+			return
+		}
+		for _, clause := range clauses {
+			_, _ = fmt.Fprintf(w, "- %s\n", capitalize(clause))
+		}
+		return
+	}
+
+	_, _ = fmt.Fprintf(w, "Summary for %s, %s:\n", summary.Username, period)
+	if len(clauses) == 0 {
+		_, _ = fmt.Fprintf(w, "No activity (%s).\n", messages.Plural("events", summary.Events))
+		return
+	}
+	_, _ = fmt.Fprintf(w, "%s.\n", capitalize(strings.Join(clauses, ", ")))
+}
+
+// runSummary handles `summary [-days=N] [-format=console|markdown] <username>`
+func (c *CLI) runSummary(args []string) int {
+	flags, err := c.resolveFlags(append([]string{"github-activity summary"}, args...))
+	if err != nil {
+		return c.reportFlagsError(err)
+	}
+	if !c.hasUsername(flags) {
+		fmt.Println("Usage:")
+		fmt.Println("  github-activity summary [-days=7] [-format=console|markdown] <username>")
+		return 1
+	}
+
+	format := strings.ToLower(flags.Format)
+	if format != "" && format != "console" && format != "markdown" {
+		fmt.Fprintf(os.Stderr, "Error: summary supports -format=console or markdown, not %s\n", format)
+		return 1
+	}
+	days := flags.Days
+	if days == 0 {
+		days = defaultSummaryDays
+	}
+
+	// The formatter is not used and the whole feed is needed
+	flags.Format = ""
+	flags.Limit = 0
+	run, err := c.setup(flags)
+	if err != nil {
+		return c.reportError(err)
+	}
+	run.filter.MaxLimit = 0
+
+	summary, err := c.service.GetPeriodSummary(run.username, run.filter, days)
+	failures, ok := partialFailures(err)
+	if !ok {
+		return c.reportError(err)
+	}
+
+	renderSummary(os.Stdout, summary, format == "markdown")
+	printWarnings(os.Stderr, failures)
+	return 0
+}
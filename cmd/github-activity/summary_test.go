@@ -0,0 +1,98 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/activity"
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+func TestRenderSummary(t *testing.T) {
+	since := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+	busy := &activity.PeriodSummary{
+		Username:             "alnah",
+		Since:                since,
+		Until:                since.AddDate(0, 0, 7),
+		Commits:              42,
+		PushedRepos:          5,
+		PullRequestsOpened:   3,
+		PullRequestsMerged:   2,
+		PullRequestsReviewed: 7,
+		IssuesOpened:         4,
+	}
+	quiet := &activity.PeriodSummary{Username: "alnah", Since: since, Until: since.AddDate(0, 0, 7), Events: 1}
+
+	tests := []struct {
+		name     string
+		summary  *activity.PeriodSummary
+		markdown bool
+		expected string
+	}{
+		{
+			"console",
+			busy,
+			false,
+			"Summary for alnah, 2024-01-08 to 2024-01-15:\n" +
+				"Pushed 42 commits to 5 repos, opened 3 PRs (2 merged), reviewed 7 PRs, opened 4 issues.\n",
+		},
+		{
+			"markdown",
+			busy,
+			true,
+			"## Activity summary for alnah\n\n_2024-01-08 to 2024-01-15_\n\n" +
+				"- Pushed 42 commits to 5 repos\n- Opened 3 PRs (2 merged)\n- Reviewed 7 PRs\n- Opened 4 issues\n",
+		},
+		{
+			"no contributions",
+			quiet,
+			false,
+			"Summary for alnah, 2024-01-08 to 2024-01-15:\nNo activity (1 event).\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			renderSummary(&buf, tt.summary, tt.markdown)
+			if buf.String() != tt.expected {
+				t.Errorf("Output = %q, want %q", buf.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestCLI_runSummary(t *testing.T) {
+	events := []github.GitHubEvent{
+		{
+			ID:        "1",
+			Type:      "PushEvent",
+			Repo:      github.Repo{Name: "user/repo"},
+			Payload:   json.RawMessage(`{"size":2}`),
+			CreatedAt: time.Now(),
+		},
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		expected int
+	}{
+		{"console", []string{"testuser"}, 0},
+		{"markdown", []string{"-format=markdown", "-days=30", "testuser"}, 0},
+		{"unsupported format", []string{"-format=csv", "testuser"}, 1},
+		{"missing username", nil, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli := NewCLI(activity.NewActivityService(github.NewMockEventRepository(events, nil)))
+			args := append([]string{"github-activity", "summary"}, tt.args...)
+			if code := cli.Run(args); code != tt.expected {
+				t.Errorf("Run() = %d, want %d", code, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/alnah/github-activity/internal/messages"
+	"github.com/alnah/github-activity/pkg/activity"
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// Team - The team subcommand
+
+// memberLine is a member's summary as one sentence
+func memberLine(summary *activity.PeriodSummary) string {
+	clauses := summaryClauses(summary)
+	if len(clauses) == 0 {
+		return fmt.Sprintf("No activity (%s)", messages.Plural("events", summary.Events))
+	}
+	return capitalize(strings.Join(clauses, ", "))
+}
+
+// renderTeam writes one line per member followed by the team total, as
+// plain text or as a Markdown section
+func renderTeam(w io.Writer, team *activity.TeamSummary, markdown bool) {
+	period := fmt.Sprintf("%s to %s", team.Since.Format("2006-01-02"), team.Until.Format("2006-01-02"))
+	members := messages.Plural("members", len(team.Members))
+
+	if markdown {
+		_, _ = fmt.Fprintf(w, "## Team activity for %s\n\n_%s, %s_\n\n", team.Team, period, members)
+		for _, member := range team.Members {
+			_, _ = fmt.Fprintf(w, "- **%s**: %s\n", member.Username, memberLine(member))
+		}
+		_, _ = fmt.Fprintf(w, "\n**Team total**: %s\n", memberLine(&team.Total))
+		return
+	}
+
+	_, _ = fmt.Fprintf(w, "Team %s, %s (%s):\n\n", team.Team, period, members)
+	width := 0
+	for _, member := range team.Members {
+		width = max(width, utf8.RuneCountInString(member.Username))
+	}
+	for _, member := range team.Members {
+		padding := strings.Repeat(" ", width-utf8.RuneCountInString(member.Username))
+		_, _ = fmt.Fprintf(w, "  %s%s  %s.\n", member.Username, padding, memberLine(member))
+	}
+	_, _ = fmt.Fprintf(w, "\nTeam total: %s.\n", memberLine(&team.Total))
+}
+
+// parseTeam splits "org/team-slug"
+func parseTeam(name string) (string, string, bool) {
+	org, team, ok := strings.Cut(name, "/")
+	if !ok || org == "" || team == "" || strings.Contains(team, "/") {
+		return "", "", false
+	}
+	return org, team, true
+}
+
+// runTeam handles `team [-days=7] [-format=console|markdown] <org>/<team-slug>`
+func (c *CLI) runTeam(args []string) int {
+	flags, err := c.resolveFlags(append([]string{"github-activity team"}, args...))
+	if err != nil {
+		return c.reportFlagsError(err)
+	}
+	if len(flags.Args) != 1 {
+		fmt.Println("Usage:")
+		fmt.Println("  github-activity team [-days=7] [-format=console|markdown] <org>/<team-slug>")
+		return 1
+	}
+	org, slug, ok := parseTeam(flags.Args[0])
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Error: invalid team %q (use org/team-slug)\n", flags.Args[0])
+		return 1
+	}
+
+	format := strings.ToLower(flags.Format)
+	if format != "" && format != "console" && format != "markdown" {
+		fmt.Fprintf(os.Stderr, "Error: team supports -format=console or markdown, not %s\n", format)
+		return 1
+	}
+	if flags.Received {
+		fmt.Fprintln(os.Stderr, "Error: team reads each member's own events and cannot use -received")
+		return 1
+	}
+	days := flags.Days
+	if days == 0 {
+		days = defaultSummaryDays
+	}
+
+	// The formatter is not used and every member's whole feed is needed
+	flags.Format = ""
+	flags.Limit = 0
+	run, err := c.setup(flags)
+	if err != nil {
+		return c.reportError(err)
+	}
+	run.filter.MaxLimit = 0
+
+	teams := c.teams
+	if teams == nil {
+		if c.repository == nil || !c.repository.HasToken() {
+			return c.reportError(fmt.Errorf("listing team members requires a token: %w", github.ErrUnauthorized))
+		}
+		teams = c.repository
+	}
+	logins, err := teams.FetchTeamMembers(org, slug)
+	if err != nil {
+		return c.reportError(err)
+	}
+	if len(logins) == 0 {
+		fmt.Printf("Team %s has no members.\n", flags.Args[0])
+		return 0
+	}
+
+	// Members are fetched in parallel; one that fails is left out of the
+	// total, and one fetched in part is counted with a warning
+	if c.repository != nil {
+		c.repository.SetProgress(nil)
+	}
+	var mu sync.Mutex
+	summaries := make(map[string]*activity.PeriodSummary, len(logins))
+	errs := activity.ForEachUser(logins, flags.Concurrency, func(login string) error {
+		summary, err := c.service.GetPeriodSummary(login, run.filter, days)
+		if summary != nil {
+			mu.Lock()
+			summaries[login] = summary
+			mu.Unlock()
+		}
+		return err
+	})
+
+	var warnings []activity.FetchFailure
+	members := make([]*activity.PeriodSummary, 0, len(logins))
+	for i, login := range logins {
+		partial, ok := partialFailures(errs[i])
+		if !ok {
+			continue
+		}
+		warnings = append(warnings, partial...)
+		members = append(members, summaries[login])
+	}
+
+	renderTeam(os.Stdout, activity.NewTeamSummary(org+"/"+slug, members), format == "markdown")
+	printWarnings(os.Stderr, warnings)
+
+	code := 0
+	for i, login := range logins {
+		if _, ok := partialFailures(errs[i]); ok {
+			continue
+		}
+		if failed := c.reportError(fmt.Errorf("%s: %w", login, errs[i])); code == 0 {
+			code = failed
+		}
+	}
+	return code
+}
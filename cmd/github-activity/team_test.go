@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/activity"
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// fakeTeams returns members for the octo/backend team only
+type fakeTeams struct {
+	members []string
+}
+
+func (f *fakeTeams) FetchTeamMembers(org, team string) ([]string, error) {
+	if org != "octo" || team != "backend" {
+		return nil, github.ErrAPIError
+	}
+	return f.members, nil
+}
+
+// memberRepository serves a push event to alice and nothing to anyone else
+type memberRepository struct{}
+
+func (memberRepository) FetchEvents(username string) ([]github.GitHubEvent, error) {
+	if username != "alice" {
+		return nil, nil
+	}
+	return []github.GitHubEvent{{
+		ID:        "1",
+		Type:      "PushEvent",
+		Repo:      github.Repo{Name: "octo/repo"},
+		Payload:   json.RawMessage(`{"size":1}`),
+		CreatedAt: time.Now().Add(-time.Hour),
+	}}, nil
+}
+
+func TestRenderTeam(t *testing.T) {
+	since := time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)
+	until := since.AddDate(0, 0, 7)
+	team := activity.NewTeamSummary("octo/backend", []*activity.PeriodSummary{
+		{Username: "bob", Since: since, Until: until},
+		{Username: "alice", Since: since, Until: until, Events: 3, Commits: 3, PushedRepos: 1},
+	})
+
+	var console bytes.Buffer
+	renderTeam(&console, team, false)
+	for _, want := range []string{
+		"Team octo/backend, 2024-01-08 to 2024-01-15 (2 members):",
+		"  alice  Pushed 3 commits to 1 repo.\n",
+		"  bob    No activity (0 events).\n",
+		"Team total: Pushed 3 commits to 1 repo.",
+	} {
+		if !strings.Contains(console.String(), want) {
+			t.Errorf("Console output missing %q:\n%s", want, console.String())
+		}
+	}
+
+	var markdown bytes.Buffer
+	renderTeam(&markdown, team, true)
+	for _, want := range []string{"## Team activity for octo/backend", "- **alice**: Pushed 3 commits", "**Team total**:"} {
+		if !strings.Contains(markdown.String(), want) {
+			t.Errorf("Markdown output missing %q:\n%s", want, markdown.String())
+		}
+	}
+}
+
+func TestCLI_runTeam(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		members  []string
+		expected int
+		output   string
+	}{
+		{name: "team", args: []string{"octo/backend"}, members: []string{"bob", "alice"}, output: "  alice  Pushed 1 commit"},
+		{name: "no members", args: []string{"octo/backend"}, output: "Team octo/backend has no members."},
+		{name: "unknown team", args: []string{"octo/frontend"}, expected: 1},
+		{name: "invalid team", args: []string{"octo"}, expected: 1},
+		{name: "format", args: []string{"-format=csv", "octo/backend"}, expected: 1},
+		{name: "missing team", expected: 1, output: "Usage:"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli := NewCLI(activity.NewActivityService(memberRepository{}))
+			cli.SetTeamRepository(&fakeTeams{members: tt.members})
+
+			var code int
+			output := captureStdout(t, func() {
+				code = cli.Run(append([]string{"github-activity", "team"}, tt.args...))
+			})
+			if code != tt.expected {
+				t.Errorf("Run() = %d, want %d", code, tt.expected)
+			}
+			if !strings.Contains(output, tt.output) {
+				t.Errorf("Output missing %q:\n%s", tt.output, output)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"os"
+	"strconv"
+)
+
+// Terminal Width - How wide console and table output may be
+
+// terminalWidth returns the columns output should fit: -width when given,
+// else the size of the terminal on stdout, or COLUMNS where the terminal
+// cannot be asked. It returns 0, which turns wrapping off, when stdout is
+// not a terminal, so piped output keeps whole lines.
+func terminalWidth(flags CLIFlags) int {
+	if flags.Width > 0 {
+		return flags.Width
+	}
+	if !isTerminal(os.Stdout) {
+		return 0
+	}
+	if width := ttyColumns(os.Stdout); width > 0 {
+		return width
+	}
+	width, err := strconv.Atoi(os.Getenv("COLUMNS"))
+	if err != nil || width < 0 {
+		return 0
+	}
+	return width
+}
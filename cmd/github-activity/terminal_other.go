@@ -0,0 +1,11 @@
+//go:build !linux && !darwin && !freebsd && !netbsd && !openbsd && !dragonfly
+
+package main
+
+import "os"
+
+// ttyColumns cannot ask the terminal for its size here; COLUMNS and -width
+// still apply
+func ttyColumns(*os.File) int {
+	return 0
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"os"
+	"testing"
+)
+
+func TestTerminalWidth(t *testing.T) {
+	t.Setenv("COLUMNS", "120")
+
+	if got := terminalWidth(CLIFlags{Width: 72}); got != 72 {
+		t.Errorf("terminalWidth(-width=72) = %d, want 72", got)
+	}
+	// Test output is not a terminal, so nothing is wrapped
+	if got := terminalWidth(CLIFlags{}); got != 0 {
+		t.Errorf("terminalWidth() = %d when piped, want 0", got)
+	}
+}
+
+func TestTtyColumns(t *testing.T) {
+	file, err := os.CreateTemp(t.TempDir(), "not-a-tty")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = file.Close() }()
+
+	if got := ttyColumns(file); got != 0 {
+		t.Errorf("ttyColumns(regular file) = %d, want 0", got)
+	}
+}
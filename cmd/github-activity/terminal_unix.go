@@ -0,0 +1,29 @@
+//go:build linux || darwin || freebsd || netbsd || openbsd || dragonfly
+
+package main
+
+import (
+	"os"
+	"syscall"
+	"unsafe"
+)
+
+// winsize is the terminal size filled in by the TIOCGWINSZ ioctl
+type winsize struct {
+	Rows, Cols, XPixel, YPixel uint16
+}
+
+// ttyColumns asks the terminal behind file for its width, or returns 0
+func ttyColumns(file *os.File) int {
+	var size winsize
+	_, _, errno := syscall.Syscall(
+		syscall.SYS_IOCTL,
+		file.Fd(),
+		uintptr(syscall.TIOCGWINSZ),
+		uintptr(unsafe.Pointer(&size)),
+	)
+	if errno != 0 {
+		return 0
+	}
+	return int(size.Cols)
+}
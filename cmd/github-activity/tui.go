@@ -0,0 +1,409 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+
+	"github.com/alnah/github-activity/internal/messages"
+	"github.com/alnah/github-activity/pkg/activity"
+	"github.com/alnah/github-activity/pkg/format"
+)
+
+// tuiAction is what the event loop should do after a key press
+type tuiAction int
+
+const (
+	tuiNone tuiAction = iota
+	tuiQuit
+	tuiRefresh
+)
+
+// tuiHelp lists the key bindings shown at the bottom of the screen
+const tuiHelp = "j/k move  t type  a all  r refresh  q quit"
+
+// tuiModel is the state of the interactive dashboard, kept free of terminal
+// I/O so it can be tested
+type tuiModel struct {
+	username string
+	all      []activity.DetailedActivity
+	visible  []activity.DetailedActivity
+	types    []string // Event types present, in the order t cycles through
+	typeName string   // Current type filter, empty for all
+	selected int
+	offset   int
+	width    int
+	height   int
+	status   string
+}
+
+// newTUIModel creates a dashboard for the given activities and screen size
+func newTUIModel(username string, activities []activity.DetailedActivity, width, height int) *tuiModel {
+	m := &tuiModel{username: username, width: width, height: height}
+	m.setActivities(activities)
+	return m
+}
+
+// setActivities replaces the loaded activities, keeping the selected event
+// and type filter when they still exist
+func (m *tuiModel) setActivities(activities []activity.DetailedActivity) {
+	selectedID := ""
+	if m.selected < len(m.visible) {
+		selectedID = m.visible[m.selected].EventID
+	}
+
+	m.all = activities
+	seen := make(map[string]bool)
+	m.types = m.types[:0]
+	for _, activity := range activities {
+		if !seen[activity.Type] {
+			seen[activity.Type] = true
+			m.types = append(m.types, activity.Type)
+		}
+	}
+	sort.Strings(m.types)
+	if !seen[m.typeName] {
+		m.typeName = ""
+	}
+
+	m.applyFilter()
+	for i, activity := range m.visible {
+		if selectedID != "" && activity.EventID == selectedID {
+			m.selected = i
+		}
+	}
+	m.scroll()
+}
+
+// applyFilter rebuilds the visible list from the type filter
+func (m *tuiModel) applyFilter() {
+	m.visible = m.visible[:0]
+	for _, activity := range m.all {
+		if m.typeName == "" || activity.Type == m.typeName {
+			m.visible = append(m.visible, activity)
+		}
+	}
+	m.selected = 0
+	m.offset = 0
+}
+
+// listRows is how many events fit in the list, the detail pane gets the rest
+func (m *tuiModel) listRows() int {
+	rows := (m.height - 3) / 2
+	if rows < 1 {
+		rows = 1
+	}
+	return rows
+}
+
+// scroll keeps the selected event inside the list window
+func (m *tuiModel) scroll() {
+	rows := m.listRows()
+	if m.selected < m.offset {
+		m.offset = m.selected
+	}
+	if m.selected >= m.offset+rows {
+		m.offset = m.selected - rows + 1
+	}
+}
+
+// move changes the selection by delta, staying inside the list
+func (m *tuiModel) move(delta int) {
+	m.selected += delta
+	if m.selected >= len(m.visible) {
+		m.selected = len(m.visible) - 1
+	}
+	if m.selected < 0 {
+		m.selected = 0
+	}
+	m.scroll()
+}
+
+// nextType cycles the type filter through the loaded event types, then back to all
+func (m *tuiModel) nextType() {
+	next := ""
+	if m.typeName == "" {
+		if len(m.types) > 0 {
+			next = m.types[0]
+		}
+	} else {
+		for i, name := range m.types {
+			if name == m.typeName && i+1 < len(m.types) {
+				next = m.types[i+1]
+			}
+		}
+	}
+	m.typeName = next
+	m.applyFilter()
+}
+
+// handleKey updates the model for a key from readKey
+func (m *tuiModel) handleKey(key string) tuiAction {
+	switch key {
+	case "q", "ctrl-c":
+		return tuiQuit
+	case "j", "down":
+		m.move(1)
+	case "k", "up":
+		m.move(-1)
+	case "pgdown", " ":
+		m.move(m.listRows())
+	case "pgup":
+		m.move(-m.listRows())
+	case "g":
+		m.move(-len(m.visible))
+	case "G":
+		m.move(len(m.visible))
+	case "t":
+		m.nextType()
+	case "a":
+		m.typeName = ""
+		m.applyFilter()
+	case "r":
+		return tuiRefresh
+	}
+	return tuiNone
+}
+
+// render draws the whole screen: header, event list, detail pane and help
+func (m *tuiModel) render(w io.Writer) {
+	lines := make([]string, 0, m.height)
+
+	header := fmt.Sprintf("%s: %s", m.username, messages.Plural("events", len(m.visible)))
+	if m.typeName != "" {
+		header += " [" + m.typeName + "]"
+	}
+	lines = append(lines, header)
+
+	rows := m.listRows()
+	for i := m.offset; i < m.offset+rows; i++ {
+		if i >= len(m.visible) {
+			lines = append(lines, "")
+			continue
+		}
+		marker := "  "
+		if i == m.selected {
+			marker = "> "
+		}
+		activity := m.visible[i]
+		lines = append(lines, fmt.Sprintf("%s%-15s %s", marker, activity.RelativeTime(), activity.Description))
+	}
+	lines = append(lines, strings.Repeat("-", m.width))
+
+	// Detail pane
+	detailRows := m.height - len(lines) - 1
+	var detail []string
+	if len(m.visible) > 0 {
+		detail = tuiDetail(m.visible[m.selected])
+	} else {
+		detail = []string{"No recent activity found."}
+	}
+	for i := 0; i < detailRows; i++ {
+		line := ""
+		if i < len(detail) {
+			line = detail[i]
+		}
+		lines = append(lines, line)
+	}
+
+	footer := tuiHelp
+	if m.status != "" {
+		footer = m.status + "  " + tuiHelp
+	}
+	lines = append(lines, footer)
+
+	// Raw mode does not translate \n, so return the carriage explicitly
+	_, _ = fmt.Fprint(w, "\x1b[H\x1b[2J")
+	for i, line := range lines {
+		if i > 0 {
+			_, _ = fmt.Fprint(w, "\r\n")
+		}
+		_, _ = fmt.Fprint(w, truncate(line, m.width))
+	}
+}
+
+// tuiDetail returns the detail pane lines for an activity: the detailed
+// console output followed by the payload fields behind the description
+func tuiDetail(detail activity.DetailedActivity) []string {
+	var buf bytes.Buffer
+	formatter := &format.ConsoleOutputFormatter{AbsoluteTime: true}
+	formatter.FormatDetailedActivities(&buf, []activity.DetailedActivity{detail})
+	lines := strings.Split(strings.TrimRight(buf.String(), "\n"), "\n")
+
+	if detail.ActorLogin != "" {
+		lines = append(lines, "  Actor: "+detail.ActorLogin)
+	}
+	if len(detail.Fields) > 0 {
+		keys := make([]string, 0, len(detail.Fields))
+		for key := range detail.Fields {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		lines = append(lines, "  Fields:")
+		for _, key := range keys {
+			lines = append(lines, fmt.Sprintf("    %s: %s", key, detail.Fields[key]))
+		}
+	}
+	return lines
+}
+
+// truncate shortens a line to width runes
+func truncate(line string, width int) string {
+	runes := []rune(line)
+	if width <= 0 || len(runes) <= width {
+		return line
+	}
+	return string(runes[:width])
+}
+
+// readKey reads one key press, naming arrow and page keys
+func readKey(r *bufio.Reader) (string, error) {
+	b, err := r.ReadByte()
+	if err != nil {
+		return "", err
+	}
+	switch b {
+	case 3:
+		return "ctrl-c", nil
+	case '\r', '\n':
+		return "enter", nil
+	case 0x1b:
+		if r.Buffered() < 2 {
+			return "esc", nil
+		}
+		sequence := make([]byte, 2)
+		if _, err := io.ReadFull(r, sequence); err != nil {
+			return "", err
+		}
+		switch string(sequence) {
+		case "[A":
+			return "up", nil
+		case "[B":
+			return "down", nil
+		case "[5", "[6":
+			// Page keys end with a tilde
+			_, _ = r.ReadByte()
+			if sequence[1] == '5' {
+				return "pgup", nil
+			}
+			return "pgdown", nil
+		}
+		return "esc", nil
+	}
+	return string(b), nil
+}
+
+// terminal is the controlling terminal switched to raw mode with stty, so the
+// dashboard needs no dependencies beyond a Unix-like system
+type terminal struct {
+	tty   *os.File
+	state string
+}
+
+// openTerminal switches /dev/tty to raw mode and the alternate screen
+func openTerminal() (*terminal, error) {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return nil, fmt.Errorf("interactive mode needs a terminal: %w", err)
+	}
+	t := &terminal{tty: tty}
+
+	state, err := t.stty("-g")
+	if err != nil {
+		_ = tty.Close()
+		return nil, fmt.Errorf("failed to read terminal settings: %w", err)
+	}
+	t.state = strings.TrimSpace(state)
+	if _, err := t.stty("raw", "-echo"); err != nil {
+		_ = tty.Close()
+		return nil, fmt.Errorf("failed to switch terminal to raw mode: %w", err)
+	}
+
+	_, _ = fmt.Fprint(tty, "\x1b[?1049h\x1b[?25l")
+	return t, nil
+}
+
+// stty runs stty against the terminal
+func (t *terminal) stty(args ...string) (string, error) {
+	return stty(t.tty, args...)
+}
+
+// stty runs stty against tty and returns what it printed
+func stty(tty *os.File, args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = tty
+	out, err := cmd.Output()
+	return string(out), err
+}
+
+// size returns the terminal width and height, defaulting to 80x24
+func (t *terminal) size() (int, int) {
+	out, err := t.stty("size")
+	if err != nil {
+		return 80, 24
+	}
+	var height, width int
+	if _, err := fmt.Sscan(out, &height, &width); err != nil || width == 0 || height == 0 {
+		return 80, 24
+	}
+	return width, height
+}
+
+// restore leaves the alternate screen and restores the saved settings
+func (t *terminal) restore() {
+	_, _ = fmt.Fprint(t.tty, "\x1b[?25h\x1b[?1049l")
+	_, _ = t.stty(t.state)
+	_ = t.tty.Close()
+}
+
+// runTUI shows the interactive dashboard until the user quits
+func (c *CLI) runTUI(username string, filter activity.EventFilter) int {
+	load := func() ([]activity.DetailedActivity, error) {
+		if c.repository != nil {
+			c.repository.Refresh()
+		}
+		return c.service.GetUserActivityDetailed(username, filter)
+	}
+
+	activities, err := load()
+	if err != nil {
+		return c.reportError(err)
+	}
+
+	term, err := openTerminal()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	defer term.restore()
+
+	width, height := term.size()
+	model := newTUIModel(username, activities, width, height)
+	input := bufio.NewReader(term.tty)
+	for {
+		model.width, model.height = term.size()
+		model.render(term.tty)
+
+		key, err := readKey(input)
+		if err != nil {
+			return 0
+		}
+		switch model.handleKey(key) {
+		case tuiQuit:
+			return 0
+		case tuiRefresh:
+			activities, err := load()
+			if err != nil {
+				model.status = "Refresh failed: " + err.Error()
+				continue
+			}
+			model.status = ""
+			model.setActivities(activities)
+		}
+	}
+}
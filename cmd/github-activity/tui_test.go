@@ -0,0 +1,162 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/alnah/github-activity/pkg/activity"
+)
+
+func tuiActivities() []activity.DetailedActivity {
+	return []activity.DetailedActivity{
+		{
+			ActivitySummary: activity.ActivitySummary{
+				Description: "Pushed 1 commit to user/repo (branch: main)",
+				Type:        "PushEvent",
+				Timestamp:   "2024-01-15 10:30:00",
+				Fields:      map[string]string{"branch": "main"},
+			},
+			EventID: "3",
+			Commits: []activity.CommitSummary{{SHA: "abc1234", Message: "Fix bug"}},
+		},
+		{
+			ActivitySummary: activity.ActivitySummary{Description: "Starred user/other", Type: "WatchEvent"},
+			EventID:         "2",
+		},
+		{
+			ActivitySummary: activity.ActivitySummary{Description: "Pushed 2 commits to user/repo", Type: "PushEvent"},
+			EventID:         "1",
+		},
+	}
+}
+
+func TestTUIModel_HandleKey(t *testing.T) {
+	t.Run("navigation stays in bounds", func(t *testing.T) {
+		model := newTUIModel("testuser", tuiActivities(), 80, 24)
+
+		keys := []struct {
+			key      string
+			expected int
+		}{
+			{"k", 0},
+			{"j", 1},
+			{"down", 2},
+			{"j", 2},
+			{"up", 1},
+			{"g", 0},
+			{"G", 2},
+		}
+		for _, k := range keys {
+			model.handleKey(k.key)
+			if model.selected != k.expected {
+				t.Errorf("After %q selected = %d, want %d", k.key, model.selected, k.expected)
+			}
+		}
+	})
+
+	t.Run("type filter cycles", func(t *testing.T) {
+		model := newTUIModel("testuser", tuiActivities(), 80, 24)
+
+		expected := []struct {
+			typeName string
+			visible  int
+		}{
+			{"PushEvent", 2},
+			{"WatchEvent", 1},
+			{"", 3},
+		}
+		for _, e := range expected {
+			model.handleKey("t")
+			if model.typeName != e.typeName || len(model.visible) != e.visible {
+				t.Errorf("Filter = %q with %d events, want %q with %d",
+					model.typeName, len(model.visible), e.typeName, e.visible)
+			}
+		}
+
+		model.handleKey("t")
+		model.handleKey("a")
+		if model.typeName != "" || len(model.visible) != 3 {
+			t.Errorf("After a, filter = %q with %d events", model.typeName, len(model.visible))
+		}
+	})
+
+	t.Run("actions", func(t *testing.T) {
+		model := newTUIModel("testuser", tuiActivities(), 80, 24)
+		if model.handleKey("r") != tuiRefresh {
+			t.Error("r should refresh")
+		}
+		if model.handleKey("q") != tuiQuit {
+			t.Error("q should quit")
+		}
+		if model.handleKey("ctrl-c") != tuiQuit {
+			t.Error("ctrl-c should quit")
+		}
+	})
+
+	t.Run("scrolls to keep selection visible", func(t *testing.T) {
+		model := newTUIModel("testuser", tuiActivities(), 80, 6)
+		model.handleKey("G")
+		if model.offset != 2 {
+			t.Errorf("offset = %d, want 2", model.offset)
+		}
+	})
+}
+
+func TestTUIModel_SetActivities(t *testing.T) {
+	model := newTUIModel("testuser", tuiActivities(), 80, 24)
+	model.handleKey("j")
+
+	// A new event arrives on refresh; the selection follows the same event
+	refreshed := append([]activity.DetailedActivity{{
+		ActivitySummary: activity.ActivitySummary{Description: "Forked user/repo", Type: "ForkEvent"},
+		EventID:         "4",
+	}}, tuiActivities()...)
+	model.setActivities(refreshed)
+
+	if model.visible[model.selected].EventID != "2" {
+		t.Errorf("Selected event = %s, want 2", model.visible[model.selected].EventID)
+	}
+}
+
+func TestTUIModel_Render(t *testing.T) {
+	model := newTUIModel("testuser", tuiActivities(), 80, 24)
+
+	var buf bytes.Buffer
+	model.render(&buf)
+	output := buf.String()
+
+	expected := []string{
+		"testuser: 3 events",
+		"> ",
+		"Starred user/other",
+		"Commits:",
+		"abc1234: Fix bug",
+		"branch: main",
+		tuiHelp,
+	}
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("Render output missing %q", want)
+		}
+	}
+	if lines := strings.Count(output, "\r\n") + 1; lines != 24 {
+		t.Errorf("Rendered %d lines, want 24", lines)
+	}
+}
+
+func TestReadKey(t *testing.T) {
+	input := bufio.NewReader(strings.NewReader("j\x1b[A\x1b[B\x1b[6~q\x03"))
+	expected := []string{"j", "up", "down", "pgdown", "q", "ctrl-c"}
+
+	for _, want := range expected {
+		key, err := readKey(input)
+		if err != nil {
+			t.Fatalf("readKey() error = %v", err)
+		}
+		if key != want {
+			t.Errorf("readKey() = %q, want %q", key, want)
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/alnah/github-activity/pkg/activity"
+	"github.com/alnah/github-activity/pkg/format"
+)
+
+// Several Users - Listing the activity of more than one user in a run
+
+// multiUserConflict names the flag that cannot be used when several
+// usernames are given, or returns "" when there is none
+func multiUserConflict(flags CLIFlags) string {
+	switch {
+	case flags.TUI:
+		return "-tui"
+	case flags.ReviewDebt:
+		return "-review-debt"
+	case flags.Spikes != "":
+		return "-spikes"
+	case flags.Histogram:
+		return "-histogram"
+	case flags.Streak:
+		return "-streak"
+	case flags.Authors:
+		return "-authors"
+	case flags.Heatmap:
+		return "-heatmap"
+	}
+	if sink, ok := selectedChatSink(flags); ok {
+		return "-" + sink.Flag
+	}
+	return ""
+}
+
+// uniqueUsers drops repeated usernames, which GitHub matches regardless of
+// case, keeping the first spelling
+func uniqueUsers(usernames []string) []string {
+	seen := make(map[string]bool, len(usernames))
+	unique := make([]string, 0, len(usernames))
+	for _, username := range usernames {
+		key := strings.ToLower(username)
+		if !seen[key] {
+			seen[key] = true
+			unique = append(unique, username)
+		}
+	}
+	return unique
+}
+
+// displayUsers fetches several users' activities on at most
+// flags.Concurrency workers. Console output has a section per user; other
+// formats get every user's activities as one list, newest first. A user whose
+// feed cannot be fetched, or only in part, is reported after the output
+// without stopping the others, and the exit code is that of the first user
+// that could not be fetched at all.
+func (c *CLI) displayUsers(usernames []string, run *runSetup, flags CLIFlags) int {
+	if conflict := multiUserConflict(flags); conflict != "" {
+		fmt.Fprintf(os.Stderr, "Error: %s shows one user; give a single username\n", conflict)
+		return 1
+	}
+	usernames = uniqueUsers(usernames)
+	if c.repository != nil {
+		// One progress line cannot follow several fetches
+		c.repository.SetProgress(nil)
+	}
+
+	detailed := flags.Detailed || flags.Enrich || format.NeedsDetails(run.format)
+	var mu sync.Mutex
+	summaries := make(map[string][]activity.ActivitySummary, len(usernames))
+	details := make(map[string][]activity.DetailedActivity, len(usernames))
+	errs := activity.ForEachUser(usernames, flags.Concurrency, func(username string) error {
+		if detailed {
+			activities, err := c.service.GetUserActivityDetailed(username, run.filter)
+			mu.Lock()
+			details[username] = activities
+			mu.Unlock()
+			return err
+		}
+		activities, err := c.service.GetUserActivity(username, run.filter)
+		mu.Lock()
+		summaries[username] = activities
+		mu.Unlock()
+		return err
+	})
+
+	// Users fetched in part are shown with a warning; users that could not
+	// be fetched at all are reported as errors, both after the output
+	var warnings []activity.FetchFailure
+	failed := make(map[string]bool)
+	for i, username := range usernames {
+		partial, ok := partialFailures(errs[i])
+		warnings = append(warnings, partial...)
+		failed[username] = !ok
+	}
+	report := func() int {
+		printWarnings(os.Stderr, warnings)
+		code := 0
+		for i, username := range usernames {
+			if failed[username] {
+				if exit := c.reportError(fmt.Errorf("%s: %w", username, errs[i])); code == 0 {
+					code = exit
+				}
+			}
+		}
+		return code
+	}
+
+	if (run.format == "" || run.format == "console") && !flags.Explain {
+		for i, username := range usernames {
+			if i > 0 {
+				fmt.Println()
+			}
+			// A section's header and its status share stdout, so piped
+			// output still says why a section is empty
+			fmt.Printf("== %s ==\n", username)
+			switch {
+			case failed[username]:
+				fmt.Println("Could not fetch activity.")
+			case len(summaries[username]) == 0 && len(details[username]) == 0:
+				fmt.Println("No recent activity found.")
+			case detailed:
+				c.output.FormatDetailedActivities(os.Stdout, details[username])
+			default:
+				c.output.FormatActivities(os.Stdout, summaries[username])
+			}
+		}
+		return report()
+	}
+
+	var allSummaries []activity.ActivitySummary
+	var allDetails []activity.DetailedActivity
+	for _, username := range usernames {
+		allSummaries = append(allSummaries, summaries[username]...)
+		allDetails = append(allDetails, details[username]...)
+	}
+	if detailed {
+		sort.SliceStable(allDetails, func(i, j int) bool {
+			return allDetails[i].CreatedAt.After(allDetails[j].CreatedAt)
+		})
+		c.output.FormatDetailedActivities(os.Stdout, allDetails)
+	} else {
+		sort.SliceStable(allSummaries, func(i, j int) bool {
+			return allSummaries[i].CreatedAt.After(allSummaries[j].CreatedAt)
+		})
+		c.output.FormatActivities(os.Stdout, allSummaries)
+	}
+	return report()
+}
@@ -0,0 +1,107 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/activity"
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// usersRepository serves one push per known user, a 404 for ghost and a
+// failed second page for patchy
+type usersRepository struct{}
+
+func (usersRepository) FetchEvents(username string) ([]github.GitHubEvent, error) {
+	var err error
+	switch username {
+	case "ghost":
+		return nil, github.ErrUserNotFound
+	case "quiet":
+		return nil, nil
+	case "patchy":
+		err = &github.PartialFetchError{Page: 2, Err: github.ErrNetworkError}
+	}
+	age := time.Hour
+	if username == "alice" {
+		age = 2 * time.Hour
+	}
+	return []github.GitHubEvent{{
+		ID:        username,
+		Type:      "PushEvent",
+		Actor:     github.Actor{Login: username},
+		Repo:      github.Repo{Name: username + "/repo"},
+		Payload:   json.RawMessage(`{"size":1,"ref":"refs/heads/main"}`),
+		CreatedAt: time.Now().Add(-age),
+	}}, err
+}
+
+func TestUniqueUsers(t *testing.T) {
+	got := uniqueUsers([]string{"alice", "Bob", "ALICE", "bob", "carol"})
+	if want := []string{"alice", "Bob", "carol"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("uniqueUsers() = %v, want %v", got, want)
+	}
+}
+
+func TestCLI_displayUsers(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected int
+		output   []string
+	}{
+		{
+			name: "sections",
+			args: []string{"alice", "quiet", "bob"},
+			output: []string{
+				"== alice ==\n- Pushed 1 commit to alice/repo",
+				"== quiet ==\nNo recent activity found.\n\n== bob ==",
+				"== bob ==\n- Pushed 1 commit to bob/repo",
+			},
+		},
+		{
+			name:     "one user fails",
+			args:     []string{"alice", "ghost", "bob"},
+			expected: userNotFoundExitCode,
+			output:   []string{"== ghost ==\nCould not fetch activity.\n\n== bob ==\n- Pushed 1 commit to bob/repo"},
+		},
+		{
+			name:   "one user fetched in part",
+			args:   []string{"alice", "patchy"},
+			output: []string{"== patchy ==\n- Pushed 1 commit to patchy/repo"},
+		},
+		{
+			name:   "csv merges newest first",
+			args:   []string{"-format=csv", "alice", "bob"},
+			output: []string{"(branch: main),bob,0\n", "(branch: main),alice,0\n"},
+		},
+		{name: "single-user view", args: []string{"-heatmap", "alice", "bob"}, expected: 1},
+		{name: "no workers", args: []string{"-concurrency=0", "alice", "bob"}, expected: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cli := NewCLI(activity.NewActivityService(usersRepository{}))
+
+			var code int
+			output := captureStdout(t, func() {
+				code = cli.Run(append([]string{"github-activity", "-quiet"}, tt.args...))
+			})
+			if code != tt.expected {
+				t.Errorf("Run() = %d, want %d", code, tt.expected)
+			}
+			for _, want := range tt.output {
+				if !strings.Contains(output, want) {
+					t.Errorf("Output missing %q:\n%s", want, output)
+				}
+			}
+			if tt.name == "csv merges newest first" &&
+				strings.Index(output, "bob/repo") > strings.Index(output, "alice/repo") {
+				t.Errorf("bob's newer push should come first:\n%s", output)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package main
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/alnah/github-activity/pkg/activity"
+)
+
+// Warnings - Partial results reported after the output
+
+// printWarnings lists the users whose feed could only be partly fetched, so
+// the results shown above are known to be incomplete
+func printWarnings(w io.Writer, failures []activity.FetchFailure) {
+	if len(failures) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintln(w, "\nWarning: some activity could not be fetched; results are incomplete:")
+	for _, failure := range failures {
+		_, _ = fmt.Fprintf(w, "  - %s: %v\n", failure.Username, failure.Err)
+	}
+}
+
+// partialFailures returns the failures err reports alongside usable results
+// and whether it is such an error; nil is reported as usable with no failures
+func partialFailures(err error) ([]activity.FetchFailure, bool) {
+	if err == nil {
+		return nil, true
+	}
+	return activity.AsPartial(err)
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/alnah/github-activity/pkg/activity"
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+func TestPrintWarnings(t *testing.T) {
+	var buf bytes.Buffer
+	printWarnings(&buf, nil)
+	if buf.Len() != 0 {
+		t.Errorf("printWarnings(nil) wrote %q, want nothing", buf.String())
+	}
+
+	printWarnings(&buf, []activity.FetchFailure{
+		{Username: "alice", Err: errors.New("page 2: timeout")},
+		{Username: "bob", Err: errors.New("page 3: rate limited")},
+	})
+	want := "\nWarning: some activity could not be fetched; results are incomplete:\n" +
+		"  - alice: page 2: timeout\n" +
+		"  - bob: page 3: rate limited\n"
+	if got := buf.String(); got != want {
+		t.Errorf("printWarnings() = %q, want %q", got, want)
+	}
+}
+
+func TestPartialFailures(t *testing.T) {
+	if failures, ok := partialFailures(nil); !ok || failures != nil {
+		t.Errorf("partialFailures(nil) = %v, %v, want nil, true", failures, ok)
+	}
+	if _, ok := partialFailures(github.ErrUserNotFound); ok {
+		t.Error("partialFailures(ErrUserNotFound) reported usable results")
+	}
+	partial := &activity.PartialResultError{Failures: []activity.FetchFailure{{Username: "alice", Err: github.ErrNetworkError}}}
+	if failures, ok := partialFailures(partial); !ok || len(failures) != 1 {
+		t.Errorf("partialFailures(partial) = %v, %v, want one failure", failures, ok)
+	}
+}
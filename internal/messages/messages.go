@@ -0,0 +1,74 @@
+// Package messages holds the count-dependent wording of user-facing text.
+package messages
+
+import "fmt"
+
+// Messages - Count-dependent wording for user-facing text
+
+// PluralForm is a CLDR plural category. Only the categories the catalog's
+// languages use are defined; a language added to it brings its own.
+type PluralForm int
+
+const (
+	PluralOne PluralForm = iota
+	PluralOther
+)
+
+// pluralRules pick the plural form of a whole-number count per language,
+// following the CLDR cardinal rules. A language added to pluralMessages needs
+// its rule here.
+var pluralRules = map[string]func(n int) PluralForm{
+	"en": func(n int) PluralForm {
+		if n == 1 {
+			return PluralOne
+		}
+		return PluralOther
+	},
+}
+
+// pluralMessages is the catalog of count-dependent messages per language.
+// Each form is a format string that receives the count. A language only
+// needs the forms its plural rule produces; PluralOther is the fallback.
+var pluralMessages = map[string]map[string]map[PluralForm]string{
+	"en": {
+		"commits":       {PluralOne: "%d commit", PluralOther: "%d commits"},
+		"contributions": {PluralOne: "%d contribution", PluralOther: "%d contributions"},
+		"days":          {PluralOne: "%d day", PluralOther: "%d days"},
+		"events":        {PluralOne: "%d event", PluralOther: "%d events"},
+		"files":         {PluralOne: "%d file", PluralOther: "%d files"},
+		"issues":        {PluralOne: "%d issue", PluralOther: "%d issues"},
+		"members":       {PluralOne: "%d member", PluralOther: "%d members"},
+		"new_events":    {PluralOne: "%d new event", PluralOther: "%d new events"},
+		"prs":           {PluralOne: "%d PR", PluralOther: "%d PRs"},
+		"problems":      {PluralOne: "%d problem", PluralOther: "%d problems"},
+		"pushes":        {PluralOne: "%d push", PluralOther: "%d pushes"},
+		"releases":      {PluralOne: "%d release", PluralOther: "%d releases"},
+		"repos":         {PluralOne: "%d repo", PluralOther: "%d repos"},
+		"spikes":        {PluralOne: "%d spike", PluralOther: "%d spikes"},
+		"stars":         {PluralOne: "%d star", PluralOther: "%d stars"},
+		"weeks":         {PluralOne: "%d week", PluralOther: "%d weeks"},
+		"wiki_pages":    {PluralOne: "%d wiki page", PluralOther: "%d wiki pages"},
+		"minutes_ago":   {PluralOne: "%d minute ago", PluralOther: "%d minutes ago"},
+		"hours_ago":     {PluralOne: "%d hour ago", PluralOther: "%d hours ago"},
+		"days_ago":      {PluralOne: "%d day ago", PluralOther: "%d days ago"},
+		"months_ago":    {PluralOne: "%d month ago", PluralOther: "%d months ago"},
+		"years_ago":     {PluralOne: "%d year ago", PluralOther: "%d years ago"},
+	},
+}
+
+// messageLanguage is the catalog Plural reads, the only one there is so far
+const messageLanguage = "en"
+
+// Plural formats the count-dependent message id for n
+func Plural(id string, n int) string {
+	forms, rule := pluralMessages[messageLanguage][id], pluralRules[messageLanguage]
+
+	format, ok := forms[rule(n)]
+	if !ok {
+		format, ok = forms[PluralOther]
+	}
+	if !ok {
+		return fmt.Sprintf("%d %s", n, id)
+	}
+	return fmt.Sprintf(format, n)
+}
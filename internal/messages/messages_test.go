@@ -0,0 +1,38 @@
+package messages
+
+import "testing"
+
+func TestPluralRules(t *testing.T) {
+	tests := []struct {
+		language string
+		n        int
+		expected PluralForm
+	}{
+		{"en", 0, PluralOther},
+		{"en", 1, PluralOne},
+		{"en", 2, PluralOther},
+	}
+
+	for _, tt := range tests {
+		if got := pluralRules[tt.language](tt.n); got != tt.expected {
+			t.Errorf("%s rule(%d) = %v, want %v", tt.language, tt.n, got, tt.expected)
+		}
+	}
+}
+
+func TestPlural(t *testing.T) {
+	t.Run("english", func(t *testing.T) {
+		if got := Plural("commits", 1); got != "1 commit" {
+			t.Errorf("Plural(commits, 1) = %q", got)
+		}
+		if got := Plural("commits", 0); got != "0 commits" {
+			t.Errorf("Plural(commits, 0) = %q", got)
+		}
+	})
+
+	t.Run("unknown message", func(t *testing.T) {
+		if got := Plural("widgets", 3); got != "3 widgets" {
+			t.Errorf("Plural(widgets, 3) = %q", got)
+		}
+	})
+}
@@ -0,0 +1,1067 @@
+// Package activity turns GitHub events into activity summaries: filtering,
+// descriptions, statistics, streaks and period summaries, with optional
+// session, archive and shared caches in front of the API.
+package activity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// Application Service Layer - Business logic and use cases
+
+// ActivityProvider is the set of activity queries the CLI and other front
+// ends depend on, so they can be tested against fakes
+type ActivityProvider interface {
+	GetUserActivity(username string, filter EventFilter) ([]ActivitySummary, error)
+	GetUserActivityDetailed(username string, filter EventFilter) ([]DetailedActivity, error)
+	GetUserActivityContext(ctx context.Context, username string, filter EventFilter) ([]ActivitySummary, error)
+	GetUserActivityDetailedContext(ctx context.Context, username string, filter EventFilter) ([]DetailedActivity, error)
+	GetUserActivityPage(ctx context.Context, username string, filter EventFilter, cursor string) (*ActivityPage, error)
+	GetUserActivityDetailedPage(
+		ctx context.Context,
+		username string,
+		filter EventFilter,
+		cursor string,
+	) (*DetailedActivityPage, error)
+	GetEventTypeStatistics(username string) (map[string]int, error)
+	GetRecentRepositories(username string, limit int) ([]string, error)
+	GetTimelineGap(username string) (*TimelineGap, error)
+	GetReviewBurndown(username string, window time.Duration) (*ReviewBurndown, error)
+	GetDailyActivity(username string, filter EventFilter) (map[string]int, error)
+	GetActivityHistogram(username string) (*ActivityHistogram, error)
+	GetActivitySpikes(username, period string) ([]Spike, error)
+	GetStreaks(username string) (*ActivityStreaks, error)
+	GetCommitAuthors(username string, filter EventFilter) ([]AuthorCount, error)
+	GetLanguageBreakdown(username string, filter EventFilter) ([]LanguageCount, error)
+	GetOwnerBreakdown(username string, filter EventFilter) ([]OwnerCount, error)
+	GetPeriodSummary(username string, filter EventFilter, days int) (*PeriodSummary, error)
+	GetCommitReport(username string, filter EventFilter, days int) (*CommitReport, error)
+	GetChangelog(username string, filter EventFilter) (*Changelog, error)
+}
+
+// ActivityService handles the business logic for GitHub activities
+type ActivityService struct {
+	repository github.EventRepository
+	enricher   *Enricher
+	searcher   ResourceFetcher // Runs the searches of GetReviewBurndown
+	now        func() time.Time
+	logger     github.Logger
+	tracer     github.Tracer
+	location   *time.Location
+
+	descriptions github.EventRendererRegistry // Overrides the built-in renderers
+	emoji        map[github.EventType]string  // Description prefixes per event type
+}
+
+// ServiceOption configures an ActivityService
+type ServiceOption func(*ActivityService)
+
+// WithClock sets the clock used for time windows, feed gap detection and the
+// age of sessions and cached feeds, such as a github.FakeClock's Now in tests
+func WithClock(now func() time.Time) ServiceOption {
+	return func(s *ActivityService) {
+		s.now = now
+	}
+}
+
+// WithLogger sets where the service reports what it fetched and payloads
+// it could not parse
+func WithLogger(logger github.Logger) ServiceOption {
+	return func(s *ActivityService) {
+		s.logger = logger
+	}
+}
+
+// WithTracer sets the tracer that receives a span for each fetch. Pass the
+// same tracer to github.WithRepositoryTracer to trace requests and cache
+// lookups too.
+func WithTracer(tracer github.Tracer) ServiceOption {
+	return func(s *ActivityService) {
+		s.tracer = tracer
+	}
+}
+
+// WithLocation sets the time zone activity timestamps are shown in
+func WithLocation(location *time.Location) ServiceOption {
+	return func(s *ActivityService) {
+		s.location = location
+	}
+}
+
+// NewActivityService creates a new activity service
+func NewActivityService(repository github.EventRepository, options ...ServiceOption) *ActivityService {
+	service := &ActivityService{
+		repository: repository,
+		now:        time.Now,
+		logger:     github.NopLogger(),
+		tracer:     github.NopTracer(),
+	}
+	for _, option := range options {
+		option(service)
+	}
+	return service
+}
+
+// fetchEvents loads the user's events from the repository, under a span
+// started from ctx. When a later page fails, the events read before it come
+// with a *PartialResultError.
+func (s *ActivityService) fetchEvents(ctx context.Context, username string) ([]github.GitHubEvent, error) {
+	ctx, span := s.tracer.Start(ctx, github.SpanFetch)
+	events, err := github.FetchWithContext(ctx, s.repository, username)
+	traceFetch(span, username, events, err)
+	if err != nil {
+		s.logger.Warn(github.LogFetchFailed, "user", username, "error", err)
+		if partial := partialFeed(username, events, err); partial != nil {
+			return events, partial
+		}
+		return nil, fmt.Errorf("failed to fetch events: %w", err)
+	}
+	s.logger.Debug(github.LogFetched, "user", username, "events", len(events))
+	return events, nil
+}
+
+// traceFetch records a fetch of username's events on span and ends it
+func traceFetch(span github.Span, username string, events []github.GitHubEvent, err error) {
+	span.SetAttributes("user", username, "events", len(events))
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.End()
+}
+
+// errPagingDone stops paging once enough of the feed has been read
+var errPagingDone = errors.New("paging done")
+
+// fetchMatching loads the user's events for filter. A paged repository is
+// read a page at a time until filter.MaxLimit activities match, or events
+// fall before filter.Since, so small limits don't download the whole feed;
+// other repositories, and unlimited filters, fetch everything.
+func (s *ActivityService) fetchMatching(
+	ctx context.Context,
+	username string,
+	filter EventFilter,
+) ([]github.GitHubEvent, error) {
+	paged, ok := s.repository.(github.PagedEventRepository)
+	if !ok || filter.MaxLimit <= 0 {
+		return s.fetchEvents(ctx, username)
+	}
+
+	ctx, span := s.tracer.Start(ctx, github.SpanFetch)
+	var events []github.GitHubEvent
+	err := paged.FetchEventPages(ctx, username, func(page []github.GitHubEvent) error {
+		events = append(events, page...)
+		if filter.satisfied(events) {
+			return errPagingDone
+		}
+		return nil
+	})
+	traced := err
+	if errors.Is(err, errPagingDone) {
+		traced = nil
+	}
+	traceFetch(span, username, events, traced)
+	if err != nil && !errors.Is(err, errPagingDone) {
+		s.logger.Warn(github.LogFetchFailed, "user", username, "error", err)
+		if partial := partialFeed(username, events, err); partial != nil {
+			return events, partial
+		}
+		return nil, fmt.Errorf("failed to fetch events: %w", err)
+	}
+	s.logger.Debug(github.LogFetched, "user", username, "events", len(events))
+	return events, nil
+}
+
+// UseSession routes event fetches through the session file at path and
+// returns the session so its max age can be set
+func (s *ActivityService) UseSession(path string) *SessionRepository {
+	next := s.repository
+	if session, ok := next.(*SessionRepository); ok {
+		next = session.next
+	}
+	session := NewSessionRepository(next, path)
+	session.now = s.now
+	s.repository = session
+	return session
+}
+
+// UseSharedCache routes event fetches through a per-user cache that is safe
+// for concurrent requests, as needed by serve mode
+func (s *ActivityService) UseSharedCache(ttl time.Duration) {
+	cache := NewSharedCacheRepository(s.repository, ttl)
+	cache.now = s.now
+	s.repository = cache
+}
+
+// UseMetrics reports every fetched feed to metrics
+func (s *ActivityService) UseMetrics(metrics *Metrics) {
+	s.repository = &observedRepository{next: s.repository, metrics: metrics}
+}
+
+// Now returns the time on the service clock
+func (s *ActivityService) Now() time.Time {
+	return s.now()
+}
+
+// SetLocation sets the time zone activity timestamps are shown in; nil keeps
+// the zone GitHub reported (UTC)
+func (s *ActivityService) SetLocation(location *time.Location) {
+	s.location = location
+}
+
+// SetLogger sets where the service reports what it fetched; nil discards it
+func (s *ActivityService) SetLogger(logger github.Logger) {
+	if logger == nil {
+		logger = github.NopLogger()
+	}
+	s.logger = logger
+}
+
+// SetDescriptions sets renderers that take precedence over the built-in
+// event descriptions, such as templates from the config file
+func (s *ActivityService) SetDescriptions(renderers github.EventRendererRegistry) {
+	s.descriptions = renderers
+}
+
+// SetEmoji sets the emoji prefixed to descriptions of each event type; nil
+// leaves descriptions undecorated
+func (s *ActivityService) SetEmoji(emoji map[github.EventType]string) {
+	s.emoji = emoji
+}
+
+// describe returns the event description, preferring the service's renderers
+func (s *ActivityService) describe(event *github.GitHubEvent) string {
+	description := s.descriptions.Describe(event)
+	if description == "" {
+		description = event.FormatDescription()
+	}
+	if emoji := s.emoji[github.EventType(event.Type)]; emoji != "" {
+		description = emoji + " " + description
+	}
+	return description
+}
+
+// calendarTime converts t for per-day and per-hour bucketing, defaulting to
+// the local zone
+func (s *ActivityService) calendarTime(t time.Time) time.Time {
+	if s.location != nil {
+		return t.In(s.location)
+	}
+	return t.Local()
+}
+
+// SetEnricher enables API enrichment of detailed activities
+func (s *ActivityService) SetEnricher(enricher *Enricher) {
+	s.enricher = enricher
+}
+
+// GetUserActivity fetches and filters user activities
+func (s *ActivityService) GetUserActivity(
+	username string,
+	filter EventFilter,
+) ([]ActivitySummary, error) {
+	return s.GetUserActivityContext(context.Background(), username, filter)
+}
+
+// GetUserActivityContext is GetUserActivity with its spans started from ctx
+func (s *ActivityService) GetUserActivityContext(
+	ctx context.Context,
+	username string,
+	filter EventFilter,
+) ([]ActivitySummary, error) {
+	if err := ValidateUsername(username); err != nil {
+		return nil, err
+	}
+
+	// Fetch events from repository; a partial feed is still summarized
+	events, err := s.fetchMatching(ctx, username, filter)
+	if _, partial := AsPartial(err); err != nil && !partial {
+		return nil, err
+	}
+
+	// Apply filtering and convert to summaries
+	summaries := make([]ActivitySummary, 0)
+	for _, group := range filter.groups(events) {
+		summaries = append(summaries, s.summarizeGroup(group))
+	}
+
+	return summaries, err
+}
+
+// GetUserActivityDetailed fetches activities with detailed information
+func (s *ActivityService) GetUserActivityDetailed(
+	username string,
+	filter EventFilter,
+) ([]DetailedActivity, error) {
+	return s.GetUserActivityDetailedContext(context.Background(), username, filter)
+}
+
+// GetUserActivityDetailedContext is GetUserActivityDetailed with its spans
+// started from ctx
+func (s *ActivityService) GetUserActivityDetailedContext(
+	ctx context.Context,
+	username string,
+	filter EventFilter,
+) ([]DetailedActivity, error) {
+	if err := ValidateUsername(username); err != nil {
+		return nil, err
+	}
+
+	// Fetch events; a partial feed is still detailed
+	events, err := s.fetchMatching(ctx, username, filter)
+	if _, partial := AsPartial(err); err != nil && !partial {
+		return nil, err
+	}
+
+	// Apply filtering and create detailed activities
+	activities := make([]DetailedActivity, 0)
+	matched := make([]github.GitHubEvent, 0)
+	for _, group := range filter.groups(events) {
+		activities = append(activities, s.detailGroup(group))
+		matched = append(matched, group[0])
+	}
+
+	if s.enricher != nil {
+		s.enricher.Enrich(matched, activities)
+	}
+
+	return activities, err
+}
+
+// ActivityPage is one page of a user's activities, newest first
+type ActivityPage struct {
+	Activities []ActivitySummary `json:"activities"`
+	NextCursor string            `json:"next_cursor,omitempty"` // Empty on the last page
+}
+
+// DetailedActivityPage is ActivityPage with detailed activities
+type DetailedActivityPage struct {
+	Activities []DetailedActivity `json:"activities"`
+	NextCursor string             `json:"next_cursor,omitempty"` // Empty on the last page
+}
+
+// pageMatching returns the page of matching events after the cursor token
+// (empty for the first page), filter.MaxLimit events long or the rest of the
+// feed when it is 0, and the token for the next page
+func (s *ActivityService) pageMatching(
+	ctx context.Context,
+	username string,
+	filter EventFilter,
+	cursor string,
+) ([]github.GitHubEvent, string, error) {
+	if err := ValidateUsername(username); err != nil {
+		return nil, "", err
+	}
+	// Cursors point anywhere in the feed, so it is read whole
+	events, err := s.fetchEvents(ctx, username)
+	if _, partial := AsPartial(err); err != nil && !partial {
+		return nil, "", err
+	}
+
+	matched := filter.matching(events)
+	size := filter.MaxLimit
+	if size <= 0 {
+		size = max(len(matched), 1)
+	}
+	page, next, pageErr := PageEvents(matched, cursor, size)
+	if pageErr != nil {
+		return nil, "", pageErr
+	}
+	return page, next, err
+}
+
+// GetUserActivityPage lists one page of a user's matching activities after
+// cursor, a token from a previous page's NextCursor or empty for the first.
+// Unlike offsets, cursors stay put while new events arrive. Spans start from
+// ctx.
+func (s *ActivityService) GetUserActivityPage(
+	ctx context.Context,
+	username string,
+	filter EventFilter,
+	cursor string,
+) (*ActivityPage, error) {
+	events, next, err := s.pageMatching(ctx, username, filter, cursor)
+	if _, partial := AsPartial(err); err != nil && !partial {
+		return nil, err
+	}
+
+	page := &ActivityPage{Activities: make([]ActivitySummary, 0, len(events)), NextCursor: next}
+	for _, event := range events {
+		page.Activities = append(page.Activities, s.Summarize(event))
+	}
+	return page, err
+}
+
+// GetUserActivityDetailedPage is GetUserActivityPage with detailed activities
+func (s *ActivityService) GetUserActivityDetailedPage(
+	ctx context.Context,
+	username string,
+	filter EventFilter,
+	cursor string,
+) (*DetailedActivityPage, error) {
+	events, next, err := s.pageMatching(ctx, username, filter, cursor)
+	if _, partial := AsPartial(err); err != nil && !partial {
+		return nil, err
+	}
+
+	page := &DetailedActivityPage{Activities: make([]DetailedActivity, 0, len(events)), NextCursor: next}
+	for _, event := range events {
+		page.Activities = append(page.Activities, s.SummarizeDetailed(event))
+	}
+	if s.enricher != nil {
+		s.enricher.Enrich(events, page.Activities)
+	}
+	return page, err
+}
+
+// ActivitySummary represents a summarized view of an activity
+type ActivitySummary struct {
+	Description string            `json:"description"`
+	Type        string            `json:"type"`
+	Repository  string            `json:"repository"`
+	Actor       string            `json:"actor"`
+	Timestamp   string            `json:"timestamp"`
+	CreatedAt   time.Time         `json:"created_at"`
+	Fields      map[string]string `json:"fields,omitempty"`
+	Warnings    []string          `json:"warnings,omitempty"` // Why the payload could not be read, if it could not
+
+	listedAt time.Time // The service clock's time when summarized, if it was
+}
+
+// RelativeTime returns the event time as "3 days ago", falling back to
+// Timestamp when the creation time is unknown. It counts from the service
+// clock's time when the summary was made, or from now for summaries built
+// by hand.
+func (a ActivitySummary) RelativeTime() string {
+	if a.CreatedAt.IsZero() {
+		return a.Timestamp
+	}
+	now := a.listedAt
+	if now.IsZero() {
+		now = time.Now()
+	}
+	return HumanizeTime(a.CreatedAt, now)
+}
+
+// DetailedActivity represents a detailed view of an activity
+type DetailedActivity struct {
+	ActivitySummary
+	EventID      string            `json:"event_id"`
+	ActorLogin   string            `json:"actor"`
+	CommitCount  int               `json:"commit_count,omitempty"`
+	Commits      []CommitSummary   `json:"commits,omitempty"`
+	ExtraDetails map[string]string `json:"extra_details,omitempty"`
+}
+
+// CommitSummary represents a simplified commit
+type CommitSummary struct {
+	SHA     string `json:"sha"`
+	Message string `json:"message"`
+	Author  string `json:"author,omitempty"`
+}
+
+// Summarize creates a summary from an event, as listed by GetUserActivity
+func (s *ActivityService) Summarize(event github.GitHubEvent) ActivitySummary {
+	createdAt := event.CreatedAt
+	if s.location != nil {
+		createdAt = createdAt.In(s.location)
+	}
+
+	summary := ActivitySummary{
+		Description: s.describe(&event),
+		Type:        event.Type,
+		Repository:  event.Repo.Name,
+		Actor:       event.Actor.Login,
+		Timestamp:   createdAt.Format("2006-01-02 15:04:05"),
+		CreatedAt:   createdAt,
+		Fields:      event.DescriptionFields(),
+		listedAt:    s.now(),
+	}
+	if _, err := event.ParsedPayload(); err != nil {
+		s.logger.Warn(github.LogParseWarning, "event_id", event.ID, "type", event.Type, "error", err)
+		summary.Warnings = append(summary.Warnings, err.Error())
+	}
+	return summary
+}
+
+// releaseNotesLength is how much of a release's notes detailed output shows
+const releaseNotesLength = 100
+
+// SummarizeDetailed creates a detailed activity from an event, as listed by
+// GetUserActivityDetailed
+func (s *ActivityService) SummarizeDetailed(event github.GitHubEvent) DetailedActivity {
+	activity := DetailedActivity{
+		ActivitySummary: s.Summarize(event),
+		EventID:         event.ID,
+		ActorLogin:      event.Actor.Login,
+		ExtraDetails:    make(map[string]string),
+	}
+
+	// Add type-specific details
+	switch github.EventType(event.Type) {
+	case github.EventTypePush:
+		commits, err := event.GetCommitDetails()
+		if err == nil {
+			activity.CommitCount = len(commits)
+			for _, commit := range commits {
+				activity.Commits = append(activity.Commits, CommitSummary{
+					SHA:     commit.GetShortSHA(),
+					Message: github.TruncateMessage(commit.GetFirstLine(), 60),
+					Author:  commit.Author.Name,
+				})
+			}
+		}
+
+	case github.EventTypeIssues:
+		if payload, ok := event.TypedPayload().(*github.IssuesPayload); ok {
+			activity.ExtraDetails["action"] = payload.Action
+			activity.ExtraDetails["state"] = payload.Issue.State
+			addTriageDetails(activity.ExtraDetails,
+				payload.Issue.Labels, payload.Issue.Assignees, payload.Issue.Milestone)
+		}
+
+	case github.EventTypePullRequest:
+		if payload, ok := event.TypedPayload().(*github.PullRequestPayload); ok {
+			pr := payload.PullRequest
+			activity.ExtraDetails["merged"] = strconv.FormatBool(pr.Merged)
+			if pr.Head.Ref != "" && pr.Base.Ref != "" {
+				activity.ExtraDetails["branches"] = fmt.Sprintf("%s -> %s", pr.Head.Ref, pr.Base.Ref)
+			}
+			// The events feed trims pull request objects, so line counts
+			// are only shown when GitHub included them.
+			if pr.Additions != nil && pr.Deletions != nil {
+				activity.ExtraDetails["changes"] = fmt.Sprintf("+%d/-%d", *pr.Additions, *pr.Deletions)
+			}
+			addTriageDetails(activity.ExtraDetails, pr.Labels, pr.Assignees, pr.Milestone)
+		}
+
+	case github.EventTypeRelease:
+		if payload, ok := event.TypedPayload().(*github.ReleasePayload); ok {
+			activity.ExtraDetails["tag"] = payload.Release.TagName
+			if payload.Release.Name != "" {
+				activity.ExtraDetails["name"] = payload.Release.Name
+			}
+			activity.ExtraDetails["prerelease"] = strconv.FormatBool(payload.Release.Prerelease)
+			if payload.Release.Draft {
+				activity.ExtraDetails["draft"] = "true"
+			}
+			if notes := strings.Join(strings.Fields(payload.Release.Body), " "); notes != "" {
+				activity.ExtraDetails["notes"] = github.TruncateMessage(notes, releaseNotesLength)
+			}
+			if payload.Release.Prerelease {
+				activity.Description += " (prerelease)"
+			}
+		}
+
+	case github.EventTypeFork:
+		if payload, ok := event.TypedPayload().(*github.ForkPayload); ok {
+			activity.ExtraDetails["forkee"] = payload.Forkee.FullName
+		}
+	}
+
+	return activity
+}
+
+// addTriageDetails adds the labels, assignees and milestone an issue or pull
+// request carries, leaving out those it has none of
+func addTriageDetails(
+	details map[string]string,
+	labels []github.Label,
+	assignees []github.Actor,
+	milestone *github.Milestone,
+) {
+	if len(labels) > 0 {
+		names := make([]string, 0, len(labels))
+		for _, label := range labels {
+			names = append(names, label.Name)
+		}
+		details["labels"] = strings.Join(names, ", ")
+	}
+	if len(assignees) > 0 {
+		logins := make([]string, 0, len(assignees))
+		for _, assignee := range assignees {
+			logins = append(logins, assignee.Login)
+		}
+		details["assignees"] = strings.Join(logins, ", ")
+	}
+	if milestone != nil && milestone.Title != "" {
+		details["milestone"] = milestone.Title
+	}
+}
+
+// GetEventTypeStatistics returns statistics about event types
+func (s *ActivityService) GetEventTypeStatistics(username string) (map[string]int, error) {
+	events, err := s.fetchEvents(context.Background(), username)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make(map[string]int)
+	for _, event := range events {
+		stats[event.Type]++
+	}
+
+	return stats, nil
+}
+
+// GetRecentRepositories returns a list of recently active repositories
+func (s *ActivityService) GetRecentRepositories(username string, limit int) ([]string, error) {
+	events, err := s.fetchEvents(context.Background(), username)
+	if err != nil {
+		return nil, err
+	}
+
+	// Use a map to track unique repositories while preserving order
+	repoMap := make(map[string]bool)
+	repos := make([]string, 0)
+
+	for _, event := range events {
+		if !repoMap[event.Repo.Name] {
+			repoMap[event.Repo.Name] = true
+			repos = append(repos, event.Repo.Name)
+
+			if limit > 0 && len(repos) >= limit {
+				break
+			}
+		}
+	}
+
+	return repos, nil
+}
+
+// GetTimelineGap reports whether the user's feed was likely truncated by GitHub
+func (s *ActivityService) GetTimelineGap(username string) (*TimelineGap, error) {
+	events, err := s.fetchEvents(context.Background(), username)
+	if err != nil {
+		return nil, err
+	}
+	return DetectTimelineGap(events, s.now()), nil
+}
+
+// GetDailyActivity counts matching events per calendar day in the service's
+// time zone, keyed by
+// date in 2006-01-02 form. The filter's limit is ignored so every fetched
+// event is counted.
+func (s *ActivityService) GetDailyActivity(
+	username string,
+	filter EventFilter,
+) (map[string]int, error) {
+	events, err := s.fetchEvents(context.Background(), username)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int)
+	for _, event := range events {
+		if !filter.Matches(event) {
+			continue
+		}
+		counts[s.calendarTime(event.CreatedAt).Format("2006-01-02")]++
+	}
+
+	return counts, nil
+}
+
+// ActivityHistogram buckets events by weekday and hour of day
+type ActivityHistogram struct {
+	Zone      string  `json:"zone"` // Time zone the buckets use
+	Total     int     `json:"total"`
+	ByWeekday [7]int  `json:"by_weekday"` // Indexed by time.Weekday, Sunday first
+	ByHour    [24]int `json:"by_hour"`    // Indexed by hour, 0-23
+}
+
+// GetActivityHistogram buckets every fetched event by weekday and hour
+func (s *ActivityService) GetActivityHistogram(username string) (*ActivityHistogram, error) {
+	events, err := s.fetchEvents(context.Background(), username)
+	if err != nil {
+		return nil, err
+	}
+
+	histogram := &ActivityHistogram{Zone: "Local"}
+	if s.location != nil {
+		histogram.Zone = s.location.String()
+	}
+	for _, event := range events {
+		created := s.calendarTime(event.CreatedAt)
+		histogram.ByWeekday[created.Weekday()]++
+		histogram.ByHour[created.Hour()]++
+		histogram.Total++
+	}
+
+	return histogram, nil
+}
+
+// spikePeriods maps -spikes values to a period and its baseline length
+var spikePeriods = map[string]struct {
+	period    time.Duration
+	baselines int
+}{
+	"hour": {time.Hour, 24},
+	"day":  {24 * time.Hour, 7},
+}
+
+// GetActivitySpikes reports repositories, and the user overall, whose event
+// volume in the current hour or day far exceeds the preceding day or week
+func (s *ActivityService) GetActivitySpikes(username, period string) ([]Spike, error) {
+	settings, ok := spikePeriods[period]
+	if !ok {
+		return nil, fmt.Errorf("invalid spike period: %s (use hour or day)", period)
+	}
+
+	events, err := s.fetchEvents(context.Background(), username)
+	if err != nil {
+		return nil, err
+	}
+
+	return DetectSpikes(events, s.now(), settings.period, settings.baselines), nil
+}
+
+// GetStreaks measures the user's current and longest streaks of active days,
+// in the service's zone. Only days within the fetched feed can be counted.
+func (s *ActivityService) GetStreaks(username string) (*ActivityStreaks, error) {
+	events, err := s.fetchEvents(context.Background(), username)
+	if err != nil {
+		return nil, err
+	}
+
+	days := make([]time.Time, 0, len(events))
+	for _, event := range events {
+		days = append(days, startOfDay(s.calendarTime(event.CreatedAt)))
+	}
+	streaks := ComputeStreaks(days, startOfDay(s.calendarTime(s.now())))
+	return &streaks, nil
+}
+
+// GetCommitAuthors counts the commits of the matching pushes by author,
+// across the whole fetched feed rather than the first filter.MaxLimit events
+func (s *ActivityService) GetCommitAuthors(username string, filter EventFilter) ([]AuthorCount, error) {
+	events, err := s.fetchEvents(context.Background(), username)
+	if err != nil {
+		return nil, err
+	}
+	return CountCommitAuthors(filter.matching(events)), nil
+}
+
+// startOfDay returns midnight of t's day in t's zone
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// PeriodSummary totals a user's contributions over the last few days
+type PeriodSummary struct {
+	Username             string    `json:"username"`
+	Since                time.Time `json:"since"`
+	Until                time.Time `json:"until"`
+	Events               int       `json:"events"`
+	Commits              int       `json:"commits"`
+	PushedRepos          int       `json:"pushed_repos"`
+	PullRequestsOpened   int       `json:"pull_requests_opened"`
+	PullRequestsMerged   int       `json:"pull_requests_merged"`
+	PullRequestsReviewed int       `json:"pull_requests_reviewed"` // Distinct pull requests
+	IssuesOpened         int       `json:"issues_opened"`
+	IssuesClosed         int       `json:"issues_closed"`
+	Releases             int       `json:"releases"`
+}
+
+// GetPeriodSummary totals matching events from the last days days
+func (s *ActivityService) GetPeriodSummary(
+	username string,
+	filter EventFilter,
+	days int,
+) (*PeriodSummary, error) {
+	// A partial feed is still totalled
+	events, err := s.fetchEvents(context.Background(), username)
+	if _, partial := AsPartial(err); err != nil && !partial {
+		return nil, err
+	}
+
+	now := s.now()
+	summary := &PeriodSummary{
+		Username: username,
+		Since:    now.AddDate(0, 0, -days),
+		Until:    now,
+	}
+	pushed := make(map[string]bool)
+	reviewed := make(map[string]bool)
+
+	for _, event := range events {
+		if event.CreatedAt.Before(summary.Since) || !filter.Matches(event) {
+			continue
+		}
+		summary.Events++
+
+		switch github.EventType(event.Type) {
+		case github.EventTypePush:
+			if payload, ok := event.TypedPayload().(*github.PushPayload); ok {
+				summary.Commits += payload.Size
+				pushed[event.Repo.Name] = true
+			}
+
+		case github.EventTypePullRequest:
+			payload, ok := event.TypedPayload().(*github.PullRequestPayload)
+			if !ok {
+				continue
+			}
+			switch {
+			case payload.Action == "opened":
+				summary.PullRequestsOpened++
+			case payload.Action == "closed" && payload.PullRequest.Merged:
+				summary.PullRequestsMerged++
+			}
+
+		case github.EventTypePullRequestReview:
+			if payload, ok := event.TypedPayload().(*github.PullRequestReviewPayload); ok {
+				reviewed[fmt.Sprintf("%s#%d", event.Repo.Name, payload.PullRequest.Number)] = true
+			}
+
+		case github.EventTypeIssues:
+			payload, ok := event.TypedPayload().(*github.IssuesPayload)
+			if !ok {
+				continue
+			}
+			switch payload.Action {
+			case "opened":
+				summary.IssuesOpened++
+			case "closed":
+				summary.IssuesClosed++
+			}
+
+		case github.EventTypeRelease:
+			summary.Releases++
+		}
+	}
+
+	summary.PushedRepos = len(pushed)
+	summary.PullRequestsReviewed = len(reviewed)
+	return summary, err
+}
+
+// ReviewRequest represents a pull request the user was asked to review
+type ReviewRequest struct {
+	Repository string
+	Number     int
+	Title      string
+	UpdatedAt  time.Time // The search API does not date the request itself
+}
+
+// ReviewBurndown compares review requests with reviews submitted
+type ReviewBurndown struct {
+	Requested   int // Reviewed plus outstanding
+	Reviewed    int
+	Outstanding []ReviewRequest
+}
+
+// reviewSearchSize is how many outstanding requests a burndown lists
+const reviewSearchSize = 100
+
+// reviewSearch is the part of a /search/issues response a burndown reads
+type reviewSearch struct {
+	TotalCount int `json:"total_count"`
+	Items      []struct {
+		Number        int       `json:"number"`
+		Title         string    `json:"title"`
+		RepositoryURL string    `json:"repository_url"`
+		UpdatedAt     time.Time `json:"updated_at"`
+	} `json:"items"`
+}
+
+// SetReviewSearcher sets where GetReviewBurndown runs its pull request
+// searches, normally the GitHub API repository
+func (s *ActivityService) SetReviewSearcher(searcher ResourceFetcher) {
+	s.searcher = searcher
+}
+
+// searchPullRequests runs an issue search for the pull requests matching
+// query, updated since the given time unless it is zero
+func (s *ActivityService) searchPullRequests(query string, since time.Time) (*reviewSearch, error) {
+	if !since.IsZero() {
+		query += " updated:>=" + since.UTC().Format("2006-01-02")
+	}
+	path := fmt.Sprintf("/search/issues?q=%s&sort=updated&per_page=%d", url.QueryEscape(query), reviewSearchSize)
+	data, err := s.searcher.FetchResource(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search pull requests: %w", err)
+	}
+	var result reviewSearch
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to search pull requests: %w", err)
+	}
+	return &result, nil
+}
+
+// GetReviewBurndown reports the open pull requests still awaiting the user's
+// review and how many others they reviewed, among those updated within the
+// window. A zero window covers every pull request. Requests live on the pull
+// requests rather than in the reviewer's feed, so they are searched for.
+func (s *ActivityService) GetReviewBurndown(
+	username string,
+	window time.Duration,
+) (*ReviewBurndown, error) {
+	if err := ValidateUsername(username); err != nil {
+		return nil, err
+	}
+	if s.searcher == nil {
+		return nil, errors.New("review requests need the GitHub search API")
+	}
+
+	var since time.Time
+	if window > 0 {
+		since = s.now().Add(-window)
+	}
+
+	// A submitted review clears the request, so pending ones are outstanding
+	pending, err := s.searchPullRequests("is:pr is:open review-requested:"+username, since)
+	if err != nil {
+		return nil, err
+	}
+	reviewed, err := s.searchPullRequests("is:pr reviewed-by:"+username+" -author:"+username, since)
+	if err != nil {
+		return nil, err
+	}
+
+	burndown := &ReviewBurndown{
+		Requested:   pending.TotalCount + reviewed.TotalCount,
+		Reviewed:    reviewed.TotalCount,
+		Outstanding: make([]ReviewRequest, 0, len(pending.Items)),
+	}
+	for _, item := range pending.Items {
+		_, repository, _ := strings.Cut(item.RepositoryURL, "/repos/")
+		burndown.Outstanding = append(burndown.Outstanding, ReviewRequest{
+			Repository: repository,
+			Number:     item.Number,
+			Title:      item.Title,
+			UpdatedAt:  item.UpdatedAt,
+		})
+	}
+	return burndown, nil
+}
+
+// ActivityOptions represents options for activity queries
+type ActivityOptions struct {
+	EventType    string
+	Limit        int
+	ShowDetailed bool
+	Days         int
+	Retries      int
+	Timezone     string
+	Spikes       string
+	Repo         string
+	Actor        string
+	Action       string
+	GroupBy      string
+	Since        string
+	Until        string
+}
+
+// DefaultActivityOptions returns default options
+func DefaultActivityOptions() ActivityOptions {
+	return ActivityOptions{
+		EventType:    "",
+		Limit:        30,
+		ShowDetailed: false,
+	}
+}
+
+// MaxUsernameLength is the longest login GitHub accepts
+const MaxUsernameLength = 39
+
+// ErrInvalidUsername is wrapped by ValidateUsername errors, so front ends
+// can tell a typo from a user that does not exist
+var ErrInvalidUsername = errors.New("invalid username")
+
+// ValidateUsername checks a login against GitHub's rules, so obviously wrong
+// input is reported without an API call ending in a 404. Older accounts may
+// break the rules new ones follow on hyphens, so only the rules every
+// account follows are checked; app logins such as "dependabot[bot]" pass.
+func ValidateUsername(username string) error {
+	if strings.TrimSpace(username) == "" {
+		return fmt.Errorf("username cannot be empty")
+	}
+	login := strings.TrimSuffix(username, "[bot]")
+	switch {
+	case len(login) > MaxUsernameLength:
+		return fmt.Errorf("%w %q: longer than %d characters", ErrInvalidUsername, username, MaxUsernameLength)
+	case login == "" || strings.HasPrefix(login, "-"):
+		return fmt.Errorf("%w %q: must start with a letter or digit", ErrInvalidUsername, username)
+	}
+	if i := strings.IndexFunc(login, func(r rune) bool {
+		return r != '-' && (r > unicode.MaxASCII || !unicode.IsLetter(r) && !unicode.IsDigit(r))
+	}); i >= 0 {
+		return fmt.Errorf("%w %q: %q is not allowed; use letters, digits and hyphens",
+			ErrInvalidUsername, username, []rune(login[i:])[0])
+	}
+	return nil
+}
+
+// Validate validates the activity options
+func (o *ActivityOptions) Validate() error {
+	if o.Limit < 0 {
+		return fmt.Errorf("limit cannot be negative")
+	}
+
+	if o.Days < 0 {
+		return fmt.Errorf("days cannot be negative")
+	}
+
+	if o.Retries < 0 {
+		return fmt.Errorf("retries cannot be negative")
+	}
+
+	location, err := LoadTimezone(o.Timezone)
+	if err != nil {
+		return err
+	}
+
+	if _, _, err := ParseDateRange(o.Since, o.Until, location); err != nil {
+		return err
+	}
+
+	if _, ok := spikePeriods[o.Spikes]; o.Spikes != "" && !ok {
+		return fmt.Errorf("invalid spike period: %s (use hour or day)", o.Spikes)
+	}
+
+	if o.GroupBy != "" && o.GroupBy != "day" {
+		return fmt.Errorf("invalid grouping: %s (use day)", o.GroupBy)
+	}
+
+	if o.Repo != "" {
+		owner, name, hasName := strings.Cut(o.Repo, "/")
+		if owner == "" || (hasName && (name == "" || strings.Contains(name, "/"))) {
+			return fmt.Errorf("invalid repo filter: %s (use owner or owner/repo)", o.Repo)
+		}
+	}
+
+	if o.Actor != "" && strings.ContainsAny(o.Actor, "/ ") {
+		return fmt.Errorf("invalid actor filter: %s (use a GitHub login)", o.Actor)
+	}
+
+	if o.Action != "" && strings.ContainsFunc(o.Action, func(r rune) bool { return !unicode.IsLetter(r) && r != '_' }) {
+		return fmt.Errorf("invalid action filter: %s (e.g. opened, closed, merged, created, published)", o.Action)
+	}
+
+	if o.EventType != "" {
+		// Validate event type
+		validTypes := github.GetAvailableEventTypes()
+		found := false
+		for eventType := range validTypes {
+			if strings.EqualFold(o.EventType, string(eventType)) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fmt.Errorf("invalid event type: %s", o.EventType)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,1084 @@
+package activity
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+func TestValidateUsername(t *testing.T) {
+	tests := []struct {
+		username string
+		wantErr  string
+	}{
+		{"octocat", ""},
+		{"Octo-Cat42", ""},
+		{"a", ""},
+		{"old--style-", ""},
+		{"dependabot[bot]", ""},
+		{strings.Repeat("a", 39), ""},
+		{"", "username cannot be empty"},
+		{"  ", "username cannot be empty"},
+		{strings.Repeat("a", 40), `invalid username "` + strings.Repeat("a", 40) + `": longer than 39 characters`},
+		{"-octocat", `invalid username "-octocat": must start with a letter or digit`},
+		{"octo_cat", `invalid username "octo_cat": '_' is not allowed; use letters, digits and hyphens`},
+		{"octo cat", `invalid username "octo cat": ' ' is not allowed; use letters, digits and hyphens`},
+		{"octo/cat", `invalid username "octo/cat": '/' is not allowed; use letters, digits and hyphens`},
+		{"café", `invalid username "café": 'é' is not allowed; use letters, digits and hyphens`},
+		{"[bot]", `invalid username "[bot]": must start with a letter or digit`},
+	}
+	for _, tt := range tests {
+		err := ValidateUsername(tt.username)
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("ValidateUsername(%q) = %v, want nil", tt.username, err)
+			}
+			continue
+		}
+		if err == nil || err.Error() != tt.wantErr {
+			t.Errorf("ValidateUsername(%q) = %v, want %s", tt.username, err, tt.wantErr)
+		}
+		if strings.TrimSpace(tt.username) != "" && !errors.Is(err, ErrInvalidUsername) {
+			t.Errorf("ValidateUsername(%q) should wrap ErrInvalidUsername", tt.username)
+		}
+	}
+}
+
+func TestActivityService_InvalidUsernameSkipsAPI(t *testing.T) {
+	repo := github.NewMockEventRepository(nil, github.ErrUserNotFound)
+	service := NewActivityService(repo)
+
+	if _, err := service.GetUserActivity("not a user", EventFilter{}); !errors.Is(err, ErrInvalidUsername) {
+		t.Errorf("GetUserActivity() error = %v, want ErrInvalidUsername rather than the API's answer", err)
+	}
+	if _, err := service.GetUserActivityDetailed("not a user", EventFilter{}); !errors.Is(err, ErrInvalidUsername) {
+		t.Errorf("GetUserActivityDetailed() error = %v, want ErrInvalidUsername", err)
+	}
+}
+
+func TestActivityService_GetUserActivity(t *testing.T) {
+	tests := []struct {
+		name          string
+		username      string
+		filter        EventFilter
+		mockEvents    []github.GitHubEvent
+		mockError     error
+		expectedError bool
+		expectedCount int
+	}{
+		{
+			name:     "successful fetch with no filter",
+			username: "testuser",
+			filter:   EventFilter{},
+			mockEvents: []github.GitHubEvent{
+				{ID: "1", Type: "PushEvent", Repo: github.Repo{Name: "user/repo1"}},
+				{ID: "2", Type: "IssuesEvent", Repo: github.Repo{Name: "user/repo2"}},
+			},
+			expectedError: false,
+			expectedCount: 2,
+		},
+		{
+			name:     "with type filter",
+			username: "testuser",
+			filter:   EventFilter{Type: "PushEvent"},
+			mockEvents: []github.GitHubEvent{
+				{ID: "1", Type: "PushEvent", Repo: github.Repo{Name: "user/repo1"}},
+				{ID: "2", Type: "IssuesEvent", Repo: github.Repo{Name: "user/repo2"}},
+				{ID: "3", Type: "PushEvent", Repo: github.Repo{Name: "user/repo3"}},
+			},
+			expectedError: false,
+			expectedCount: 2,
+		},
+		{
+			name:     "with limit",
+			username: "testuser",
+			filter:   EventFilter{MaxLimit: 1},
+			mockEvents: []github.GitHubEvent{
+				{ID: "1", Type: "PushEvent", Repo: github.Repo{Name: "user/repo1"}},
+				{ID: "2", Type: "IssuesEvent", Repo: github.Repo{Name: "user/repo2"}},
+			},
+			expectedError: false,
+			expectedCount: 1,
+		},
+		{
+			name:     "zero limit is unbounded",
+			username: "testuser",
+			filter:   EventFilter{MaxLimit: 0},
+			mockEvents: []github.GitHubEvent{
+				{ID: "1", Type: "PushEvent", Repo: github.Repo{Name: "user/repo1"}},
+				{ID: "2", Type: "IssuesEvent", Repo: github.Repo{Name: "user/repo2"}},
+				{ID: "3", Type: "WatchEvent", Repo: github.Repo{Name: "user/repo3"}},
+			},
+			expectedError: false,
+			expectedCount: 3,
+		},
+		{
+			name:          "empty username",
+			username:      "",
+			filter:        EventFilter{},
+			mockEvents:    nil,
+			expectedError: true,
+			expectedCount: 0,
+		},
+		{
+			name:          "repository error",
+			username:      "testuser",
+			filter:        EventFilter{},
+			mockError:     errors.New("API error"),
+			expectedError: true,
+			expectedCount: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := github.NewMockEventRepository(tt.mockEvents, tt.mockError)
+			service := NewActivityService(repo)
+
+			activities, err := service.GetUserActivity(tt.username, tt.filter)
+
+			if tt.expectedError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+			} else {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+
+				if len(activities) != tt.expectedCount {
+					t.Errorf("Got %d activities, want %d", len(activities), tt.expectedCount)
+				}
+			}
+		})
+	}
+}
+
+func TestActivityService_GetUserActivity_StopsPaging(t *testing.T) {
+	push := func(id, repo, branch string) github.GitHubEvent {
+		return github.GitHubEvent{
+			ID:      id,
+			Type:    "PushEvent",
+			Repo:    github.Repo{Name: repo},
+			Payload: json.RawMessage(`{"size": 1, "ref": "refs/heads/` + branch + `"}`),
+		}
+	}
+	pages := [][]github.GitHubEvent{
+		{push("1", "user/a", "main"), {ID: "2", Type: "WatchEvent", Repo: github.Repo{Name: "user/b"}}},
+		{push("3", "user/a", "main"), push("4", "user/a", "main")},
+		{push("5", "user/a", "dev"), push("6", "user/c", "main")},
+	}
+
+	tests := []struct {
+		name    string
+		filter  EventFilter
+		count   int
+		fetched int
+	}{
+		{"limit filled on the first page", EventFilter{MaxLimit: 2}, 2, 1},
+		{"filters read on", EventFilter{Type: "PushEvent", MaxLimit: 3}, 3, 2},
+		{"collapsed run may continue", EventFilter{Collapse: true, MaxLimit: 3}, 3, 3},
+		{"feed shorter than the limit", EventFilter{MaxLimit: 10}, 6, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &pagedRepository{pages: pages}
+			summaries, err := NewActivityService(repo).GetUserActivity("testuser", tt.filter)
+			if err != nil {
+				t.Fatalf("GetUserActivity() error = %v", err)
+			}
+			if len(summaries) != tt.count {
+				t.Errorf("Got %d activities, want %d", len(summaries), tt.count)
+			}
+			if repo.fetched != tt.fetched {
+				t.Errorf("Fetched %d pages, want %d", repo.fetched, tt.fetched)
+			}
+		})
+	}
+}
+
+func TestActivityService_GetUserActivityDetailed(t *testing.T) {
+	pushEvent := github.GitHubEvent{
+		ID:        "1",
+		Type:      "PushEvent",
+		Repo:      github.Repo{Name: "user/repo"},
+		Actor:     github.Actor{Login: "testuser"},
+		CreatedAt: time.Now(),
+		Payload: json.RawMessage(`{
+			"size": 2,
+			"commits": [
+				{"sha": "abc123", "message": "First commit", "author": {"name": "John"}},
+				{"sha": "def456", "message": "Second commit with a very long message that should be truncated", "author": {"name": "Jane"}}
+			],
+			"ref": "refs/heads/main"
+		}`),
+	}
+
+	repo := github.NewMockEventRepository([]github.GitHubEvent{pushEvent}, nil)
+	service := NewActivityService(repo)
+
+	activities, err := service.GetUserActivityDetailed("testuser", EventFilter{})
+	if err != nil {
+		t.Fatalf("GetUserActivityDetailed() error = %v", err)
+	}
+
+	if len(activities) != 1 {
+		t.Fatalf("Expected 1 activity, got %d", len(activities))
+	}
+
+	activity := activities[0]
+
+	// Check basic fields
+	if activity.EventID != "1" {
+		t.Errorf("EventID = %v, want %v", activity.EventID, "1")
+	}
+
+	if activity.ActorLogin != "testuser" {
+		t.Errorf("ActorLogin = %v, want %v", activity.ActorLogin, "testuser")
+	}
+
+	// Check commit details
+	if activity.CommitCount != 2 {
+		t.Errorf("CommitCount = %v, want %v", activity.CommitCount, 2)
+	}
+
+	if len(activity.Commits) != 2 {
+		t.Errorf("Commits length = %v, want %v", len(activity.Commits), 2)
+	}
+
+	// Check first commit
+	if activity.Commits[0].SHA != "abc123" {
+		t.Errorf("First commit SHA = %v, want %v", activity.Commits[0].SHA, "abc123")
+	}
+
+	// Check message truncation
+	if len(activity.Commits[1].Message) > 60 {
+		t.Errorf(
+			"Commit message should be truncated to 60 chars, got %d",
+			len(activity.Commits[1].Message),
+		)
+	}
+}
+
+func TestActivityService_GetUserActivityDetailed_ExtraDetails(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    github.GitHubEvent
+		expected map[string]string
+	}{
+		{
+			name: "IssuesEvent",
+			event: github.GitHubEvent{
+				Type: "IssuesEvent",
+				Payload: json.RawMessage(`{
+					"action": "opened",
+					"issue": {"number": 1, "state": "open", "labels": [{"name": "bug"}, {"name": "ui"}]}
+				}`),
+			},
+			expected: map[string]string{"action": "opened", "state": "open", "labels": "bug, ui"},
+		},
+		{
+			name: "PullRequestEvent with line counts",
+			event: github.GitHubEvent{
+				Type: "PullRequestEvent",
+				Payload: json.RawMessage(`{
+					"action": "closed",
+					"pull_request": {
+						"number": 2, "merged": true, "additions": 10, "deletions": 3,
+						"head": {"ref": "feature"}, "base": {"ref": "main"}
+					}
+				}`),
+			},
+			expected: map[string]string{"merged": "true", "branches": "feature -> main", "changes": "+10/-3"},
+		},
+		{
+			name: "IssuesEvent with assignees and milestone",
+			event: github.GitHubEvent{
+				Type: "IssuesEvent",
+				Payload: json.RawMessage(`{
+					"action": "assigned",
+					"issue": {
+						"number": 4, "state": "open", "labels": [{"name": "bug"}],
+						"assignees": [{"login": "alice"}, {"login": "bob"}],
+						"milestone": {"title": "v1.2"}
+					}
+				}`),
+			},
+			expected: map[string]string{
+				"action": "assigned", "state": "open", "labels": "bug",
+				"assignees": "alice, bob", "milestone": "v1.2",
+			},
+		},
+		{
+			name: "PullRequestEvent with triage details",
+			event: github.GitHubEvent{
+				Type: "PullRequestEvent",
+				Payload: json.RawMessage(`{
+					"action": "opened",
+					"pull_request": {
+						"number": 5, "labels": [{"name": "enhancement"}],
+						"assignees": [{"login": "alice"}], "milestone": {"title": "v2.0"}
+					}
+				}`),
+			},
+			expected: map[string]string{
+				"merged": "false", "labels": "enhancement", "assignees": "alice", "milestone": "v2.0",
+			},
+		},
+		{
+			name: "PullRequestEvent without line counts",
+			event: github.GitHubEvent{
+				Type:    "PullRequestEvent",
+				Payload: json.RawMessage(`{"action": "opened", "pull_request": {"number": 3}}`),
+			},
+			expected: map[string]string{"merged": "false"},
+		},
+		{
+			name: "ReleaseEvent",
+			event: github.GitHubEvent{
+				Type: "ReleaseEvent",
+				Payload: json.RawMessage(`{
+					"action": "published",
+					"release": {"tag_name": "v2.0.0-rc1", "name": "RC 1", "prerelease": true}
+				}`),
+			},
+			expected: map[string]string{"tag": "v2.0.0-rc1", "name": "RC 1", "prerelease": "true"},
+		},
+		{
+			name: "ReleaseEvent with notes",
+			event: github.GitHubEvent{
+				Type: "ReleaseEvent",
+				Payload: json.RawMessage(`{
+					"action": "published",
+					"release": {"tag_name": "v2.0.0", "draft": true, "body": "## Changes\r\n\r\n- Faster   paging"}
+				}`),
+			},
+			expected: map[string]string{
+				"tag": "v2.0.0", "prerelease": "false", "draft": "true", "notes": "## Changes - Faster paging",
+			},
+		},
+		{
+			name: "ForkEvent",
+			event: github.GitHubEvent{
+				Type:    "ForkEvent",
+				Payload: json.RawMessage(`{"forkee": {"full_name": "me/repo"}}`),
+			},
+			expected: map[string]string{"forkee": "me/repo"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.event.CreatedAt = time.Now()
+			service := NewActivityService(github.NewMockEventRepository([]github.GitHubEvent{tt.event}, nil))
+
+			activities, err := service.GetUserActivityDetailed("testuser", EventFilter{})
+			if err != nil {
+				t.Fatalf("GetUserActivityDetailed() error = %v", err)
+			}
+			if len(activities) != 1 {
+				t.Fatalf("Expected 1 activity, got %d", len(activities))
+			}
+			if !reflect.DeepEqual(activities[0].ExtraDetails, tt.expected) {
+				t.Errorf("ExtraDetails = %v, want %v", activities[0].ExtraDetails, tt.expected)
+			}
+		})
+	}
+}
+
+func TestActivityService_SummarizeDetailed_Release(t *testing.T) {
+	service := NewActivityService(github.NewMockEventRepository(nil, nil))
+	event := github.GitHubEvent{
+		Type: "ReleaseEvent",
+		Repo: github.Repo{Name: "user/repo"},
+		Payload: json.RawMessage(`{
+			"action": "published",
+			"release": {"tag_name": "v2.0.0-rc1", "prerelease": true, "body": "` + strings.Repeat("a", 150) + `"}
+		}`),
+	}
+
+	activity := service.SummarizeDetailed(event)
+	if want := "Released v2.0.0-rc1 in user/repo (prerelease)"; activity.Description != want {
+		t.Errorf("Description = %q, want %q", activity.Description, want)
+	}
+	if notes := activity.ExtraDetails["notes"]; len(notes) != releaseNotesLength || !strings.HasSuffix(notes, "...") {
+		t.Errorf("notes = %q, want an excerpt of %d characters", notes, releaseNotesLength)
+	}
+}
+
+func TestActivityService_SummarizeDetailed_ReleaseMultiByteNotes(t *testing.T) {
+	service := NewActivityService(github.NewMockEventRepository(nil, nil))
+	event := github.GitHubEvent{
+		Type: "ReleaseEvent",
+		Repo: github.Repo{Name: "user/repo"},
+		Payload: json.RawMessage(`{
+			"action": "published",
+			"release": {"tag_name": "v1.0.0", "body": "` + strings.Repeat("新機能🚀", 60) + `"}
+		}`),
+	}
+
+	notes := service.SummarizeDetailed(event).ExtraDetails["notes"]
+	if !utf8.ValidString(notes) {
+		t.Errorf("notes = %q, want valid UTF-8", notes)
+	}
+	if got := utf8.RuneCountInString(notes); got != releaseNotesLength || !strings.HasSuffix(notes, "...") {
+		t.Errorf("notes = %q (%d characters), want an excerpt of %d characters", notes, got, releaseNotesLength)
+	}
+}
+
+func TestActivityService_GetEventTypeStatistics(t *testing.T) {
+	mockEvents := []github.GitHubEvent{
+		{Type: "PushEvent"},
+		{Type: "PushEvent"},
+		{Type: "IssuesEvent"},
+		{Type: "PushEvent"},
+		{Type: "WatchEvent"},
+		{Type: "IssuesEvent"},
+	}
+
+	repo := github.NewMockEventRepository(mockEvents, nil)
+	service := NewActivityService(repo)
+
+	stats, err := service.GetEventTypeStatistics("testuser")
+	if err != nil {
+		t.Fatalf("GetEventTypeStatistics() error = %v", err)
+	}
+
+	expected := map[string]int{
+		"PushEvent":   3,
+		"IssuesEvent": 2,
+		"WatchEvent":  1,
+	}
+
+	for eventType, count := range expected {
+		if stats[eventType] != count {
+			t.Errorf("Stats[%s] = %d, want %d", eventType, stats[eventType], count)
+		}
+	}
+}
+
+func TestActivityService_GetRecentRepositories(t *testing.T) {
+	mockEvents := []github.GitHubEvent{
+		{Repo: github.Repo{Name: "user/repo1"}},
+		{Repo: github.Repo{Name: "user/repo2"}},
+		{Repo: github.Repo{Name: "user/repo1"}}, // Duplicate
+		{Repo: github.Repo{Name: "user/repo3"}},
+		{Repo: github.Repo{Name: "user/repo2"}}, // Duplicate
+		{Repo: github.Repo{Name: "user/repo4"}},
+	}
+
+	repo := github.NewMockEventRepository(mockEvents, nil)
+	service := NewActivityService(repo)
+
+	t.Run("no limit", func(t *testing.T) {
+		repos, err := service.GetRecentRepositories("testuser", 0)
+		if err != nil {
+			t.Fatalf("GetRecentRepositories() error = %v", err)
+		}
+
+		expected := []string{"user/repo1", "user/repo2", "user/repo3", "user/repo4"}
+		if len(repos) != len(expected) {
+			t.Errorf("Got %d repos, want %d", len(repos), len(expected))
+		}
+
+		for i, repo := range repos {
+			if repo != expected[i] {
+				t.Errorf("Repo[%d] = %v, want %v", i, repo, expected[i])
+			}
+		}
+	})
+
+	t.Run("with limit", func(t *testing.T) {
+		repos, err := service.GetRecentRepositories("testuser", 2)
+		if err != nil {
+			t.Fatalf("GetRecentRepositories() error = %v", err)
+		}
+
+		if len(repos) != 2 {
+			t.Errorf("Got %d repos, want 2", len(repos))
+		}
+
+		expected := []string{"user/repo1", "user/repo2"}
+		for i, repo := range repos {
+			if repo != expected[i] {
+				t.Errorf("Repo[%d] = %v, want %v", i, repo, expected[i])
+			}
+		}
+	})
+}
+
+func TestActivityOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		options     ActivityOptions
+		expectError bool
+	}{
+		{
+			name:        "valid options",
+			options:     ActivityOptions{EventType: "PushEvent", Limit: 10},
+			expectError: false,
+		},
+		{
+			name:        "empty event type is valid",
+			options:     ActivityOptions{EventType: "", Limit: 10},
+			expectError: false,
+		},
+		{
+			name:        "negative limit",
+			options:     ActivityOptions{Limit: -1},
+			expectError: true,
+		},
+		{
+			name:        "negative days",
+			options:     ActivityOptions{Days: -1},
+			expectError: true,
+		},
+		{
+			name:        "negative retries",
+			options:     ActivityOptions{Retries: -1},
+			expectError: true,
+		},
+		{
+			name:        "invalid event type",
+			options:     ActivityOptions{EventType: "InvalidEvent"},
+			expectError: true,
+		},
+		{
+			name:        "valid repo filter",
+			options:     ActivityOptions{Repo: "alnah/github-activity"},
+			expectError: false,
+		},
+		{
+			name:        "valid owner filter",
+			options:     ActivityOptions{Repo: "alnah"},
+			expectError: false,
+		},
+		{
+			name:        "invalid repo filter",
+			options:     ActivityOptions{Repo: "alnah/github-activity/extra"},
+			expectError: true,
+		},
+		{
+			name:        "valid actor filter",
+			options:     ActivityOptions{Actor: "hubot"},
+			expectError: false,
+		},
+		{
+			name:        "invalid actor filter",
+			options:     ActivityOptions{Actor: "alnah/dotfiles"},
+			expectError: true,
+		},
+		{
+			name:        "valid action filter",
+			options:     ActivityOptions{Action: "merged"},
+			expectError: false,
+		},
+		{
+			name:        "invalid action filter",
+			options:     ActivityOptions{Action: "opened,closed"},
+			expectError: true,
+		},
+		{
+			name:        "valid spike period",
+			options:     ActivityOptions{Spikes: "day"},
+			expectError: false,
+		},
+		{
+			name:        "invalid spike period",
+			options:     ActivityOptions{Spikes: "week"},
+			expectError: true,
+		},
+		{
+			name:        "valid timezone",
+			options:     ActivityOptions{Timezone: "America/New_York"},
+			expectError: false,
+		},
+		{
+			name:        "invalid timezone",
+			options:     ActivityOptions{Timezone: "Mars/Olympus_Mons"},
+			expectError: true,
+		},
+		{
+			name:        "case insensitive event type",
+			options:     ActivityOptions{EventType: "pushevent"},
+			expectError: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.options.Validate()
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+			} else {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestDefaultActivityOptions(t *testing.T) {
+	options := DefaultActivityOptions()
+
+	if options.EventType != "" {
+		t.Errorf("Default EventType = %v, want empty", options.EventType)
+	}
+
+	if options.Limit != 30 {
+		t.Errorf("Default Limit = %v, want 30", options.Limit)
+	}
+
+	if options.ShowDetailed {
+		t.Error("Default ShowDetailed should be false")
+	}
+}
+
+func TestActivityService_Summarize(t *testing.T) {
+	event := github.GitHubEvent{
+		ID:        "123",
+		Type:      "PushEvent",
+		Repo:      github.Repo{Name: "user/repo"},
+		CreatedAt: time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC),
+		Payload: json.RawMessage(`{
+			"size": 1,
+			"ref": "refs/heads/main"
+		}`),
+	}
+
+	service := NewActivityService(nil)
+	summary := service.Summarize(event)
+
+	if summary.Type != "PushEvent" {
+		t.Errorf("Type = %v, want PushEvent", summary.Type)
+	}
+
+	if summary.Repository != "user/repo" {
+		t.Errorf("Repository = %v, want user/repo", summary.Repository)
+	}
+
+	expectedTime := "2024-01-15 10:30:00"
+	if summary.Timestamp != expectedTime {
+		t.Errorf("Timestamp = %v, want %v", summary.Timestamp, expectedTime)
+	}
+
+	if summary.Description == "" {
+		t.Error("Description should not be empty")
+	}
+
+	// Relative times count from the service clock, not the wall clock
+	clocked := NewActivityService(nil, WithClock(func() time.Time { return event.CreatedAt.AddDate(0, 0, 3) }))
+	if got := clocked.Summarize(event).RelativeTime(); got != "3 days ago" {
+		t.Errorf("RelativeTime() = %q, want 3 days ago", got)
+	}
+}
+
+func TestActivityService_ErrorHandling(t *testing.T) {
+	t.Run("whitespace username", func(t *testing.T) {
+		service := NewActivityService(nil)
+		_, err := service.GetUserActivity("   ", EventFilter{})
+		if err == nil {
+			t.Error("Expected error for whitespace username")
+		}
+	})
+
+	t.Run("repository error propagation", func(t *testing.T) {
+		expectedErr := errors.New("API error")
+		repo := github.NewMockEventRepository(nil, expectedErr)
+		service := NewActivityService(repo)
+
+		_, err := service.GetUserActivity("testuser", EventFilter{})
+		if err == nil {
+			t.Error("Expected error to be propagated")
+		}
+	})
+}
+
+func TestActivityService_GetReviewBurndown(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	search := func(query string) string {
+		return "/search/issues?q=" + url.QueryEscape(query) + "&sort=updated&per_page=100"
+	}
+	fetcher := &fakeResourceFetcher{
+		resources: map[string]string{
+			search("is:pr is:open review-requested:testuser updated:>=2024-01-14"): `{
+				"total_count": 1,
+				"items": [{
+					"number": 7,
+					"title": "Fix layout",
+					"repository_url": "https://api.github.com/repos/org/web",
+					"updated_at": "2024-01-15T09:00:00Z"
+				}]
+			}`,
+			search("is:pr reviewed-by:testuser -author:testuser updated:>=2024-01-14"): `{"total_count": 3, "items": []}`,
+			search("is:pr is:open review-requested:testuser"):                          `{"total_count": 2, "items": [{"number": 7}, {"number": 3}]}`,
+			search("is:pr reviewed-by:testuser -author:testuser"):                      `{"total_count": 5, "items": []}`,
+		},
+		calls: make(map[string]int),
+	}
+	// The feed is never read: requests live on the pull requests
+	service := NewActivityService(
+		github.NewMockEventRepository(nil, errors.New("feed used")),
+		WithClock(func() time.Time { return now }),
+	)
+	service.SetReviewSearcher(fetcher)
+
+	t.Run("within window", func(t *testing.T) {
+		burndown, err := service.GetReviewBurndown("testuser", 24*time.Hour)
+		if err != nil {
+			t.Fatalf("GetReviewBurndown() error = %v", err)
+		}
+
+		if burndown.Requested != 4 {
+			t.Errorf("Requested = %d, want 4", burndown.Requested)
+		}
+		if burndown.Reviewed != 3 {
+			t.Errorf("Reviewed = %d, want 3", burndown.Reviewed)
+		}
+		want := []ReviewRequest{{
+			Repository: "org/web",
+			Number:     7,
+			Title:      "Fix layout",
+			UpdatedAt:  time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC),
+		}}
+		if !reflect.DeepEqual(burndown.Outstanding, want) {
+			t.Errorf("Outstanding = %+v, want %+v", burndown.Outstanding, want)
+		}
+	})
+
+	t.Run("no window", func(t *testing.T) {
+		burndown, err := service.GetReviewBurndown("testuser", 0)
+		if err != nil {
+			t.Fatalf("GetReviewBurndown() error = %v", err)
+		}
+
+		if burndown.Requested != 7 || len(burndown.Outstanding) != 2 {
+			t.Errorf("Burndown = %+v, want 7 requested and 2 outstanding", burndown)
+		}
+	})
+
+	t.Run("search error", func(t *testing.T) {
+		if _, err := service.GetReviewBurndown("someone", 0); err == nil {
+			t.Error("Expected error to be propagated")
+		}
+	})
+
+	t.Run("no searcher", func(t *testing.T) {
+		service := NewActivityService(github.NewMockEventRepository(nil, nil))
+		if _, err := service.GetReviewBurndown("testuser", 0); err == nil {
+			t.Error("Expected an error without a searcher")
+		}
+	})
+}
+
+func TestNewActivityService_Options(t *testing.T) {
+	var _ ActivityProvider = NewActivityService(nil)
+
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	events := []github.GitHubEvent{
+		{ID: "1", Type: "PushEvent", CreatedAt: now.Add(-100 * 24 * time.Hour)},
+	}
+
+	var logs bytes.Buffer
+	service := NewActivityService(
+		github.NewMockEventRepository(events, nil),
+		WithClock(func() time.Time { return now }),
+		WithLogger(github.NewTextLogger(&logs)),
+	)
+
+	gap, err := service.GetTimelineGap("octocat")
+	if err != nil {
+		t.Fatalf("GetTimelineGap() error = %v", err)
+	}
+	if gap == nil {
+		t.Error("Expected a gap 100 days before the injected clock")
+	}
+
+	if !strings.Contains(logs.String(), `msg=fetched user=octocat events=1`) {
+		t.Errorf("log = %q, want fetch summary", logs.String())
+	}
+}
+
+func TestActivityService_GetStreaks(t *testing.T) {
+	now := time.Date(2024, 1, 20, 12, 0, 0, 0, time.UTC)
+	events := []github.GitHubEvent{
+		{ID: "3", Type: "PushEvent", CreatedAt: now.Add(-time.Hour)},
+		{ID: "2", Type: "PushEvent", CreatedAt: now.Add(-24 * time.Hour)},
+		{ID: "1", Type: "PushEvent", CreatedAt: now.Add(-4 * 24 * time.Hour)},
+	}
+	service := NewActivityService(
+		github.NewMockEventRepository(events, nil),
+		WithClock(func() time.Time { return now }),
+		WithLocation(time.UTC),
+	)
+
+	streaks, err := service.GetStreaks("testuser")
+	if err != nil {
+		t.Fatalf("GetStreaks() error = %v", err)
+	}
+	if streaks.Current != 2 || streaks.Longest != 2 || streaks.ActiveDays != 3 {
+		t.Errorf("Streaks = %+v, want current 2, longest 2, 3 active days", streaks)
+	}
+}
+
+func TestActivityService_GetPeriodSummary(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	event := func(eventType, repo, payload string, age time.Duration) github.GitHubEvent {
+		return github.GitHubEvent{
+			Type:      eventType,
+			Repo:      github.Repo{Name: repo},
+			Payload:   json.RawMessage(payload),
+			CreatedAt: now.Add(-age),
+		}
+	}
+	events := []github.GitHubEvent{
+		event("PushEvent", "user/a", `{"size":3}`, time.Hour),
+		event("PushEvent", "user/b", `{"size":2}`, 2*time.Hour),
+		event("PushEvent", "user/a", `{"size":1}`, 3*time.Hour),
+		event("PullRequestEvent", "user/a", `{"action":"opened","pull_request":{"number":1}}`, time.Hour),
+		event("PullRequestEvent", "user/a",
+			`{"action":"closed","pull_request":{"number":1,"merged":true}}`, time.Hour),
+		event("PullRequestReviewEvent", "user/c", `{"pull_request":{"number":9}}`, time.Hour),
+		event("PullRequestReviewEvent", "user/c", `{"pull_request":{"number":9}}`, 2*time.Hour),
+		event("IssuesEvent", "user/a", `{"action":"opened"}`, time.Hour),
+		event("IssuesEvent", "user/a", `{"action":"closed"}`, time.Hour),
+		// Outside the period
+		event("PushEvent", "user/old", `{"size":50}`, 10*24*time.Hour),
+	}
+
+	service := NewActivityService(
+		github.NewMockEventRepository(events, nil),
+		WithClock(func() time.Time { return now }),
+	)
+
+	summary, err := service.GetPeriodSummary("testuser", EventFilter{}, 7)
+	if err != nil {
+		t.Fatalf("GetPeriodSummary() error = %v", err)
+	}
+
+	expected := PeriodSummary{
+		Username:             "testuser",
+		Since:                now.AddDate(0, 0, -7),
+		Until:                now,
+		Events:               9,
+		Commits:              6,
+		PushedRepos:          2,
+		PullRequestsOpened:   1,
+		PullRequestsMerged:   1,
+		PullRequestsReviewed: 1,
+		IssuesOpened:         1,
+		IssuesClosed:         1,
+	}
+	if *summary != expected {
+		t.Errorf("Summary = %+v, want %+v", *summary, expected)
+	}
+
+	// Filters narrow the summary
+	summary, err = service.GetPeriodSummary("testuser", EventFilter{Repo: "user/b"}, 7)
+	if err != nil {
+		t.Fatalf("GetPeriodSummary() error = %v", err)
+	}
+	if summary.Commits != 2 || summary.Events != 1 {
+		t.Errorf("Filtered summary = %+v, want 2 commits in 1 event", *summary)
+	}
+}
+
+func TestActivityService_SetLocation(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("time zone data unavailable: %v", err)
+	}
+
+	// 02:30 UTC on the 15th is still the 14th in New York
+	event := github.GitHubEvent{
+		Type:      "WatchEvent",
+		CreatedAt: time.Date(2024, 1, 15, 2, 30, 0, 0, time.UTC),
+	}
+	service := NewActivityService(github.NewMockEventRepository([]github.GitHubEvent{event}, nil))
+
+	summary := service.Summarize(event)
+	if summary.Timestamp != "2024-01-15 02:30:00" {
+		t.Errorf("default Timestamp = %v, want UTC", summary.Timestamp)
+	}
+
+	service.SetLocation(newYork)
+	summary = service.Summarize(event)
+	if summary.Timestamp != "2024-01-14 21:30:00" {
+		t.Errorf("Timestamp = %v, want 2024-01-14 21:30:00", summary.Timestamp)
+	}
+
+	counts, err := service.GetDailyActivity("octocat", EventFilter{})
+	if err != nil {
+		t.Fatalf("GetDailyActivity() error = %v", err)
+	}
+	if counts["2024-01-14"] != 1 {
+		t.Errorf("GetDailyActivity() = %v, want the event on 2024-01-14", counts)
+	}
+
+	histogram, err := service.GetActivityHistogram("octocat")
+	if err != nil {
+		t.Fatalf("GetActivityHistogram() error = %v", err)
+	}
+	if histogram.Zone != "America/New_York" || histogram.ByHour[21] != 1 {
+		t.Errorf("histogram zone %s, hours %v", histogram.Zone, histogram.ByHour)
+	}
+}
+
+func TestActivityService_SetDescriptions(t *testing.T) {
+	events := []github.GitHubEvent{
+		{Type: "WatchEvent", Repo: github.Repo{Name: "user/starred"}},
+		{Type: "ForkEvent", Repo: github.Repo{Name: "user/forked"}},
+	}
+	service := NewActivityService(github.NewMockEventRepository(events, nil))
+
+	renderers, err := github.NewTemplateRenderers(map[github.EventType]string{github.EventTypeWatch: "⭐ {{.Repo}}"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	service.SetDescriptions(renderers)
+
+	activities, err := service.GetUserActivity("octocat", EventFilter{})
+	if err != nil {
+		t.Fatalf("GetUserActivity() error = %v", err)
+	}
+	if activities[0].Description != "⭐ user/starred" {
+		t.Errorf("templated Description = %q", activities[0].Description)
+	}
+	if activities[1].Description != "Forked user/forked" {
+		t.Errorf("Description without a template = %q, want the built-in one", activities[1].Description)
+	}
+}
+
+func TestActivityService_SetEmoji(t *testing.T) {
+	events := []github.GitHubEvent{
+		{Type: "WatchEvent", Repo: github.Repo{Name: "user/starred"}},
+		{Type: "MemberEvent", Repo: github.Repo{Name: "user/team"}},
+	}
+	service := NewActivityService(github.NewMockEventRepository(events, nil))
+	service.SetEmoji(DefaultEmoji)
+
+	activities, err := service.GetUserActivity("octocat", EventFilter{})
+	if err != nil {
+		t.Fatalf("GetUserActivity() error = %v", err)
+	}
+	if activities[0].Description != "⭐ Starred user/starred" {
+		t.Errorf("Description = %q, want a star prefix", activities[0].Description)
+	}
+	if activities[1].Description != "Added a member to user/team" {
+		t.Errorf("Description = %q, want no prefix for an unmapped type", activities[1].Description)
+	}
+}
+
+func TestActivityService_GetActivitySpikes(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	events := make([]github.GitHubEvent, 0)
+	for i := 0; i < 20; i++ {
+		events = append(events, github.GitHubEvent{
+			Repo:      github.Repo{Name: "bot/repo"},
+			CreatedAt: now.Add(-5 * time.Hour),
+		})
+	}
+	service := NewActivityService(
+		github.NewMockEventRepository(events, nil),
+		WithClock(func() time.Time { return now }),
+	)
+
+	hourly, err := service.GetActivitySpikes("octocat", "hour")
+	if err != nil {
+		t.Fatalf("GetActivitySpikes() error = %v", err)
+	}
+	if len(hourly) != 0 {
+		t.Errorf("hourly spikes = %+v, want none", hourly)
+	}
+
+	daily, err := service.GetActivitySpikes("octocat", "day")
+	if err != nil {
+		t.Fatalf("GetActivitySpikes() error = %v", err)
+	}
+	if len(daily) != 2 || daily[1].Repository != "bot/repo" {
+		t.Errorf("daily spikes = %+v, want bot/repo and the user total", daily)
+	}
+
+	if _, err := service.GetActivitySpikes("octocat", "week"); err == nil {
+		t.Error("Expected error for an unknown period")
+	}
+}
+
+func TestActivityService_GetDailyActivity(t *testing.T) {
+	day := time.Date(2024, 3, 13, 12, 0, 0, 0, time.Local)
+	events := []github.GitHubEvent{
+		{Type: "PushEvent", CreatedAt: day},
+		{Type: "PushEvent", CreatedAt: day.Add(time.Hour)},
+		{Type: "WatchEvent", CreatedAt: day.AddDate(0, 0, -1)},
+	}
+	service := NewActivityService(github.NewMockEventRepository(events, nil))
+
+	counts, err := service.GetDailyActivity("octocat", EventFilter{Type: "PushEvent", MaxLimit: 1})
+	if err != nil {
+		t.Fatalf("GetDailyActivity() error = %v", err)
+	}
+	if len(counts) != 1 || counts["2024-03-13"] != 2 {
+		t.Errorf("GetDailyActivity() = %v, want 2 pushes on 2024-03-13", counts)
+	}
+}
+
+func TestActivityService_GetActivityHistogram(t *testing.T) {
+	// 2024-03-11 is a Monday
+	monday := time.Date(2024, 3, 11, 9, 30, 0, 0, time.Local)
+	events := []github.GitHubEvent{
+		{Type: "PushEvent", CreatedAt: monday},
+		{Type: "PushEvent", CreatedAt: monday.Add(10 * time.Minute)},
+		{Type: "WatchEvent", CreatedAt: monday.AddDate(0, 0, 5).Add(13 * time.Hour)},
+	}
+	service := NewActivityService(github.NewMockEventRepository(events, nil))
+
+	histogram, err := service.GetActivityHistogram("octocat")
+	if err != nil {
+		t.Fatalf("GetActivityHistogram() error = %v", err)
+	}
+	if histogram.Total != 3 {
+		t.Errorf("Total = %d, want 3", histogram.Total)
+	}
+	if histogram.ByWeekday[time.Monday] != 2 || histogram.ByWeekday[time.Saturday] != 1 {
+		t.Errorf("ByWeekday = %v", histogram.ByWeekday)
+	}
+	if histogram.ByHour[9] != 2 || histogram.ByHour[22] != 1 {
+		t.Errorf("ByHour = %v", histogram.ByHour)
+	}
+}
+
+// BenchmarkGetUserActivity lists a feed the size of a large archive
+func BenchmarkGetUserActivity(b *testing.B) {
+	base := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	events := make([]github.GitHubEvent, 0, 5000)
+	for i := range cap(events) {
+		events = append(events, github.GitHubEvent{
+			ID:        strconv.Itoa(i),
+			Type:      "PushEvent",
+			Repo:      github.Repo{Name: "octo/repo"},
+			Payload:   json.RawMessage(`{"ref": "refs/heads/main", "size": 1, "commits": [{"sha": "abc1234", "message": "Fix"}]}`),
+			CreatedAt: base.Add(-time.Duration(i) * time.Minute),
+		})
+	}
+	service := NewActivityService(github.NewMockEventRepository(events, nil))
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := service.GetUserActivity("octocat", EventFilter{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,332 @@
+package activity
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// EventArchive accumulates every event seen for each user in a local file,
+// keeping history past the window the GitHub events API serves. The file
+// holds one JSON record per line, and each sync appends a single line with
+// the events it added, so the archive is never rewritten as it grows.
+type EventArchive struct {
+	path string
+
+	mu sync.Mutex
+}
+
+// ArchiveEntry is one user's archived events, newest first
+type ArchiveEntry struct {
+	SyncedAt time.Time            `json:"synced_at"`
+	Events   []github.GitHubEvent `json:"events"`
+}
+
+// archiveRecord is one line of the archive file: the events one sync added
+// for a user. Archives written before records were appended hold a single
+// line with every user under Users instead; the next sync rewrites it once
+// as records.
+type archiveRecord struct {
+	User     string                  `json:"user,omitempty"`
+	SyncedAt time.Time               `json:"synced_at"`
+	Events   []github.GitHubEvent    `json:"events,omitempty"`
+	Users    map[string]ArchiveEntry `json:"users,omitempty"`
+}
+
+// archiveFile is an archive as read back, keyed by username
+type archiveFile struct {
+	Users  map[string]ArchiveEntry
+	size   int64 // Bytes of whole records; a torn last line lies beyond
+	legacy bool  // Read from the single-document layout
+}
+
+// NewEventArchive returns the archive stored at path
+func NewEventArchive(path string) *EventArchive {
+	return &EventArchive{path: path}
+}
+
+// DefaultArchivePath returns where `sync` keeps the archive. It lives next to
+// the config rather than in the cache directory, which may be cleared.
+func DefaultArchivePath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "github-activity", "archive.json")
+}
+
+// Path returns the archive file location
+func (a *EventArchive) Path() string {
+	return a.path
+}
+
+// Append adds the events not yet archived for username, matched by event
+// ID, and returns how many were added and the archived total
+func (a *EventArchive) Append(username string, events []github.GitHubEvent, now time.Time) (int, int, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	archive, err := a.load()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	entry := archive.Users[username]
+	seen := make(map[string]bool, len(entry.Events))
+	for _, event := range entry.Events {
+		seen[event.ID] = true
+	}
+	record := archiveRecord{User: username, SyncedAt: now}
+	for _, event := range events {
+		if seen[event.ID] {
+			continue
+		}
+		seen[event.ID] = true
+		record.Events = append(record.Events, event)
+	}
+	total := len(entry.Events) + len(record.Events)
+
+	if archive.legacy {
+		entry.Events = append(entry.Events, record.Events...)
+		entry.SyncedAt = now
+		archive.Users[username] = entry
+		err = a.rewrite(archive)
+	} else {
+		err = a.append(record, archive.size)
+	}
+	if err != nil {
+		return 0, 0, err
+	}
+	return len(record.Events), total, nil
+}
+
+// Events returns the archived events for username, newest first, and
+// whether the user was ever synced
+func (a *EventArchive) Events(username string) ([]github.GitHubEvent, bool, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	archive, err := a.load()
+	if err != nil {
+		return nil, false, err
+	}
+	entry, ok := archive.Users[username]
+	return entry.Events, ok, nil
+}
+
+// load reads the archive file; a missing file is an empty archive. A last
+// line without its newline, left by a sync that was cut short, is ignored.
+func (a *EventArchive) load() (*archiveFile, error) {
+	archive := &archiveFile{Users: make(map[string]ArchiveEntry)}
+
+	data, err := os.ReadFile(a.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return archive, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive: %w", err)
+	}
+
+	for len(data) > 0 {
+		line, rest, complete := bytes.Cut(data, []byte("\n"))
+		if !complete {
+			break
+		}
+		data = rest
+		archive.size += int64(len(line)) + 1
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		var record archiveRecord
+		if err := json.Unmarshal(line, &record); err != nil {
+			return nil, fmt.Errorf("invalid archive file %s: %w", a.path, err)
+		}
+		if record.Users != nil {
+			archive.legacy = true
+			for username, entry := range record.Users {
+				archive.Users[username] = entry
+			}
+			continue
+		}
+		entry := archive.Users[record.User]
+		entry.Events = append(entry.Events, record.Events...)
+		if record.SyncedAt.After(entry.SyncedAt) {
+			entry.SyncedAt = record.SyncedAt
+		}
+		archive.Users[record.User] = entry
+	}
+	// The single-document layout was written without a trailing newline
+	if archive.size == 0 && len(bytes.TrimSpace(data)) > 0 {
+		var legacy archiveRecord
+		if err := json.Unmarshal(data, &legacy); err != nil || legacy.Users == nil {
+			return nil, fmt.Errorf("invalid archive file %s", a.path)
+		}
+		archive.Users, archive.legacy = legacy.Users, true
+	}
+
+	for username, entry := range archive.Users {
+		sort.SliceStable(entry.Events, func(i, j int) bool {
+			return entry.Events[i].CreatedAt.After(entry.Events[j].CreatedAt)
+		})
+		archive.Users[username] = entry
+	}
+	return archive, nil
+}
+
+// append writes record as a new line after the size bytes of whole records,
+// creating the archive and its directory on first sync
+func (a *EventArchive) append(record archiveRecord, size int64) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode archive: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(a.path), 0o755); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	file, err := os.OpenFile(a.path, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	// Drop a torn line so the record starts on a line of its own
+	if err := file.Truncate(size); err != nil {
+		_ = file.Close()
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	// One write per record, so a crash loses at most this sync
+	_, err = file.Write(append(line, '\n'))
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	return nil
+}
+
+// rewrite replaces the archive atomically with one record per user, which
+// converts the single-document layout once
+func (a *EventArchive) rewrite(archive *archiveFile) error {
+	usernames := make([]string, 0, len(archive.Users))
+	for username := range archive.Users {
+		usernames = append(usernames, username)
+	}
+	sort.Strings(usernames)
+
+	var data []byte
+	for _, username := range usernames {
+		entry := archive.Users[username]
+		line, err := json.Marshal(archiveRecord{User: username, SyncedAt: entry.SyncedAt, Events: entry.Events})
+		if err != nil {
+			return fmt.Errorf("failed to encode archive: %w", err)
+		}
+		data = append(append(data, line...), '\n')
+	}
+	if err := os.MkdirAll(filepath.Dir(a.path), 0o755); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	if err := writeFileAtomic(a.path, data); err != nil {
+		return fmt.Errorf("failed to write archive: %w", err)
+	}
+	return nil
+}
+
+// ArchiveRepository serves events from an archive instead of the API
+type ArchiveRepository struct {
+	archive *EventArchive
+}
+
+// NewArchiveRepository returns a repository reading from archive
+func NewArchiveRepository(archive *EventArchive) *ArchiveRepository {
+	return &ArchiveRepository{archive: archive}
+}
+
+// FetchEvents returns the archived events for username
+func (r *ArchiveRepository) FetchEvents(username string) ([]github.GitHubEvent, error) {
+	events, ok, err := r.archive.Events(username)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, &github.RepositoryError{
+			Code:    github.ErrNotArchived.Code,
+			Message: fmt.Sprintf("%s for %s (run `github-activity sync %s` first)", github.ErrNotArchived.Message, username, username),
+		}
+	}
+	return events, nil
+}
+
+// SyncArchive fetches the user's feed and appends the new events to archive
+func (s *ActivityService) SyncArchive(username string, archive *EventArchive) (int, int, error) {
+	events, err := s.fetchEvents(context.Background(), username)
+	if err != nil {
+		return 0, 0, err
+	}
+	return archive.Append(username, events, s.now())
+}
+
+// UseArchive serves event fetches from archive instead of the API
+func (s *ActivityService) UseArchive(archive *EventArchive) {
+	s.repository = NewArchiveRepository(archive)
+}
+
+// archiveFallbackRepository fills in, from an archive, the events that fell
+// out of GitHub's feed window
+type archiveFallbackRepository struct {
+	next    github.EventRepository
+	archive *EventArchive
+	now     func() time.Time
+}
+
+// FetchEvents returns the fresh events, followed by the archived ones older
+// than all of them when the feed hit GitHub's cap. A missing or unreadable
+// archive leaves the fresh events as they are.
+func (r *archiveFallbackRepository) FetchEvents(username string) ([]github.GitHubEvent, error) {
+	return r.FetchEventsContext(context.Background(), username)
+}
+
+// FetchEventsContext is FetchEvents passing ctx to the wrapped repository
+func (r *archiveFallbackRepository) FetchEventsContext(
+	ctx context.Context,
+	username string,
+) ([]github.GitHubEvent, error) {
+	events, err := github.FetchWithContext(ctx, r.next, username)
+	if err != nil {
+		// Events read before a partial failure are passed on
+		return events, err
+	}
+	gap := DetectTimelineGap(events, r.now())
+	if gap == nil {
+		return events, nil
+	}
+	archived, ok, err := r.archive.Events(username)
+	if err != nil || !ok {
+		return events, nil
+	}
+
+	older := make([]github.GitHubEvent, 0, len(archived))
+	for _, event := range archived {
+		if event.CreatedAt.Before(gap.OldestEvent) {
+			older = append(older, event)
+		}
+	}
+	return github.MergeEvents(events, older), nil
+}
+
+// UseArchiveFallback reads the events GitHub no longer serves from archive
+// whenever the user's feed reaches GitHub's 300-event or 90-day cap
+func (s *ActivityService) UseArchiveFallback(archive *EventArchive) {
+	s.repository = &archiveFallbackRepository{next: s.repository, archive: archive, now: s.now}
+}
@@ -0,0 +1,187 @@
+package activity
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+func TestEventArchive_Append(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "archive.json")
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	event := func(id string, hoursAgo int) github.GitHubEvent {
+		return github.GitHubEvent{ID: id, Type: "PushEvent", CreatedAt: now.Add(-time.Duration(hoursAgo) * time.Hour)}
+	}
+
+	added, total, err := NewEventArchive(path).Append("octocat", []github.GitHubEvent{event("2", 1), event("1", 2)}, now)
+	if err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	if added != 2 || total != 2 {
+		t.Errorf("First sync added %d of %d, want 2 of 2", added, total)
+	}
+
+	first, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A later sync overlaps the first and brings one new event
+	added, total, err = NewEventArchive(path).Append("octocat", []github.GitHubEvent{event("3", 0), event("2", 1)}, now)
+	if err != nil {
+		t.Fatalf("Append() error = %v", err)
+	}
+	if added != 1 || total != 3 {
+		t.Errorf("Second sync added %d of %d, want 1 of 3", added, total)
+	}
+	// The sync is appended as a line of its own, leaving the first in place
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), string(first)) || strings.Count(string(data), "\n") != 2 {
+		t.Errorf("Archive = %s, want the first sync followed by one more line", data)
+	}
+
+	events, ok, err := NewEventArchive(path).Events("octocat")
+	if err != nil || !ok {
+		t.Fatalf("Events() = %v, %v", ok, err)
+	}
+	for i, id := range []string{"3", "2", "1"} {
+		if events[i].ID != id {
+			t.Errorf("events[%d] = %s, want %s (newest first)", i, events[i].ID, id)
+		}
+	}
+
+	if _, ok, _ := NewEventArchive(path).Events("someone-else"); ok {
+		t.Error("Expected users to be archived separately")
+	}
+}
+
+func TestEventArchive_Layouts(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	newer := github.GitHubEvent{ID: "2", Type: "PushEvent", CreatedAt: now}
+
+	t.Run("single document", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "archive.json")
+		legacy := `{"users":{"octocat":{"synced_at":"2024-01-01T00:00:00Z","events":[{"id":"1","type":"WatchEvent"}]}}}`
+		if err := os.WriteFile(path, []byte(legacy), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		archive := NewEventArchive(path)
+		if events, ok, err := archive.Events("octocat"); err != nil || !ok || len(events) != 1 {
+			t.Fatalf("Events() = %v, %v, %v, want the archived event", events, ok, err)
+		}
+
+		// The next sync converts the file to one record per line
+		added, total, err := archive.Append("octocat", []github.GitHubEvent{newer}, now)
+		if err != nil || added != 1 || total != 2 {
+			t.Fatalf("Append() = %d, %d, %v, want 1 of 2", added, total, err)
+		}
+		data, _ := os.ReadFile(path)
+		if strings.Contains(string(data), `"users"`) || strings.Count(string(data), "\n") != 1 {
+			t.Errorf("Archive = %s, want one record", data)
+		}
+		if events, _, _ := archive.Events("octocat"); len(events) != 2 || events[0].ID != "2" {
+			t.Errorf("Events() = %+v, want both, newest first", events)
+		}
+	})
+
+	t.Run("torn last line", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "archive.json")
+		archive := NewEventArchive(path)
+		if _, _, err := archive.Append("octocat", []github.GitHubEvent{{ID: "1", Type: "WatchEvent"}}, now); err != nil {
+			t.Fatal(err)
+		}
+		// A sync cut short leaves part of its line behind
+		file, _ := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o644)
+		_, _ = file.WriteString(`{"user":"octocat","events":[{"id":"9`)
+		_ = file.Close()
+
+		if events, _, err := archive.Events("octocat"); err != nil || len(events) != 1 {
+			t.Fatalf("Events() = %v, %v, want the whole records only", events, err)
+		}
+		if _, total, err := archive.Append("octocat", []github.GitHubEvent{newer}, now); err != nil || total != 2 {
+			t.Fatalf("Append() total = %d, %v, want 2", total, err)
+		}
+		if data, _ := os.ReadFile(path); strings.Contains(string(data), `"9`) {
+			t.Errorf("Archive = %s, want the torn line dropped", data)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "archive.json")
+		if err := os.WriteFile(path, []byte("not json"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := NewEventArchive(path).Events("octocat"); err == nil {
+			t.Error("Expected an invalid archive to fail")
+		}
+	})
+}
+
+func TestArchiveRepository_FetchEvents(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "archive.json")
+	repo := NewArchiveRepository(NewEventArchive(path))
+
+	_, err := repo.FetchEvents("octocat")
+	var repoErr *github.RepositoryError
+	if !errors.As(err, &repoErr) || repoErr.Code != github.ErrNotArchived.Code {
+		t.Fatalf("FetchEvents() error = %v, want %s", err, github.ErrNotArchived.Code)
+	}
+
+	if err := os.WriteFile(path, []byte("{"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := repo.FetchEvents("octocat"); err == nil {
+		t.Error("Expected an error for a corrupt archive")
+	}
+}
+
+func TestActivityService_UseArchiveFallback(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	event := func(id string, daysAgo int) github.GitHubEvent {
+		return github.GitHubEvent{ID: id, Type: "PushEvent", CreatedAt: now.AddDate(0, 0, -daysAgo)}
+	}
+	archive := NewEventArchive(filepath.Join(t.TempDir(), "archive.json"))
+	if _, _, err := archive.Append("octocat", []github.GitHubEvent{event("2", 89), event("1", 120)}, now); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		user     string
+		fresh    []github.GitHubEvent
+		expected []string
+	}{
+		{"feed within the window", "octocat", []github.GitHubEvent{event("4", 1), event("3", 10)}, []string{"4", "3"}},
+		{"feed at the 90-day cap", "octocat", []github.GitHubEvent{event("3", 10), event("2", 89)}, []string{"3", "2", "1"}},
+		{"user never synced", "someone", []github.GitHubEvent{event("3", 10), event("2", 89)}, []string{"3", "2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewActivityService(github.NewMockEventRepository(tt.fresh, nil))
+			service.now = func() time.Time { return now }
+			service.UseArchiveFallback(archive)
+
+			events, err := service.fetchEvents(context.Background(), tt.user)
+			if err != nil {
+				t.Fatalf("fetchEvents() error = %v", err)
+			}
+			var ids []string
+			for _, event := range events {
+				ids = append(ids, event.ID)
+			}
+			if strings.Join(ids, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("events = %v, want %v", ids, tt.expected)
+			}
+		})
+	}
+}
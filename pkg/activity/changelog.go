@@ -0,0 +1,158 @@
+package activity
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// Changelog - Merged pull requests and pushed commits grouped for release notes
+
+// Changelog section titles, in the order they are written
+const (
+	SectionBreaking = "Breaking Changes"
+	SectionFeatures = "Features"
+	SectionFixes    = "Fixes"
+	SectionOther    = "Other Changes"
+)
+
+// changelogSections is the order sections appear in a changelog
+var changelogSections = []string{SectionBreaking, SectionFeatures, SectionFixes, SectionOther}
+
+// ChangelogEntry is one merged pull request or pushed commit
+type ChangelogEntry struct {
+	Scope       string    `json:"scope,omitempty"` // Conventional-commit scope, such as api in feat(api)
+	Description string    `json:"description"`
+	Repo        string    `json:"repo"`
+	Ref         string    `json:"ref"` // #number for a pull request, the SHA for a commit
+	At          time.Time `json:"at"`
+}
+
+// ChangelogSection is the entries of one kind of change, newest first
+type ChangelogSection struct {
+	Title   string           `json:"title"`
+	Entries []ChangelogEntry `json:"entries"`
+}
+
+// Changelog is a draft of release notes for a date range
+type Changelog struct {
+	Since    time.Time          `json:"since"` // Zero when the range has no lower bound
+	Until    time.Time          `json:"until"`
+	Sections []ChangelogSection `json:"sections"` // Only sections with entries
+}
+
+// conventionalCommit matches a conventional-commit header: type(scope)!: description
+var conventionalCommit = regexp.MustCompile(`^([a-zA-Z]+)(?:\(([^)]*)\))?(!)?:\s*(.+)$`)
+
+// ParseConventionalCommit splits a commit message or pull request title into
+// its changelog section, scope and description. feat goes to Features, fix to
+// Fixes, a ! after the type or a BREAKING CHANGE footer to Breaking Changes,
+// and any other message, prefixed or not, to Other Changes as written.
+func ParseConventionalCommit(message string) (section, scope, description string) {
+	header, body, _ := strings.Cut(strings.TrimSpace(message), "\n")
+	header = strings.TrimSpace(header)
+	match := conventionalCommit.FindStringSubmatch(header)
+	if match == nil {
+		return SectionOther, "", header
+	}
+
+	kind, scope, description := strings.ToLower(match[1]), match[2], match[4]
+	switch {
+	case match[3] == "!" || strings.Contains(body, "BREAKING CHANGE"):
+		return SectionBreaking, scope, description
+	case kind == "feat":
+		return SectionFeatures, scope, description
+	case kind == "fix":
+		return SectionFixes, scope, description
+	}
+	return SectionOther, "", header
+}
+
+// isMergeCommit reports whether a commit message is one git or GitHub
+// writes when merging, which the pull request already describes
+func isMergeCommit(message string) bool {
+	return strings.HasPrefix(message, "Merge pull request ") ||
+		strings.HasPrefix(message, "Merge branch ") ||
+		strings.HasPrefix(message, "Merge remote-tracking branch ")
+}
+
+// BuildChangelog drafts a changelog from the merged pull requests and the
+// commits pushed in events. A commit whose description repeats a pull
+// request's, as a squash merge does, is left out, as are merge commits.
+func BuildChangelog(events []github.GitHubEvent, since, until time.Time) *Changelog {
+	entries := make(map[string][]ChangelogEntry)
+	seen := make(map[string]bool) // Lowercased descriptions per repository
+	add := func(message, repo, ref string, at time.Time) {
+		section, scope, description := ParseConventionalCommit(message)
+		key := repo + "\x00" + strings.ToLower(description)
+		if description == "" || seen[key] {
+			return
+		}
+		seen[key] = true
+		entries[section] = append(entries[section], ChangelogEntry{
+			Scope:       scope,
+			Description: description,
+			Repo:        repo,
+			Ref:         ref,
+			At:          at,
+		})
+	}
+
+	// Pull requests first, so they describe a change rather than its commits
+	for _, event := range events {
+		if github.EventType(event.Type) != github.EventTypePullRequest {
+			continue
+		}
+		payload, ok := event.TypedPayload().(*github.PullRequestPayload)
+		if ok && payload.Action == "closed" && payload.PullRequest.Merged {
+			add(payload.PullRequest.Title, event.Repo.Name, fmt.Sprintf("#%d", payload.PullRequest.Number), event.CreatedAt)
+		}
+	}
+	for _, commit := range pushedCommits(events) {
+		if !isMergeCommit(commit.Message) {
+			add(commit.Message, commit.Repo, commit.SHA, commit.PushedAt)
+		}
+	}
+
+	changelog := &Changelog{Since: since, Until: until, Sections: make([]ChangelogSection, 0)}
+	for _, title := range changelogSections {
+		if len(entries[title]) == 0 {
+			continue
+		}
+		section := ChangelogSection{Title: title, Entries: entries[title]}
+		sortEntries(section.Entries)
+		changelog.Sections = append(changelog.Sections, section)
+	}
+	return changelog
+}
+
+// sortEntries orders entries newest first, by repository on ties
+func sortEntries(entries []ChangelogEntry) {
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].At.Equal(entries[j].At) {
+			return entries[i].At.After(entries[j].At)
+		}
+		return entries[i].Repo < entries[j].Repo
+	})
+}
+
+// GetChangelog drafts a changelog from the matching events between
+// filter.Since and filter.Until, the latter defaulting to now
+func (s *ActivityService) GetChangelog(username string, filter EventFilter) (*Changelog, error) {
+	// A partial feed still drafts what was fetched
+	events, err := s.fetchEvents(context.Background(), username)
+	if _, partial := AsPartial(err); err != nil && !partial {
+		return nil, err
+	}
+
+	until := filter.Until
+	if until.IsZero() {
+		until = s.now()
+	}
+	return BuildChangelog(filter.matching(events), filter.Since, until), err
+}
@@ -0,0 +1,108 @@
+package activity
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+func TestParseConventionalCommit(t *testing.T) {
+	tests := []struct {
+		message     string
+		section     string
+		scope       string
+		description string
+	}{
+		{"feat: add a changelog subcommand", SectionFeatures, "", "add a changelog subcommand"},
+		{"fix(api): retry 502 responses\n\nDetails", SectionFixes, "api", "retry 502 responses"},
+		{"FEAT(cli): colour output", SectionFeatures, "cli", "colour output"},
+		{"feat!: drop Go 1.21", SectionBreaking, "", "drop Go 1.21"},
+		{"refactor(cache): key by feed\n\nBREAKING CHANGE: old caches are ignored", SectionBreaking, "cache", "key by feed"},
+		{"chore: bump dependencies", SectionOther, "", "chore: bump dependencies"},
+		{"Update README", SectionOther, "", "Update README"},
+		{"  ", SectionOther, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			section, scope, description := ParseConventionalCommit(tt.message)
+			if section != tt.section || scope != tt.scope || description != tt.description {
+				t.Errorf("ParseConventionalCommit() = %q, %q, %q, want %q, %q, %q",
+					section, scope, description, tt.section, tt.scope, tt.description)
+			}
+		})
+	}
+}
+
+// mergedPullRequest builds the event of a pull request being merged
+func mergedPullRequest(repo string, number int, title string, at time.Time) github.GitHubEvent {
+	var payload github.PullRequestPayload
+	payload.Action = "closed"
+	payload.PullRequest.Number = number
+	payload.PullRequest.Title = title
+	payload.PullRequest.Merged = true
+	raw, _ := json.Marshal(payload)
+	return github.GitHubEvent{Type: "PullRequestEvent", Repo: github.Repo{Name: repo}, Payload: raw, CreatedAt: at}
+}
+
+func TestBuildChangelog(t *testing.T) {
+	day := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	events := []github.GitHubEvent{
+		// Squash merge: the pushed commit repeats the pull request title
+		pushEvent("user/a", day.Add(3*time.Hour), "aaa:feat(cli): add changelog (#7)"),
+		mergedPullRequest("user/a", 7, "feat(cli): add changelog (#7)", day.Add(3*time.Hour)),
+		pushEvent("user/a", day.Add(2*time.Hour), "bbb:Merge pull request #6 from user/fix", "ccc:fix: handle empty pages"),
+		pushEvent("user/b", day.Add(time.Hour), "ddd:docs: explain tokens"),
+		pushEvent("user/b", day, "eee:feat!: drop the v1 API"),
+	}
+
+	changelog := BuildChangelog(events, day.AddDate(0, 0, -1), day.AddDate(0, 0, 1))
+	want := []ChangelogSection{
+		{Title: SectionBreaking, Entries: []ChangelogEntry{
+			{Description: "drop the v1 API", Repo: "user/b", Ref: "eee", At: day},
+		}},
+		{Title: SectionFeatures, Entries: []ChangelogEntry{
+			{Scope: "cli", Description: "add changelog (#7)", Repo: "user/a", Ref: "#7", At: day.Add(3 * time.Hour)},
+		}},
+		{Title: SectionFixes, Entries: []ChangelogEntry{
+			{Description: "handle empty pages", Repo: "user/a", Ref: "ccc", At: day.Add(2 * time.Hour)},
+		}},
+		{Title: SectionOther, Entries: []ChangelogEntry{
+			{Description: "docs: explain tokens", Repo: "user/b", Ref: "ddd", At: day.Add(time.Hour)},
+		}},
+	}
+	if !reflect.DeepEqual(changelog.Sections, want) {
+		t.Errorf("Sections = %+v, want %+v", changelog.Sections, want)
+	}
+
+	if got := BuildChangelog(nil, time.Time{}, day).Sections; len(got) != 0 {
+		t.Errorf("Sections of no events = %v, want none", got)
+	}
+}
+
+func TestActivityService_GetChangelog(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	events := []github.GitHubEvent{
+		pushEvent("user/a", now.Add(-time.Hour), "aaa:fix: one"),
+		pushEvent("user/b", now.Add(-2*time.Hour), "bbb:feat: two"),
+		pushEvent("user/a", now.AddDate(0, 0, -10), "ccc:feat: old"),
+	}
+	service := NewActivityService(
+		github.NewMockEventRepository(events, nil),
+		WithClock(func() time.Time { return now }),
+	)
+
+	changelog, err := service.GetChangelog("testuser", EventFilter{Repo: "user/a", Since: now.AddDate(0, 0, -7)})
+	if err != nil {
+		t.Fatalf("GetChangelog() error = %v", err)
+	}
+	if !changelog.Until.Equal(now) {
+		t.Errorf("Until = %v, want now", changelog.Until)
+	}
+	if len(changelog.Sections) != 1 || changelog.Sections[0].Title != SectionFixes {
+		t.Errorf("Sections = %+v, want only the fix to user/a", changelog.Sections)
+	}
+}
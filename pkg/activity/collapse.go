@@ -0,0 +1,124 @@
+package activity
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/alnah/github-activity/internal/messages"
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// Collapse - Consecutive pushes to one branch shown as a single activity
+
+// pushTarget returns the repository and branch a push went to, or false for
+// other events and pushes whose payload cannot be read
+func pushTarget(event github.GitHubEvent) (string, string, bool) {
+	if github.EventType(event.Type) != github.EventTypePush {
+		return "", "", false
+	}
+	payload, ok := event.TypedPayload().(*github.PushPayload)
+	if !ok {
+		return "", "", false
+	}
+	return event.Repo.Name, payload.GetBranch(), true
+}
+
+// groupEvents splits events into runs of consecutive pushes to the same
+// repository and branch when collapse is set; every other event, and every
+// event without collapse, is a run of its own
+func groupEvents(events []github.GitHubEvent, collapse bool) [][]github.GitHubEvent {
+	groups := make([][]github.GitHubEvent, 0, len(events))
+	for _, event := range events {
+		if collapse && len(groups) > 0 {
+			last := groups[len(groups)-1]
+			repo, branch, isPush := pushTarget(event)
+			lastRepo, lastBranch, lastIsPush := pushTarget(last[0])
+			if isPush && lastIsPush && repo == lastRepo && branch == lastBranch {
+				groups[len(groups)-1] = append(last, event)
+				continue
+			}
+		}
+		groups = append(groups, []github.GitHubEvent{event})
+	}
+	return groups
+}
+
+// summarizeGroup summarizes a run of events as its newest one; a run of
+// several pushes reads "Pushed 17 commits to repo (branch: main) over 4 pushes"
+func (s *ActivityService) summarizeGroup(group []github.GitHubEvent) ActivitySummary {
+	summary := s.Summarize(group[0])
+	if len(group) == 1 {
+		return summary
+	}
+
+	repo, branch, _ := pushTarget(group[0])
+	commits := 0
+	for _, event := range group {
+		if payload, ok := event.TypedPayload().(*github.PushPayload); ok {
+			commits += payload.Size
+		}
+	}
+	summary.Description = fmt.Sprintf("Pushed %s to %s (branch: %s) over %s",
+		messages.Plural("commits", commits), repo, branch, messages.Plural("pushes", len(group)))
+	if emoji := s.emoji[github.EventTypePush]; emoji != "" {
+		summary.Description = emoji + " " + summary.Description
+	}
+	summary.Fields["size"] = strconv.Itoa(commits)
+	summary.Fields["pushes"] = strconv.Itoa(len(group))
+	return summary
+}
+
+// detailGroup is summarizeGroup for detailed activities, listing the
+// commits of every push in the run
+func (s *ActivityService) detailGroup(group []github.GitHubEvent) DetailedActivity {
+	activity := s.SummarizeDetailed(group[0])
+	if len(group) == 1 {
+		return activity
+	}
+
+	activity.ActivitySummary = s.summarizeGroup(group)
+	for _, event := range group[1:] {
+		more := s.SummarizeDetailed(event)
+		activity.CommitCount += more.CommitCount
+		activity.Commits = append(activity.Commits, more.Commits...)
+	}
+	return activity
+}
+
+// matching returns the events f matches
+func (f *EventFilter) matching(events []github.GitHubEvent) []github.GitHubEvent {
+	matched := make([]github.GitHubEvent, 0, len(events))
+	for _, event := range events {
+		if f.Matches(event) {
+			matched = append(matched, event)
+		}
+	}
+	return matched
+}
+
+// groups returns the matching events, grouped when f.Collapse is set, up to
+// f.MaxLimit groups
+func (f *EventFilter) groups(events []github.GitHubEvent) [][]github.GitHubEvent {
+	groups := groupEvents(f.matching(events), f.Collapse)
+	if f.MaxLimit > 0 && len(groups) > f.MaxLimit {
+		groups = groups[:f.MaxLimit]
+	}
+	return groups
+}
+
+// satisfied reports whether events, newest first, already hold every
+// activity f can show: MaxLimit of them, or the events before f.Since. A
+// collapsed run may go on in the next page, so it needs one group more.
+func (f *EventFilter) satisfied(events []github.GitHubEvent) bool {
+	if len(events) == 0 {
+		return false
+	}
+	if !f.Since.IsZero() && events[len(events)-1].CreatedAt.Before(f.Since) {
+		return true
+	}
+	groups := len(groupEvents(f.matching(events), f.Collapse))
+	if f.Collapse {
+		return groups > f.MaxLimit
+	}
+	return groups >= f.MaxLimit
+}
@@ -0,0 +1,152 @@
+package activity
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// push builds a PushEvent of size commits to repo and branch
+func push(id, repo, branch string, size int) github.GitHubEvent {
+	return github.GitHubEvent{
+		ID:   id,
+		Type: "PushEvent",
+		Repo: github.Repo{Name: repo},
+		Payload: json.RawMessage(fmt.Sprintf(
+			`{"size": %d, "ref": "refs/heads/%s", "commits": [{"sha": "sha%s", "message": "commit %s"}]}`,
+			size, branch, id, id,
+		)),
+	}
+}
+
+func TestGroupEvents(t *testing.T) {
+	watch := github.GitHubEvent{ID: "w", Type: "WatchEvent", Repo: github.Repo{Name: "user/repo"}}
+
+	tests := []struct {
+		name     string
+		events   []github.GitHubEvent
+		collapse bool
+		expected [][]string
+	}{
+		{
+			name:     "without collapse",
+			events:   []github.GitHubEvent{push("1", "user/repo", "main", 1), push("2", "user/repo", "main", 1)},
+			expected: [][]string{{"1"}, {"2"}},
+		},
+		{
+			name:     "same branch merges",
+			events:   []github.GitHubEvent{push("1", "user/repo", "main", 1), push("2", "user/repo", "main", 1)},
+			collapse: true,
+			expected: [][]string{{"1", "2"}},
+		},
+		{
+			name:     "other branch breaks the run",
+			events:   []github.GitHubEvent{push("1", "user/repo", "main", 1), push("2", "user/repo", "dev", 1)},
+			collapse: true,
+			expected: [][]string{{"1"}, {"2"}},
+		},
+		{
+			name:     "other repository breaks the run",
+			events:   []github.GitHubEvent{push("1", "user/repo", "main", 1), push("2", "user/other", "main", 1)},
+			collapse: true,
+			expected: [][]string{{"1"}, {"2"}},
+		},
+		{
+			name: "interleaved event breaks the run",
+			events: []github.GitHubEvent{
+				push("1", "user/repo", "main", 1), watch, push("2", "user/repo", "main", 1),
+			},
+			collapse: true,
+			expected: [][]string{{"1"}, {"w"}, {"2"}},
+		},
+		{
+			name:     "non-push events stay apart",
+			events:   []github.GitHubEvent{watch, watch},
+			collapse: true,
+			expected: [][]string{{"w"}, {"w"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got [][]string
+			for _, group := range groupEvents(tt.events, tt.collapse) {
+				var ids []string
+				for _, event := range group {
+					ids = append(ids, event.ID)
+				}
+				got = append(got, ids)
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("groupEvents() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestActivityService_GetUserActivity_Collapse(t *testing.T) {
+	events := []github.GitHubEvent{
+		push("1", "user/repo", "main", 3),
+		push("2", "user/repo", "main", 2),
+		{ID: "3", Type: "WatchEvent", Repo: github.Repo{Name: "user/other"}},
+		push("4", "user/repo", "main", 1),
+	}
+	service := NewActivityService(github.NewMockEventRepository(events, nil))
+
+	summaries, err := service.GetUserActivity("testuser", EventFilter{Collapse: true})
+	if err != nil {
+		t.Fatalf("GetUserActivity() error = %v", err)
+	}
+	if len(summaries) != 3 {
+		t.Fatalf("Expected 3 activities, got %d", len(summaries))
+	}
+
+	first := summaries[0]
+	if want := "Pushed 5 commits to user/repo (branch: main) over 2 pushes"; first.Description != want {
+		t.Errorf("Description = %q, want %q", first.Description, want)
+	}
+	if first.Fields["size"] != "5" || first.Fields["pushes"] != "2" {
+		t.Errorf("Fields = %v, want size 5 over 2 pushes", first.Fields)
+	}
+	if summaries[2].Description != "Pushed 1 commit to user/repo (branch: main)" {
+		t.Errorf("A lone push should read as usual, got %q", summaries[2].Description)
+	}
+
+	limited, err := service.GetUserActivity("testuser", EventFilter{Collapse: true, MaxLimit: 2})
+	if err != nil {
+		t.Fatalf("GetUserActivity() error = %v", err)
+	}
+	if len(limited) != 2 {
+		t.Errorf("MaxLimit should count merged lines, got %d activities", len(limited))
+	}
+}
+
+func TestActivityService_GetUserActivityDetailed_Collapse(t *testing.T) {
+	events := []github.GitHubEvent{
+		push("1", "user/repo", "main", 1),
+		push("2", "user/repo", "main", 1),
+	}
+	service := NewActivityService(github.NewMockEventRepository(events, nil))
+
+	activities, err := service.GetUserActivityDetailed("testuser", EventFilter{Collapse: true})
+	if err != nil {
+		t.Fatalf("GetUserActivityDetailed() error = %v", err)
+	}
+	if len(activities) != 1 {
+		t.Fatalf("Expected 1 activity, got %d", len(activities))
+	}
+
+	activity := activities[0]
+	if activity.CommitCount != 2 {
+		t.Errorf("CommitCount = %d, want 2", activity.CommitCount)
+	}
+	if len(activity.Commits) != 2 || activity.Commits[1].SHA != "sha2" {
+		t.Errorf("Commits = %v, want the commits of both pushes", activity.Commits)
+	}
+	if want := "Pushed 2 commits to user/repo (branch: main) over 2 pushes"; activity.Description != want {
+		t.Errorf("Description = %q, want %q", activity.Description, want)
+	}
+}
@@ -0,0 +1,139 @@
+package activity
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// Commits - Pushed commits collected from push events
+
+// PushedCommit is a commit as it was first pushed
+type PushedCommit struct {
+	SHA      string    `json:"sha"`
+	Message  string    `json:"message"` // First line of the commit message
+	Author   string    `json:"author"`
+	Repo     string    `json:"repo"`
+	PushedAt time.Time `json:"pushed_at"`
+}
+
+// CommitCount is how many commits landed in one repository or on one day
+type CommitCount struct {
+	Key     string `json:"key"` // Repository name or YYYY-MM-DD day
+	Commits int    `json:"commits"`
+}
+
+// CommitReport lists the distinct commits a user pushed over the last few days
+type CommitReport struct {
+	Username string         `json:"username"`
+	Since    time.Time      `json:"since"`
+	Until    time.Time      `json:"until"`
+	Commits  []PushedCommit `json:"commits"` // Newest first
+	ByRepo   []CommitCount  `json:"by_repo"` // Most commits first
+	ByDay    []CommitCount  `json:"by_day"`  // Oldest day first
+}
+
+// CollectCommits gathers the commits of every push in events, newest first.
+// A commit pushed more than once, to another branch or after a rebase that
+// kept it, is listed once with its earliest push.
+func CollectCommits(events []github.GitHubEvent) []PushedCommit {
+	commits := pushedCommits(events)
+	for i := range commits {
+		message, _, _ := strings.Cut(commits[i].Message, "\n")
+		commits[i].Message = strings.TrimSpace(message)
+	}
+	return commits
+}
+
+// pushedCommits is CollectCommits keeping whole commit messages
+func pushedCommits(events []github.GitHubEvent) []PushedCommit {
+	seen := make(map[string]bool)
+	commits := make([]PushedCommit, 0)
+	// The feed is newest first, so walk it backwards to meet first pushes first
+	for i := len(events) - 1; i >= 0; i-- {
+		event := events[i]
+		details, err := event.GetCommitDetails()
+		if err != nil {
+			continue
+		}
+		for _, commit := range details {
+			if commit.SHA == "" || seen[commit.SHA] {
+				continue
+			}
+			seen[commit.SHA] = true
+			commits = append(commits, PushedCommit{
+				SHA:      commit.SHA,
+				Message:  commit.Message,
+				Author:   commit.Author.Name,
+				Repo:     event.Repo.Name,
+				PushedAt: event.CreatedAt,
+			})
+		}
+	}
+
+	sort.SliceStable(commits, func(i, j int) bool {
+		return commits[i].PushedAt.After(commits[j].PushedAt)
+	})
+	return commits
+}
+
+// countCommits counts commits under the key each one maps to
+func countCommits(commits []PushedCommit, key func(PushedCommit) string) []CommitCount {
+	counts := make(map[string]int)
+	for _, commit := range commits {
+		counts[key(commit)]++
+	}
+	result := make([]CommitCount, 0, len(counts))
+	for key, commits := range counts {
+		result = append(result, CommitCount{Key: key, Commits: commits})
+	}
+	return result
+}
+
+// GetCommitReport collects the distinct commits of matching pushes from the
+// last days days and counts them per repository and per calendar day
+func (s *ActivityService) GetCommitReport(
+	username string,
+	filter EventFilter,
+	days int,
+) (*CommitReport, error) {
+	// A partial feed still lists what was fetched
+	events, err := s.fetchEvents(context.Background(), username)
+	if _, partial := AsPartial(err); err != nil && !partial {
+		return nil, err
+	}
+
+	now := s.now()
+	report := &CommitReport{
+		Username: username,
+		Since:    now.AddDate(0, 0, -days),
+		Until:    now,
+	}
+	window := make([]github.GitHubEvent, 0, len(events))
+	for _, event := range events {
+		if !event.CreatedAt.Before(report.Since) && filter.Matches(event) {
+			window = append(window, event)
+		}
+	}
+	report.Commits = CollectCommits(window)
+
+	report.ByRepo = countCommits(report.Commits, func(commit PushedCommit) string {
+		return commit.Repo
+	})
+	sort.Slice(report.ByRepo, func(i, j int) bool {
+		if report.ByRepo[i].Commits != report.ByRepo[j].Commits {
+			return report.ByRepo[i].Commits > report.ByRepo[j].Commits
+		}
+		return report.ByRepo[i].Key < report.ByRepo[j].Key
+	})
+	report.ByDay = countCommits(report.Commits, func(commit PushedCommit) string {
+		return s.calendarTime(commit.PushedAt).Format("2006-01-02")
+	})
+	sort.Slice(report.ByDay, func(i, j int) bool {
+		return report.ByDay[i].Key < report.ByDay[j].Key
+	})
+	return report, err
+}
@@ -0,0 +1,88 @@
+package activity
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// pushEvent builds a push of commits, each given as sha:message
+func pushEvent(repo string, at time.Time, commits ...string) github.GitHubEvent {
+	payload := github.PushPayload{Size: len(commits)}
+	for _, commit := range commits {
+		sha, message := commit[:3], commit[4:]
+		entry := github.Commit{SHA: sha, Message: message}
+		entry.Author.Name = "Mona"
+		payload.Commits = append(payload.Commits, entry)
+	}
+	raw, _ := json.Marshal(payload)
+	return github.GitHubEvent{Type: "PushEvent", Repo: github.Repo{Name: repo}, Payload: raw, CreatedAt: at}
+}
+
+func TestCollectCommits(t *testing.T) {
+	day := time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)
+	events := []github.GitHubEvent{
+		// The feature branch merged into main pushes its commits again
+		pushEvent("user/a", day.Add(2*time.Hour), "aaa:Fix login\n\nLonger explanation", "ccc:Merge feature"),
+		{Type: "WatchEvent", Repo: github.Repo{Name: "user/b"}, CreatedAt: day.Add(time.Hour)},
+		pushEvent("user/a", day, "aaa:Fix login\n\nLonger explanation"),
+	}
+
+	want := []PushedCommit{
+		{SHA: "ccc", Message: "Merge feature", Author: "Mona", Repo: "user/a", PushedAt: day.Add(2 * time.Hour)},
+		{SHA: "aaa", Message: "Fix login", Author: "Mona", Repo: "user/a", PushedAt: day},
+	}
+	if got := CollectCommits(events); !reflect.DeepEqual(got, want) {
+		t.Errorf("CollectCommits() = %+v, want %+v", got, want)
+	}
+	if got := CollectCommits(nil); len(got) != 0 {
+		t.Errorf("CollectCommits(nil) = %v, want none", got)
+	}
+}
+
+func TestActivityService_GetCommitReport(t *testing.T) {
+	now := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	events := []github.GitHubEvent{
+		pushEvent("user/a", now.Add(-time.Hour), "aaa:One", "bbb:Two"),
+		pushEvent("user/b", now.Add(-2*time.Hour), "ccc:Three"),
+		pushEvent("user/b", now.Add(-26*time.Hour), "ddd:Four", "ccc:Three"),
+		// Outside the window
+		pushEvent("user/old", now.AddDate(0, 0, -10), "eee:Old"),
+	}
+	service := NewActivityService(
+		github.NewMockEventRepository(events, nil),
+		WithClock(func() time.Time { return now }),
+		WithLocation(time.UTC),
+	)
+
+	report, err := service.GetCommitReport("testuser", EventFilter{}, 7)
+	if err != nil {
+		t.Fatalf("GetCommitReport() error = %v", err)
+	}
+	if len(report.Commits) != 4 {
+		t.Fatalf("got %d commits, want 4 distinct: %+v", len(report.Commits), report.Commits)
+	}
+	if got := report.Commits[3]; got.SHA != "ccc" || !got.PushedAt.Equal(now.Add(-26*time.Hour)) {
+		t.Errorf("oldest commit = %+v, want ccc at its first push", got)
+	}
+	wantRepos := []CommitCount{{Key: "user/a", Commits: 2}, {Key: "user/b", Commits: 2}}
+	if !reflect.DeepEqual(report.ByRepo, wantRepos) {
+		t.Errorf("ByRepo = %v, want %v", report.ByRepo, wantRepos)
+	}
+	wantDays := []CommitCount{{Key: "2024-01-14", Commits: 2}, {Key: "2024-01-15", Commits: 2}}
+	if !reflect.DeepEqual(report.ByDay, wantDays) {
+		t.Errorf("ByDay = %v, want %v", report.ByDay, wantDays)
+	}
+
+	// Filters narrow the report
+	report, err = service.GetCommitReport("testuser", EventFilter{Repo: "user/a"}, 7)
+	if err != nil {
+		t.Fatalf("GetCommitReport() error = %v", err)
+	}
+	if len(report.Commits) != 2 || len(report.ByRepo) != 1 {
+		t.Errorf("Filtered report = %+v, want the 2 commits of user/a", report)
+	}
+}
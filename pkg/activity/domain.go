@@ -0,0 +1,402 @@
+package activity
+
+import (
+	"encoding/base64"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alnah/github-activity/internal/messages"
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// Timeline Analysis - Filtering, paging and patterns over event feeds
+
+// GitHub only exposes a bounded window of recent events
+const (
+	MaxFeedEvents = 300
+	MaxFeedAge    = 90 * 24 * time.Hour
+)
+
+// TimelineGap explains why a fetched timeline is likely missing older events
+type TimelineGap struct {
+	Reason      string
+	OldestEvent time.Time
+}
+
+// DetectTimelineGap reports whether the events hit GitHub's feed caps, meaning
+// older history was likely truncated. It returns nil when the feed looks complete.
+func DetectTimelineGap(events []github.GitHubEvent, now time.Time) *TimelineGap {
+	if len(events) == 0 {
+		return nil
+	}
+
+	oldest := events[0].CreatedAt
+	for _, event := range events {
+		if event.CreatedAt.Before(oldest) {
+			oldest = event.CreatedAt
+		}
+	}
+
+	if len(events) >= MaxFeedEvents {
+		return &TimelineGap{
+			Reason:      fmt.Sprintf("GitHub only exposes the latest %d events", MaxFeedEvents),
+			OldestEvent: oldest,
+		}
+	}
+
+	// Allow a day of slack since GitHub prunes events in batches
+	if !oldest.IsZero() && now.Sub(oldest) >= MaxFeedAge-24*time.Hour {
+		return &TimelineGap{
+			Reason:      "GitHub only exposes events from the last 90 days",
+			OldestEvent: oldest,
+		}
+	}
+
+	return nil
+}
+
+// Spike detection thresholds: a period is a spike when it has at least
+// SpikeMinEvents events and SpikeFactor times its baseline
+const (
+	SpikeFactor    = 3.0
+	SpikeMinEvents = 10
+)
+
+// Spike is a period whose event volume far exceeds the rolling baseline
+type Spike struct {
+	Repository string  `json:"repository,omitempty"` // Empty for the user's activity across all repositories
+	Count      int     `json:"count"`
+	Baseline   float64 `json:"baseline"` // Average events per period before the current one
+}
+
+// DetectSpikes compares each repository's events in the period ending at
+// now, and the user's total, against the average of the preceding
+// baselinePeriods periods
+func DetectSpikes(events []github.GitHubEvent, now time.Time, period time.Duration, baselinePeriods int) []Spike {
+	current := make(map[string]int)
+	previous := make(map[string]int)
+	start := now.Add(-period)
+	baselineStart := start.Add(-time.Duration(baselinePeriods) * period)
+
+	for _, event := range events {
+		switch {
+		case event.CreatedAt.After(now):
+			continue
+		case event.CreatedAt.After(start):
+			current[event.Repo.Name]++
+			current[""]++
+		case event.CreatedAt.After(baselineStart):
+			previous[event.Repo.Name]++
+			previous[""]++
+		}
+	}
+
+	spikes := make([]Spike, 0)
+	for repo, count := range current {
+		baseline := 0.0
+		if baselinePeriods > 0 {
+			baseline = float64(previous[repo]) / float64(baselinePeriods)
+		}
+		if count < SpikeMinEvents || float64(count) < SpikeFactor*math.Max(baseline, 1) {
+			continue
+		}
+		spikes = append(spikes, Spike{Repository: repo, Count: count, Baseline: baseline})
+	}
+
+	sort.Slice(spikes, func(i, j int) bool {
+		if spikes[i].Count != spikes[j].Count {
+			return spikes[i].Count > spikes[j].Count
+		}
+		return spikes[i].Repository < spikes[j].Repository
+	})
+	return spikes
+}
+
+// ActivityStreaks summarizes how consistently a user is active, counted in
+// calendar days
+type ActivityStreaks struct {
+	Current           int       `json:"current"` // Active days in a row up to today, or yesterday while today is empty
+	Longest           int       `json:"longest"`
+	LongestStart      time.Time `json:"longest_start"`
+	ActiveDays        int       `json:"active_days"`
+	Weeks             int       `json:"weeks"` // Weeks from the first active day to today, at least 1
+	ActiveDaysPerWeek float64   `json:"active_days_per_week"`
+}
+
+// ComputeStreaks measures streaks from the set of active days, given as
+// midnight times in the user's zone, up to today
+func ComputeStreaks(days []time.Time, today time.Time) ActivityStreaks {
+	var streaks ActivityStreaks
+	if len(days) == 0 {
+		return streaks
+	}
+
+	active := make(map[string]bool, len(days))
+	for _, day := range days {
+		active[day.Format("2006-01-02")] = true
+	}
+	sorted := append([]time.Time(nil), days...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Before(sorted[j]) })
+
+	run := 0
+	var runStart time.Time
+	previous := ""
+	for _, day := range sorted {
+		key := day.Format("2006-01-02")
+		if key == previous {
+			continue
+		}
+		if previous != "" && day.AddDate(0, 0, -1).Format("2006-01-02") == previous {
+			run++
+		} else {
+			run, runStart = 1, day
+		}
+		if run > streaks.Longest {
+			streaks.Longest, streaks.LongestStart = run, runStart
+		}
+		previous = key
+	}
+	streaks.ActiveDays = len(active)
+
+	// Today still counts as part of the streak until it is over
+	day := today
+	if !active[day.Format("2006-01-02")] {
+		day = day.AddDate(0, 0, -1)
+	}
+	for active[day.Format("2006-01-02")] {
+		streaks.Current++
+		day = day.AddDate(0, 0, -1)
+	}
+
+	// Round so days shortened or lengthened by DST still count as one
+	span := int(math.Round(today.Sub(sorted[0]).Hours()/24)) + 1
+	streaks.Weeks = (span + 6) / 7
+	if streaks.Weeks < 1 {
+		streaks.Weeks = 1
+	}
+	streaks.ActiveDaysPerWeek = float64(streaks.ActiveDays) / float64(streaks.Weeks)
+	return streaks
+}
+
+// AuthorCount is how many commits one author pushed
+type AuthorCount struct {
+	Author  string `json:"author"`
+	Commits int    `json:"commits"`
+}
+
+// CountCommitAuthors counts the commits of every push in events by author
+// name, most commits first. Commits without an author name are skipped.
+func CountCommitAuthors(events []github.GitHubEvent) []AuthorCount {
+	counts := make(map[string]int)
+	for _, event := range events {
+		commits, err := event.GetCommitDetails()
+		if err != nil {
+			continue
+		}
+		for _, commit := range commits {
+			if commit.Author.Name != "" {
+				counts[commit.Author.Name]++
+			}
+		}
+	}
+
+	authors := make([]AuthorCount, 0, len(counts))
+	for author, commits := range counts {
+		authors = append(authors, AuthorCount{Author: author, Commits: commits})
+	}
+	sort.Slice(authors, func(i, j int) bool {
+		if authors[i].Commits != authors[j].Commits {
+			return authors[i].Commits > authors[j].Commits
+		}
+		return authors[i].Author < authors[j].Author
+	})
+	return authors
+}
+
+// EventCursor marks a position in a newest-first timeline. Paging from a
+// cursor stays stable while new events arrive, since those sort before it.
+type EventCursor struct {
+	ID        string
+	CreatedAt time.Time
+}
+
+// Encode returns the cursor as an opaque URL-safe token
+func (c EventCursor) Encode() string {
+	raw := fmt.Sprintf("%d:%s", c.CreatedAt.UnixNano(), c.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeEventCursor parses a token produced by EventCursor.Encode
+func DecodeEventCursor(token string) (EventCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return EventCursor{}, fmt.Errorf("invalid cursor")
+	}
+	nanos, id, ok := strings.Cut(string(raw), ":")
+	if !ok || id == "" {
+		return EventCursor{}, fmt.Errorf("invalid cursor")
+	}
+	n, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return EventCursor{}, fmt.Errorf("invalid cursor")
+	}
+	return EventCursor{ID: id, CreatedAt: time.Unix(0, n).UTC()}, nil
+}
+
+// before reports whether event sorts after the cursor in a newest-first timeline
+func (c EventCursor) before(event github.GitHubEvent) bool {
+	if !event.CreatedAt.Equal(c.CreatedAt) {
+		return event.CreatedAt.Before(c.CreatedAt)
+	}
+	return github.CompareEventIDs(event.ID, c.ID) < 0
+}
+
+// PageEvents returns up to size events, newest first, that come after the
+// cursor token (empty for the first page), plus the token for the next page.
+// The next token is empty on the last page.
+func PageEvents(events []github.GitHubEvent, after string, size int) ([]github.GitHubEvent, string, error) {
+	if size <= 0 {
+		return nil, "", fmt.Errorf("page size must be positive")
+	}
+
+	timeline := github.MergeEvents(events)
+	start := 0
+	if after != "" {
+		cursor, err := DecodeEventCursor(after)
+		if err != nil {
+			return nil, "", err
+		}
+		for start < len(timeline) && !cursor.before(timeline[start]) {
+			start++
+		}
+	}
+
+	end := start + size
+	if end >= len(timeline) {
+		return timeline[start:], "", nil
+	}
+
+	last := timeline[end-1]
+	next := EventCursor{ID: last.ID, CreatedAt: last.CreatedAt}.Encode()
+	return timeline[start:end], next, nil
+}
+
+// LoadTimezone resolves an IANA zone name such as "America/New_York", or
+// "local" for the system zone. An empty name returns nil.
+func LoadTimezone(name string) (*time.Location, error) {
+	if name == "" {
+		return nil, nil
+	}
+	if strings.EqualFold(name, "local") {
+		return time.Local, nil
+	}
+	location, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone: %s", name)
+	}
+	return location, nil
+}
+
+// HumanizeTime describes t relative to now, e.g. "5 minutes ago" or
+// "3 days ago". Times in the future read as "just now".
+func HumanizeTime(t, now time.Time) string {
+	elapsed := now.Sub(t)
+
+	switch {
+	case elapsed < time.Minute:
+		return "just now"
+	case elapsed < time.Hour:
+		return messages.Plural("minutes_ago", int(elapsed/time.Minute))
+	case elapsed < 24*time.Hour:
+		return messages.Plural("hours_ago", int(elapsed/time.Hour))
+	case elapsed < 30*24*time.Hour:
+		return messages.Plural("days_ago", int(elapsed/(24*time.Hour)))
+	case elapsed < 365*24*time.Hour:
+		return messages.Plural("months_ago", int(elapsed/(30*24*time.Hour)))
+	default:
+		return messages.Plural("years_ago", int(elapsed/(365*24*time.Hour)))
+	}
+}
+
+// EventFilter represents filtering criteria for events
+type EventFilter struct {
+	Type     string
+	Repo     string    // owner/repo, or owner for every repository it owns
+	Actor    string    // Login of who acted, for shared feeds such as -received
+	Action   string    // Payload action such as opened or published; closed includes merged
+	Since    time.Time // Inclusive; zero for no lower bound
+	Until    time.Time // Exclusive; zero for no upper bound
+	MaxLimit int
+	Collapse bool // Merge consecutive pushes to one branch into a single activity
+}
+
+// Matches checks if an event matches the filter criteria
+func (f *EventFilter) Matches(event github.GitHubEvent) bool {
+	if f.Type != "" && !strings.EqualFold(event.Type, f.Type) {
+		return false
+	}
+	if !f.Since.IsZero() && event.CreatedAt.Before(f.Since) {
+		return false
+	}
+	if !f.Until.IsZero() && !event.CreatedAt.Before(f.Until) {
+		return false
+	}
+	if f.Actor != "" && !strings.EqualFold(event.Actor.Login, f.Actor) {
+		return false
+	}
+	if f.Action != "" {
+		action := event.Action()
+		if !strings.EqualFold(action, f.Action) && !(strings.EqualFold(f.Action, "closed") && action == "merged") {
+			return false
+		}
+	}
+	if f.Repo != "" {
+		if strings.Contains(f.Repo, "/") {
+			return strings.EqualFold(event.Repo.Name, f.Repo)
+		}
+		owner, _, _ := strings.Cut(event.Repo.Name, "/")
+		return strings.EqualFold(owner, f.Repo)
+	}
+	return true
+}
+
+// ParseDateRange parses -since and -until values, each a date (2006-01-02)
+// in location or an RFC 3339 time. A date given as until includes that
+// whole day. Empty values leave that end open.
+func ParseDateRange(since, until string, location *time.Location) (time.Time, time.Time, error) {
+	if location == nil {
+		location = time.Local
+	}
+	parse := func(name, value string, endOfDay bool) (time.Time, error) {
+		if value == "" {
+			return time.Time{}, nil
+		}
+		if t, err := time.ParseInLocation("2006-01-02", value, location); err == nil {
+			if endOfDay {
+				t = t.AddDate(0, 0, 1)
+			}
+			return t, nil
+		}
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			return t, nil
+		}
+		return time.Time{}, fmt.Errorf("invalid %s: %s (use YYYY-MM-DD or an RFC 3339 time)", name, value)
+	}
+
+	start, err := parse("since", since, false)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	end, err := parse("until", until, true)
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	if !start.IsZero() && !end.IsZero() && !start.Before(end) {
+		return time.Time{}, time.Time{}, fmt.Errorf("since must be before until")
+	}
+	return start, end, nil
+}
@@ -0,0 +1,492 @@
+package activity
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+func TestEventFilter_Matches(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   EventFilter
+		event    github.GitHubEvent
+		expected bool
+	}{
+		{
+			name:     "no filter matches any",
+			filter:   EventFilter{},
+			event:    github.GitHubEvent{Type: "PushEvent"},
+			expected: true,
+		},
+		{
+			name:     "type filter matches",
+			filter:   EventFilter{Type: "PushEvent"},
+			event:    github.GitHubEvent{Type: "PushEvent"},
+			expected: true,
+		},
+		{
+			name:     "type filter case insensitive",
+			filter:   EventFilter{Type: "pushevent"},
+			event:    github.GitHubEvent{Type: "PushEvent"},
+			expected: true,
+		},
+		{
+			name:     "type filter no match",
+			filter:   EventFilter{Type: "IssuesEvent"},
+			event:    github.GitHubEvent{Type: "PushEvent"},
+			expected: false,
+		},
+		{
+			name:     "repo filter matches",
+			filter:   EventFilter{Repo: "Alnah/github-activity"},
+			event:    github.GitHubEvent{Repo: github.Repo{Name: "alnah/github-activity"}},
+			expected: true,
+		},
+		{
+			name:     "repo filter no match",
+			filter:   EventFilter{Repo: "alnah/github-activity"},
+			event:    github.GitHubEvent{Repo: github.Repo{Name: "alnah/dotfiles"}},
+			expected: false,
+		},
+		{
+			name:     "owner filter matches any repo of the owner",
+			filter:   EventFilter{Repo: "alnah"},
+			event:    github.GitHubEvent{Repo: github.Repo{Name: "alnah/dotfiles"}},
+			expected: true,
+		},
+		{
+			name:     "owner filter is not a prefix match",
+			filter:   EventFilter{Repo: "aln"},
+			event:    github.GitHubEvent{Repo: github.Repo{Name: "alnah/dotfiles"}},
+			expected: false,
+		},
+		{
+			name:     "actor filter matches case-insensitively",
+			filter:   EventFilter{Actor: "Hubot"},
+			event:    github.GitHubEvent{Actor: github.Actor{Login: "hubot"}},
+			expected: true,
+		},
+		{
+			name:     "actor filter no match",
+			filter:   EventFilter{Actor: "hubot"},
+			event:    github.GitHubEvent{Actor: github.Actor{Login: "octocat"}},
+			expected: false,
+		},
+		{
+			name:     "action filter matches",
+			filter:   EventFilter{Action: "opened"},
+			event:    github.GitHubEvent{Type: "IssuesEvent", Payload: json.RawMessage(`{"action": "opened"}`)},
+			expected: true,
+		},
+		{
+			name:   "closed action includes merged pull requests",
+			filter: EventFilter{Action: "closed"},
+			event: github.GitHubEvent{
+				Type:    "PullRequestEvent",
+				Payload: json.RawMessage(`{"action": "closed", "pull_request": {"merged": true}}`),
+			},
+			expected: true,
+		},
+		{
+			name:   "merged action skips unmerged pull requests",
+			filter: EventFilter{Action: "merged"},
+			event: github.GitHubEvent{
+				Type:    "PullRequestEvent",
+				Payload: json.RawMessage(`{"action": "closed", "pull_request": {"merged": false}}`),
+			},
+			expected: false,
+		},
+		{
+			name:     "action filter skips events without an action",
+			filter:   EventFilter{Action: "created"},
+			event:    github.GitHubEvent{Type: "WatchEvent"},
+			expected: false,
+		},
+		{
+			name:     "type and repo filters combine",
+			filter:   EventFilter{Type: "PushEvent", Repo: "alnah"},
+			event:    github.GitHubEvent{Type: "WatchEvent", Repo: github.Repo{Name: "alnah/dotfiles"}},
+			expected: false,
+		},
+		{
+			name:     "since is inclusive",
+			filter:   EventFilter{Since: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+			event:    github.GitHubEvent{CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+			expected: true,
+		},
+		{
+			name:     "before since",
+			filter:   EventFilter{Since: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+			event:    github.GitHubEvent{CreatedAt: time.Date(2023, 12, 31, 23, 59, 0, 0, time.UTC)},
+			expected: false,
+		},
+		{
+			name:     "until is exclusive",
+			filter:   EventFilter{Until: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+			event:    github.GitHubEvent{CreatedAt: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.filter.Matches(tt.event)
+			if result != tt.expected {
+				t.Errorf("Matches() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseDateRange(t *testing.T) {
+	paris, err := time.LoadLocation("Europe/Paris")
+	if err != nil {
+		t.Skip("time zone database unavailable")
+	}
+
+	tests := []struct {
+		name        string
+		since       string
+		until       string
+		wantSince   time.Time
+		wantUntil   time.Time
+		expectError bool
+	}{
+		{name: "open range"},
+		{
+			name:      "dates cover whole days in the location",
+			since:     "2024-01-01",
+			until:     "2024-01-31",
+			wantSince: time.Date(2024, 1, 1, 0, 0, 0, 0, paris),
+			wantUntil: time.Date(2024, 2, 1, 0, 0, 0, 0, paris),
+		},
+		{
+			name:      "RFC 3339 times are exact",
+			since:     "2024-01-01T10:00:00Z",
+			wantSince: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC),
+		},
+		{name: "invalid date", since: "01/02/2024", expectError: true},
+		{name: "reversed range", since: "2024-02-01", until: "2024-01-01", expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			since, until, err := ParseDateRange(tt.since, tt.until, paris)
+			if tt.expectError != (err != nil) {
+				t.Fatalf("ParseDateRange() error = %v, expectError %v", err, tt.expectError)
+			}
+			if !since.Equal(tt.wantSince) || !until.Equal(tt.wantUntil) {
+				t.Errorf("ParseDateRange() = %v, %v, want %v, %v", since, until, tt.wantSince, tt.wantUntil)
+			}
+		})
+	}
+}
+
+func TestDetectTimelineGap(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	recentEvents := func(count int) []github.GitHubEvent {
+		events := make([]github.GitHubEvent, count)
+		for i := range events {
+			events[i] = github.GitHubEvent{CreatedAt: now.Add(-time.Duration(i) * time.Hour)}
+		}
+		return events
+	}
+
+	t.Run("empty feed", func(t *testing.T) {
+		if gap := DetectTimelineGap(nil, now); gap != nil {
+			t.Errorf("DetectTimelineGap() = %+v, want nil", gap)
+		}
+	})
+
+	t.Run("short recent feed", func(t *testing.T) {
+		if gap := DetectTimelineGap(recentEvents(20), now); gap != nil {
+			t.Errorf("DetectTimelineGap() = %+v, want nil", gap)
+		}
+	})
+
+	t.Run("event cap reached", func(t *testing.T) {
+		gap := DetectTimelineGap(recentEvents(MaxFeedEvents), now)
+		if gap == nil || !strings.Contains(gap.Reason, "300 events") {
+			t.Errorf("DetectTimelineGap() = %+v, want event cap", gap)
+		}
+	})
+
+	t.Run("age cap reached", func(t *testing.T) {
+		events := recentEvents(5)
+		events = append(events, github.GitHubEvent{CreatedAt: now.Add(-89 * 24 * time.Hour)})
+
+		gap := DetectTimelineGap(events, now)
+		if gap == nil || !strings.Contains(gap.Reason, "90 days") {
+			t.Fatalf("DetectTimelineGap() = %+v, want age cap", gap)
+		}
+		if !gap.OldestEvent.Equal(events[5].CreatedAt) {
+			t.Errorf("OldestEvent = %v, want %v", gap.OldestEvent, events[5].CreatedAt)
+		}
+	})
+}
+
+func TestDetectSpikes(t *testing.T) {
+	now := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	events := func(repo string, count int, ago time.Duration) []github.GitHubEvent {
+		result := make([]github.GitHubEvent, count)
+		for i := range result {
+			result[i] = github.GitHubEvent{Repo: github.Repo{Name: repo}, CreatedAt: now.Add(-ago)}
+		}
+		return result
+	}
+
+	tests := []struct {
+		name     string
+		events   []github.GitHubEvent
+		expected []Spike
+	}{
+		{
+			name:     "no events",
+			events:   nil,
+			expected: []Spike{},
+		},
+		{
+			name:     "burst with no history",
+			events:   events("bot/repo", 50, 10*time.Minute),
+			expected: []Spike{{Count: 50}, {Repository: "bot/repo", Count: 50}},
+		},
+		{
+			name:     "below the minimum",
+			events:   events("user/repo", SpikeMinEvents-1, 10*time.Minute),
+			expected: []Spike{},
+		},
+		{
+			name: "steady activity is not a spike",
+			events: append(
+				events("user/repo", 12, 10*time.Minute),
+				events("user/repo", 24*10, 5*time.Hour)...,
+			),
+			expected: []Spike{},
+		},
+		{
+			name: "one busy repository among quiet ones",
+			events: append(append(
+				events("bot/repo", 40, 30*time.Minute),
+				events("bot/repo", 24, 3*time.Hour)...),
+				events("user/repo", 2, 20*time.Minute)...,
+			),
+			expected: []Spike{{Count: 42, Baseline: 1}, {Repository: "bot/repo", Count: 40, Baseline: 1}},
+		},
+		{
+			name:     "events older than the baseline are ignored",
+			events:   append(events("bot/repo", 30, 5*time.Minute), events("bot/repo", 1000, 48*time.Hour)...),
+			expected: []Spike{{Count: 30}, {Repository: "bot/repo", Count: 30}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spikes := DetectSpikes(tt.events, now, time.Hour, 24)
+			if !reflect.DeepEqual(spikes, tt.expected) {
+				t.Errorf("DetectSpikes() = %+v, want %+v", spikes, tt.expected)
+			}
+		})
+	}
+}
+
+func TestEventCursor_EncodeDecode(t *testing.T) {
+	cursor := EventCursor{ID: "12345", CreatedAt: time.Date(2024, 1, 1, 12, 0, 0, 5, time.UTC)}
+
+	decoded, err := DecodeEventCursor(cursor.Encode())
+	if err != nil {
+		t.Fatalf("DecodeEventCursor() error = %v", err)
+	}
+	if decoded.ID != cursor.ID || !decoded.CreatedAt.Equal(cursor.CreatedAt) {
+		t.Errorf("DecodeEventCursor() = %+v, want %+v", decoded, cursor)
+	}
+
+	for _, token := range []string{"!!!", "bm9jb2xvbg", "eDox"} {
+		if _, err := DecodeEventCursor(token); err == nil {
+			t.Errorf("DecodeEventCursor(%q) should fail", token)
+		}
+	}
+}
+
+func TestPageEvents(t *testing.T) {
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	events := make([]github.GitHubEvent, 0)
+	for i := 1; i <= 5; i++ {
+		events = append(events, github.GitHubEvent{
+			ID:        strconv.Itoa(i),
+			CreatedAt: base.Add(time.Duration(i) * time.Minute),
+		})
+	}
+
+	ids := func(events []github.GitHubEvent) []string {
+		result := make([]string, len(events))
+		for i, event := range events {
+			result[i] = event.ID
+		}
+		return result
+	}
+
+	page, next, err := PageEvents(events, "", 2)
+	if err != nil {
+		t.Fatalf("PageEvents() error = %v", err)
+	}
+	if !reflect.DeepEqual(ids(page), []string{"5", "4"}) || next == "" {
+		t.Fatalf("first page = %v, next %q", ids(page), next)
+	}
+
+	// New events arriving between requests do not shift later pages
+	events = append(events, github.GitHubEvent{ID: "6", CreatedAt: base.Add(time.Hour)})
+
+	page, next, err = PageEvents(events, next, 2)
+	if err != nil {
+		t.Fatalf("PageEvents() error = %v", err)
+	}
+	if !reflect.DeepEqual(ids(page), []string{"3", "2"}) {
+		t.Errorf("second page = %v, want [3 2]", ids(page))
+	}
+
+	page, next, err = PageEvents(events, next, 2)
+	if err != nil {
+		t.Fatalf("PageEvents() error = %v", err)
+	}
+	if !reflect.DeepEqual(ids(page), []string{"1"}) || next != "" {
+		t.Errorf("last page = %v, next %q", ids(page), next)
+	}
+
+	if _, _, err := PageEvents(events, "", 0); err == nil {
+		t.Error("PageEvents() with size 0 should fail")
+	}
+	if _, _, err := PageEvents(events, "garbage!", 2); err == nil {
+		t.Error("PageEvents() with a bad cursor should fail")
+	}
+}
+
+func TestLoadTimezone(t *testing.T) {
+	tests := []struct {
+		name        string
+		expected    string
+		expectError bool
+	}{
+		{"", "", false},
+		{"local", "Local", false},
+		{"LOCAL", "Local", false},
+		{"UTC", "UTC", false},
+		{"America/New_York", "America/New_York", false},
+		{"Not/AZone", "", true},
+	}
+
+	for _, tt := range tests {
+		location, err := LoadTimezone(tt.name)
+		if (err != nil) != tt.expectError {
+			t.Errorf("LoadTimezone(%q) error = %v, expectError %v", tt.name, err, tt.expectError)
+			continue
+		}
+		got := ""
+		if location != nil {
+			got = location.String()
+		}
+		if got != tt.expected {
+			t.Errorf("LoadTimezone(%q) = %q, want %q", tt.name, got, tt.expected)
+		}
+	}
+}
+
+func TestHumanizeTime(t *testing.T) {
+	now := time.Date(2024, 6, 15, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		ago      time.Duration
+		expected string
+	}{
+		{-time.Hour, "just now"},
+		{30 * time.Second, "just now"},
+		{time.Minute, "1 minute ago"},
+		{45 * time.Minute, "45 minutes ago"},
+		{2 * time.Hour, "2 hours ago"},
+		{24 * time.Hour, "1 day ago"},
+		{3 * 24 * time.Hour, "3 days ago"},
+		{60 * 24 * time.Hour, "2 months ago"},
+		{400 * 24 * time.Hour, "1 year ago"},
+	}
+
+	for _, tt := range tests {
+		if got := HumanizeTime(now.Add(-tt.ago), now); got != tt.expected {
+			t.Errorf("HumanizeTime(now - %v) = %q, want %q", tt.ago, got, tt.expected)
+		}
+	}
+}
+
+func TestComputeStreaks(t *testing.T) {
+	today := time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)
+	day := func(offset int) time.Time { return today.AddDate(0, 0, -offset) }
+
+	tests := []struct {
+		name         string
+		days         []time.Time
+		current      int
+		longest      int
+		longestStart time.Time
+		activeDays   int
+		weeks        int
+	}{
+		{"no activity", nil, 0, 0, time.Time{}, 0, 0},
+		{"active today", []time.Time{day(0), day(1), day(1), day(2)}, 3, 3, day(2), 3, 1},
+		{"today still open", []time.Time{day(1), day(2)}, 2, 2, day(2), 2, 1},
+		{"broken streak", []time.Time{day(2), day(3)}, 0, 2, day(3), 2, 1},
+		{
+			"longest in the past",
+			[]time.Time{day(0), day(10), day(11), day(12), day(13)},
+			1, 4, day(13), 5, 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			streaks := ComputeStreaks(tt.days, today)
+
+			if streaks.Current != tt.current {
+				t.Errorf("Current = %d, want %d", streaks.Current, tt.current)
+			}
+			if streaks.Longest != tt.longest || !streaks.LongestStart.Equal(tt.longestStart) {
+				t.Errorf("Longest = %d from %v, want %d from %v",
+					streaks.Longest, streaks.LongestStart, tt.longest, tt.longestStart)
+			}
+			if streaks.ActiveDays != tt.activeDays {
+				t.Errorf("ActiveDays = %d, want %d", streaks.ActiveDays, tt.activeDays)
+			}
+			if streaks.Weeks != tt.weeks {
+				t.Errorf("Weeks = %d, want %d", streaks.Weeks, tt.weeks)
+			}
+		})
+	}
+}
+
+func TestCountCommitAuthors(t *testing.T) {
+	push := func(authors ...string) github.GitHubEvent {
+		commits := make([]string, 0, len(authors))
+		for i, author := range authors {
+			commits = append(commits, `{"sha": "`+strconv.Itoa(i)+`", "author": {"name": "`+author+`"}}`)
+		}
+		return github.GitHubEvent{
+			Type:    "PushEvent",
+			Payload: json.RawMessage(`{"commits": [` + strings.Join(commits, ",") + `]}`),
+		}
+	}
+	events := []github.GitHubEvent{
+		push("Jo", "Jane"),
+		{Type: "WatchEvent"},
+		push("Jane", ""),
+		push("Al"),
+	}
+
+	expected := []AuthorCount{{"Jane", 2}, {"Al", 1}, {"Jo", 1}}
+	if got := CountCommitAuthors(events); !reflect.DeepEqual(got, expected) {
+		t.Errorf("CountCommitAuthors() = %v, want %v", got, expected)
+	}
+}
@@ -0,0 +1,45 @@
+package activity
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// Emoji - Symbols put before descriptions with -emoji
+
+// DefaultEmoji is the prefix -emoji puts before each type's descriptions
+var DefaultEmoji = map[github.EventType]string{
+	github.EventTypePush:         "⬆️",
+	github.EventTypeWatch:        "⭐",
+	github.EventTypeIssues:       "🐛",
+	github.EventTypeIssueComment: "💬",
+	github.EventTypePullRequest:  "🔀",
+	github.EventTypeRelease:      "🏷️",
+	github.EventTypeFork:         "🍴",
+	github.EventTypeCreate:       "✨",
+	github.EventTypeDelete:       "🗑️",
+}
+
+// ParseEmojiMap parses emoji overrides written as "push=🚀, star=🌟". Types
+// may be names or aliases; an empty emoji turns the prefix off for that type.
+func ParseEmojiMap(value string) (map[github.EventType]string, error) {
+	emoji := make(map[github.EventType]string)
+	for _, pair := range strings.Split(value, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		name, symbol, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("%q is not type=emoji", strings.TrimSpace(pair))
+		}
+		eventType := github.ResolveEventType(strings.TrimSpace(name))
+		options := ActivityOptions{EventType: eventType}
+		if err := options.Validate(); err != nil {
+			return nil, err
+		}
+		emoji[github.EventType(eventType)] = strings.TrimSpace(symbol)
+	}
+	return emoji, nil
+}
@@ -0,0 +1,27 @@
+package activity
+
+import (
+	"testing"
+
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+func TestParseEmojiMap(t *testing.T) {
+	emoji, err := ParseEmojiMap("push=🚀, WatchEvent = 🌟, release=")
+	if err != nil {
+		t.Fatalf("ParseEmojiMap() error = %v", err)
+	}
+	if emoji[github.EventTypePush] != "🚀" || emoji[github.EventTypeWatch] != "🌟" {
+		t.Errorf("ParseEmojiMap() = %v", emoji)
+	}
+	if symbol, ok := emoji[github.EventTypeRelease]; !ok || symbol != "" {
+		t.Errorf("Expected an empty release emoji to be kept, got %q, %v", symbol, ok)
+	}
+
+	for _, value := range []string{"push", "nope=🚀"} {
+		if _, err := ParseEmojiMap(value); err == nil {
+			t.Errorf("ParseEmojiMap(%q) expected an error", value)
+		}
+	}
+
+}
@@ -0,0 +1,228 @@
+package activity
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// Enrichment - Additional metadata fetched from the API for each event
+
+// ResourceFetcher fetches raw API resources by path
+type ResourceFetcher interface {
+	FetchResource(path string) ([]byte, error)
+}
+
+// Enricher fetches repository, pull request and commit status metadata
+// through a bounded worker pool, deduplicating requests and sharing a disk cache
+type Enricher struct {
+	fetcher ResourceFetcher
+	cache   *github.DiskCache
+	workers int
+}
+
+// NewEnricher creates an enricher; cache may be nil to disable disk caching
+func NewEnricher(fetcher ResourceFetcher, cache *github.DiskCache, workers int) *Enricher {
+	if workers < 1 {
+		workers = 1
+	}
+	return &Enricher{
+		fetcher: fetcher,
+		cache:   cache,
+		workers: workers,
+	}
+}
+
+// repositoryMetadata is the subset of /repos/{owner}/{repo} used for enrichment
+type repositoryMetadata struct {
+	Language        string `json:"language"`
+	StargazersCount int    `json:"stargazers_count"`
+}
+
+// pullRequestDetails is the subset of /repos/{owner}/{repo}/pulls/{n} used for enrichment
+type pullRequestDetails struct {
+	Merged    bool `json:"merged"`
+	Additions int  `json:"additions"`
+	Deletions int  `json:"deletions"`
+	Base      struct {
+		Ref string `json:"ref"`
+	} `json:"base"`
+	Head struct {
+		Ref string `json:"ref"`
+	} `json:"head"`
+}
+
+// commitStatus is the combined status of a commit
+type commitStatus struct {
+	State string `json:"state"`
+}
+
+// enrichmentPaths lists the API paths needed to enrich an event
+type enrichmentPaths struct {
+	Repository  string
+	PullRequest string
+	Status      string
+}
+
+// pathsFor returns the API paths needed to enrich the given event
+func pathsFor(event github.GitHubEvent) enrichmentPaths {
+	paths := enrichmentPaths{}
+	if event.Repo.Name == "" {
+		return paths
+	}
+	paths.Repository = "/repos/" + event.Repo.Name
+
+	switch github.EventType(event.Type) {
+	case github.EventTypePullRequest:
+		if payload, ok := event.TypedPayload().(*github.PullRequestPayload); ok &&
+			payload.PullRequest.Number > 0 {
+			paths.PullRequest = fmt.Sprintf(
+				"/repos/%s/pulls/%d",
+				event.Repo.Name,
+				payload.PullRequest.Number,
+			)
+		}
+
+	case github.EventTypePush:
+		if payload, ok := event.TypedPayload().(*github.PushPayload); ok && payload.Head != "" {
+			paths.Status = fmt.Sprintf("/repos/%s/commits/%s/status", event.Repo.Name, payload.Head)
+		}
+	}
+
+	return paths
+}
+
+// Enrich adds API metadata to the ExtraDetails of each activity; events and
+// activities must be aligned. Failed lookups are skipped.
+func (e *Enricher) Enrich(events []github.GitHubEvent, activities []DetailedActivity) {
+	allPaths := make([]enrichmentPaths, len(events))
+	unique := make([]string, 0)
+	seen := make(map[string]bool)
+
+	for i, event := range events {
+		allPaths[i] = pathsFor(event)
+		for _, path := range []string{
+			allPaths[i].Repository,
+			allPaths[i].PullRequest,
+			allPaths[i].Status,
+		} {
+			if path != "" && !seen[path] {
+				seen[path] = true
+				unique = append(unique, path)
+			}
+		}
+	}
+
+	results := e.fetchAll(unique)
+
+	for i := range activities {
+		if i >= len(allPaths) {
+			break
+		}
+		if activities[i].ExtraDetails == nil {
+			activities[i].ExtraDetails = make(map[string]string)
+		}
+		details := activities[i].ExtraDetails
+		paths := allPaths[i]
+
+		var repo repositoryMetadata
+		if data, ok := results[paths.Repository]; ok && json.Unmarshal(data, &repo) == nil {
+			if repo.Language != "" {
+				details["language"] = repo.Language
+			}
+			details["stars"] = strconv.Itoa(repo.StargazersCount)
+		}
+
+		var pull pullRequestDetails
+		if data, ok := results[paths.PullRequest]; ok && json.Unmarshal(data, &pull) == nil {
+			details["merged"] = strconv.FormatBool(pull.Merged)
+			details["changes"] = fmt.Sprintf("+%d/-%d", pull.Additions, pull.Deletions)
+			details["branches"] = fmt.Sprintf("%s -> %s", pull.Head.Ref, pull.Base.Ref)
+		}
+
+		var status commitStatus
+		if data, ok := results[paths.Status]; ok && json.Unmarshal(data, &status) == nil &&
+			status.State != "" {
+			details["status"] = strings.ToLower(status.State)
+		}
+	}
+}
+
+// Languages looks up the primary language of each repository, given as
+// owner/name. Repositories without a language, or whose lookup failed, are
+// left out.
+func (e *Enricher) Languages(repos []string) map[string]string {
+	paths := make([]string, 0, len(repos))
+	seen := make(map[string]bool)
+	for _, repo := range repos {
+		if repo != "" && !seen[repo] {
+			seen[repo] = true
+			paths = append(paths, "/repos/"+repo)
+		}
+	}
+
+	results := e.fetchAll(paths)
+	languages := make(map[string]string, len(results))
+	for path, data := range results {
+		var repo repositoryMetadata
+		if json.Unmarshal(data, &repo) == nil && repo.Language != "" {
+			languages[strings.TrimPrefix(path, "/repos/")] = repo.Language
+		}
+	}
+	return languages
+}
+
+// fetchAll fetches each path once through a bounded pool of workers
+func (e *Enricher) fetchAll(paths []string) map[string][]byte {
+	results := make(map[string][]byte, len(paths))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	jobs := make(chan string)
+	for i := 0; i < e.workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for path := range jobs {
+				data, err := e.fetch(path)
+				if err != nil {
+					continue
+				}
+				mu.Lock()
+				results[path] = data
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for _, path := range paths {
+		jobs <- path
+	}
+	close(jobs)
+	wg.Wait()
+
+	return results
+}
+
+// fetch returns a resource from the disk cache or the API
+func (e *Enricher) fetch(path string) ([]byte, error) {
+	if e.cache != nil {
+		if data, ok := e.cache.Get(path); ok {
+			return data, nil
+		}
+	}
+
+	data, err := e.fetcher.FetchResource(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if e.cache != nil {
+		_ = e.cache.Set(path, data)
+	}
+	return data, nil
+}
@@ -0,0 +1,130 @@
+package activity
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// fakeResourceFetcher serves canned resources and counts requests per path
+type fakeResourceFetcher struct {
+	mu        sync.Mutex
+	resources map[string]string
+	calls     map[string]int
+}
+
+func (f *fakeResourceFetcher) FetchResource(path string) ([]byte, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.calls[path]++
+	data, ok := f.resources[path]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return []byte(data), nil
+}
+
+func newFakeResourceFetcher() *fakeResourceFetcher {
+	return &fakeResourceFetcher{
+		resources: map[string]string{
+			"/repos/user/repo":                       `{"language": "Go", "stargazers_count": 42}`,
+			"/repos/user/repo/pulls/7":               `{"merged": true, "additions": 10, "deletions": 2, "base": {"ref": "main"}, "head": {"ref": "feature"}}`,
+			"/repos/user/repo/commits/abc123/status": `{"state": "success"}`,
+		},
+		calls: make(map[string]int),
+	}
+}
+
+func TestEnricher_Enrich(t *testing.T) {
+	events := []github.GitHubEvent{
+		{
+			Type:    "PullRequestEvent",
+			Repo:    github.Repo{Name: "user/repo"},
+			Payload: json.RawMessage(`{"action": "closed", "pull_request": {"number": 7}}`),
+		},
+		{
+			Type:    "PushEvent",
+			Repo:    github.Repo{Name: "user/repo"},
+			Payload: json.RawMessage(`{"size": 1, "head": "abc123"}`),
+		},
+		{
+			Type:    "WatchEvent",
+			Repo:    github.Repo{Name: "user/missing"},
+			Payload: json.RawMessage(`{}`),
+		},
+	}
+	activities := make([]DetailedActivity, len(events))
+
+	fetcher := newFakeResourceFetcher()
+	NewEnricher(fetcher, nil, 2).Enrich(events, activities)
+
+	pull := activities[0].ExtraDetails
+	if pull["language"] != "Go" || pull["stars"] != "42" {
+		t.Errorf("Repository details = %v", pull)
+	}
+	if pull["merged"] != "true" || pull["changes"] != "+10/-2" || pull["branches"] != "feature -> main" {
+		t.Errorf("Pull request details = %v", pull)
+	}
+
+	if activities[1].ExtraDetails["status"] != "success" {
+		t.Errorf("Status = %v, want success", activities[1].ExtraDetails["status"])
+	}
+
+	if len(activities[2].ExtraDetails) != 0 {
+		t.Errorf("Failed lookups should be skipped, got %v", activities[2].ExtraDetails)
+	}
+
+	if fetcher.calls["/repos/user/repo"] != 1 {
+		t.Errorf("Repository fetched %d times, want 1", fetcher.calls["/repos/user/repo"])
+	}
+}
+
+func TestEnricher_UsesDiskCache(t *testing.T) {
+	cache := github.NewDiskCache(t.TempDir(), time.Hour)
+	events := []github.GitHubEvent{{Type: "WatchEvent", Repo: github.Repo{Name: "user/repo"}}}
+
+	fetcher := newFakeResourceFetcher()
+	enricher := NewEnricher(fetcher, cache, 1)
+	enricher.Enrich(events, make([]DetailedActivity, 1))
+
+	activities := make([]DetailedActivity, 1)
+	enricher.Enrich(events, activities)
+
+	if fetcher.calls["/repos/user/repo"] != 1 {
+		t.Errorf("Repository fetched %d times, want 1", fetcher.calls["/repos/user/repo"])
+	}
+	if activities[0].ExtraDetails["language"] != "Go" {
+		t.Errorf("Cached details = %v", activities[0].ExtraDetails)
+	}
+}
+
+func TestActivityService_EnrichesDetailedActivities(t *testing.T) {
+	events := []github.GitHubEvent{{ID: "1", Type: "WatchEvent", Repo: github.Repo{Name: "user/repo"}}}
+	service := NewActivityService(github.NewMockEventRepository(events, nil))
+	service.SetEnricher(NewEnricher(newFakeResourceFetcher(), nil, 1))
+
+	activities, err := service.GetUserActivityDetailed("testuser", EventFilter{})
+	if err != nil {
+		t.Fatalf("GetUserActivityDetailed() error = %v", err)
+	}
+	if activities[0].ExtraDetails["stars"] != "42" {
+		t.Errorf("ExtraDetails = %v, want stars", activities[0].ExtraDetails)
+	}
+}
+
+func TestEnricher_Languages(t *testing.T) {
+	fetcher := newFakeResourceFetcher()
+	fetcher.resources["/repos/user/docs"] = `{"language": null}`
+
+	got := NewEnricher(fetcher, nil, 2).Languages([]string{"user/repo", "user/docs", "user/missing", "user/repo", ""})
+	if len(got) != 1 || got["user/repo"] != "Go" {
+		t.Errorf("Languages() = %v, want only user/repo in Go", got)
+	}
+	if fetcher.calls["/repos/user/repo"] != 1 {
+		t.Errorf("Repository fetched %d times, want 1", fetcher.calls["/repos/user/repo"])
+	}
+}
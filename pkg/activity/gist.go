@@ -0,0 +1,95 @@
+package activity
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alnah/github-activity/internal/messages"
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// Gists - Gist creations and updates as activities
+
+// GistType is the activity type of a gist, named after the event GitHub
+// once reported gists with
+const GistType = "GistEvent"
+
+// gistEditWindow is how long after creation a change still counts as part
+// of creating the gist
+const gistEditWindow = time.Minute
+
+// SummarizeGist describes a gist as a detailed activity dated by its last
+// update, shown in location when it is set
+func SummarizeGist(gist github.Gist, location *time.Location) DetailedActivity {
+	updatedAt := gist.UpdatedAt
+	if updatedAt.IsZero() {
+		updatedAt = gist.CreatedAt
+	}
+	if location != nil {
+		updatedAt = updatedAt.In(location)
+	}
+
+	files := make([]string, 0, len(gist.Files))
+	for name := range gist.Files {
+		files = append(files, name)
+	}
+	sort.Strings(files)
+
+	action := "updated"
+	if updatedAt.Sub(gist.CreatedAt) < gistEditWindow {
+		action = "created"
+	}
+	title := gist.Description
+	if title == "" && len(files) > 0 {
+		title = files[0]
+	}
+	description := fmt.Sprintf("%s gist %q (%s)",
+		strings.ToUpper(action[:1])+action[1:], title, messages.Plural("files", len(files)))
+
+	visibility := "public"
+	if !gist.Public {
+		visibility = "secret"
+	}
+	activity := DetailedActivity{
+		ActivitySummary: ActivitySummary{
+			Description: description,
+			Type:        GistType,
+			Timestamp:   updatedAt.Format("2006-01-02 15:04:05"),
+			CreatedAt:   updatedAt,
+			Fields: map[string]string{
+				"action":      action,
+				"description": gist.Description,
+				"files":       strconv.Itoa(len(files)),
+				"url":         gist.HTMLURL,
+			},
+		},
+		EventID:      gist.ID,
+		ExtraDetails: map[string]string{"visibility": visibility},
+	}
+	if len(files) > 0 {
+		activity.ExtraDetails["files"] = strings.Join(files, ", ")
+	}
+	if gist.HTMLURL != "" {
+		activity.ExtraDetails["url"] = gist.HTMLURL
+	}
+	return activity
+}
+
+// GistActivities summarizes gists, most recently updated first, keeping at
+// most limit of them when limit is positive
+func GistActivities(gists []github.Gist, limit int, location *time.Location) []DetailedActivity {
+	activities := make([]DetailedActivity, 0, len(gists))
+	for _, gist := range gists {
+		activities = append(activities, SummarizeGist(gist, location))
+	}
+	sort.SliceStable(activities, func(i, j int) bool {
+		return activities[i].CreatedAt.After(activities[j].CreatedAt)
+	})
+	if limit > 0 && len(activities) > limit {
+		activities = activities[:limit]
+	}
+	return activities
+}
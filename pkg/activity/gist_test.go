@@ -0,0 +1,75 @@
+package activity
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+func TestSummarizeGist(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	files := map[string]github.GistFile{"b.go": {Filename: "b.go"}, "a.md": {Filename: "a.md"}}
+
+	tests := []struct {
+		name        string
+		gist        github.Gist
+		description string
+		details     map[string]string
+	}{
+		{
+			name: "created",
+			gist: github.Gist{
+				ID: "1", Description: "Notes", HTMLURL: "https://gist.github.com/1", Public: true,
+				Files: files, CreatedAt: created, UpdatedAt: created.Add(10 * time.Second),
+			},
+			description: `Created gist "Notes" (2 files)`,
+			details:     map[string]string{"visibility": "public", "files": "a.md, b.go", "url": "https://gist.github.com/1"},
+		},
+		{
+			name: "updated secret gist without description",
+			gist: github.Gist{
+				ID: "2", Files: map[string]github.GistFile{"x.sh": {}},
+				CreatedAt: created, UpdatedAt: created.Add(24 * time.Hour),
+			},
+			description: `Updated gist "x.sh" (1 file)`,
+			details:     map[string]string{"visibility": "secret", "files": "x.sh"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			activity := SummarizeGist(tt.gist, time.UTC)
+			if activity.Description != tt.description {
+				t.Errorf("Description = %q, want %q", activity.Description, tt.description)
+			}
+			if activity.Type != GistType || activity.EventID != tt.gist.ID {
+				t.Errorf("Type, EventID = %s, %s", activity.Type, activity.EventID)
+			}
+			if !activity.CreatedAt.Equal(tt.gist.UpdatedAt) {
+				t.Errorf("CreatedAt = %v, want the update time %v", activity.CreatedAt, tt.gist.UpdatedAt)
+			}
+			if !reflect.DeepEqual(activity.ExtraDetails, tt.details) {
+				t.Errorf("ExtraDetails = %v, want %v", activity.ExtraDetails, tt.details)
+			}
+		})
+	}
+}
+
+func TestGistActivities(t *testing.T) {
+	now := time.Now()
+	gists := []github.Gist{
+		{ID: "old", CreatedAt: now.Add(-72 * time.Hour), UpdatedAt: now.Add(-72 * time.Hour)},
+		{ID: "edited", CreatedAt: now.Add(-96 * time.Hour), UpdatedAt: now.Add(-time.Hour)},
+		{ID: "new", CreatedAt: now.Add(-2 * time.Hour), UpdatedAt: now.Add(-2 * time.Hour)},
+	}
+
+	var ids []string
+	for _, activity := range GistActivities(gists, 2, nil) {
+		ids = append(ids, activity.EventID)
+	}
+	if !reflect.DeepEqual(ids, []string{"edited", "new"}) {
+		t.Errorf("GistActivities() = %v, want the 2 most recently updated", ids)
+	}
+}
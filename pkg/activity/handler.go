@@ -0,0 +1,261 @@
+package activity
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// HandlerOptions configures the activity HTTP API
+type HandlerOptions struct {
+	DefaultLimit int // Events returned when a request has no limit (default 30)
+	MaxLimit     int // Largest limit a request may ask for, 0 for no cap
+}
+
+// activityHandler serves activity queries as JSON
+type activityHandler struct {
+	service ActivityProvider
+	options HandlerOptions
+}
+
+// NewHandler returns the activity API as an http.Handler, so Go services can
+// mount it under their own router and middleware:
+//
+//	GET /activity/{username}   ?type=&repo=&actor=&action=&limit=&detailed=true&cursor=
+//	GET /stats/{username}
+//	GET /repos/{username}      ?limit=
+//	GET /daily/{username}      ?type=&repo=&actor=&action=
+//	GET /histogram/{username}
+//	GET /spikes/{username}     ?period=hour|day
+//	GET /streaks/{username}
+//	GET /authors/{username}    ?type=&repo=&actor=&action=&limit=
+//	GET /owners/{username}     ?type=&repo=&actor=&action=&limit=
+//
+// With cursor, even empty for the first page, /activity returns a page of
+// activities with the next_cursor to pass for the one after it. Mount the
+// handler under a prefix with http.StripPrefix.
+func NewHandler(service ActivityProvider, options HandlerOptions) http.Handler {
+	if options.DefaultLimit <= 0 {
+		options.DefaultLimit = DefaultActivityOptions().Limit
+	}
+	h := &activityHandler{service: service, options: options}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /activity/{username}", validUsername(h.events))
+	mux.HandleFunc("GET /stats/{username}", validUsername(h.stats))
+	mux.HandleFunc("GET /repos/{username}", validUsername(h.repos))
+	mux.HandleFunc("GET /daily/{username}", validUsername(h.daily))
+	mux.HandleFunc("GET /histogram/{username}", validUsername(h.histogram))
+	mux.HandleFunc("GET /spikes/{username}", validUsername(h.spikes))
+	mux.HandleFunc("GET /streaks/{username}", validUsername(h.streaks))
+	mux.HandleFunc("GET /authors/{username}", validUsername(h.authors))
+	mux.HandleFunc("GET /owners/{username}", validUsername(h.owners))
+	return mux
+}
+
+// validUsername answers 400 to a request whose username is not a valid
+// login before next sees it. The path value is unescaped, so an encoded
+// slash or question mark would otherwise reach the GitHub URLs the service
+// builds, which are requested with the server's token.
+func validUsername(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := ValidateUsername(r.PathValue("username")); err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		next(w, r)
+	}
+}
+
+// filter builds and validates an EventFilter from query parameters
+func (h *activityHandler) filter(r *http.Request) (EventFilter, error) {
+	query := r.URL.Query()
+	options := ActivityOptions{
+		EventType: github.ResolveEventType(query.Get("type")),
+		Repo:      query.Get("repo"),
+		Actor:     query.Get("actor"),
+		Action:    query.Get("action"),
+		Limit:     h.options.DefaultLimit,
+	}
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil {
+			return EventFilter{}, errors.New("limit must be a number")
+		}
+		options.Limit = limit
+	}
+	if err := options.Validate(); err != nil {
+		return EventFilter{}, err
+	}
+	if h.options.MaxLimit > 0 && (options.Limit == 0 || options.Limit > h.options.MaxLimit) {
+		options.Limit = h.options.MaxLimit
+	}
+
+	return EventFilter{
+		Type:     options.EventType,
+		Repo:     options.Repo,
+		Actor:    options.Actor,
+		Action:   options.Action,
+		MaxLimit: options.Limit,
+	}, nil
+}
+
+func (h *activityHandler) events(w http.ResponseWriter, r *http.Request) {
+	filter, err := h.filter(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	username := r.PathValue("username")
+	detailed, _ := strconv.ParseBool(r.URL.Query().Get("detailed"))
+	// With a cursor, even an empty one for the first page, the activities
+	// come wrapped with the cursor of the next page
+	if r.URL.Query().Has("cursor") {
+		h.page(w, r, username, filter, detailed)
+		return
+	}
+	if detailed {
+		activities, err := h.service.GetUserActivityDetailedContext(r.Context(), username, filter)
+		h.respond(w, activities, err)
+		return
+	}
+	activities, err := h.service.GetUserActivityContext(r.Context(), username, filter)
+	h.respond(w, activities, err)
+}
+
+// page writes one page of activities after the request's cursor
+func (h *activityHandler) page(
+	w http.ResponseWriter,
+	r *http.Request,
+	username string,
+	filter EventFilter,
+	detailed bool,
+) {
+	cursor := r.URL.Query().Get("cursor")
+	if _, err := DecodeEventCursor(cursor); cursor != "" && err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if detailed {
+		page, err := h.service.GetUserActivityDetailedPage(r.Context(), username, filter, cursor)
+		h.respond(w, page, err)
+		return
+	}
+	page, err := h.service.GetUserActivityPage(r.Context(), username, filter, cursor)
+	h.respond(w, page, err)
+}
+
+func (h *activityHandler) stats(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.service.GetEventTypeStatistics(r.PathValue("username"))
+	h.respond(w, stats, err)
+}
+
+func (h *activityHandler) repos(w http.ResponseWriter, r *http.Request) {
+	limit := 0
+	if value := r.URL.Query().Get("limit"); value != "" {
+		var err error
+		if limit, err = strconv.Atoi(value); err != nil || limit < 0 {
+			writeError(w, http.StatusBadRequest, errors.New("limit must be a non-negative number"))
+			return
+		}
+	}
+	repos, err := h.service.GetRecentRepositories(r.PathValue("username"), limit)
+	h.respond(w, repos, err)
+}
+
+func (h *activityHandler) daily(w http.ResponseWriter, r *http.Request) {
+	filter, err := h.filter(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	filter.MaxLimit = 0
+	counts, err := h.service.GetDailyActivity(r.PathValue("username"), filter)
+	h.respond(w, counts, err)
+}
+
+func (h *activityHandler) histogram(w http.ResponseWriter, r *http.Request) {
+	histogram, err := h.service.GetActivityHistogram(r.PathValue("username"))
+	h.respond(w, histogram, err)
+}
+
+func (h *activityHandler) spikes(w http.ResponseWriter, r *http.Request) {
+	period := r.URL.Query().Get("period")
+	if period == "" {
+		period = "hour"
+	}
+	options := ActivityOptions{Spikes: period}
+	if err := options.Validate(); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	spikes, err := h.service.GetActivitySpikes(r.PathValue("username"), period)
+	h.respond(w, spikes, err)
+}
+
+func (h *activityHandler) streaks(w http.ResponseWriter, r *http.Request) {
+	streaks, err := h.service.GetStreaks(r.PathValue("username"))
+	h.respond(w, streaks, err)
+}
+
+func (h *activityHandler) authors(w http.ResponseWriter, r *http.Request) {
+	filter, err := h.filter(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	authors, err := h.service.GetCommitAuthors(r.PathValue("username"), filter)
+	h.respond(w, authors, err)
+}
+
+func (h *activityHandler) owners(w http.ResponseWriter, r *http.Request) {
+	filter, err := h.filter(r)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	owners, err := h.service.GetOwnerBreakdown(r.PathValue("username"), filter)
+	h.respond(w, owners, err)
+}
+
+// respond writes value as JSON, or the service error with a matching status
+func (h *activityHandler) respond(w http.ResponseWriter, value any, err error) {
+	if err != nil {
+		writeError(w, errorStatus(err), err)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+// errorStatus maps service errors to HTTP status codes
+func errorStatus(err error) int {
+	if errors.Is(err, ErrInvalidUsername) {
+		return http.StatusBadRequest
+	}
+	var repoErr *github.RepositoryError
+	if errors.As(err, &repoErr) {
+		switch repoErr.Code {
+		case github.ErrUserNotFound.Code, github.ErrNotArchived.Code, github.ErrOffline.Code:
+			return http.StatusNotFound
+		case github.ErrRateLimitExceeded.Code:
+			return http.StatusTooManyRequests
+		case github.ErrDataPending.Code:
+			return http.StatusServiceUnavailable
+		}
+		return http.StatusBadGateway
+	}
+	return http.StatusBadGateway
+}
+
+// writeError writes {"error": "..."} with the given status
+func writeError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
@@ -0,0 +1,184 @@
+package activity
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+func TestNewHandler(t *testing.T) {
+	now := time.Now()
+	events := []github.GitHubEvent{
+		{ID: "3", Type: "PushEvent", Repo: github.Repo{Name: "user/repo"}, CreatedAt: now},
+		{ID: "2", Type: "WatchEvent", Repo: github.Repo{Name: "user/other"}, CreatedAt: now.Add(-time.Hour)},
+		{ID: "1", Type: "PushEvent", Repo: github.Repo{Name: "user/repo"}, CreatedAt: now.Add(-2 * time.Hour)},
+	}
+	service := NewActivityService(github.NewMockEventRepository(events, nil))
+	handler := NewHandler(service, HandlerOptions{MaxLimit: 2})
+
+	tests := []struct {
+		name           string
+		path           string
+		expectedStatus int
+		expectedCount  int
+	}{
+		{"events", "/activity/testuser", http.StatusOK, 2},
+		{"type alias", "/activity/testuser?type=star", http.StatusOK, 1},
+		{"repo filter", "/activity/testuser?repo=user/repo&limit=1", http.StatusOK, 1},
+		{"detailed", "/activity/testuser?detailed=true", http.StatusOK, 2},
+		{"invalid type", "/activity/testuser?type=Nope", http.StatusBadRequest, -1},
+		{"invalid limit", "/activity/testuser?limit=many", http.StatusBadRequest, -1},
+		{"stats", "/stats/testuser", http.StatusOK, 2},
+		{"daily", "/daily/testuser", http.StatusOK, -1},
+		{"spikes", "/spikes/testuser?period=day", http.StatusOK, -1},
+		{"streaks", "/streaks/testuser", http.StatusOK, 6},
+		{"authors", "/authors/testuser?repo=user/repo", http.StatusOK, 0},
+		{"owners", "/owners/testuser?type=PushEvent", http.StatusOK, 1},
+		{"invalid period", "/spikes/testuser?period=week", http.StatusBadRequest, -1},
+		{"repos", "/repos/testuser", http.StatusOK, 2},
+		{"repos limit", "/repos/testuser?limit=1", http.StatusOK, 1},
+		{"invalid repos limit", "/repos/testuser?limit=-1", http.StatusBadRequest, -1},
+		{"unknown route", "/users/testuser", http.StatusNotFound, -1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if recorder.Code != tt.expectedStatus {
+				t.Fatalf("Status = %d, want %d: %s", recorder.Code, tt.expectedStatus, recorder.Body)
+			}
+			if tt.expectedCount < 0 {
+				return
+			}
+
+			var body any
+			if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil {
+				t.Fatalf("Response is not valid JSON: %v", err)
+			}
+			count := 0
+			switch value := body.(type) {
+			case []any:
+				count = len(value)
+			case map[string]any:
+				count = len(value)
+			}
+			if count != tt.expectedCount {
+				t.Errorf("Got %d items, want %d", count, tt.expectedCount)
+			}
+		})
+	}
+
+	t.Run("method not allowed", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/activity/testuser", nil))
+		if recorder.Code != http.StatusMethodNotAllowed {
+			t.Errorf("Status = %d, want %d", recorder.Code, http.StatusMethodNotAllowed)
+		}
+	})
+}
+
+func TestNewHandler_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		expectedStatus int
+	}{
+		{"user not found", github.ErrUserNotFound, http.StatusNotFound},
+		{"rate limit", github.ErrRateLimitExceeded, http.StatusTooManyRequests},
+		{"data pending", github.ErrDataPending, http.StatusServiceUnavailable},
+		{"other", &github.RepositoryError{Code: "NETWORK", Message: "boom"}, http.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewActivityService(github.NewMockEventRepository(nil, tt.err))
+			recorder := httptest.NewRecorder()
+			NewHandler(service, HandlerOptions{}).ServeHTTP(
+				recorder,
+				httptest.NewRequest(http.MethodGet, "/activity/testuser", nil),
+			)
+
+			if recorder.Code != tt.expectedStatus {
+				t.Errorf("Status = %d, want %d", recorder.Code, tt.expectedStatus)
+			}
+			var body map[string]string
+			if err := json.Unmarshal(recorder.Body.Bytes(), &body); err != nil || body["error"] == "" {
+				t.Errorf("Body = %s, want an error message", recorder.Body)
+			}
+		})
+	}
+}
+
+func TestNewHandler_InvalidUsername(t *testing.T) {
+	paths := []string{
+		"/activity/-octocat",
+		"/stats/octocat%2Frepos",
+		"/repos/octocat%3Fper_page=100",
+		"/daily/octocat%2F%2E%2E",
+		"/histogram/octocat%2F..%2F..%2Fuser",
+		"/spikes/octo%20cat",
+		"/streaks/octocat%23",
+		"/authors/octocat%2Fevents",
+		"/owners/octocat%3F",
+	}
+
+	for _, path := range paths {
+		t.Run(path, func(t *testing.T) {
+			service := NewActivityService(github.NewMockEventRepository(nil, nil))
+			recorder := httptest.NewRecorder()
+			NewHandler(service, HandlerOptions{}).ServeHTTP(
+				recorder,
+				httptest.NewRequest(http.MethodGet, path, nil),
+			)
+
+			if recorder.Code != http.StatusBadRequest {
+				t.Errorf("Status = %d, want %d", recorder.Code, http.StatusBadRequest)
+			}
+		})
+	}
+}
+
+func TestNewHandler_Cursor(t *testing.T) {
+	now := time.Now()
+	events := []github.GitHubEvent{
+		{ID: "3", Type: "PushEvent", Repo: github.Repo{Name: "user/repo"}, CreatedAt: now},
+		{ID: "2", Type: "PushEvent", Repo: github.Repo{Name: "user/repo"}, CreatedAt: now.Add(-time.Hour)},
+		{ID: "1", Type: "PushEvent", Repo: github.Repo{Name: "user/repo"}, CreatedAt: now.Add(-2 * time.Hour)},
+	}
+	handler := NewHandler(NewActivityService(github.NewMockEventRepository(events, nil)), HandlerOptions{})
+
+	get := func(path string) (int, ActivityPage) {
+		t.Helper()
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+		var page ActivityPage
+		if recorder.Code == http.StatusOK {
+			if err := json.Unmarshal(recorder.Body.Bytes(), &page); err != nil {
+				t.Fatalf("Response is not a page: %v", err)
+			}
+		}
+		return recorder.Code, page
+	}
+
+	status, first := get("/activity/testuser?limit=2&cursor=")
+	if status != http.StatusOK || len(first.Activities) != 2 || first.NextCursor == "" {
+		t.Fatalf("First page = %d %+v, want 2 activities and a next cursor", status, first)
+	}
+	status, second := get("/activity/testuser?limit=2&cursor=" + first.NextCursor)
+	if status != http.StatusOK || len(second.Activities) != 1 || second.NextCursor != "" {
+		t.Fatalf("Second page = %d %+v, want the last activity and no cursor", status, second)
+	}
+	if second.Activities[0].CreatedAt.Equal(first.Activities[1].CreatedAt) {
+		t.Errorf("Second page repeats %+v", second.Activities[0])
+	}
+
+	if status, _ := get("/activity/testuser?cursor=nope!"); status != http.StatusBadRequest {
+		t.Errorf("Invalid cursor status = %d, want %d", status, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,81 @@
+package activity
+
+import (
+	"context"
+	"errors"
+	"sort"
+
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// Languages - Where activity goes, by the primary language of each repository
+
+// UnknownLanguage labels activity in repositories with no detected language,
+// such as documentation repositories, or whose lookup failed
+const UnknownLanguage = "Unknown"
+
+// ErrNoEnricher is returned by queries that look repositories up through the
+// API when the service has no Enricher
+var ErrNoEnricher = errors.New("repository lookups need an enricher; see SetEnricher")
+
+// LanguageCount is how much activity went to repositories in one language
+type LanguageCount struct {
+	Language string `json:"language"`
+	Events   int    `json:"events"`
+	Repos    int    `json:"repos"`
+}
+
+// CountLanguages counts events and distinct repositories per language, given
+// each repository's language, most events first
+func CountLanguages(events []github.GitHubEvent, languages map[string]string) []LanguageCount {
+	counts := make(map[string]*LanguageCount)
+	seen := make(map[string]bool)
+	for _, event := range events {
+		language, ok := languages[event.Repo.Name]
+		if !ok {
+			language = UnknownLanguage
+		}
+		count := counts[language]
+		if count == nil {
+			count = &LanguageCount{Language: language}
+			counts[language] = count
+		}
+		count.Events++
+		if !seen[event.Repo.Name] {
+			seen[event.Repo.Name] = true
+			count.Repos++
+		}
+	}
+
+	result := make([]LanguageCount, 0, len(counts))
+	for _, count := range counts {
+		result = append(result, *count)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Events != result[j].Events {
+			return result[i].Events > result[j].Events
+		}
+		return result[i].Language < result[j].Language
+	})
+	return result
+}
+
+// GetLanguageBreakdown counts the matching events across the whole fetched
+// feed by the primary language of their repository, which the enricher
+// looks up once per repository
+func (s *ActivityService) GetLanguageBreakdown(username string, filter EventFilter) ([]LanguageCount, error) {
+	if s.enricher == nil {
+		return nil, ErrNoEnricher
+	}
+	events, err := s.fetchEvents(context.Background(), username)
+	if err != nil {
+		return nil, err
+	}
+
+	matched := filter.matching(events)
+	repos := make([]string, len(matched))
+	for i, event := range matched {
+		repos[i] = event.Repo.Name
+	}
+	return CountLanguages(matched, s.enricher.Languages(repos)), nil
+}
@@ -0,0 +1,57 @@
+package activity
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+func TestCountLanguages(t *testing.T) {
+	event := func(eventType, repo string) github.GitHubEvent {
+		return github.GitHubEvent{Type: eventType, Repo: github.Repo{Name: repo}}
+	}
+	events := []github.GitHubEvent{
+		event("PushEvent", "user/api"),
+		event("PushEvent", "user/api"),
+		event("WatchEvent", "user/cli"),
+		event("IssuesEvent", "user/web"),
+		event("PushEvent", "user/docs"),
+	}
+	languages := map[string]string{"user/api": "Go", "user/cli": "Go", "user/web": "TypeScript"}
+
+	want := []LanguageCount{
+		{Language: "Go", Events: 3, Repos: 2},
+		{Language: "TypeScript", Events: 1, Repos: 1},
+		{Language: UnknownLanguage, Events: 1, Repos: 1},
+	}
+	if got := CountLanguages(events, languages); !reflect.DeepEqual(got, want) {
+		t.Errorf("CountLanguages() = %+v, want %+v", got, want)
+	}
+	if got := CountLanguages(nil, nil); len(got) != 0 {
+		t.Errorf("CountLanguages(nil) = %v, want none", got)
+	}
+}
+
+func TestActivityService_GetLanguageBreakdown(t *testing.T) {
+	events := []github.GitHubEvent{
+		{ID: "2", Type: "PushEvent", Repo: github.Repo{Name: "user/repo"}},
+		{ID: "1", Type: "WatchEvent", Repo: github.Repo{Name: "user/missing"}},
+	}
+	service := NewActivityService(github.NewMockEventRepository(events, nil))
+
+	if _, err := service.GetLanguageBreakdown("testuser", EventFilter{}); !errors.Is(err, ErrNoEnricher) {
+		t.Fatalf("GetLanguageBreakdown() without an enricher error = %v, want ErrNoEnricher", err)
+	}
+
+	service.SetEnricher(NewEnricher(newFakeResourceFetcher(), nil, 2))
+	got, err := service.GetLanguageBreakdown("testuser", EventFilter{Type: "PushEvent"})
+	if err != nil {
+		t.Fatalf("GetLanguageBreakdown() error = %v", err)
+	}
+	want := []LanguageCount{{Language: "Go", Events: 1, Repos: 1}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetLanguageBreakdown() = %+v, want %+v", got, want)
+	}
+}
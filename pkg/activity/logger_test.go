@@ -0,0 +1,99 @@
+package activity
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// recordingLogger keeps the events it receives
+type recordingLogger struct {
+	events []string
+}
+
+func (l *recordingLogger) Debug(msg string, args ...any) { l.events = append(l.events, "debug "+msg) }
+
+func (l *recordingLogger) Warn(msg string, args ...any) { l.events = append(l.events, "warn "+msg) }
+
+func TestActivityService_LogsParseWarnings(t *testing.T) {
+	events := []github.GitHubEvent{
+		{ID: "1", Type: "PushEvent", Repo: github.Repo{Name: "user/repo"}, Payload: json.RawMessage(`{"size":"many"}`)},
+		{ID: "2", Type: "WatchEvent", Repo: github.Repo{Name: "user/repo"}},
+	}
+	logger := &recordingLogger{}
+	service := NewActivityService(github.NewMockEventRepository(events, nil), WithLogger(logger))
+
+	activities, err := service.GetUserActivity("octocat", EventFilter{})
+	if err != nil {
+		t.Fatalf("GetUserActivity() error = %v", err)
+	}
+	expected := "debug fetched,warn parse warning"
+	if got := strings.Join(logger.events, ","); got != expected {
+		t.Errorf("Logged %s, want %s", got, expected)
+	}
+	want := []string{"event 1: PushEvent payload field size is a string, want int"}
+	if len(activities) != 2 || !reflect.DeepEqual(activities[0].Warnings, want) || activities[1].Warnings != nil {
+		t.Errorf("Warnings = %q and %q, want %q on the first activity only",
+			activities[0].Warnings, activities[1].Warnings, want)
+	}
+}
+
+func TestActivityService_TracesFetches(t *testing.T) {
+	events := []github.GitHubEvent{{ID: "1", Type: "WatchEvent", Repo: github.Repo{Name: "user/repo"}}}
+	tracer := github.NewRecordingTracer()
+	service := NewActivityService(github.NewMockEventRepository(events, nil), WithTracer(tracer))
+
+	if _, err := service.GetUserActivity("octocat", EventFilter{}); err != nil {
+		t.Fatalf("GetUserActivity() error = %v", err)
+	}
+	spans := tracer.Spans()
+	if len(spans) != 1 || spans[0].Name != github.SpanFetch {
+		t.Fatalf("Recorded %+v, want one %s span", spans, github.SpanFetch)
+	}
+	if spans[0].Attributes["user"] != "octocat" || spans[0].Attributes["events"] != 1 {
+		t.Errorf("Attributes = %v, want user octocat and 1 event", spans[0].Attributes)
+	}
+
+	t.Run("parent links", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`[{"id": "1", "type": "WatchEvent"}]`))
+		}))
+		defer server.Close()
+
+		tracer := github.NewRecordingTracer()
+		repo := github.NewGitHubAPIRepository(github.WithBaseURL(server.URL), github.WithRepositoryTracer(tracer))
+		repo.SetMaxPages(1)
+		// The shared cache passes the context on to the API repository
+		service := NewActivityService(NewSharedCacheRepository(repo, time.Minute), WithTracer(tracer))
+
+		ctx, request := tracer.Start(context.Background(), "request")
+		if _, err := service.GetUserActivityContext(ctx, "octocat", EventFilter{}); err != nil {
+			t.Fatalf("GetUserActivityContext() error = %v", err)
+		}
+		request.End()
+
+		spans := tracer.Spans()
+		want := []struct{ name, parent string }{
+			{github.SpanCacheLookup, github.SpanFetchEvents},
+			{github.SpanHTTPRequest, github.SpanFetchEvents},
+			{github.SpanFetchEvents, github.SpanFetch},
+			{github.SpanFetch, "request"},
+			{"request", ""},
+		}
+		if len(spans) != len(want) {
+			t.Fatalf("Recorded %d spans, want %d: %+v", len(spans), len(want), spans)
+		}
+		for i, w := range want {
+			if spans[i].Name != w.name || spans[i].Parent != w.parent {
+				t.Errorf("Span %d = %s under %q, want %s under %q", i, spans[i].Name, spans[i].Parent, w.name, w.parent)
+			}
+		}
+	})
+}
@@ -0,0 +1,164 @@
+package activity
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// Metrics counts the events seen in fetched feeds and reports the API rate
+// limit in the Prometheus text format, for scraping in serve mode
+type Metrics struct {
+	rateLimit func() (github.RateLimit, bool)
+
+	mu     sync.Mutex
+	seen   map[string]map[string]time.Time // Creation times of the event IDs already counted, per user
+	events map[metricKey]int
+}
+
+// metricKey labels an event counter
+type metricKey struct {
+	user      string
+	eventType string
+}
+
+// NewMetrics creates an empty collector. rateLimit reports the last known
+// rate limit and may be nil.
+func NewMetrics(rateLimit func() (github.RateLimit, bool)) *Metrics {
+	return &Metrics{
+		rateLimit: rateLimit,
+		seen:      make(map[string]map[string]time.Time),
+		events:    make(map[metricKey]int),
+	}
+}
+
+// Observe counts events not seen before for username. Feeds are refetched,
+// so an event is only counted the first time it shows up. Only the newest
+// MaxFeedEvents IDs are remembered per user, since GitHub never serves older
+// ones again, and usernames that are not valid logins are ignored so they
+// cannot add labels.
+func (m *Metrics) Observe(username string, events []github.GitHubEvent) {
+	if ValidateUsername(username) != nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	seen, ok := m.seen[username]
+	if !ok {
+		seen = make(map[string]time.Time)
+		m.seen[username] = seen
+	}
+	for _, event := range events {
+		if _, counted := seen[event.ID]; event.ID != "" && counted {
+			continue
+		}
+		seen[event.ID] = event.CreatedAt
+		m.events[metricKey{user: username, eventType: event.Type}]++
+	}
+	if len(seen) > MaxFeedEvents {
+		m.seen[username] = newestIDs(seen, MaxFeedEvents)
+	}
+}
+
+// newestIDs keeps the n most recently created of the seen event IDs
+func newestIDs(seen map[string]time.Time, n int) map[string]time.Time {
+	ids := make([]string, 0, len(seen))
+	for id := range seen {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		if !seen[ids[i]].Equal(seen[ids[j]]) {
+			return seen[ids[i]].After(seen[ids[j]])
+		}
+		return ids[i] > ids[j]
+	})
+	kept := make(map[string]time.Time, n)
+	for _, id := range ids[:n] {
+		kept[id] = seen[id]
+	}
+	return kept
+}
+
+// ServeHTTP writes the metrics in the Prometheus text exposition format
+func (m *Metrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+
+	m.mu.Lock()
+	keys := make([]metricKey, 0, len(m.events))
+	for key := range m.events {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].user != keys[j].user {
+			return keys[i].user < keys[j].user
+		}
+		return keys[i].eventType < keys[j].eventType
+	})
+	_, _ = fmt.Fprintln(w, "# HELP github_activity_events_total Events seen in fetched feeds.")
+	_, _ = fmt.Fprintln(w, "# TYPE github_activity_events_total counter")
+	for _, key := range keys {
+		_, _ = fmt.Fprintf(w, "github_activity_events_total{user=\"%s\",type=\"%s\"} %d\n",
+			escapeLabel(key.user), escapeLabel(key.eventType), m.events[key])
+	}
+	m.mu.Unlock()
+
+	if m.rateLimit == nil {
+		return
+	}
+	rateLimit, ok := m.rateLimit()
+	if !ok {
+		return
+	}
+	gauges := []struct {
+		name  string
+		help  string
+		value int64
+	}{
+		{"github_activity_rate_limit_limit", "Requests allowed per rate-limit window.", int64(rateLimit.Limit)},
+		{"github_activity_rate_limit_remaining", "Requests left in the current window.", int64(rateLimit.Remaining)},
+		{"github_activity_rate_limit_reset_timestamp_seconds", "When the window resets, as a Unix time.",
+			rateLimit.Reset.Unix()},
+	}
+	if rateLimit.Reset.IsZero() {
+		gauges = gauges[:2]
+	}
+	for _, gauge := range gauges {
+		_, _ = fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s gauge\n%s %d\n",
+			gauge.name, gauge.help, gauge.name, gauge.name, gauge.value)
+	}
+}
+
+// escapeLabel escapes a Prometheus label value
+func escapeLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
+
+// observedRepository reports every fetched feed to a Metrics collector
+type observedRepository struct {
+	next    github.EventRepository
+	metrics *Metrics
+}
+
+// FetchEvents fetches from the wrapped repository and observes the result
+func (r *observedRepository) FetchEvents(username string) ([]github.GitHubEvent, error) {
+	return r.FetchEventsContext(context.Background(), username)
+}
+
+// FetchEventsContext is FetchEvents passing ctx to the wrapped repository
+func (r *observedRepository) FetchEventsContext(ctx context.Context, username string) ([]github.GitHubEvent, error) {
+	events, err := github.FetchWithContext(ctx, r.next, username)
+	if err != nil {
+		// Events read before a partial failure are passed on
+		return events, err
+	}
+	r.metrics.Observe(username, events)
+	return events, nil
+}
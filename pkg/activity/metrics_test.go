@@ -0,0 +1,102 @@
+package activity
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+func TestMetrics(t *testing.T) {
+	reset := time.Unix(1700000000, 0)
+	metrics := NewMetrics(func() (github.RateLimit, bool) {
+		return github.RateLimit{Limit: 60, Remaining: 42, Reset: reset}, true
+	})
+
+	metrics.Observe("alice", []github.GitHubEvent{
+		{ID: "2", Type: "PushEvent"},
+		{ID: "1", Type: "WatchEvent"},
+	})
+	// A refetched feed only adds the new event
+	metrics.Observe("alice", []github.GitHubEvent{
+		{ID: "3", Type: "PushEvent"},
+		{ID: "2", Type: "PushEvent"},
+		{ID: "1", Type: "WatchEvent"},
+	})
+	metrics.Observe("bob", []github.GitHubEvent{{ID: "1", Type: `Push"Event`}})
+	// A username that is not a login never becomes a label
+	metrics.Observe("bob/../user", []github.GitHubEvent{{ID: "1", Type: "PushEvent"}})
+
+	recorder := httptest.NewRecorder()
+	metrics.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	output := recorder.Body.String()
+
+	expected := []string{
+		"# TYPE github_activity_events_total counter\n",
+		`github_activity_events_total{user="alice",type="PushEvent"} 2` + "\n",
+		`github_activity_events_total{user="alice",type="WatchEvent"} 1` + "\n",
+		`github_activity_events_total{user="bob",type="Push\"Event"} 1` + "\n",
+		"# TYPE github_activity_rate_limit_remaining gauge\ngithub_activity_rate_limit_remaining 42\n",
+		"github_activity_rate_limit_limit 60\n",
+		"github_activity_rate_limit_reset_timestamp_seconds 1700000000\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(output, want) {
+			t.Errorf("Metrics missing %q:\n%s", want, output)
+		}
+	}
+	if strings.Contains(output, "bob/../user") {
+		t.Errorf("Metrics labelled an invalid username:\n%s", output)
+	}
+}
+
+func TestMetrics_BoundedSeen(t *testing.T) {
+	metrics := NewMetrics(nil)
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	for batch := 0; batch < 5; batch++ {
+		events := make([]github.GitHubEvent, 100)
+		for i := range events {
+			n := batch*100 + i
+			events[i] = github.GitHubEvent{
+				ID:        fmt.Sprint(n),
+				Type:      "PushEvent",
+				CreatedAt: start.Add(time.Duration(n) * time.Minute),
+			}
+		}
+		metrics.Observe("alice", events)
+	}
+
+	if got := len(metrics.seen["alice"]); got != MaxFeedEvents {
+		t.Errorf("Remembered %d event IDs, want %d", got, MaxFeedEvents)
+	}
+	if _, ok := metrics.seen["alice"]["499"]; !ok {
+		t.Error("The newest event ID was forgotten")
+	}
+	if got := metrics.events[metricKey{user: "alice", eventType: "PushEvent"}]; got != 500 {
+		t.Errorf("Counted %d events, want 500", got)
+	}
+}
+
+func TestMetrics_NoRateLimit(t *testing.T) {
+	tests := []struct {
+		name      string
+		rateLimit func() (github.RateLimit, bool)
+	}{
+		{"no source", nil},
+		{"not yet known", func() (github.RateLimit, bool) { return github.RateLimit{}, false }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			NewMetrics(tt.rateLimit).ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+			if strings.Contains(recorder.Body.String(), "rate_limit") {
+				t.Errorf("Unexpected rate-limit gauges:\n%s", recorder.Body.String())
+			}
+		})
+	}
+}
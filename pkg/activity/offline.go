@@ -0,0 +1,32 @@
+package activity
+
+import (
+	"fmt"
+
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// offlineRepository stands in for the GitHub API under -offline, so events
+// missing from the session or archive fail instead of being fetched
+type offlineRepository struct {
+	source string // Where events were looked for, for the error message
+}
+
+// FetchEvents always fails, naming the user and where they were looked for
+func (r offlineRepository) FetchEvents(username string) ([]github.GitHubEvent, error) {
+	return nil, &github.RepositoryError{
+		Code: github.ErrOffline.Code,
+		Message: fmt.Sprintf("%s for %s in %s; run once without -offline to fetch them",
+			github.ErrOffline.Message, username, r.source),
+	}
+}
+
+// UseOffline routes event fetches to the session file, or else the archive,
+// without ever falling back to the API
+func (s *ActivityService) UseOffline(session string, archive *EventArchive) {
+	if session != "" {
+		s.repository = NewSessionRepository(offlineRepository{source: session}, session)
+		return
+	}
+	s.repository = NewArchiveRepository(archive)
+}
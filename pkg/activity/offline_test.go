@@ -0,0 +1,42 @@
+package activity
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+func TestActivityService_UseOffline(t *testing.T) {
+	dir := t.TempDir()
+	session := filepath.Join(dir, "session.json")
+	events := []github.GitHubEvent{{ID: "1", Type: "WatchEvent", Repo: github.Repo{Name: "user/repo"}, CreatedAt: time.Now()}}
+
+	// Fill the session while online
+	if _, err := NewSessionRepository(&countingRepository{events: events}, session).FetchEvents("octocat"); err != nil {
+		t.Fatal(err)
+	}
+
+	next := &countingRepository{err: errors.New("network used")}
+	service := NewActivityService(next)
+	service.UseOffline(session, NewEventArchive(filepath.Join(dir, "archive.json")))
+
+	if activities, err := service.GetUserActivity("octocat", EventFilter{}); err != nil || len(activities) != 1 {
+		t.Errorf("GetUserActivity() = %d activities, %v; want the session's event", len(activities), err)
+	}
+
+	_, err := service.GetUserActivity("someone", EventFilter{})
+	var repoErr *github.RepositoryError
+	if !errors.As(err, &repoErr) || repoErr.Code != github.ErrOffline.Code {
+		t.Fatalf("GetUserActivity() error = %v, want %s", err, github.ErrOffline.Code)
+	}
+	if !strings.Contains(err.Error(), "someone") || !strings.Contains(err.Error(), session) {
+		t.Errorf("Error %q should name the user and the session file", err)
+	}
+	if len(next.calls) != 0 {
+		t.Errorf("Offline service fetched from the API: %v", next.calls)
+	}
+}
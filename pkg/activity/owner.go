@@ -0,0 +1,67 @@
+package activity
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// Owners - Where activity goes, by the user or organization owning each repository
+
+// OwnerCount is how much activity went to repositories of one owner
+type OwnerCount struct {
+	Owner  string `json:"owner"`
+	Own    bool   `json:"own"` // The owner is the user whose activity it is
+	Events int    `json:"events"`
+	Repos  int    `json:"repos"`
+}
+
+// CountOwners counts events and distinct repositories per repository owner,
+// the part of owner/name before the slash, most events first. Owners are
+// matched case-insensitively and named as first seen; username marks the
+// user's own repositories.
+func CountOwners(events []github.GitHubEvent, username string) []OwnerCount {
+	counts := make(map[string]*OwnerCount)
+	seen := make(map[string]bool)
+	for _, event := range events {
+		owner, _, _ := strings.Cut(event.Repo.Name, "/")
+		if owner == "" {
+			continue
+		}
+		key := strings.ToLower(owner)
+		count := counts[key]
+		if count == nil {
+			count = &OwnerCount{Owner: owner, Own: strings.EqualFold(owner, username)}
+			counts[key] = count
+		}
+		count.Events++
+		if repo := strings.ToLower(event.Repo.Name); !seen[repo] {
+			seen[repo] = true
+			count.Repos++
+		}
+	}
+
+	result := make([]OwnerCount, 0, len(counts))
+	for _, count := range counts {
+		result = append(result, *count)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Events != result[j].Events {
+			return result[i].Events > result[j].Events
+		}
+		return strings.ToLower(result[i].Owner) < strings.ToLower(result[j].Owner)
+	})
+	return result
+}
+
+// GetOwnerBreakdown counts the matching events across the whole fetched feed
+// by the owner of their repository
+func (s *ActivityService) GetOwnerBreakdown(username string, filter EventFilter) ([]OwnerCount, error) {
+	events, err := s.fetchEvents(context.Background(), username)
+	if err != nil {
+		return nil, err
+	}
+	return CountOwners(filter.matching(events), username), nil
+}
@@ -0,0 +1,55 @@
+package activity
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+func TestCountOwners(t *testing.T) {
+	event := func(repo string) github.GitHubEvent {
+		return github.GitHubEvent{Type: "PushEvent", Repo: github.Repo{Name: repo}}
+	}
+	events := []github.GitHubEvent{
+		event("acme/api"),
+		event("acme/web"),
+		event("Acme/api"),
+		event("alnah/dotfiles"),
+		event("golang/go"),
+		event(""),
+	}
+
+	want := []OwnerCount{
+		{Owner: "acme", Events: 3, Repos: 2},
+		{Owner: "alnah", Own: true, Events: 1, Repos: 1},
+		{Owner: "golang", Events: 1, Repos: 1},
+	}
+	if got := CountOwners(events, "Alnah"); !reflect.DeepEqual(got, want) {
+		t.Errorf("CountOwners() = %+v, want %+v", got, want)
+	}
+	if got := CountOwners(nil, "alnah"); len(got) != 0 {
+		t.Errorf("CountOwners(nil) = %v, want none", got)
+	}
+}
+
+func TestActivityService_GetOwnerBreakdown(t *testing.T) {
+	events := []github.GitHubEvent{
+		{ID: "3", Type: "PushEvent", Repo: github.Repo{Name: "acme/api"}},
+		{ID: "2", Type: "WatchEvent", Repo: github.Repo{Name: "golang/go"}},
+		{ID: "1", Type: "PushEvent", Repo: github.Repo{Name: "testuser/notes"}},
+	}
+	service := NewActivityService(github.NewMockEventRepository(events, nil))
+
+	got, err := service.GetOwnerBreakdown("testuser", EventFilter{Type: "PushEvent"})
+	if err != nil {
+		t.Fatalf("GetOwnerBreakdown() error = %v", err)
+	}
+	want := []OwnerCount{
+		{Owner: "acme", Events: 1, Repos: 1},
+		{Owner: "testuser", Own: true, Events: 1, Repos: 1},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("GetOwnerBreakdown() = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,64 @@
+package activity
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// Partial Results - What could be fetched, and what could not
+
+// FetchFailure is a user whose feed, or part of it, could not be fetched
+type FetchFailure struct {
+	Username string `json:"username"`
+	Err      error  `json:"-"`
+}
+
+// PartialResultError is returned along with the activities that could be
+// fetched when some users, or some pages of a feed, could not be
+type PartialResultError struct {
+	Failures []FetchFailure
+}
+
+func (e *PartialResultError) Error() string {
+	failures := make([]string, len(e.Failures))
+	for i, failure := range e.Failures {
+		failures[i] = failure.Username + ": " + failure.Err.Error()
+	}
+	return "partial results: " + strings.Join(failures, "; ")
+}
+
+// Unwrap lets errors.Is match the error behind any of the failures
+func (e *PartialResultError) Unwrap() []error {
+	errs := make([]error, len(e.Failures))
+	for i, failure := range e.Failures {
+		errs[i] = failure.Err
+	}
+	return errs
+}
+
+// AsPartial returns the failures err reports when the results it came with
+// are usable, or false when err is nil or a plain failure
+func AsPartial(err error) ([]FetchFailure, bool) {
+	var partial *PartialResultError
+	if !errors.As(err, &partial) {
+		return nil, false
+	}
+	return partial.Failures, true
+}
+
+// partialFeed returns the PartialResultError for events read before err
+// stopped username's feed at a later page, or nil when err is anything else
+// or nothing was read
+func partialFeed(username string, events []github.GitHubEvent, err error) error {
+	var page *github.PartialFetchError
+	if len(events) == 0 || !errors.As(err, &page) {
+		return nil
+	}
+	return &PartialResultError{Failures: []FetchFailure{{
+		Username: username,
+		Err:      fmt.Errorf("failed to fetch events: %w", err),
+	}}}
+}
@@ -0,0 +1,78 @@
+package activity
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// partialRepository serves one push and the failure of the next page
+type partialRepository struct{ err error }
+
+func (r partialRepository) FetchEvents(username string) ([]github.GitHubEvent, error) {
+	return []github.GitHubEvent{{
+		ID:        "1",
+		Type:      "PushEvent",
+		Repo:      github.Repo{Name: username + "/repo"},
+		Payload:   json.RawMessage(`{"size":2}`),
+		CreatedAt: time.Now().Add(-time.Hour),
+	}}, r.err
+}
+
+func TestPartialResultError(t *testing.T) {
+	err := &PartialResultError{Failures: []FetchFailure{
+		{Username: "alice", Err: github.ErrNetworkError},
+		{Username: "bob", Err: errors.New("page 2: timeout")},
+	}}
+	if !strings.HasPrefix(err.Error(), "partial results: alice: ") || !strings.HasSuffix(err.Error(), "; bob: page 2: timeout") {
+		t.Errorf("Error() = %q", err.Error())
+	}
+	if !errors.Is(err, github.ErrNetworkError) {
+		t.Error("errors.Is should match a failure's error")
+	}
+	if failures, ok := AsPartial(err); !ok || len(failures) != 2 {
+		t.Errorf("AsPartial() = %v, %v, want both failures", failures, ok)
+	}
+	if _, ok := AsPartial(github.ErrNetworkError); ok {
+		t.Error("AsPartial() accepted a plain error")
+	}
+}
+
+func TestActivityService_PartialFeed(t *testing.T) {
+	page := &github.PartialFetchError{Page: 2, Err: github.ErrRateLimitExceeded}
+	service := NewActivityService(partialRepository{err: page})
+
+	summaries, err := service.GetUserActivity("alice", EventFilter{})
+	failures, ok := AsPartial(err)
+	if !ok || len(failures) != 1 || failures[0].Username != "alice" {
+		t.Fatalf("GetUserActivity() error = %v, want a partial result for alice", err)
+	}
+	if !errors.Is(err, github.ErrRateLimitExceeded) {
+		t.Errorf("error %v should wrap the page's failure", err)
+	}
+	if len(summaries) != 1 {
+		t.Errorf("GetUserActivity() = %d summaries, want the 1 fetched", len(summaries))
+	}
+
+	detailed, err := service.GetUserActivityDetailed("alice", EventFilter{})
+	if _, ok := AsPartial(err); !ok || len(detailed) != 1 {
+		t.Errorf("GetUserActivityDetailed() = %d, %v, want 1 activity and a partial result", len(detailed), err)
+	}
+
+	summary, err := service.GetPeriodSummary("alice", EventFilter{}, 7)
+	if _, ok := AsPartial(err); !ok || summary == nil || summary.Commits != 2 {
+		t.Errorf("GetPeriodSummary() = %+v, %v, want 2 commits and a partial result", summary, err)
+	}
+
+	// A failure with nothing fetched, or of the first page, is not partial
+	service = NewActivityService(partialRepository{err: github.ErrNetworkError})
+	if _, err := service.GetUserActivity("alice", EventFilter{}); err == nil {
+		t.Error("GetUserActivity() should fail")
+	} else if _, ok := AsPartial(err); ok {
+		t.Errorf("GetUserActivity() error = %v, want a plain failure", err)
+	}
+}
@@ -0,0 +1,36 @@
+package activity
+
+import "sync"
+
+// Worker Pool - Several users' feeds fetched at once
+
+// DefaultConcurrency is how many users ForEachUser serves at once by default
+const DefaultConcurrency = 4
+
+// ForEachUser calls fn for every username on at most workers goroutines and
+// returns fn's error for each username, in the order given. A user whose
+// fetch fails does not stop the others.
+func ForEachUser(usernames []string, workers int, fn func(username string) error) []error {
+	if workers < 1 {
+		workers = 1
+	}
+	errs := make([]error, len(usernames))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for range min(workers, len(usernames)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				errs[i] = fn(usernames[i])
+			}
+		}()
+	}
+	for i := range usernames {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+	return errs
+}
@@ -0,0 +1,52 @@
+package activity
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestForEachUser(t *testing.T) {
+	usernames := []string{"alice", "ghost", "bob", "carol", "dave"}
+	var running, peak atomic.Int32
+	var mu sync.Mutex
+	seen := make(map[string]bool)
+
+	errs := ForEachUser(usernames, 2, func(username string) error {
+		n := running.Add(1)
+		defer running.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+
+		mu.Lock()
+		seen[username] = true
+		mu.Unlock()
+		if username == "ghost" {
+			return errors.New("not found")
+		}
+		return nil
+	})
+
+	if len(seen) != len(usernames) {
+		t.Errorf("Fetched %d users, want all %d despite the failure", len(seen), len(usernames))
+	}
+	if peak.Load() > 2 {
+		t.Errorf("%d fetches ran at once, want at most 2", peak.Load())
+	}
+	for i, err := range errs {
+		if (err != nil) != (usernames[i] == "ghost") {
+			t.Errorf("errs[%d] = %v for %s", i, err, usernames[i])
+		}
+	}
+
+	if errs := ForEachUser(nil, 0, func(string) error { return nil }); len(errs) != 0 {
+		t.Errorf("ForEachUser(nil) = %v, want no errors", errs)
+	}
+}
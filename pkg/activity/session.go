@@ -0,0 +1,214 @@
+package activity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// SessionRepository reuses events stored in a session file so consecutive
+// invocations against the same user share one fetched dataset
+type SessionRepository struct {
+	next github.EventRepository
+	path string
+	now  func() time.Time
+
+	maxAge    time.Duration // Stored events older than this are fetched again; 0 keeps them
+	refreshAt time.Time     // Stored events fetched before this are fetched again
+
+	mu sync.Mutex
+}
+
+// SessionEntry is one user's dataset inside a session file
+type SessionEntry struct {
+	FetchedAt time.Time            `json:"fetched_at"`
+	Events    []github.GitHubEvent `json:"events"`
+}
+
+// sessionFile is the on-disk layout of a session, keyed by username
+type sessionFile struct {
+	Users map[string]SessionEntry `json:"users"`
+}
+
+// NewSessionRepository wraps next so events are read from and saved to path
+func NewSessionRepository(next github.EventRepository, path string) *SessionRepository {
+	return &SessionRepository{
+		next: next,
+		path: path,
+		now:  time.Now,
+	}
+}
+
+// SetMaxAge sets how long stored events are reused before they are fetched
+// again; 0 reuses them for as long as the session file exists
+func (r *SessionRepository) SetMaxAge(maxAge time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.maxAge = maxAge
+}
+
+// Refresh makes the next fetch of every user hit the wrapped repository,
+// replacing whatever the session stored before
+func (r *SessionRepository) Refresh() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.refreshAt = r.now()
+}
+
+// FetchEvents returns the session's events for username, fetching and
+// storing them on first use and once they are stale
+func (r *SessionRepository) FetchEvents(username string) ([]github.GitHubEvent, error) {
+	return r.FetchEventsContext(context.Background(), username)
+}
+
+// FetchEventsContext is FetchEvents passing ctx to the wrapped repository
+func (r *SessionRepository) FetchEventsContext(ctx context.Context, username string) ([]github.GitHubEvent, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	session, err := r.load()
+	if err != nil {
+		return nil, err
+	}
+	if entry, ok := session.Users[username]; ok && r.fresh(entry) {
+		return entry.Events, nil
+	}
+
+	events, err := github.FetchWithContext(ctx, r.next, username)
+	if err != nil {
+		// Events read before a partial failure are passed on, not kept
+		return events, err
+	}
+
+	session.Users[username] = SessionEntry{
+		FetchedAt: r.now(),
+		Events:    events,
+	}
+	if err := r.save(session); err != nil {
+		return nil, err
+	}
+
+	return events, nil
+}
+
+// fresh reports whether a stored entry may be reused
+func (r *SessionRepository) fresh(entry SessionEntry) bool {
+	if !r.refreshAt.IsZero() && entry.FetchedAt.Before(r.refreshAt) {
+		return false
+	}
+	return r.maxAge <= 0 || r.now().Sub(entry.FetchedAt) < r.maxAge
+}
+
+// load reads the session file; a missing file is an empty session
+func (r *SessionRepository) load() (*sessionFile, error) {
+	session := &sessionFile{Users: make(map[string]SessionEntry)}
+
+	data, err := os.ReadFile(r.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return session, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read session: %w", err)
+	}
+	if err := json.Unmarshal(data, session); err != nil {
+		return nil, fmt.Errorf("invalid session file %s: %w", r.path, err)
+	}
+	if session.Users == nil {
+		session.Users = make(map[string]SessionEntry)
+	}
+
+	return session, nil
+}
+
+// save writes the session atomically so an interrupted run cannot leave a
+// truncated file behind
+func (r *SessionRepository) save(session *sessionFile) error {
+	data, err := json.Marshal(session)
+	if err != nil {
+		return fmt.Errorf("failed to encode session: %w", err)
+	}
+
+	if err := writeFileAtomic(r.path, data); err != nil {
+		return fmt.Errorf("failed to write session: %w", err)
+	}
+
+	return nil
+}
+
+// writeFileAtomic replaces path with data through a temporary file in the
+// same directory, so readers never see a partial write
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// SharedCacheRepository keeps every user's events for a TTL and is safe for
+// concurrent use, so a long-running server answers repeated queries for many
+// users from one cache
+type SharedCacheRepository struct {
+	next github.EventRepository
+	ttl  time.Duration
+	now  func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]SessionEntry
+}
+
+// NewSharedCacheRepository wraps next with a per-user cache
+func NewSharedCacheRepository(next github.EventRepository, ttl time.Duration) *SharedCacheRepository {
+	return &SharedCacheRepository{
+		next:    next,
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[string]SessionEntry),
+	}
+}
+
+// FetchEvents returns username's cached events, fetching them when missing or
+// older than the TTL. Events that rolled off the feed are kept.
+func (r *SharedCacheRepository) FetchEvents(username string) ([]github.GitHubEvent, error) {
+	return r.FetchEventsContext(context.Background(), username)
+}
+
+// FetchEventsContext is FetchEvents passing ctx to the wrapped repository
+func (r *SharedCacheRepository) FetchEventsContext(ctx context.Context, username string) ([]github.GitHubEvent, error) {
+	r.mu.Lock()
+	entry, ok := r.entries[username]
+	r.mu.Unlock()
+	if ok && r.now().Sub(entry.FetchedAt) < r.ttl {
+		return entry.Events, nil
+	}
+
+	events, err := github.FetchWithContext(ctx, r.next, username)
+	if err != nil {
+		// Events read before a partial failure are passed on, not kept
+		return events, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if previous, ok := r.entries[username]; ok {
+		events = github.MergeEvents(events, previous.Events)
+	}
+	r.entries[username] = SessionEntry{FetchedAt: r.now(), Events: events}
+	return events, nil
+}
@@ -0,0 +1,219 @@
+package activity
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// countingRepository counts FetchEvents calls per user
+type countingRepository struct {
+	events []github.GitHubEvent
+	err    error
+	calls  map[string]int
+}
+
+func (r *countingRepository) FetchEvents(username string) ([]github.GitHubEvent, error) {
+	if r.calls == nil {
+		r.calls = make(map[string]int)
+	}
+	r.calls[username]++
+	if r.err != nil {
+		return nil, r.err
+	}
+	return r.events, nil
+}
+
+func TestSessionRepository_FetchEvents(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	events := []github.GitHubEvent{
+		{ID: "1", Type: "PushEvent", Repo: github.Repo{Name: "user/repo"}, CreatedAt: time.Now().UTC()},
+	}
+	next := &countingRepository{events: events}
+
+	// Each invocation gets a fresh repository reading the same file
+	for i := 0; i < 3; i++ {
+		got, err := NewSessionRepository(next, path).FetchEvents("octocat")
+		if err != nil {
+			t.Fatalf("FetchEvents() error = %v", err)
+		}
+		if len(got) != 1 || got[0].ID != "1" {
+			t.Errorf("FetchEvents() = %v, want event 1", got)
+		}
+	}
+	if next.calls["octocat"] != 1 {
+		t.Errorf("API fetched %d times, want 1", next.calls["octocat"])
+	}
+
+	// Another user in the same session is fetched once as well
+	if _, err := NewSessionRepository(next, path).FetchEvents("torvalds"); err != nil {
+		t.Fatalf("FetchEvents() error = %v", err)
+	}
+	if _, err := NewSessionRepository(next, path).FetchEvents("octocat"); err != nil {
+		t.Fatalf("FetchEvents() error = %v", err)
+	}
+	if next.calls["torvalds"] != 1 || next.calls["octocat"] != 1 {
+		t.Errorf("calls = %v, want one per user", next.calls)
+	}
+}
+
+func TestSessionRepository_MaxAge(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	next := &countingRepository{events: []github.GitHubEvent{
+		{ID: "1", Type: "PushEvent", Repo: github.Repo{Name: "user/repo"}, CreatedAt: time.Now().UTC()},
+	}}
+	clock := github.NewFakeClock(time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC))
+	first := NewSessionRepository(next, path)
+	first.now = clock.Now
+	if _, err := first.FetchEvents("octocat"); err != nil {
+		t.Fatalf("FetchEvents() error = %v", err)
+	}
+	clock.Advance(30 * time.Minute)
+
+	tests := []struct {
+		name    string
+		maxAge  time.Duration
+		refresh bool
+		fetched bool
+	}{
+		{"no max age reuses the session", 0, false, false},
+		{"fresh within max age", time.Hour, false, false},
+		{"stale past max age", time.Minute, false, true},
+		{"refresh ignores a fresh session", time.Hour, true, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := next.calls["octocat"]
+			clock.Advance(time.Second)
+			session := NewSessionRepository(next, path)
+			session.now = clock.Now
+			session.SetMaxAge(tt.maxAge)
+			if tt.refresh {
+				session.Refresh()
+			}
+			if _, err := session.FetchEvents("octocat"); err != nil {
+				t.Fatalf("FetchEvents() error = %v", err)
+			}
+			if fetched := next.calls["octocat"] > before; fetched != tt.fetched {
+				t.Errorf("fetched = %v, want %v", fetched, tt.fetched)
+			}
+			// The refetched events replace the stored ones, so a second read
+			// in the same run is served from the session
+			if tt.fetched {
+				before = next.calls["octocat"]
+				if _, err := session.FetchEvents("octocat"); err != nil {
+					t.Fatalf("FetchEvents() error = %v", err)
+				}
+				if tt.refresh && next.calls["octocat"] != before {
+					t.Error("Refresh() should refetch once, not on every read")
+				}
+			}
+		})
+	}
+}
+
+func TestSessionRepository_Errors(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("fetch errors are not stored", func(t *testing.T) {
+		path := filepath.Join(dir, "failed.json")
+		next := &countingRepository{err: errors.New("boom")}
+
+		if _, err := NewSessionRepository(next, path).FetchEvents("octocat"); err == nil {
+			t.Fatal("Expected error but got none")
+		}
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("session file should not exist after a failed fetch")
+		}
+	})
+
+	t.Run("corrupt session file", func(t *testing.T) {
+		path := filepath.Join(dir, "corrupt.json")
+		if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := NewSessionRepository(&countingRepository{}, path).FetchEvents("octocat")
+		if err == nil {
+			t.Fatal("Expected error but got none")
+		}
+	})
+}
+
+func TestActivityService_UseSession(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "session.json")
+	next := &countingRepository{events: []github.GitHubEvent{
+		{ID: "1", Type: "WatchEvent", Repo: github.Repo{Name: "user/repo"}, CreatedAt: time.Now()},
+	}}
+
+	service := NewActivityService(next)
+	service.UseSession(path)
+	service.UseSession(path)
+
+	if session, ok := service.repository.(*SessionRepository); !ok || session.next != next {
+		t.Fatalf("UseSession() should wrap the original repository exactly once")
+	}
+
+	if _, err := service.GetUserActivity("octocat", EventFilter{}); err != nil {
+		t.Fatalf("GetUserActivity() error = %v", err)
+	}
+	if _, err := service.GetEventTypeStatistics("octocat"); err != nil {
+		t.Fatalf("GetEventTypeStatistics() error = %v", err)
+	}
+	if next.calls["octocat"] != 1 {
+		t.Errorf("API fetched %d times, want 1", next.calls["octocat"])
+	}
+}
+
+func TestSharedCacheRepository(t *testing.T) {
+	next := &countingRepository{events: []github.GitHubEvent{{ID: "1"}}}
+	repo := NewSharedCacheRepository(next, time.Minute)
+	now := time.Now()
+	repo.now = func() time.Time { return now }
+
+	for _, username := range []string{"alice", "bob", "alice", "bob"} {
+		if _, err := repo.FetchEvents(username); err != nil {
+			t.Fatalf("FetchEvents() error = %v", err)
+		}
+	}
+	if next.calls["alice"] != 1 || next.calls["bob"] != 1 {
+		t.Errorf("Fetches = %v, want one per user", next.calls)
+	}
+
+	// After the TTL the feed is fetched again and merged with what was cached
+	now = now.Add(2 * time.Minute)
+	next.events = []github.GitHubEvent{{ID: "2"}}
+	events, err := repo.FetchEvents("alice")
+	if err != nil {
+		t.Fatalf("FetchEvents() error = %v", err)
+	}
+	if next.calls["alice"] != 2 || len(events) != 2 {
+		t.Errorf("Got %d fetches and %d events, want 2 and 2", next.calls["alice"], len(events))
+	}
+}
+
+func TestActivityService_UseSessionClock(t *testing.T) {
+	clock := github.NewFakeClock(time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC))
+	next := &countingRepository{events: []github.GitHubEvent{{ID: "1", Type: "WatchEvent"}}}
+	service := NewActivityService(next, WithClock(clock.Now))
+	session := service.UseSession(filepath.Join(t.TempDir(), "session.json"))
+	session.SetMaxAge(time.Hour)
+
+	for range 2 {
+		if _, err := service.GetUserActivity("octocat", EventFilter{}); err != nil {
+			t.Fatalf("GetUserActivity() error = %v", err)
+		}
+	}
+	clock.Advance(2 * time.Hour)
+	if _, err := service.GetUserActivity("octocat", EventFilter{}); err != nil {
+		t.Fatalf("GetUserActivity() error = %v", err)
+	}
+	if next.calls["octocat"] != 2 {
+		t.Errorf("Fetched %d times, want 2: once, then after the session aged past its max age", next.calls["octocat"])
+	}
+}
@@ -0,0 +1,83 @@
+package activity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// Streaming - Activities delivered while the feed is still being read
+
+// ActivityStreamer is implemented by providers that can deliver activities
+// while the feed is still being read
+type ActivityStreamer interface {
+	StreamUserActivity(ctx context.Context, username string, filter EventFilter) (<-chan ActivitySummary, <-chan error)
+}
+
+// StreamUserActivity sends the user's matching activities, newest first, as
+// the feed is read. When the repository is a github.PagedEventRepository
+// only one page is held at a time and paging stops once the limit is reached
+// or events fall before filter.Since; other repositories are fetched whole
+// first. Both channels are closed when the stream ends; the error channel
+// carries at most one error, including ctx.Err() when ctx is cancelled.
+func (s *ActivityService) StreamUserActivity(
+	ctx context.Context,
+	username string,
+	filter EventFilter,
+) (<-chan ActivitySummary, <-chan error) {
+	summaries := make(chan ActivitySummary)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(summaries)
+
+		if err := ValidateUsername(username); err != nil {
+			errs <- err
+			return
+		}
+
+		count := 0
+		send := func(events []github.GitHubEvent) error {
+			for _, event := range events {
+				if !filter.Since.IsZero() && event.CreatedAt.Before(filter.Since) {
+					// The feed is newest first, so the rest is older still
+					return errPagingDone
+				}
+				if !filter.Matches(event) {
+					continue
+				}
+				select {
+				case summaries <- s.Summarize(event):
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+				if count++; filter.MaxLimit > 0 && count >= filter.MaxLimit {
+					return errPagingDone
+				}
+			}
+			return nil
+		}
+
+		var err error
+		if paged, ok := s.repository.(github.PagedEventRepository); ok {
+			err = paged.FetchEventPages(ctx, username, send)
+			if err != nil && !errors.Is(err, errPagingDone) && ctx.Err() == nil {
+				s.logger.Warn(github.LogFetchFailed, "user", username, "error", err)
+				err = fmt.Errorf("failed to fetch events: %w", err)
+			}
+		} else {
+			var events []github.GitHubEvent
+			if events, err = s.fetchEvents(ctx, username); err == nil {
+				err = send(events)
+			}
+		}
+		if err != nil && !errors.Is(err, errPagingDone) {
+			errs <- err
+		}
+	}()
+
+	return summaries, errs
+}
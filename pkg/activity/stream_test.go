@@ -0,0 +1,128 @@
+package activity
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// pagedRepository serves fixed pages and counts how many were requested
+type pagedRepository struct {
+	pages   [][]github.GitHubEvent
+	fetched int
+}
+
+func (r *pagedRepository) FetchEvents(username string) ([]github.GitHubEvent, error) {
+	return nil, errors.New("FetchEvents called on a paged repository")
+}
+
+func (r *pagedRepository) FetchEventPages(
+	ctx context.Context,
+	username string,
+	page func([]github.GitHubEvent) error,
+) error {
+	for _, events := range r.pages {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		r.fetched++
+		if err := page(events); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// collect drains a stream
+func collect(summaries <-chan ActivitySummary, errs <-chan error) ([]string, error) {
+	var ids []string
+	for summary := range summaries {
+		ids = append(ids, summary.Repository)
+	}
+	return ids, <-errs
+}
+
+func TestActivityService_StreamUserActivity(t *testing.T) {
+	base := time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+	event := func(i int, eventType string) github.GitHubEvent {
+		return github.GitHubEvent{
+			ID:        fmt.Sprint(i),
+			Type:      eventType,
+			Repo:      github.Repo{Name: fmt.Sprintf("user/repo%d", i)},
+			CreatedAt: base.Add(-time.Duration(i) * time.Hour),
+		}
+	}
+	pages := [][]github.GitHubEvent{
+		{event(1, "PushEvent"), event(2, "WatchEvent")},
+		{event(3, "PushEvent"), event(4, "PushEvent")},
+		{event(5, "PushEvent"), event(6, "WatchEvent")},
+	}
+
+	tests := []struct {
+		name     string
+		filter   EventFilter
+		expected []string
+		fetched  int
+	}{
+		{"whole feed", EventFilter{}, []string{"user/repo1", "user/repo2", "user/repo3", "user/repo4", "user/repo5", "user/repo6"}, 3},
+		{"stops at the limit", EventFilter{Type: "PushEvent", MaxLimit: 2}, []string{"user/repo1", "user/repo3"}, 2},
+		{"stops before since", EventFilter{Since: base.Add(-2*time.Hour - time.Minute)}, []string{"user/repo1", "user/repo2"}, 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &pagedRepository{pages: pages}
+			service := NewActivityService(repo)
+			got, err := collect(service.StreamUserActivity(context.Background(), "octocat", tt.filter))
+			if err != nil {
+				t.Fatalf("StreamUserActivity() error = %v", err)
+			}
+			if fmt.Sprint(got) != fmt.Sprint(tt.expected) {
+				t.Errorf("Streamed %v, want %v", got, tt.expected)
+			}
+			if repo.fetched != tt.fetched {
+				t.Errorf("Fetched %d pages, want %d", repo.fetched, tt.fetched)
+			}
+		})
+	}
+
+	t.Run("unpaged repository", func(t *testing.T) {
+		service := NewActivityService(github.NewMockEventRepository(pages[0], nil))
+		got, err := collect(service.StreamUserActivity(context.Background(), "octocat", EventFilter{MaxLimit: 1}))
+		if err != nil || fmt.Sprint(got) != "[user/repo1]" {
+			t.Errorf("StreamUserActivity() = %v, %v", got, err)
+		}
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		service := NewActivityService(github.NewMockEventRepository(nil, github.ErrUserNotFound))
+		_, err := collect(service.StreamUserActivity(context.Background(), "octocat", EventFilter{}))
+		if !errors.Is(err, github.ErrUserNotFound) {
+			t.Errorf("Expected ErrUserNotFound, got %v", err)
+		}
+	})
+
+	t.Run("empty username", func(t *testing.T) {
+		service := NewActivityService(&pagedRepository{pages: pages})
+		if _, err := collect(service.StreamUserActivity(context.Background(), " ", EventFilter{})); err == nil {
+			t.Error("Expected an error for an empty username")
+		}
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		service := NewActivityService(&pagedRepository{pages: pages})
+		summaries, errs := service.StreamUserActivity(ctx, "octocat", EventFilter{})
+		<-summaries
+		cancel()
+		for range summaries {
+		}
+		if err := <-errs; !errors.Is(err, context.Canceled) {
+			t.Errorf("Expected context.Canceled, got %v", err)
+		}
+	})
+}
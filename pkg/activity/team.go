@@ -0,0 +1,55 @@
+package activity
+
+import (
+	"sort"
+	"time"
+)
+
+// Teams - Period summaries of several members rolled up into one
+
+// TeamSummary is the period summary of each member of a team and their total
+type TeamSummary struct {
+	Team    string           `json:"team"`
+	Since   time.Time        `json:"since"`
+	Until   time.Time        `json:"until"`
+	Members []*PeriodSummary `json:"members"` // Most events first
+	Total   PeriodSummary    `json:"total"`
+}
+
+// NewTeamSummary rolls members' summaries up into a team summary. The total
+// adds the members' counts, so a repository two members pushed to, or a pull
+// request both reviewed, counts once for each of them.
+func NewTeamSummary(team string, members []*PeriodSummary) *TeamSummary {
+	summary := &TeamSummary{
+		Team:    team,
+		Members: append([]*PeriodSummary(nil), members...),
+		Total:   PeriodSummary{Username: team},
+	}
+	sort.SliceStable(summary.Members, func(i, j int) bool {
+		if summary.Members[i].Events != summary.Members[j].Events {
+			return summary.Members[i].Events > summary.Members[j].Events
+		}
+		return summary.Members[i].Username < summary.Members[j].Username
+	})
+
+	total := &summary.Total
+	for _, member := range summary.Members {
+		if summary.Since.IsZero() || member.Since.Before(summary.Since) {
+			summary.Since = member.Since
+		}
+		if member.Until.After(summary.Until) {
+			summary.Until = member.Until
+		}
+		total.Events += member.Events
+		total.Commits += member.Commits
+		total.PushedRepos += member.PushedRepos
+		total.PullRequestsOpened += member.PullRequestsOpened
+		total.PullRequestsMerged += member.PullRequestsMerged
+		total.PullRequestsReviewed += member.PullRequestsReviewed
+		total.IssuesOpened += member.IssuesOpened
+		total.IssuesClosed += member.IssuesClosed
+		total.Releases += member.Releases
+	}
+	total.Since, total.Until = summary.Since, summary.Until
+	return summary
+}
@@ -0,0 +1,39 @@
+package activity
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewTeamSummary(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := since.AddDate(0, 0, 7)
+	members := []*PeriodSummary{
+		{Username: "bob", Since: since, Until: until, Events: 2, Commits: 1, PushedRepos: 1},
+		{Username: "alice", Since: since, Until: until, Events: 5, Commits: 4, PushedRepos: 1, PullRequestsReviewed: 2},
+		{Username: "carol", Since: since, Until: until},
+		{Username: "adam", Since: since, Until: until, Events: 2, IssuesOpened: 1},
+	}
+
+	summary := NewTeamSummary("octo/backend", members)
+
+	var order []string
+	for _, member := range summary.Members {
+		order = append(order, member.Username)
+	}
+	if got := order; len(got) != 4 || got[0] != "alice" || got[1] != "adam" || got[2] != "bob" || got[3] != "carol" {
+		t.Errorf("Members = %v, want most events first, then by name", got)
+	}
+	if members[0].Username != "bob" {
+		t.Error("NewTeamSummary() should not reorder the caller's slice")
+	}
+
+	total := summary.Total
+	if total.Username != "octo/backend" || total.Events != 9 || total.Commits != 5 ||
+		total.PushedRepos != 2 || total.PullRequestsReviewed != 2 || total.IssuesOpened != 1 {
+		t.Errorf("Total = %+v", total)
+	}
+	if !summary.Since.Equal(since) || !summary.Until.Equal(until) || !total.Since.Equal(since) {
+		t.Errorf("Period = %v to %v, want %v to %v", summary.Since, summary.Until, since, until)
+	}
+}
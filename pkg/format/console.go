@@ -0,0 +1,166 @@
+package format
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/alnah/github-activity/pkg/activity"
+)
+
+// Console Output - The default human-readable formatter
+
+// OutputFormatter interface for formatting output
+type OutputFormatter interface {
+	FormatActivities(w io.Writer, activities []activity.ActivitySummary)
+	FormatDetailedActivities(w io.Writer, activities []activity.DetailedActivity)
+}
+
+// ConsoleOutputFormatter formats output for console
+type ConsoleOutputFormatter struct {
+	AbsoluteTime bool // Show timestamps instead of "3 days ago"
+	ShowActor    bool // Prefix descriptions with who acted, for feeds of other people's events
+	GroupByDay   bool // Insert a date header before each day's events
+	Width        int  // Wrap descriptions and cut commit lines to this many columns; 0 leaves them whole
+}
+
+// dayHeader writes a date header when activity starts a new day, after an
+// empty line if separate is set. It returns the day to pass for the next activity.
+func (f *ConsoleOutputFormatter) dayHeader(
+	w io.Writer,
+	activity activity.ActivitySummary,
+	previous string,
+	separate bool,
+) string {
+	if !f.GroupByDay || activity.CreatedAt.IsZero() {
+		return previous
+	}
+	day := activity.CreatedAt.Format("Monday, Jan 2")
+	if day != previous {
+		if separate && previous != "" {
+			_, _ = fmt.Fprintln(w)
+		}
+		_, _ = fmt.Fprintln(w, day)
+	}
+	return day
+}
+
+// description returns the line shown for an activity
+func (f *ConsoleOutputFormatter) description(activity activity.ActivitySummary) string {
+	if f.ShowActor && activity.Actor != "" {
+		return activity.Actor + ": " + activity.Description
+	}
+	return activity.Description
+}
+
+// item writes an activity's bullet line, wrapped under the bullet when it
+// is wider than f.Width
+func (f *ConsoleOutputFormatter) item(w io.Writer, activity activity.ActivitySummary) {
+	for i, line := range wrap(f.description(activity), f.Width-2) {
+		prefix := "  "
+		if i == 0 {
+			prefix = "- "
+		}
+		_, _ = fmt.Fprintln(w, prefix+line)
+	}
+}
+
+// FormatActivities formats activity summaries for console
+func (f *ConsoleOutputFormatter) FormatActivities(w io.Writer, activities []activity.ActivitySummary) {
+	day := ""
+	for _, activity := range activities {
+		day = f.dayHeader(w, activity, day, true)
+		f.item(w, activity)
+	}
+}
+
+// FormatDetailedActivities formats detailed activities for console
+func (f *ConsoleOutputFormatter) FormatDetailedActivities(
+	w io.Writer,
+	activities []activity.DetailedActivity,
+) {
+	day := ""
+	for _, activity := range activities {
+		// Detailed entries already end with an empty line
+		day = f.dayHeader(w, activity.ActivitySummary, day, false)
+		f.item(w, activity.ActivitySummary)
+		timestamp := activity.RelativeTime()
+		if f.AbsoluteTime {
+			timestamp = activity.Timestamp
+		}
+		_, _ = fmt.Fprintf(w, "  Time: %s\n", timestamp)
+		_, _ = fmt.Fprintf(w, "  Type: %s\n", activity.Type)
+
+		// Show commits for push events
+		if len(activity.Commits) > 0 {
+			// One author for several commits is named once in the header
+			author := soleAuthor(activity.Commits)
+			if author != "" {
+				_, _ = fmt.Fprintf(w, "  Commits (all by %s):\n", author)
+			} else {
+				_, _ = fmt.Fprintln(w, "  Commits:")
+			}
+			for _, commit := range activity.Commits {
+				line := fmt.Sprintf("    - %s: %s", commit.SHA, commit.Message)
+				if author == "" && commit.Author != "" {
+					line += " (" + commit.Author + ")"
+				}
+				_, _ = fmt.Fprintln(w, ellipsize(line, f.Width))
+			}
+		}
+
+		// Show extra details if any, in a stable order
+		keys := make([]string, 0, len(activity.ExtraDetails))
+		for key := range activity.ExtraDetails {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			// Capitalize first letter of key
+			capitalizedKey := key
+			if len(key) > 0 {
+				capitalizedKey = strings.ToUpper(key[:1]) + key[1:]
+			}
+			_, _ = fmt.Fprintf(w, "  %s: %s\n", capitalizedKey, activity.ExtraDetails[key])
+		}
+
+		_, _ = fmt.Fprintln(w)
+	}
+}
+
+// soleAuthor returns the author of every commit when several commits all
+// share one, and "" otherwise
+func soleAuthor(commits []activity.CommitSummary) string {
+	if len(commits) < 2 {
+		return ""
+	}
+	author := commits[0].Author
+	for _, commit := range commits[1:] {
+		if commit.Author != author {
+			return ""
+		}
+	}
+	return author
+}
+
+// wrap breaks text into lines of at most width runes at spaces; words wider
+// than width get a line of their own. A width of 0 or less leaves text whole.
+func wrap(text string, width int) []string {
+	words := strings.Fields(text)
+	if width <= 0 || utf8.RuneCountInString(text) <= width || len(words) == 0 {
+		return []string{text}
+	}
+
+	lines := []string{words[0]}
+	for _, word := range words[1:] {
+		last := &lines[len(lines)-1]
+		if utf8.RuneCountInString(*last)+1+utf8.RuneCountInString(word) > width {
+			lines = append(lines, word)
+			continue
+		}
+		*last += " " + word
+	}
+	return lines
+}
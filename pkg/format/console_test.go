@@ -0,0 +1,269 @@
+package format
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/activity"
+)
+
+func TestConsoleOutputFormatter_FormatActivities(t *testing.T) {
+	activities := []activity.ActivitySummary{
+		{Description: "Pushed 1 commit to user/repo"},
+		{Description: "Opened issue #42 in user/repo"},
+	}
+
+	// Use a buffer instead of capturing stdout
+	var buf bytes.Buffer
+	formatter := &ConsoleOutputFormatter{}
+	formatter.FormatActivities(&buf, activities)
+
+	output := buf.String()
+
+	// Check output contains activities
+	for _, activity := range activities {
+		if !strings.Contains(output, activity.Description) {
+			t.Errorf("Output missing activity: %s", activity.Description)
+		}
+		if !strings.Contains(output, "- ") {
+			t.Error("Output should have bullet points")
+		}
+	}
+}
+
+func TestConsoleOutputFormatter_FormatDetailedActivities(t *testing.T) {
+	activities := []activity.DetailedActivity{
+		{
+			ActivitySummary: activity.ActivitySummary{
+				Description: "Pushed 2 commits to user/repo",
+				Type:        "PushEvent",
+				Timestamp:   "2024-01-15 10:30:00",
+			},
+			Commits: []activity.CommitSummary{
+				{SHA: "abc123", Message: "First commit"},
+				{SHA: "def456", Message: "Second commit"},
+			},
+		},
+	}
+
+	// Use a buffer instead of capturing stdout
+	var buf bytes.Buffer
+	formatter := &ConsoleOutputFormatter{}
+	formatter.FormatDetailedActivities(&buf, activities)
+
+	output := buf.String()
+
+	// Check output contains expected elements
+	expectedStrings := []string{
+		"Pushed 2 commits",
+		"Time: 2024-01-15 10:30:00",
+		"Type: PushEvent",
+		"Commits:",
+		"abc123: First commit",
+		"def456: Second commit",
+	}
+
+	for _, expected := range expectedStrings {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Output missing: %s", expected)
+		}
+	}
+}
+
+func TestConsoleOutputFormatter_CommitAuthors(t *testing.T) {
+	tests := []struct {
+		name     string
+		commits  []activity.CommitSummary
+		expected []string
+	}{
+		{
+			name: "one author",
+			commits: []activity.CommitSummary{
+				{SHA: "abc123", Message: "First commit", Author: "Jane"},
+				{SHA: "def456", Message: "Second commit", Author: "Jane"},
+			},
+			expected: []string{"Commits (all by Jane):", "abc123: First commit\n", "def456: Second commit\n"},
+		},
+		{
+			name: "several authors",
+			commits: []activity.CommitSummary{
+				{SHA: "abc123", Message: "First commit", Author: "Jane"},
+				{SHA: "def456", Message: "Second commit", Author: "John"},
+			},
+			expected: []string{"Commits:\n", "abc123: First commit (Jane)", "def456: Second commit (John)"},
+		},
+		{
+			name:     "single commit",
+			commits:  []activity.CommitSummary{{SHA: "abc123", Message: "First commit", Author: "Jane"}},
+			expected: []string{"Commits:\n", "abc123: First commit (Jane)"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			formatter := &ConsoleOutputFormatter{}
+			formatter.FormatDetailedActivities(&buf, []activity.DetailedActivity{{Commits: tt.commits}})
+
+			output := buf.String()
+			for _, expected := range tt.expected {
+				if !strings.Contains(output, expected) {
+					t.Errorf("Output missing %q:\n%s", expected, output)
+				}
+			}
+		})
+	}
+}
+
+func TestConsoleOutputFormatter_RelativeTime(t *testing.T) {
+	activities := []activity.DetailedActivity{
+		{
+			ActivitySummary: activity.ActivitySummary{
+				Description: "Starred user/repo",
+				Timestamp:   "2024-01-15 10:30:00",
+				CreatedAt:   time.Now().Add(-3 * 24 * time.Hour),
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		absoluteTime bool
+		expected     string
+	}{
+		{"relative by default", false, "Time: 3 days ago"},
+		{"absolute when requested", true, "Time: 2024-01-15 10:30:00"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			formatter := &ConsoleOutputFormatter{AbsoluteTime: tt.absoluteTime}
+			formatter.FormatDetailedActivities(&buf, activities)
+
+			if !strings.Contains(buf.String(), tt.expected) {
+				t.Errorf("Output missing %q:\n%s", tt.expected, buf.String())
+			}
+		})
+	}
+}
+
+func TestConsoleOutputFormatter_ShowActor(t *testing.T) {
+	activities := []activity.ActivitySummary{{Description: "Starred user/repo", Actor: "octocat"}}
+
+	tests := []struct {
+		name      string
+		showActor bool
+		expected  string
+	}{
+		{"hidden by default", false, "- Starred user/repo\n"},
+		{"shown for received events", true, "- octocat: Starred user/repo\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			formatter := &ConsoleOutputFormatter{ShowActor: tt.showActor}
+			formatter.FormatActivities(&buf, activities)
+
+			if buf.String() != tt.expected {
+				t.Errorf("Output = %q, want %q", buf.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestConsoleOutputFormatter_GroupByDay(t *testing.T) {
+	monday := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	activities := []activity.ActivitySummary{
+		{Description: "Starred user/repo", CreatedAt: monday},
+		{Description: "Forked user/repo", CreatedAt: monday.Add(-time.Hour)},
+		{Description: "Created branch", CreatedAt: monday.Add(-24 * time.Hour)},
+	}
+
+	t.Run("summaries", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := &ConsoleOutputFormatter{GroupByDay: true}
+		formatter.FormatActivities(&buf, activities)
+
+		expected := "Monday, Jan 15\n- Starred user/repo\n- Forked user/repo\n\n" +
+			"Sunday, Jan 14\n- Created branch\n"
+		if buf.String() != expected {
+			t.Errorf("Output = %q, want %q", buf.String(), expected)
+		}
+	})
+
+	t.Run("detailed", func(t *testing.T) {
+		detailed := make([]activity.DetailedActivity, len(activities))
+		for i, summary := range activities {
+			detailed[i] = activity.DetailedActivity{ActivitySummary: summary}
+		}
+
+		var buf bytes.Buffer
+		formatter := &ConsoleOutputFormatter{GroupByDay: true, AbsoluteTime: true}
+		formatter.FormatDetailedActivities(&buf, detailed)
+
+		if strings.Count(buf.String(), "Monday, Jan 15\n") != 1 {
+			t.Errorf("Expected one Monday header:\n%s", buf.String())
+		}
+		if !strings.Contains(buf.String(), "\n\nSunday, Jan 14\n- Created branch") {
+			t.Errorf("Expected Sunday header before its event:\n%s", buf.String())
+		}
+	})
+
+	t.Run("off by default", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := &ConsoleOutputFormatter{}
+		formatter.FormatActivities(&buf, activities)
+
+		if strings.Contains(buf.String(), "Monday") {
+			t.Errorf("Unexpected header:\n%s", buf.String())
+		}
+	})
+}
+
+func TestConsoleOutputFormatter_Width(t *testing.T) {
+	formatter := &ConsoleOutputFormatter{AbsoluteTime: true, Width: 30}
+
+	var buf bytes.Buffer
+	formatter.FormatActivities(&buf, []activity.ActivitySummary{
+		{Description: "Opened pull request #42 in user/repo: Add wrapping"},
+		{Description: "Starred user/repo"},
+	})
+	want := "- Opened pull request #42 in\n" +
+		"  user/repo: Add wrapping\n" +
+		"- Starred user/repo\n"
+	if got := buf.String(); got != want {
+		t.Errorf("FormatActivities() =\n%s\nwant\n%s", got, want)
+	}
+
+	buf.Reset()
+	formatter.FormatDetailedActivities(&buf, []activity.DetailedActivity{{
+		ActivitySummary: activity.ActivitySummary{Description: "Pushed 1 commit", Type: "PushEvent"},
+		Commits:         []activity.CommitSummary{{SHA: "abc1234", Message: "Fix the flaky retry test on slow runners"}},
+	}})
+	if !strings.Contains(buf.String(), "    - abc1234: Fix the flaky …\n") {
+		t.Errorf("Commit line should be cut to 30 columns:\n%s", buf.String())
+	}
+}
+
+func TestWrap(t *testing.T) {
+	tests := []struct {
+		text  string
+		width int
+		want  []string
+	}{
+		{"fits as is", 20, []string{"fits as is"}},
+		{"one two three four", 9, []string{"one two", "three", "four"}},
+		{"a https://example.com/very/long/url b", 10, []string{"a", "https://example.com/very/long/url", "b"}},
+		{"unbounded text", 0, []string{"unbounded text"}},
+	}
+	for _, tt := range tests {
+		got := wrap(tt.text, tt.width)
+		if strings.Join(got, "|") != strings.Join(tt.want, "|") {
+			t.Errorf("wrap(%q, %d) = %q, want %q", tt.text, tt.width, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,518 @@
+// Package format writes activity summaries as console text, aligned tables,
+// CSV, TSV, JSON Lines, YAML, templates, Slack and Discord messages, or
+// GitHub Actions job summaries.
+package format
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/alnah/github-activity/internal/messages"
+	"github.com/alnah/github-activity/pkg/activity"
+)
+
+// Output Formats - Alternative OutputFormatter implementations
+
+// FormatOptions carries format-specific settings from the CLI
+type FormatOptions struct {
+	Template string
+	Title    string // Heading for formats that have one, such as slack and discord
+	Width    int    // Terminal columns for formats that fit their lines to it; 0 if unknown
+}
+
+// FormatterRegistry maps format names to formatter constructors
+type FormatterRegistry map[string]func(FormatOptions) (OutputFormatter, error)
+
+// Formats is the registry of built-in formats. Copy it before adding entries
+// rather than changing it for every user of the package.
+var Formats = FormatterRegistry{
+	"console": func(FormatOptions) (OutputFormatter, error) {
+		return &ConsoleOutputFormatter{}, nil
+	},
+	"table": func(options FormatOptions) (OutputFormatter, error) {
+		return &TableOutputFormatter{Width: options.Width}, nil
+	},
+	"csv": func(FormatOptions) (OutputFormatter, error) {
+		return &DelimitedOutputFormatter{Delimiter: ','}, nil
+	},
+	"tsv": func(FormatOptions) (OutputFormatter, error) {
+		return &DelimitedOutputFormatter{Delimiter: '\t'}, nil
+	},
+	"ndjson": func(FormatOptions) (OutputFormatter, error) {
+		return &NDJSONOutputFormatter{}, nil
+	},
+	"yaml": func(FormatOptions) (OutputFormatter, error) {
+		return &YAMLOutputFormatter{}, nil
+	},
+	"template": func(options FormatOptions) (OutputFormatter, error) {
+		return NewTemplateOutputFormatter(options.Template)
+	},
+	"slack": func(options FormatOptions) (OutputFormatter, error) {
+		return &SlackOutputFormatter{Title: options.Title}, nil
+	},
+	"discord": func(options FormatOptions) (OutputFormatter, error) {
+		return &DiscordOutputFormatter{Title: options.Title}, nil
+	},
+	"gha": func(options FormatOptions) (OutputFormatter, error) {
+		return &GitHubActionsOutputFormatter{Title: options.Title, SummaryPath: os.Getenv(StepSummaryEnvVar)}, nil
+	},
+}
+
+// detailedFormats lists formats that need detailed activities to fill their fields
+var detailedFormats = map[string]bool{
+	"csv":      true,
+	"tsv":      true,
+	"template": true,
+}
+
+// New returns the formatter registered under the given name
+func (r FormatterRegistry) New(format string, options FormatOptions) (OutputFormatter, error) {
+	constructor, ok := r[strings.ToLower(format)]
+	if !ok {
+		return nil, fmt.Errorf(
+			"invalid output format: %s (available: %s)",
+			format,
+			strings.Join(r.Names(), ", "),
+		)
+	}
+	return constructor(options)
+}
+
+// Names returns the sorted names of the registered formats
+func (r FormatterRegistry) Names() []string {
+	formats := make([]string, 0, len(r))
+	for name := range r {
+		formats = append(formats, name)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// NeedsDetails reports whether the named format needs detailed activities to
+// fill its fields
+func NeedsDetails(format string) bool {
+	return detailedFormats[strings.ToLower(format)]
+}
+
+// NewOutputFormatter returns the built-in formatter registered under the given name
+func NewOutputFormatter(format string, options FormatOptions) (OutputFormatter, error) {
+	return Formats.New(format, options)
+}
+
+// GetAvailableFormats returns the sorted names of all built-in output formats
+func GetAvailableFormats() []string {
+	return Formats.Names()
+}
+
+// delimitedHeader is the header row shared by CSV and TSV output
+var delimitedHeader = []string{"timestamp", "type", "repo", "description", "actor", "commits"}
+
+// DelimitedOutputFormatter formats output as CSV or TSV rows, one per event
+type DelimitedOutputFormatter struct {
+	Delimiter rune
+}
+
+// FormatActivities writes one row per activity summary
+func (f *DelimitedOutputFormatter) FormatActivities(w io.Writer, activities []activity.ActivitySummary) {
+	writer := f.newWriter(w)
+	_ = writer.Write(delimitedHeader)
+	for _, activity := range activities {
+		_ = writer.Write([]string{
+			activity.Timestamp,
+			activity.Type,
+			activity.Repository,
+			activity.Description,
+			"",
+			"",
+		})
+	}
+	writer.Flush()
+}
+
+// FormatDetailedActivities writes one row per detailed activity
+func (f *DelimitedOutputFormatter) FormatDetailedActivities(
+	w io.Writer,
+	activities []activity.DetailedActivity,
+) {
+	writer := f.newWriter(w)
+	_ = writer.Write(delimitedHeader)
+	for _, activity := range activities {
+		_ = writer.Write([]string{
+			activity.Timestamp,
+			activity.Type,
+			activity.Repository,
+			activity.Description,
+			activity.ActorLogin,
+			strconv.Itoa(activity.CommitCount),
+		})
+	}
+	writer.Flush()
+}
+
+// newWriter creates a csv.Writer using the configured delimiter
+func (f *DelimitedOutputFormatter) newWriter(w io.Writer) *csv.Writer {
+	writer := csv.NewWriter(w)
+	if f.Delimiter != 0 {
+		writer.Comma = f.Delimiter
+	}
+	return writer
+}
+
+// NDJSONOutputFormatter writes one JSON object per event, each on its own
+// line, so output can be piped to tools that read JSON Lines. Each call
+// writes complete lines, so activities may be written as they arrive.
+type NDJSONOutputFormatter struct{}
+
+// FormatActivities writes a JSON line per activity summary
+func (f *NDJSONOutputFormatter) FormatActivities(w io.Writer, activities []activity.ActivitySummary) {
+	encoder := json.NewEncoder(w)
+	for _, activity := range activities {
+		_ = encoder.Encode(activity)
+	}
+}
+
+// FormatDetailedActivities writes a JSON line per detailed activity, with
+// its commits and extra details
+func (f *NDJSONOutputFormatter) FormatDetailedActivities(
+	w io.Writer,
+	activities []activity.DetailedActivity,
+) {
+	encoder := json.NewEncoder(w)
+	for _, activity := range activities {
+		_ = encoder.Encode(activity)
+	}
+}
+
+// TemplateOutputFormatter renders each activity with a user-defined text/template
+type TemplateOutputFormatter struct {
+	template *template.Template
+}
+
+// NewTemplateOutputFormatter parses the template used for every activity
+func NewTemplateOutputFormatter(text string) (*TemplateOutputFormatter, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, fmt.Errorf("template format requires a -template")
+	}
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+
+	tmpl, err := template.New("activity").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	// Execution errors such as an unknown field only show up with data, so
+	// run the template once on a sample rather than after the fetch
+	if err := tmpl.Execute(io.Discard, sampleActivity); err != nil {
+		return nil, fmt.Errorf("invalid template: %w", err)
+	}
+	return &TemplateOutputFormatter{template: tmpl}, nil
+}
+
+// sampleActivity is the detailed activity templates are checked against
+var sampleActivity = activity.DetailedActivity{
+	ActivitySummary: activity.ActivitySummary{
+		Type:        "PushEvent",
+		Repository:  "octocat/hello-world",
+		Actor:       "octocat",
+		Description: "Pushed 1 commit to octocat/hello-world",
+		Timestamp:   "2024-01-15 12:00:00",
+		CreatedAt:   time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC),
+		Fields:      map[string]string{"ref": "main"},
+	},
+	EventID:      "1",
+	ActorLogin:   "octocat",
+	CommitCount:  1,
+	Commits:      []activity.CommitSummary{{SHA: "abc1234", Message: "Initial commit"}},
+	ExtraDetails: map[string]string{},
+}
+
+// FormatActivities executes the template once per activity summary
+func (f *TemplateOutputFormatter) FormatActivities(w io.Writer, activities []activity.ActivitySummary) {
+	for _, activity := range activities {
+		if err := f.template.Execute(w, activity); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: template: %v\n", err)
+			return
+		}
+	}
+}
+
+// FormatDetailedActivities executes the template once per detailed activity
+func (f *TemplateOutputFormatter) FormatDetailedActivities(
+	w io.Writer,
+	activities []activity.DetailedActivity,
+) {
+	for _, activity := range activities {
+		if err := f.template.Execute(w, activity); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: template: %v\n", err)
+			return
+		}
+	}
+}
+
+// explanation is the machine-readable record written for each event by -explain
+type explanation struct {
+	Description string            `json:"description"`
+	Type        string            `json:"type"`
+	Timestamp   string            `json:"timestamp"`
+	Fields      map[string]string `json:"fields"`
+}
+
+// ExplainOutputFormatter writes one JSON object per event with the payload
+// fields that produced its description
+type ExplainOutputFormatter struct{}
+
+// FormatActivities writes an explanation line per activity summary
+func (f *ExplainOutputFormatter) FormatActivities(w io.Writer, activities []activity.ActivitySummary) {
+	encoder := json.NewEncoder(w)
+	for _, activity := range activities {
+		_ = encoder.Encode(explanation{
+			Description: activity.Description,
+			Type:        activity.Type,
+			Timestamp:   activity.Timestamp,
+			Fields:      activity.Fields,
+		})
+	}
+}
+
+// FormatDetailedActivities writes an explanation line per detailed activity
+func (f *ExplainOutputFormatter) FormatDetailedActivities(
+	w io.Writer,
+	activities []activity.DetailedActivity,
+) {
+	summaries := make([]activity.ActivitySummary, 0, len(activities))
+	for _, activity := range activities {
+		summaries = append(summaries, activity.ActivitySummary)
+	}
+	f.FormatActivities(w, summaries)
+}
+
+// slackMaxBlocks is the most blocks Slack accepts in one message
+const slackMaxBlocks = 50
+
+// slackMessage is a Slack Block Kit message; only the fields used here are modelled
+type slackMessage struct {
+	Text   string       `json:"text"` // Fallback for notifications
+	Blocks []slackBlock `json:"blocks"`
+}
+
+// slackBlock is a header, section or context block
+type slackBlock struct {
+	Type     string      `json:"type"`
+	Text     *slackText  `json:"text,omitempty"`
+	Elements []slackText `json:"elements,omitempty"`
+}
+
+// slackText is a plain_text or mrkdwn text object
+type slackText struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// SlackOutputFormatter writes one Slack Block Kit message, as a single line of
+// JSON, with a section per event
+type SlackOutputFormatter struct {
+	Title string
+}
+
+// FormatActivities writes a message with each activity's description and time
+func (f *SlackOutputFormatter) FormatActivities(w io.Writer, activities []activity.ActivitySummary) {
+	sections := make([]string, 0, len(activities))
+	for _, activity := range activities {
+		sections = append(sections, fmt.Sprintf("%s\n_%s_",
+			slackEscape(activity.Description), activity.Timestamp))
+	}
+	f.write(w, sections)
+}
+
+// FormatDetailedActivities also lists commits and extra details per activity
+func (f *SlackOutputFormatter) FormatDetailedActivities(
+	w io.Writer,
+	activities []activity.DetailedActivity,
+) {
+	sections := make([]string, 0, len(activities))
+	for _, activity := range activities {
+		lines := []string{
+			slackEscape(activity.Description),
+			"_" + activity.Timestamp + "_",
+		}
+		for _, commit := range activity.Commits {
+			lines = append(lines, fmt.Sprintf("• `%s` %s", commit.SHA, slackEscape(commit.Message)))
+		}
+		keys := make([]string, 0, len(activity.ExtraDetails))
+		for key := range activity.ExtraDetails {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			lines = append(lines, fmt.Sprintf("%s: %s", key, slackEscape(activity.ExtraDetails[key])))
+		}
+		sections = append(sections, strings.Join(lines, "\n"))
+	}
+	f.write(w, sections)
+}
+
+// write encodes the title and sections as one message, summarizing what does
+// not fit in Slack's block limit
+func (f *SlackOutputFormatter) write(w io.Writer, sections []string) {
+	title := f.Title
+	if title == "" {
+		title = "GitHub activity"
+	}
+	message := slackMessage{
+		Text:   title,
+		Blocks: []slackBlock{{Type: "header", Text: &slackText{Type: "plain_text", Text: title}}},
+	}
+
+	if len(sections) == 0 {
+		sections = []string{"No recent activity found."}
+	}
+	// Keep room for the header and the overflow note
+	shown := sections
+	if len(sections) > slackMaxBlocks-1 {
+		shown = sections[:slackMaxBlocks-2]
+	}
+	for _, section := range shown {
+		message.Blocks = append(message.Blocks, slackBlock{
+			Type: "section",
+			Text: &slackText{Type: "mrkdwn", Text: section},
+		})
+	}
+	if hidden := len(sections) - len(shown); hidden > 0 {
+		message.Blocks = append(message.Blocks, slackBlock{
+			Type:     "context",
+			Elements: []slackText{{Type: "mrkdwn", Text: fmt.Sprintf("…%s not shown", messages.Plural("events", hidden))}},
+		})
+	}
+
+	_ = json.NewEncoder(w).Encode(message)
+}
+
+// slackEscape escapes the characters Slack treats as markup
+func slackEscape(text string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(text)
+}
+
+// Discord limits on one webhook message
+const (
+	discordMaxEmbeds           = 10
+	discordMaxTitle            = 256
+	discordMaxEmbedDescription = 4096
+)
+
+// discordMessage is a Discord webhook message; only the fields used here are modelled
+type discordMessage struct {
+	Content string         `json:"content,omitempty"`
+	Embeds  []discordEmbed `json:"embeds,omitempty"`
+}
+
+// discordEmbed is the rich card Discord shows for one event
+type discordEmbed struct {
+	Title       string         `json:"title"`
+	Description string         `json:"description,omitempty"`
+	URL         string         `json:"url,omitempty"`
+	Timestamp   string         `json:"timestamp,omitempty"`
+	Footer      *discordFooter `json:"footer,omitempty"`
+}
+
+// discordFooter is the small text under an embed
+type discordFooter struct {
+	Text string `json:"text"`
+}
+
+// DiscordOutputFormatter writes Discord webhook messages with an embed per
+// event, one message per line of JSON since Discord caps the embeds a
+// message may carry
+type DiscordOutputFormatter struct {
+	Title string
+}
+
+// FormatActivities writes an embed with each activity's description and time
+func (f *DiscordOutputFormatter) FormatActivities(w io.Writer, activities []activity.ActivitySummary) {
+	embeds := make([]discordEmbed, 0, len(activities))
+	for _, activity := range activities {
+		embeds = append(embeds, discordActivityEmbed(activity))
+	}
+	f.write(w, embeds)
+}
+
+// FormatDetailedActivities also lists commits and extra details per activity
+func (f *DiscordOutputFormatter) FormatDetailedActivities(
+	w io.Writer,
+	activities []activity.DetailedActivity,
+) {
+	embeds := make([]discordEmbed, 0, len(activities))
+	for _, activity := range activities {
+		embed := discordActivityEmbed(activity.ActivitySummary)
+		var lines []string
+		for _, commit := range activity.Commits {
+			lines = append(lines, fmt.Sprintf("• `%s` %s", commit.SHA, commit.Message))
+		}
+		keys := make([]string, 0, len(activity.ExtraDetails))
+		for key := range activity.ExtraDetails {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			lines = append(lines, fmt.Sprintf("%s: %s", key, activity.ExtraDetails[key]))
+		}
+		embed.Description = truncate(strings.Join(lines, "\n"), discordMaxEmbedDescription)
+		embeds = append(embeds, embed)
+	}
+	f.write(w, embeds)
+}
+
+// discordActivityEmbed builds the embed shared by both activity views
+func discordActivityEmbed(activity activity.ActivitySummary) discordEmbed {
+	embed := discordEmbed{Title: truncate(activity.Description, discordMaxTitle)}
+	if activity.Repository != "" {
+		embed.URL = "https://github.com/" + activity.Repository
+	}
+	if !activity.CreatedAt.IsZero() {
+		embed.Timestamp = activity.CreatedAt.UTC().Format(time.RFC3339)
+	}
+	if activity.Type != "" {
+		embed.Footer = &discordFooter{Text: activity.Type}
+	}
+	return embed
+}
+
+// write encodes the embeds in as many messages as Discord's embed limit
+// needs; the first message carries the title
+func (f *DiscordOutputFormatter) write(w io.Writer, embeds []discordEmbed) {
+	title := f.Title
+	if title == "" {
+		title = "GitHub activity"
+	}
+	encoder := json.NewEncoder(w)
+	if len(embeds) == 0 {
+		_ = encoder.Encode(discordMessage{Content: "**" + title + "**\nNo recent activity found."})
+		return
+	}
+
+	for start := 0; start < len(embeds); start += discordMaxEmbeds {
+		end := min(start+discordMaxEmbeds, len(embeds))
+		message := discordMessage{Embeds: embeds[start:end]}
+		if start == 0 {
+			message.Content = "**" + title + "**"
+		}
+		_ = encoder.Encode(message)
+	}
+}
+
+// truncate shortens text to width runes
+func truncate(text string, width int) string {
+	runes := []rune(text)
+	if width <= 0 || len(runes) <= width {
+		return text
+	}
+	return string(runes[:width])
+}
@@ -0,0 +1,459 @@
+package format
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/activity"
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+func TestNewOutputFormatter(t *testing.T) {
+	tests := []struct {
+		format      string
+		expectError bool
+	}{
+		{"console", false},
+		{"csv", false},
+		{"TSV", false},
+		{"ndjson", false},
+		{"yaml", false},
+		{"table", false},
+		{"gha", false},
+		{"xml", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			formatter, err := NewOutputFormatter(tt.format, FormatOptions{})
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if formatter == nil {
+				t.Error("Formatter should not be nil")
+			}
+		})
+	}
+}
+
+func TestDelimitedOutputFormatter_FormatDetailedActivities(t *testing.T) {
+	activities := []activity.DetailedActivity{
+		{
+			ActivitySummary: activity.ActivitySummary{
+				Description: "Pushed 2 commits to user/repo (branch: main)",
+				Type:        "PushEvent",
+				Repository:  "user/repo",
+				Timestamp:   "2024-01-15 10:30:00",
+			},
+			ActorLogin:  "testuser",
+			CommitCount: 2,
+		},
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := &DelimitedOutputFormatter{Delimiter: ','}
+		formatter.FormatDetailedActivities(&buf, activities)
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("Got %d lines, want 2", len(lines))
+		}
+		if lines[0] != "timestamp,type,repo,description,actor,commits" {
+			t.Errorf("Header = %q", lines[0])
+		}
+		expected := "2024-01-15 10:30:00,PushEvent,user/repo," +
+			"Pushed 2 commits to user/repo (branch: main),testuser,2"
+		if lines[1] != expected {
+			t.Errorf("Row = %q, want %q", lines[1], expected)
+		}
+	})
+
+	t.Run("tsv", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := &DelimitedOutputFormatter{Delimiter: '\t'}
+		formatter.FormatDetailedActivities(&buf, activities)
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		if fields := strings.Split(lines[1], "\t"); len(fields) != 6 {
+			t.Errorf("Got %d fields, want 6", len(fields))
+		}
+	})
+}
+
+func TestDelimitedOutputFormatter_FormatActivities(t *testing.T) {
+	activities := []activity.ActivitySummary{
+		{Description: `Opened issue #1 in user/repo: Quote "this", please`, Type: "IssuesEvent"},
+	}
+
+	var buf bytes.Buffer
+	formatter := &DelimitedOutputFormatter{Delimiter: ','}
+	formatter.FormatActivities(&buf, activities)
+
+	if !strings.Contains(buf.String(), `"Opened issue #1 in user/repo: Quote ""this"", please"`) {
+		t.Errorf("Description should be quoted, got %q", buf.String())
+	}
+}
+
+func TestTemplateOutputFormatter(t *testing.T) {
+	t.Run("summaries", func(t *testing.T) {
+		formatter, err := NewTemplateOutputFormatter("{{.Timestamp}} {{.Description}}")
+		if err != nil {
+			t.Fatalf("NewTemplateOutputFormatter() error = %v", err)
+		}
+
+		var buf bytes.Buffer
+		formatter.FormatActivities(&buf, []activity.ActivitySummary{
+			{Timestamp: "2024-01-15 10:30:00", Description: "Starred user/repo"},
+			{Timestamp: "2024-01-14 09:00:00", Description: "Forked user/repo"},
+		})
+
+		expected := "2024-01-15 10:30:00 Starred user/repo\n2024-01-14 09:00:00 Forked user/repo\n"
+		if buf.String() != expected {
+			t.Errorf("Output = %q, want %q", buf.String(), expected)
+		}
+	})
+
+	t.Run("relative time", func(t *testing.T) {
+		formatter, err := NewTemplateOutputFormatter("{{.RelativeTime}}")
+		if err != nil {
+			t.Fatalf("NewTemplateOutputFormatter() error = %v", err)
+		}
+
+		var buf bytes.Buffer
+		formatter.FormatActivities(&buf, []activity.ActivitySummary{
+			{CreatedAt: time.Now().Add(-2 * time.Hour)},
+		})
+
+		if buf.String() != "2 hours ago\n" {
+			t.Errorf("Output = %q, want %q", buf.String(), "2 hours ago\n")
+		}
+	})
+
+	t.Run("detailed fields", func(t *testing.T) {
+		formatter, err := NewTemplateOutputFormatter("{{.ActorLogin}}:{{.CommitCount}}")
+		if err != nil {
+			t.Fatalf("NewTemplateOutputFormatter() error = %v", err)
+		}
+
+		var buf bytes.Buffer
+		formatter.FormatDetailedActivities(&buf, []activity.DetailedActivity{
+			{ActorLogin: "testuser", CommitCount: 3},
+		})
+
+		if buf.String() != "testuser:3\n" {
+			t.Errorf("Output = %q, want %q", buf.String(), "testuser:3\n")
+		}
+	})
+
+	t.Run("empty template", func(t *testing.T) {
+		if _, err := NewTemplateOutputFormatter(""); err == nil {
+			t.Error("Expected error for empty template")
+		}
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		if _, err := NewTemplateOutputFormatter("{{.Description"); err == nil {
+			t.Error("Expected error for invalid template")
+		}
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		_, err := NewTemplateOutputFormatter("{{.Descripton}}")
+		if err == nil || !strings.Contains(err.Error(), "Descripton") {
+			t.Errorf("NewTemplateOutputFormatter() error = %v, want the unknown field", err)
+		}
+	})
+}
+
+func TestExplainOutputFormatter(t *testing.T) {
+	activities := []activity.ActivitySummary{
+		{
+			Description: "Pushed 1 commit to user/repo (branch: main)",
+			Type:        "PushEvent",
+			Timestamp:   "2024-01-15 10:30:00",
+			Fields:      map[string]string{"repo": "user/repo", "branch": "main", "size": "1"},
+		},
+	}
+
+	var buf bytes.Buffer
+	formatter := &ExplainOutputFormatter{}
+	formatter.FormatActivities(&buf, activities)
+
+	var decoded explanation
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Output is not valid JSON: %v", err)
+	}
+	if decoded.Description != activities[0].Description {
+		t.Errorf("Description = %v, want %v", decoded.Description, activities[0].Description)
+	}
+	if decoded.Fields["branch"] != "main" {
+		t.Errorf("Fields[branch] = %v, want main", decoded.Fields["branch"])
+	}
+}
+
+func TestNDJSONOutputFormatter(t *testing.T) {
+	created := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	summaries := []activity.ActivitySummary{
+		{Description: "Pushed 1 commit to user/repo", Type: "PushEvent", Repository: "user/repo", CreatedAt: created},
+		{Description: "Starred user/other", Type: "WatchEvent", Repository: "user/other", CreatedAt: created},
+	}
+
+	t.Run("summaries", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := &NDJSONOutputFormatter{}
+		formatter.FormatActivities(&buf, summaries[:1])
+		formatter.FormatActivities(&buf, summaries[1:])
+
+		lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+		if len(lines) != 2 {
+			t.Fatalf("Got %d lines, want one per activity:\n%s", len(lines), buf.String())
+		}
+		for i, line := range lines {
+			var decoded activity.ActivitySummary
+			if err := json.Unmarshal([]byte(line), &decoded); err != nil {
+				t.Fatalf("Line %d is not valid JSON: %v", i, err)
+			}
+			if decoded.Type != summaries[i].Type || !decoded.CreatedAt.Equal(created) {
+				t.Errorf("Line %d = %+v, want %+v", i, decoded, summaries[i])
+			}
+		}
+	})
+
+	t.Run("detailed", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := &NDJSONOutputFormatter{}
+		formatter.FormatDetailedActivities(&buf, []activity.DetailedActivity{{
+			ActivitySummary: summaries[0],
+			ActorLogin:      "octocat",
+			CommitCount:     1,
+			Commits:         []activity.CommitSummary{{SHA: "abc1234", Message: "Fix"}},
+		}})
+
+		var decoded map[string]any
+		if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+			t.Fatalf("Output is not valid JSON: %v", err)
+		}
+		if decoded["actor"] != "octocat" || decoded["commit_count"] != 1.0 || decoded["description"] != summaries[0].Description {
+			t.Errorf("Decoded = %v", decoded)
+		}
+	})
+}
+
+func TestSlackOutputFormatter(t *testing.T) {
+	decode := func(t *testing.T, buf *bytes.Buffer) slackMessage {
+		t.Helper()
+		if strings.Count(buf.String(), "\n") != 1 {
+			t.Fatalf("Expected one message line, got %q", buf.String())
+		}
+		var message slackMessage
+		if err := json.Unmarshal(buf.Bytes(), &message); err != nil {
+			t.Fatalf("Output is not valid JSON: %v", err)
+		}
+		return message
+	}
+
+	t.Run("summaries", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := &SlackOutputFormatter{Title: "GitHub activity for octocat"}
+		formatter.FormatActivities(&buf, []activity.ActivitySummary{
+			{Description: "Opened issue #1 in user/repo: <script> & co", Timestamp: "2024-01-15 10:30:00"},
+		})
+
+		message := decode(t, &buf)
+		if len(message.Blocks) != 2 || message.Blocks[0].Type != "header" {
+			t.Fatalf("Blocks = %+v, want a header and a section", message.Blocks)
+		}
+		if message.Blocks[0].Text.Text != "GitHub activity for octocat" {
+			t.Errorf("Header = %q", message.Blocks[0].Text.Text)
+		}
+		expected := "Opened issue #1 in user/repo: &lt;script&gt; &amp; co\n_2024-01-15 10:30:00_"
+		if message.Blocks[1].Text.Text != expected {
+			t.Errorf("Section = %q, want %q", message.Blocks[1].Text.Text, expected)
+		}
+	})
+
+	t.Run("detailed", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := &SlackOutputFormatter{}
+		formatter.FormatDetailedActivities(&buf, []activity.DetailedActivity{{
+			ActivitySummary: activity.ActivitySummary{Description: "Pushed 1 commit to user/repo"},
+			Commits:         []activity.CommitSummary{{SHA: "abc1234", Message: "Fix bug"}},
+			ExtraDetails:    map[string]string{"branches": "main"},
+		}})
+
+		section := decode(t, &buf).Blocks[1].Text.Text
+		for _, want := range []string{"• `abc1234` Fix bug", "branches: main"} {
+			if !strings.Contains(section, want) {
+				t.Errorf("Section missing %q: %q", want, section)
+			}
+		}
+	})
+
+	t.Run("block limit", func(t *testing.T) {
+		activities := make([]activity.ActivitySummary, 60)
+		var buf bytes.Buffer
+		formatter := &SlackOutputFormatter{}
+		formatter.FormatActivities(&buf, activities)
+
+		message := decode(t, &buf)
+		if len(message.Blocks) != slackMaxBlocks {
+			t.Fatalf("Got %d blocks, want %d", len(message.Blocks), slackMaxBlocks)
+		}
+		last := message.Blocks[len(message.Blocks)-1]
+		if last.Type != "context" || last.Elements[0].Text != "…12 events not shown" {
+			t.Errorf("Last block = %+v, want an overflow note", last)
+		}
+	})
+}
+
+func TestDiscordOutputFormatter(t *testing.T) {
+	decode := func(t *testing.T, buf *bytes.Buffer) []discordMessage {
+		t.Helper()
+		var messages []discordMessage
+		for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+			var message discordMessage
+			if err := json.Unmarshal([]byte(line), &message); err != nil {
+				t.Fatalf("Line is not valid JSON: %v", err)
+			}
+			messages = append(messages, message)
+		}
+		return messages
+	}
+
+	t.Run("summaries", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := &DiscordOutputFormatter{Title: "GitHub activity for octocat"}
+		formatter.FormatActivities(&buf, []activity.ActivitySummary{{
+			Description: "Opened issue #1 in user/repo",
+			Type:        "IssuesEvent",
+			Repository:  "user/repo",
+			CreatedAt:   time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC),
+		}})
+
+		messages := decode(t, &buf)
+		if len(messages) != 1 || messages[0].Content != "**GitHub activity for octocat**" {
+			t.Fatalf("Messages = %+v, want one titled message", messages)
+		}
+		embed := messages[0].Embeds[0]
+		if embed.Title != "Opened issue #1 in user/repo" ||
+			embed.URL != "https://github.com/user/repo" ||
+			embed.Timestamp != "2024-01-15T10:30:00Z" ||
+			embed.Footer == nil || embed.Footer.Text != "IssuesEvent" {
+			t.Errorf("Embed = %+v", embed)
+		}
+	})
+
+	t.Run("detailed", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := &DiscordOutputFormatter{}
+		formatter.FormatDetailedActivities(&buf, []activity.DetailedActivity{{
+			ActivitySummary: activity.ActivitySummary{Description: "Pushed 1 commit to user/repo"},
+			Commits:         []activity.CommitSummary{{SHA: "abc1234", Message: "Fix bug"}},
+			ExtraDetails:    map[string]string{"branches": "main"},
+		}})
+
+		description := decode(t, &buf)[0].Embeds[0].Description
+		if description != "• `abc1234` Fix bug\nbranches: main" {
+			t.Errorf("Description = %q", description)
+		}
+	})
+
+	t.Run("embed limit", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := &DiscordOutputFormatter{}
+		formatter.FormatActivities(&buf, make([]activity.ActivitySummary, 23))
+
+		messages := decode(t, &buf)
+		if len(messages) != 3 {
+			t.Fatalf("Got %d messages, want 3", len(messages))
+		}
+		for i, want := range []int{10, 10, 3} {
+			if len(messages[i].Embeds) != want {
+				t.Errorf("Message %d has %d embeds, want %d", i, len(messages[i].Embeds), want)
+			}
+		}
+		if messages[1].Content != "" {
+			t.Errorf("Only the first message should carry the title, got %q", messages[1].Content)
+		}
+	})
+
+	t.Run("no activity", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := &DiscordOutputFormatter{}
+		formatter.FormatActivities(&buf, nil)
+
+		messages := decode(t, &buf)
+		if len(messages) != 1 || len(messages[0].Embeds) != 0 ||
+			!strings.Contains(messages[0].Content, "No recent activity found.") {
+			t.Errorf("Messages = %+v", messages)
+		}
+	})
+}
+
+// BenchmarkPipeline fetches three pages from a local API, summarizes them
+// and formats the result, as a run of the CLI does
+func BenchmarkPipeline(b *testing.B) {
+	// Event IDs descend across the pages, as in a real feed
+	page := func(number int) string {
+		var page strings.Builder
+		page.WriteString("[")
+		for i := range 100 {
+			if i > 0 {
+				page.WriteString(",")
+			}
+			id := 1000 - (number-1)*100 - i
+			fmt.Fprintf(&page, `{"id": "%d", "type": "IssuesEvent", "actor": {"login": "octocat"},
+				"repo": {"name": "octo/repo"}, "created_at": "2024-01-15T10:30:00Z",
+				"payload": {"action": "opened", "issue": {"number": %d, "title": "Crash on start"}}}`, id, id)
+		}
+		page.WriteString("]")
+		return page.String()
+	}
+	pages := []string{page(1), page(2), page(3)}
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		number, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		number = max(number, 1)
+		if number < len(pages) {
+			w.Header().Set("Link", fmt.Sprintf(`<%s%s?page=%d>; rel="next"`, server.URL, r.URL.Path, number+1))
+		}
+		_, _ = io.WriteString(w, pages[number-1])
+	}))
+	defer server.Close()
+
+	for _, name := range []string{"console", "csv", "ndjson"} {
+		b.Run(name, func(b *testing.B) {
+			formatter, err := NewOutputFormatter(name, FormatOptions{})
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.ReportAllocs()
+			for b.Loop() {
+				// A fresh repository per run, so its cache is not measured
+				repo := github.NewGitHubAPIRepository(github.WithBaseURL(server.URL))
+				repo.SetMaxPages(3)
+				activities, err := activity.NewActivityService(repo).GetUserActivity("octocat", activity.EventFilter{})
+				if err != nil || len(activities) != 300 {
+					b.Fatalf("GetUserActivity() = %d activities, %v; want 300", len(activities), err)
+				}
+				formatter.FormatActivities(io.Discard, activities)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package format
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/alnah/github-activity/internal/messages"
+	"github.com/alnah/github-activity/pkg/activity"
+)
+
+// GitHub Actions Output - Job summaries and workflow notices
+
+// StepSummaryEnvVar names the file GitHub Actions renders as the job summary
+const StepSummaryEnvVar = "GITHUB_STEP_SUMMARY"
+
+// GitHubActionsOutputFormatter appends a Markdown table of the activities to
+// the job summary and writes a workflow notice with their count, so a
+// workflow run shows the digest without extra scripting
+type GitHubActionsOutputFormatter struct {
+	Title       string
+	SummaryPath string // Job summary file; "" writes the table to the output instead, as outside Actions
+}
+
+// FormatActivities summarizes the activity summaries
+func (f *GitHubActionsOutputFormatter) FormatActivities(w io.Writer, activities []activity.ActivitySummary) {
+	f.write(w, activities)
+}
+
+// FormatDetailedActivities summarizes the detailed activities; the table has
+// no room for their details
+func (f *GitHubActionsOutputFormatter) FormatDetailedActivities(
+	w io.Writer,
+	activities []activity.DetailedActivity,
+) {
+	summaries := make([]activity.ActivitySummary, 0, len(activities))
+	for _, activity := range activities {
+		summaries = append(summaries, activity.ActivitySummary)
+	}
+	f.write(w, summaries)
+}
+
+// write appends the table to the job summary and writes the notice to w
+func (f *GitHubActionsOutputFormatter) write(w io.Writer, activities []activity.ActivitySummary) {
+	title := f.Title
+	if title == "" {
+		title = "GitHub activity"
+	}
+
+	var summary strings.Builder
+	_, _ = fmt.Fprintf(&summary, "## %s\n\n", title)
+	if len(activities) == 0 {
+		summary.WriteString("No recent activity found.\n")
+	} else {
+		summary.WriteString("| Time | Type | Repository | Description |\n| --- | --- | --- | --- |\n")
+		for _, activity := range activities {
+			repository := tableCell(activity.Repository)
+			if activity.Repository != "" {
+				repository = fmt.Sprintf("[%s](https://github.com/%s)", repository, activity.Repository)
+			}
+			_, _ = fmt.Fprintf(&summary, "| %s | %s | %s | %s |\n",
+				tableCell(activity.Timestamp), tableCell(activity.Type), repository, tableCell(activity.Description))
+		}
+	}
+	summary.WriteString("\n")
+
+	if f.SummaryPath == "" {
+		_, _ = io.WriteString(w, summary.String())
+	} else if err := appendFile(f.SummaryPath, summary.String()); err != nil {
+		_, _ = fmt.Fprintf(w, "::warning::%s\n", workflowEscape(fmt.Sprintf("could not write the job summary: %v", err)))
+	}
+	_, _ = fmt.Fprintf(w, "::notice title=%s::%s\n",
+		workflowPropertyEscape(title), workflowEscape(messages.Plural("events", len(activities))))
+}
+
+// appendFile adds text to the end of the file at path, creating it if needed
+func appendFile(path, text string) error {
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	_, err = file.WriteString(text)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// tableCell keeps text within one Markdown table cell
+func tableCell(text string) string {
+	return strings.NewReplacer("|", `\|`, "\r", "", "\n", " ").Replace(text)
+}
+
+// workflowEscape encodes the message of a workflow command
+func workflowEscape(text string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(text)
+}
+
+// workflowPropertyEscape encodes a property value of a workflow command
+func workflowPropertyEscape(text string) string {
+	return strings.NewReplacer(":", "%3A", ",", "%2C").Replace(workflowEscape(text))
+}
@@ -0,0 +1,76 @@
+package format
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/alnah/github-activity/pkg/activity"
+)
+
+func TestGitHubActionsOutputFormatter(t *testing.T) {
+	activities := []activity.ActivitySummary{
+		{
+			Timestamp:   "2024-01-15 10:30:00",
+			Type:        "IssuesEvent",
+			Repository:  "user/repo",
+			Description: "Opened issue #1 in user/repo: a | b",
+		},
+	}
+	table := "## Nightly: alice, bob\n\n" +
+		"| Time | Type | Repository | Description |\n| --- | --- | --- | --- |\n" +
+		"| 2024-01-15 10:30:00 | IssuesEvent | [user/repo](https://github.com/user/repo) | Opened issue #1 in user/repo: a \\| b |\n\n"
+	notice := "::notice title=Nightly%3A alice%2C bob::1 event\n"
+
+	t.Run("job summary", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "summary.md")
+		if err := os.WriteFile(path, []byte("Earlier step\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+
+		var buf bytes.Buffer
+		formatter := &GitHubActionsOutputFormatter{Title: "Nightly: alice, bob", SummaryPath: path}
+		formatter.FormatActivities(&buf, activities)
+
+		if got := buf.String(); got != notice {
+			t.Errorf("Output = %q, want %q", got, notice)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := string(data); got != "Earlier step\n"+table {
+			t.Errorf("Summary = %q, want it appended after the earlier step", got)
+		}
+	})
+
+	t.Run("outside actions", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := &GitHubActionsOutputFormatter{Title: "Nightly: alice, bob"}
+		formatter.FormatDetailedActivities(&buf, []activity.DetailedActivity{{ActivitySummary: activities[0]}})
+
+		if got := buf.String(); got != table+notice {
+			t.Errorf("Output = %q, want %q", got, table+notice)
+		}
+	})
+
+	t.Run("unwritable summary", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := &GitHubActionsOutputFormatter{SummaryPath: filepath.Join(t.TempDir(), "missing", "summary.md")}
+		formatter.FormatActivities(&buf, nil)
+
+		if got := buf.String(); !bytes.HasPrefix([]byte(got), []byte("::warning::could not write the job summary: ")) {
+			t.Errorf("Output = %q, want a workflow warning", got)
+		}
+	})
+}
+
+func TestWorkflowEscape(t *testing.T) {
+	if got := workflowEscape("100%\nsure"); got != "100%25%0Asure" {
+		t.Errorf("workflowEscape() = %q", got)
+	}
+	if got := workflowPropertyEscape("a:b,c"); got != "a%3Ab%2Cc" {
+		t.Errorf("workflowPropertyEscape() = %q", got)
+	}
+}
@@ -0,0 +1,86 @@
+package format
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/alnah/github-activity/pkg/activity"
+)
+
+// Table Output - Activities as column-aligned rows for dense scanning
+
+// tableHeader names the columns of table output
+var tableHeader = [...]string{"TIME", "TYPE", "REPO", "DESCRIPTION"}
+
+// tableMinDescription is the narrowest the description column is truncated to,
+// however narrow the terminal
+const tableMinDescription = 20
+
+// TableOutputFormatter writes one row per event with the time, type,
+// repository and description in aligned columns
+type TableOutputFormatter struct {
+	Width int // Truncate descriptions so rows fit this many columns; 0 never truncates
+}
+
+// FormatActivities writes a row per activity summary
+func (f *TableOutputFormatter) FormatActivities(w io.Writer, activities []activity.ActivitySummary) {
+	f.write(w, activities)
+}
+
+// FormatDetailedActivities writes the same rows as FormatActivities; the
+// details do not fit in a column
+func (f *TableOutputFormatter) FormatDetailedActivities(
+	w io.Writer,
+	activities []activity.DetailedActivity,
+) {
+	summaries := make([]activity.ActivitySummary, 0, len(activities))
+	for _, activity := range activities {
+		summaries = append(summaries, activity.ActivitySummary)
+	}
+	f.write(w, summaries)
+}
+
+// write sizes the columns to their widest cell and writes the header and rows
+func (f *TableOutputFormatter) write(w io.Writer, activities []activity.ActivitySummary) {
+	rows := make([][len(tableHeader)]string, 0, len(activities)+1)
+	rows = append(rows, tableHeader)
+	for _, activity := range activities {
+		rows = append(rows, [len(tableHeader)]string{
+			activity.Timestamp,
+			activity.Type,
+			activity.Repository,
+			activity.Description,
+		})
+	}
+
+	var widths [len(tableHeader)]int
+	for _, row := range rows {
+		for i, cell := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+		}
+	}
+	description := 0
+	if f.Width > 0 {
+		description = max(f.Width-widths[0]-widths[1]-widths[2]-2*(len(tableHeader)-1), tableMinDescription)
+	}
+
+	for _, row := range rows {
+		line := make([]string, 0, len(row))
+		for i, cell := range row[:len(row)-1] {
+			line = append(line, cell+strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)))
+		}
+		line = append(line, ellipsize(row[len(row)-1], description))
+		_, _ = fmt.Fprintln(w, strings.TrimRight(strings.Join(line, "  "), " "))
+	}
+}
+
+// ellipsize shortens text to width runes, ending with "…" when cut; a width
+// of 0 leaves text whole
+func ellipsize(text string, width int) string {
+	if width <= 0 || utf8.RuneCountInString(text) <= width {
+		return text
+	}
+	return string([]rune(text)[:width-1]) + "…"
+}
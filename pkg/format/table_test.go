@@ -0,0 +1,73 @@
+package format
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/alnah/github-activity/pkg/activity"
+)
+
+func TestTableOutputFormatter(t *testing.T) {
+	activities := []activity.ActivitySummary{
+		{
+			Timestamp:   "2024-01-15 10:30:00",
+			Type:        "PushEvent",
+			Repository:  "user/repo",
+			Description: "Pushed 2 commits to user/repo (branch: main)",
+		},
+		{
+			Timestamp:   "2024-01-14 09:00:00",
+			Type:        "WatchEvent",
+			Repository:  "octo/café",
+			Description: "Starred octo/café",
+		},
+	}
+
+	t.Run("aligned", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := &TableOutputFormatter{}
+		formatter.FormatActivities(&buf, activities)
+
+		want := "TIME                 TYPE        REPO       DESCRIPTION\n" +
+			"2024-01-15 10:30:00  PushEvent   user/repo  Pushed 2 commits to user/repo (branch: main)\n" +
+			"2024-01-14 09:00:00  WatchEvent  octo/café  Starred octo/café\n"
+		if got := buf.String(); got != want {
+			t.Errorf("FormatActivities() =\n%s\nwant\n%s", got, want)
+		}
+	})
+
+	t.Run("truncated to width", func(t *testing.T) {
+		var buf bytes.Buffer
+		formatter := &TableOutputFormatter{Width: 64}
+		formatter.FormatDetailedActivities(&buf, []activity.DetailedActivity{
+			{ActivitySummary: activities[0]},
+			{ActivitySummary: activities[1]},
+		})
+
+		want := "TIME                 TYPE        REPO       DESCRIPTION\n" +
+			"2024-01-15 10:30:00  PushEvent   user/repo  Pushed 2 commits to…\n" +
+			"2024-01-14 09:00:00  WatchEvent  octo/café  Starred octo/café\n"
+		if got := buf.String(); got != want {
+			t.Errorf("FormatDetailedActivities() =\n%s\nwant\n%s", got, want)
+		}
+	})
+}
+
+func TestEllipsize(t *testing.T) {
+	tests := []struct {
+		text  string
+		width int
+		want  string
+	}{
+		{"short", 10, "short"},
+		{"exactly10!", 10, "exactly10!"},
+		{"much too long", 8, "much to…"},
+		{"crème brûlée", 6, "crème…"},
+		{"unbounded", 0, "unbounded"},
+	}
+	for _, tt := range tests {
+		if got := ellipsize(tt.text, tt.width); got != tt.want {
+			t.Errorf("ellipsize(%q, %d) = %q, want %q", tt.text, tt.width, got, tt.want)
+		}
+	}
+}
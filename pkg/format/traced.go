@@ -0,0 +1,61 @@
+package format
+
+import (
+	"context"
+	"io"
+
+	"github.com/alnah/github-activity/pkg/activity"
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// Traced Output - Formatting time reported to a tracer
+
+// TracedOutputFormatter runs another formatter inside a github.SpanFormat
+// span per call, so slow templates or large outputs show up in traces
+type TracedOutputFormatter struct {
+	Formatter OutputFormatter
+	Name      string // Recorded as the span's format attribute
+	Tracer    github.Tracer
+	Context   context.Context // Spans start from it, or a new trace when nil
+}
+
+// WithTracing returns formatter with every call traced by tracer
+func WithTracing(formatter OutputFormatter, name string, tracer github.Tracer) *TracedOutputFormatter {
+	return &TracedOutputFormatter{Formatter: formatter, Name: name, Tracer: tracer}
+}
+
+// FormatActivities formats the activity summaries inside a span
+func (f *TracedOutputFormatter) FormatActivities(w io.Writer, activities []activity.ActivitySummary) {
+	span := f.start(len(activities))
+	defer span.End()
+	f.Formatter.FormatActivities(w, activities)
+}
+
+// FormatDetailedActivities formats the detailed activities inside a span
+func (f *TracedOutputFormatter) FormatDetailedActivities(
+	w io.Writer,
+	activities []activity.DetailedActivity,
+) {
+	span := f.start(len(activities))
+	defer span.End()
+	f.Formatter.FormatDetailedActivities(w, activities)
+}
+
+// WithContext returns a copy of f whose spans start from ctx, such as the
+// context of the request being answered
+func (f *TracedOutputFormatter) WithContext(ctx context.Context) *TracedOutputFormatter {
+	traced := *f
+	traced.Context = ctx
+	return &traced
+}
+
+// start begins the span for formatting count activities
+func (f *TracedOutputFormatter) start(count int) github.Span {
+	ctx := f.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	_, span := f.Tracer.Start(ctx, github.SpanFormat)
+	span.SetAttributes("format", f.Name, "activities", count)
+	return span
+}
@@ -0,0 +1,42 @@
+package format
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/alnah/github-activity/pkg/activity"
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+func TestTracedOutputFormatter(t *testing.T) {
+	activities := []activity.ActivitySummary{
+		{Timestamp: "2024-01-15 10:30:00", Type: "WatchEvent", Repository: "user/repo", Description: "Starred user/repo"},
+	}
+	tracer := github.NewRecordingTracer()
+	formatter := WithTracing(&TableOutputFormatter{}, "table", tracer)
+
+	var traced, plain bytes.Buffer
+	formatter.FormatActivities(&traced, activities)
+	(&TableOutputFormatter{}).FormatActivities(&plain, activities)
+
+	if traced.String() != plain.String() {
+		t.Errorf("Output = %q, want the wrapped formatter's %q", traced.String(), plain.String())
+	}
+	spans := tracer.Spans()
+	if len(spans) != 1 || spans[0].Name != github.SpanFormat {
+		t.Fatalf("Recorded %+v, want one %s span", spans, github.SpanFormat)
+	}
+	if spans[0].Attributes["format"] != "table" || spans[0].Attributes["activities"] != 1 {
+		t.Errorf("Attributes = %v, want format table and 1 activity", spans[0].Attributes)
+	}
+
+	// Spans nest under the caller's once given its context
+	ctx, request := tracer.Start(context.Background(), "request")
+	formatter.WithContext(ctx).FormatActivities(&traced, activities)
+	request.End()
+	spans = tracer.Spans()
+	if len(spans) != 3 || spans[1].Name != github.SpanFormat || spans[1].Parent != "request" {
+		t.Errorf("Recorded %+v, want a %s span under request", spans, github.SpanFormat)
+	}
+}
@@ -0,0 +1,129 @@
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/activity"
+)
+
+// YAML Output - Activities as a YAML sequence, for configuration tooling
+
+// YAMLOutputFormatter writes activities as a YAML sequence of mappings whose
+// keys match the JSON field names. Strings are always double-quoted, so
+// values such as "yes", "null" or timestamps are never read back as other
+// types.
+type YAMLOutputFormatter struct{}
+
+// FormatActivities writes a mapping per activity summary
+func (f *YAMLOutputFormatter) FormatActivities(w io.Writer, activities []activity.ActivitySummary) {
+	if len(activities) == 0 {
+		_, _ = fmt.Fprintln(w, "[]")
+		return
+	}
+	for _, activity := range activities {
+		item := &yamlItem{w: w}
+		item.summary(activity, activity.Actor)
+	}
+}
+
+// FormatDetailedActivities also writes each activity's event ID, commits
+// and extra details
+func (f *YAMLOutputFormatter) FormatDetailedActivities(
+	w io.Writer,
+	activities []activity.DetailedActivity,
+) {
+	if len(activities) == 0 {
+		_, _ = fmt.Fprintln(w, "[]")
+		return
+	}
+	for _, activity := range activities {
+		item := &yamlItem{w: w}
+		item.summary(activity.ActivitySummary, activity.ActorLogin)
+		item.scalar("event_id", yamlQuote(activity.EventID))
+		if activity.CommitCount > 0 {
+			item.scalar("commit_count", strconv.Itoa(activity.CommitCount))
+		}
+		if len(activity.Commits) > 0 {
+			item.key("commits")
+			for _, commit := range activity.Commits {
+				_, _ = fmt.Fprintf(w, "    - sha: %s\n", yamlQuote(commit.SHA))
+				_, _ = fmt.Fprintf(w, "      message: %s\n", yamlQuote(commit.Message))
+				if commit.Author != "" {
+					_, _ = fmt.Fprintf(w, "      author: %s\n", yamlQuote(commit.Author))
+				}
+			}
+		}
+		item.mapping("extra_details", activity.ExtraDetails)
+	}
+}
+
+// yamlItem writes the keys of one sequence item; the first key carries the dash
+type yamlItem struct {
+	w       io.Writer
+	started bool
+}
+
+// summary writes the fields shared by both activity views
+func (y *yamlItem) summary(activity activity.ActivitySummary, actor string) {
+	y.scalar("description", yamlQuote(activity.Description))
+	y.scalar("type", yamlQuote(activity.Type))
+	y.scalar("repository", yamlQuote(activity.Repository))
+	y.scalar("actor", yamlQuote(actor))
+	y.scalar("timestamp", yamlQuote(activity.Timestamp))
+	if !activity.CreatedAt.IsZero() {
+		y.scalar("created_at", yamlQuote(activity.CreatedAt.Format(time.RFC3339)))
+	}
+	y.mapping("fields", activity.Fields)
+}
+
+// key starts a key whose value is nested on the following lines
+func (y *yamlItem) key(key string) {
+	_, _ = fmt.Fprintf(y.w, "%s%s:\n", y.indent(), key)
+}
+
+// scalar writes a key and its already encoded value
+func (y *yamlItem) scalar(key, value string) {
+	_, _ = fmt.Fprintf(y.w, "%s%s: %s\n", y.indent(), key, value)
+}
+
+// mapping writes values sorted by key, or nothing when there are none
+func (y *yamlItem) mapping(key string, values map[string]string) {
+	if len(values) == 0 {
+		return
+	}
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	y.key(key)
+	for _, key := range keys {
+		_, _ = fmt.Fprintf(y.w, "    %s: %s\n", yamlQuote(key), yamlQuote(values[key]))
+	}
+}
+
+// indent returns the prefix of the next key
+func (y *yamlItem) indent() string {
+	if !y.started {
+		y.started = true
+		return "- "
+	}
+	return "  "
+}
+
+// yamlQuote encodes text as a double-quoted scalar; JSON's string escapes
+// are all valid in YAML
+func yamlQuote(text string) string {
+	var buf strings.Builder
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(text)
+	return strings.TrimSuffix(buf.String(), "\n")
+}
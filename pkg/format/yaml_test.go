@@ -0,0 +1,90 @@
+package format
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/activity"
+)
+
+func TestYAMLOutputFormatter_FormatActivities(t *testing.T) {
+	activities := []activity.ActivitySummary{
+		{
+			Description: `Opened issue #1 in user/repo: "yes" <please>`,
+			Type:        "IssuesEvent",
+			Repository:  "user/repo",
+			Timestamp:   "2024-01-15 10:30",
+			CreatedAt:   time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC),
+			Fields:      map[string]string{"number": "1", "action": "opened"},
+		},
+		{Description: "Starred user/other", Type: "WatchEvent"},
+	}
+
+	var buf bytes.Buffer
+	formatter := &YAMLOutputFormatter{}
+	formatter.FormatActivities(&buf, activities)
+
+	want := `- description: "Opened issue #1 in user/repo: \"yes\" <please>"
+  type: "IssuesEvent"
+  repository: "user/repo"
+  actor: ""
+  timestamp: "2024-01-15 10:30"
+  created_at: "2024-01-15T10:30:00Z"
+  fields:
+    "action": "opened"
+    "number": "1"
+- description: "Starred user/other"
+  type: "WatchEvent"
+  repository: ""
+  actor: ""
+  timestamp: ""
+`
+	if got := buf.String(); got != want {
+		t.Errorf("FormatActivities() =\n%s\nwant\n%s", got, want)
+	}
+
+	buf.Reset()
+	formatter.FormatActivities(&buf, nil)
+	if got := buf.String(); got != "[]\n" {
+		t.Errorf("FormatActivities(nil) = %q, want an empty sequence", got)
+	}
+}
+
+func TestYAMLOutputFormatter_FormatDetailedActivities(t *testing.T) {
+	activities := []activity.DetailedActivity{{
+		ActivitySummary: activity.ActivitySummary{
+			Description: "Pushed 2 commits to user/repo",
+			Type:        "PushEvent",
+			Repository:  "user/repo",
+			Timestamp:   "2024-01-15 10:30",
+		},
+		EventID:      "42",
+		ActorLogin:   "octocat",
+		CommitCount:  2,
+		Commits:      []activity.CommitSummary{{SHA: "abc1234", Message: "Fix: the\nbug", Author: "Mona"}},
+		ExtraDetails: map[string]string{"branch": "main"},
+	}}
+
+	var buf bytes.Buffer
+	formatter := &YAMLOutputFormatter{}
+	formatter.FormatDetailedActivities(&buf, activities)
+
+	want := `- description: "Pushed 2 commits to user/repo"
+  type: "PushEvent"
+  repository: "user/repo"
+  actor: "octocat"
+  timestamp: "2024-01-15 10:30"
+  event_id: "42"
+  commit_count: 2
+  commits:
+    - sha: "abc1234"
+      message: "Fix: the\nbug"
+      author: "Mona"
+  extra_details:
+    "branch": "main"
+`
+	if got := buf.String(); got != want {
+		t.Errorf("FormatDetailedActivities() =\n%s\nwant\n%s", got, want)
+	}
+}
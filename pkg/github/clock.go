@@ -0,0 +1,88 @@
+package github
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Clock - The time as seen by caches and retries
+
+// Clock tells the time and waits, so code that expires or retries can be
+// tested without sleeping
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+}
+
+// systemClock is the wall clock
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) Sleep(d time.Duration)                  { time.Sleep(d) }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// SystemClock returns the default Clock, the wall clock
+func SystemClock() Clock {
+	return systemClock{}
+}
+
+// FakeClock is a Clock that only moves when told to, for tests. Sleep
+// returns at once, advancing the clock by the duration.
+type FakeClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewFakeClock returns a clock stopped at now
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{now: now}
+}
+
+// Now returns the clock's time
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Sleep advances the clock by d
+func (c *FakeClock) Sleep(d time.Duration) {
+	c.Advance(d)
+}
+
+// After advances the clock by d and returns a channel that is already ready
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	c.Advance(d)
+	ready := make(chan time.Time, 1)
+	ready <- c.Now()
+	return ready
+}
+
+// Advance moves the clock forward by d
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// sleepContext waits d on clock, returning ctx's error if ctx is done first.
+// Clocks without an After method cannot be interrupted and sleep the whole d.
+func sleepContext(ctx context.Context, clock Clock, d time.Duration) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	timer, ok := clock.(interface {
+		After(d time.Duration) <-chan time.Time
+	})
+	if !ok {
+		clock.Sleep(d)
+		return ctx.Err()
+	}
+	select {
+	case <-timer.After(d):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
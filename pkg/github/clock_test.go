@@ -0,0 +1,51 @@
+package github
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	clock := NewFakeClock(start)
+
+	if !clock.Now().Equal(start) {
+		t.Errorf("Now() = %v, want %v", clock.Now(), start)
+	}
+	clock.Sleep(time.Minute)
+	clock.Advance(time.Hour)
+	if want := start.Add(time.Hour + time.Minute); !clock.Now().Equal(want) {
+		t.Errorf("Now() = %v, want %v", clock.Now(), want)
+	}
+	if got, want := <-clock.After(time.Second), start.Add(time.Hour+time.Minute+time.Second); !got.Equal(want) {
+		t.Errorf("After() sent %v, want %v", got, want)
+	}
+}
+
+func TestSystemClock(t *testing.T) {
+	before := time.Now()
+	if now := SystemClock().Now(); now.Before(before) {
+		t.Errorf("Now() = %v, want no earlier than %v", now, before)
+	}
+}
+
+func TestGitHubAPIRepository_SetClock(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC))
+	repo := NewGitHubAPIRepository(WithRepositoryClock(clock))
+	repo.SetCacheTTL(5 * time.Minute)
+
+	repo.cache.Update("octocat", []GitHubEvent{{ID: "1"}})
+	clock.Advance(4 * time.Minute)
+	if !repo.cache.IsValid("octocat") {
+		t.Error("Cache should be fresh before its TTL on the repository's clock")
+	}
+	clock.Advance(time.Minute)
+	if repo.cache.IsValid("octocat") {
+		t.Error("Cache should expire after its TTL on the repository's clock")
+	}
+
+	repo.SetClock(nil)
+	if _, ok := repo.clock.(systemClock); !ok {
+		t.Errorf("SetClock(nil) = %T, want the system clock", repo.clock)
+	}
+}
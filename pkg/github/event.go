@@ -0,0 +1,662 @@
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+// Domain Models - GitHub events and their payloads
+
+// EventType represents the type of GitHub event
+type EventType string
+
+const (
+	EventTypePush         EventType = "PushEvent"
+	EventTypeCreate       EventType = "CreateEvent"
+	EventTypeDelete       EventType = "DeleteEvent"
+	EventTypeIssues       EventType = "IssuesEvent"
+	EventTypePullRequest  EventType = "PullRequestEvent"
+	EventTypeWatch        EventType = "WatchEvent"
+	EventTypeFork         EventType = "ForkEvent"
+	EventTypeIssueComment EventType = "IssueCommentEvent"
+	EventTypePublic       EventType = "PublicEvent"
+	EventTypeMember       EventType = "MemberEvent"
+	EventTypeRelease      EventType = "ReleaseEvent"
+
+	EventTypePullRequestReview        EventType = "PullRequestReviewEvent"
+	EventTypePullRequestReviewComment EventType = "PullRequestReviewCommentEvent"
+	EventTypePullRequestReviewThread  EventType = "PullRequestReviewThreadEvent"
+	EventTypeGollum                   EventType = "GollumEvent"
+	EventTypeCommitComment            EventType = "CommitCommentEvent"
+	EventTypeSponsorship              EventType = "SponsorshipEvent"
+	EventTypeDeployment               EventType = "DeploymentEvent"
+	EventTypeDeploymentStatus         EventType = "DeploymentStatusEvent"
+	EventTypeStatus                   EventType = "StatusEvent"
+	EventTypeCheckRun                 EventType = "CheckRunEvent"
+)
+
+// GitHubEvent represents a GitHub event from the API
+type GitHubEvent struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	Actor     Actor           `json:"actor"`
+	Repo      Repo            `json:"repo"`
+	Payload   json.RawMessage `json:"payload"`
+	Public    bool            `json:"public"`
+	CreatedAt time.Time       `json:"created_at"`
+
+	parsed *payloadCache // Shared by copies of a decoded event
+}
+
+// payloadCache holds an event's decoded payload so it is parsed once
+type payloadCache struct {
+	once    sync.Once
+	payload any
+	err     error
+}
+
+// UnmarshalJSON decodes an event and gives it a payload cache, which copies
+// of the event then share
+func (e *GitHubEvent) UnmarshalJSON(data []byte) error {
+	type plain GitHubEvent
+	if err := json.Unmarshal(data, (*plain)(e)); err != nil {
+		return err
+	}
+	e.parsed = &payloadCache{}
+	return nil
+}
+
+// Actor represents the user who performed the action
+type Actor struct {
+	ID           int    `json:"id"`
+	Login        string `json:"login"`
+	DisplayLogin string `json:"display_login"`
+	GravatarID   string `json:"gravatar_id"`
+	URL          string `json:"url"`
+	AvatarURL    string `json:"avatar_url"`
+}
+
+// Repo represents the repository
+type Repo struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// Various payload types for different events
+type PushPayload struct {
+	Size    int      `json:"size"`
+	Commits []Commit `json:"commits"`
+	Ref     string   `json:"ref"`
+	Head    string   `json:"head"`
+}
+
+type CreatePayload struct {
+	Ref         string `json:"ref"`
+	RefType     string `json:"ref_type"`
+	Description string `json:"description"`
+}
+
+// Label is an issue or pull request label
+type Label struct {
+	Name string `json:"name"`
+}
+
+// Milestone is the milestone an issue or pull request is planned for
+type Milestone struct {
+	Title string `json:"title"`
+}
+
+type IssuesPayload struct {
+	Action string `json:"action"`
+	Issue  struct {
+		Number    int        `json:"number"`
+		Title     string     `json:"title"`
+		State     string     `json:"state"`
+		Labels    []Label    `json:"labels"`
+		Assignees []Actor    `json:"assignees"`
+		Milestone *Milestone `json:"milestone"`
+	} `json:"issue"`
+}
+
+type PullRequestPayload struct {
+	Action      string `json:"action"`
+	PullRequest struct {
+		Number    int        `json:"number"`
+		Title     string     `json:"title"`
+		State     string     `json:"state"`
+		Merged    bool       `json:"merged"`
+		Additions *int       `json:"additions"`
+		Deletions *int       `json:"deletions"`
+		Labels    []Label    `json:"labels"`
+		Assignees []Actor    `json:"assignees"`
+		Milestone *Milestone `json:"milestone"`
+		Head      struct {
+			Ref string `json:"ref"`
+		} `json:"head"`
+		Base struct {
+			Ref string `json:"ref"`
+		} `json:"base"`
+	} `json:"pull_request"`
+	RequestedReviewer *Actor `json:"requested_reviewer"`
+}
+
+type PullRequestReviewPayload struct {
+	Action string `json:"action"`
+	Review struct {
+		State string `json:"state"`
+	} `json:"review"`
+	PullRequest struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+	} `json:"pull_request"`
+}
+
+type PullRequestReviewCommentPayload struct {
+	Action  string `json:"action"`
+	Comment struct {
+		Body string `json:"body"`
+	} `json:"comment"`
+	PullRequest struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+	} `json:"pull_request"`
+}
+
+type PullRequestReviewThreadPayload struct {
+	Action      string `json:"action"`
+	PullRequest struct {
+		Number int    `json:"number"`
+		Title  string `json:"title"`
+	} `json:"pull_request"`
+}
+
+type ForkPayload struct {
+	Forkee struct {
+		FullName string `json:"full_name"`
+	} `json:"forkee"`
+}
+
+type ReleasePayload struct {
+	Action  string `json:"action"`
+	Release struct {
+		TagName    string `json:"tag_name"`
+		Name       string `json:"name"`
+		Prerelease bool   `json:"prerelease"`
+		Draft      bool   `json:"draft"`
+		Body       string `json:"body"`
+	} `json:"release"`
+}
+
+type GollumPayload struct {
+	Pages []GollumPage `json:"pages"`
+}
+
+// GollumPage is a wiki page created or edited by a GollumEvent
+type GollumPage struct {
+	PageName string `json:"page_name"`
+	Title    string `json:"title"`
+	Action   string `json:"action"`
+}
+
+// Name returns the page title, or its page name when it has no title
+func (p GollumPage) Name() string {
+	if p.Title != "" {
+		return p.Title
+	}
+	return p.PageName
+}
+
+type CommitCommentPayload struct {
+	Comment struct {
+		CommitID string `json:"commit_id"`
+		Body     string `json:"body"`
+	} `json:"comment"`
+}
+
+type SponsorshipPayload struct {
+	Action      string `json:"action"`
+	Sponsorship struct {
+		Sponsorable struct {
+			Login string `json:"login"`
+		} `json:"sponsorable"`
+		Tier struct {
+			Name string `json:"name"`
+		} `json:"tier"`
+	} `json:"sponsorship"`
+}
+
+type DeploymentPayload struct {
+	Deployment struct {
+		Ref         string `json:"ref"`
+		Environment string `json:"environment"`
+	} `json:"deployment"`
+}
+
+type DeploymentStatusPayload struct {
+	DeploymentStatus struct {
+		State       string `json:"state"`
+		Environment string `json:"environment"`
+	} `json:"deployment_status"`
+	Deployment struct {
+		Environment string `json:"environment"`
+	} `json:"deployment"`
+}
+
+type StatusPayload struct {
+	SHA     string `json:"sha"`
+	State   string `json:"state"`
+	Context string `json:"context"`
+}
+
+type CheckRunPayload struct {
+	Action   string `json:"action"`
+	CheckRun struct {
+		Name       string `json:"name"`
+		Status     string `json:"status"`
+		Conclusion string `json:"conclusion"`
+	} `json:"check_run"`
+}
+
+// Commit represents a git commit
+type Commit struct {
+	SHA     string `json:"sha"`
+	Message string `json:"message"`
+	Author  struct {
+		Name  string `json:"name"`
+		Email string `json:"email"`
+	} `json:"author"`
+}
+
+// newPayload returns an empty payload model for an event type, or nil for
+// types whose payload carries nothing that is shown
+func newPayload(eventType string) any {
+	switch EventType(eventType) {
+	case EventTypePush:
+		return &PushPayload{}
+	case EventTypeCreate, EventTypeDelete:
+		return &CreatePayload{}
+	case EventTypeIssues, EventTypeIssueComment:
+		return &IssuesPayload{}
+	case EventTypePullRequest:
+		return &PullRequestPayload{}
+	case EventTypePullRequestReview:
+		return &PullRequestReviewPayload{}
+	case EventTypePullRequestReviewComment:
+		return &PullRequestReviewCommentPayload{}
+	case EventTypePullRequestReviewThread:
+		return &PullRequestReviewThreadPayload{}
+	case EventTypeFork:
+		return &ForkPayload{}
+	case EventTypeRelease:
+		return &ReleasePayload{}
+	case EventTypeGollum:
+		return &GollumPayload{}
+	case EventTypeCommitComment:
+		return &CommitCommentPayload{}
+	case EventTypeSponsorship:
+		return &SponsorshipPayload{}
+	case EventTypeDeployment:
+		return &DeploymentPayload{}
+	case EventTypeDeploymentStatus:
+		return &DeploymentStatusPayload{}
+	case EventTypeStatus:
+		return &StatusPayload{}
+	case EventTypeCheckRun:
+		return &CheckRunPayload{}
+	}
+	return nil
+}
+
+// ParsedPayload returns the payload decoded into its typed model, such as
+// *PushPayload for a PushEvent, or nil for types without one. Events decoded
+// from JSON parse it once and share the result, so callers must not modify it.
+func (e *GitHubEvent) ParsedPayload() (any, error) {
+	if e.parsed == nil {
+		return e.decodePayload()
+	}
+	e.parsed.once.Do(func() {
+		e.parsed.payload, e.parsed.err = e.decodePayload()
+	})
+	return e.parsed.payload, e.parsed.err
+}
+
+// decodePayload unmarshals the payload into the model for the event type
+func (e *GitHubEvent) decodePayload() (any, error) {
+	payload := newPayload(e.Type)
+	if payload == nil {
+		return nil, nil
+	}
+	if err := json.Unmarshal(e.Payload, payload); err != nil {
+		parseErr := &PayloadError{EventID: e.ID, Type: e.Type, Err: err}
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			parseErr.Field = typeErr.Field
+		}
+		return nil, parseErr
+	}
+	return payload, nil
+}
+
+// PayloadError reports an event whose payload does not match the model for
+// its type; the event is then described generically
+type PayloadError struct {
+	EventID string
+	Type    string
+	Field   string // Dotted path of the mismatched field, when known
+	Err     error
+}
+
+func (e *PayloadError) Error() string {
+	var typeErr *json.UnmarshalTypeError
+	if e.Field != "" && errors.As(e.Err, &typeErr) {
+		return fmt.Sprintf("event %s: %s payload field %s is a %s, want %s",
+			e.EventID, e.Type, e.Field, typeErr.Value, typeErr.Type)
+	}
+	return fmt.Sprintf("event %s: %s payload: %v", e.EventID, e.Type, e.Err)
+}
+
+func (e *PayloadError) Unwrap() error {
+	return e.Err
+}
+
+// TypedPayload returns the parsed payload, or nil when it cannot be parsed
+func (e *GitHubEvent) TypedPayload() any {
+	payload, err := e.ParsedPayload()
+	if err != nil {
+		return nil
+	}
+	return payload
+}
+
+// Domain Business Logic
+
+// GetBranch extracts the branch name from a git ref
+func (p *PushPayload) GetBranch() string {
+	return strings.TrimPrefix(p.Ref, "refs/heads/")
+}
+
+// GetAction is the pull request's action, reporting merged rather than
+// closed when the pull request was merged
+func (p *PullRequestPayload) GetAction() string {
+	if p.Action == "closed" && p.PullRequest.Merged {
+		return "merged"
+	}
+	return p.Action
+}
+
+// GetShortSHA returns the first 7 characters of the commit SHA
+func (c *Commit) GetShortSHA() string {
+	if len(c.SHA) >= 7 {
+		return c.SHA[:7]
+	}
+	return c.SHA
+}
+
+// GetFirstLine returns the first line of the commit message
+func (c *Commit) GetFirstLine() string {
+	lines := strings.Split(c.Message, "\n")
+	if len(lines) > 0 {
+		return lines[0]
+	}
+	return c.Message
+}
+
+// TruncateMessage truncates a message to maxLength characters and adds
+// ellipsis if needed. It counts and cuts runes, so multi-byte text such as
+// emoji or CJK stays valid UTF-8.
+func TruncateMessage(message string, maxLength int) string {
+	if utf8.RuneCountInString(message) <= maxLength {
+		return message
+	}
+	return string([]rune(message)[:maxLength-3]) + "..."
+}
+
+// FormatDescription returns a human-readable description of the event,
+// using the renderer registered for its type
+func (e *GitHubEvent) FormatDescription() string {
+	if description := eventRenderers.Describe(e); description != "" {
+		return description
+	}
+	return fmt.Sprintf("%s in %s", e.Type, e.Repo.Name)
+}
+
+// DescriptionFields returns the parsed payload fields that feed FormatDescription
+func (e *GitHubEvent) DescriptionFields() map[string]string {
+	fields := map[string]string{
+		"repo": e.Repo.Name,
+	}
+
+	switch EventType(e.Type) {
+	case EventTypePush:
+		if payload, ok := e.TypedPayload().(*PushPayload); ok {
+			fields["branch"] = payload.GetBranch()
+			fields["size"] = strconv.Itoa(payload.Size)
+		}
+
+	case EventTypeCreate, EventTypeDelete:
+		if payload, ok := e.TypedPayload().(*CreatePayload); ok {
+			fields["ref"] = payload.Ref
+			fields["ref_type"] = payload.RefType
+		}
+
+	case EventTypeIssues, EventTypeIssueComment:
+		if payload, ok := e.TypedPayload().(*IssuesPayload); ok {
+			fields["action"] = payload.Action
+			fields["number"] = strconv.Itoa(payload.Issue.Number)
+			fields["title"] = payload.Issue.Title
+		}
+
+	case EventTypePullRequest:
+		if payload, ok := e.TypedPayload().(*PullRequestPayload); ok {
+			fields["action"] = payload.GetAction()
+			fields["number"] = strconv.Itoa(payload.PullRequest.Number)
+			fields["title"] = payload.PullRequest.Title
+		}
+
+	case EventTypePullRequestReview:
+		if payload, ok := e.TypedPayload().(*PullRequestReviewPayload); ok {
+			fields["action"] = payload.Action
+			fields["number"] = strconv.Itoa(payload.PullRequest.Number)
+			fields["state"] = payload.Review.State
+		}
+
+	case EventTypePullRequestReviewComment:
+		if payload, ok := e.TypedPayload().(*PullRequestReviewCommentPayload); ok {
+			fields["action"] = payload.Action
+			fields["number"] = strconv.Itoa(payload.PullRequest.Number)
+		}
+
+	case EventTypePullRequestReviewThread:
+		if payload, ok := e.TypedPayload().(*PullRequestReviewThreadPayload); ok {
+			fields["action"] = payload.Action
+			fields["number"] = strconv.Itoa(payload.PullRequest.Number)
+		}
+
+	case EventTypeGollum:
+		if payload, ok := e.TypedPayload().(*GollumPayload); ok {
+			fields["pages"] = strconv.Itoa(len(payload.Pages))
+			if len(payload.Pages) == 1 {
+				fields["action"] = payload.Pages[0].Action
+				fields["title"] = payload.Pages[0].Name()
+			}
+		}
+
+	case EventTypeCommitComment:
+		if payload, ok := e.TypedPayload().(*CommitCommentPayload); ok {
+			fields["commit"] = payload.Comment.CommitID
+		}
+
+	case EventTypeSponsorship:
+		if payload, ok := e.TypedPayload().(*SponsorshipPayload); ok {
+			fields["action"] = payload.Action
+			fields["sponsorable"] = payload.Sponsorship.Sponsorable.Login
+			fields["tier"] = payload.Sponsorship.Tier.Name
+		}
+
+	case EventTypeDeployment:
+		if payload, ok := e.TypedPayload().(*DeploymentPayload); ok {
+			fields["ref"] = payload.Deployment.Ref
+			fields["environment"] = payload.Deployment.Environment
+		}
+
+	case EventTypeDeploymentStatus:
+		if payload, ok := e.TypedPayload().(*DeploymentStatusPayload); ok {
+			fields["state"] = payload.DeploymentStatus.State
+			fields["environment"] = payload.DeploymentStatus.Environment
+		}
+
+	case EventTypeStatus:
+		if payload, ok := e.TypedPayload().(*StatusPayload); ok {
+			fields["sha"] = payload.SHA
+			fields["state"] = payload.State
+			fields["context"] = payload.Context
+		}
+
+	case EventTypeCheckRun:
+		if payload, ok := e.TypedPayload().(*CheckRunPayload); ok {
+			fields["action"] = payload.Action
+			fields["name"] = payload.CheckRun.Name
+			fields["status"] = payload.CheckRun.Status
+			fields["conclusion"] = payload.CheckRun.Conclusion
+		}
+
+	case EventTypeFork:
+		if payload, ok := e.TypedPayload().(*ForkPayload); ok {
+			fields["forkee"] = payload.Forkee.FullName
+		}
+
+	case EventTypeRelease:
+		if payload, ok := e.TypedPayload().(*ReleasePayload); ok {
+			fields["action"] = payload.Action
+			fields["tag"] = payload.Release.TagName
+		}
+	}
+
+	return fields
+}
+
+// Action returns what the event did according to its payload, such as
+// opened, closed, created or published, and "" for events without one. A
+// pull request closed by merging reports merged.
+func (e *GitHubEvent) Action() string {
+	return e.DescriptionFields()["action"]
+}
+
+// GetCommitDetails extracts commit information from a PushEvent
+func (e *GitHubEvent) GetCommitDetails() ([]Commit, error) {
+	if EventType(e.Type) != EventTypePush {
+		return nil, fmt.Errorf("event is not a PushEvent")
+	}
+
+	payload, err := e.ParsedPayload()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse push payload: %w", err)
+	}
+
+	return payload.(*PushPayload).Commits, nil
+}
+
+// titleCase converts the first letter to uppercase
+func titleCase(s string) string {
+	if len(s) == 0 {
+		return s
+	}
+	return strings.ToUpper(string(s[0])) + strings.ToLower(s[1:])
+}
+
+// GetEventTypeAliases returns the shorthands accepted for -type
+func GetEventTypeAliases() map[string]EventType {
+	return map[string]EventType{
+		"push":    EventTypePush,
+		"pr":      EventTypePullRequest,
+		"issue":   EventTypeIssues,
+		"star":    EventTypeWatch,
+		"fork":    EventTypeFork,
+		"release": EventTypeRelease,
+	}
+}
+
+// ResolveEventType maps a shorthand to its canonical event type; any other
+// name is returned unchanged
+func ResolveEventType(name string) string {
+	if eventType, ok := GetEventTypeAliases()[strings.ToLower(name)]; ok {
+		return string(eventType)
+	}
+	return name
+}
+
+// GetAvailableEventTypes returns all available event types with descriptions
+func GetAvailableEventTypes() map[EventType]string {
+	return map[EventType]string{
+		EventTypePush:         "Git push",
+		EventTypeCreate:       "Branch or tag creation",
+		EventTypeDelete:       "Branch or tag deletion",
+		EventTypeIssues:       "Issue opened, closed, etc.",
+		EventTypePullRequest:  "PR opened, closed, merged, etc.",
+		EventTypeWatch:        "Repository starred",
+		EventTypeFork:         "Repository forked",
+		EventTypeIssueComment: "Comment on issue or PR",
+		EventTypePublic:       "Repository made public",
+		EventTypeMember:       "Member added to repository",
+		EventTypeRelease:      "Release published",
+
+		EventTypePullRequestReview:        "PR review submitted",
+		EventTypePullRequestReviewComment: "Comment on a PR review",
+		EventTypePullRequestReviewThread:  "PR review thread resolved or unresolved",
+		EventTypeGollum:                   "Wiki page created or edited",
+		EventTypeCommitComment:            "Comment on a commit",
+		EventTypeSponsorship:              "Sponsorship created, changed or cancelled",
+		EventTypeDeployment:               "Deployment created",
+		EventTypeDeploymentStatus:         "Deployment status changed",
+		EventTypeStatus:                   "Commit status changed",
+		EventTypeCheckRun:                 "Check run created or completed",
+	}
+}
+
+// MergeEvents combines event lists into one timeline, newest first. Each
+// event ID is kept once, taking the copy from the earliest list, so callers
+// pass fresh data before cached or archived data. Events are ordered by
+// creation time and then by ID, which keeps the timeline stable when pages
+// overlap or deliveries arrive out of order.
+func MergeEvents(lists ...[]GitHubEvent) []GitHubEvent {
+	seen := make(map[string]bool)
+	merged := make([]GitHubEvent, 0)
+	for _, events := range lists {
+		for _, event := range events {
+			if event.ID != "" && seen[event.ID] {
+				continue
+			}
+			seen[event.ID] = true
+			merged = append(merged, event)
+		}
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool {
+		if !merged[i].CreatedAt.Equal(merged[j].CreatedAt) {
+			return merged[i].CreatedAt.After(merged[j].CreatedAt)
+		}
+		return CompareEventIDs(merged[i].ID, merged[j].ID) > 0
+	})
+
+	return merged
+}
+
+// CompareEventIDs orders GitHub's numeric event IDs as numbers without
+// parsing them
+func CompareEventIDs(a, b string) int {
+	if len(a) != len(b) {
+		if len(a) < len(b) {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(a, b)
+}
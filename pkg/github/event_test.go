@@ -0,0 +1,841 @@
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestFormatDescription(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    GitHubEvent
+		expected string
+	}{
+		{
+			name: "PushEvent with single commit",
+			event: GitHubEvent{
+				Type: "PushEvent",
+				Repo: Repo{Name: "user/repo"},
+				Payload: json.RawMessage(`{
+					"size": 1,
+					"commits": [{"sha": "abc123", "message": "test commit"}],
+					"ref": "refs/heads/main"
+				}`),
+			},
+			expected: "Pushed 1 commit to user/repo (branch: main)",
+		},
+		{
+			name: "PushEvent with multiple commits",
+			event: GitHubEvent{
+				Type: "PushEvent",
+				Repo: Repo{Name: "user/repo"},
+				Payload: json.RawMessage(`{
+					"size": 3,
+					"commits": [
+						{"sha": "abc123", "message": "commit 1"},
+						{"sha": "def456", "message": "commit 2"},
+						{"sha": "ghi789", "message": "commit 3"}
+					],
+					"ref": "refs/heads/develop"
+				}`),
+			},
+			expected: "Pushed 3 commits to user/repo (branch: develop)",
+		},
+		{
+			name: "PullRequestEvent merged",
+			event: GitHubEvent{
+				Type: "PullRequestEvent",
+				Repo: Repo{Name: "user/repo"},
+				Payload: json.RawMessage(`{
+					"action": "closed",
+					"pull_request": {"number": 7, "title": "Add feature", "merged": true}
+				}`),
+			},
+			expected: "Merged pull request #7 in user/repo: Add feature",
+		},
+		{
+			name: "PullRequestEvent closed without merging",
+			event: GitHubEvent{
+				Type: "PullRequestEvent",
+				Repo: Repo{Name: "user/repo"},
+				Payload: json.RawMessage(`{
+					"action": "closed",
+					"pull_request": {"number": 7, "title": "Add feature", "merged": false}
+				}`),
+			},
+			expected: "Closed pull request #7 in user/repo: Add feature",
+		},
+		{
+			name: "CreateEvent for branch",
+			event: GitHubEvent{
+				Type: "CreateEvent",
+				Repo: Repo{Name: "user/repo"},
+				Payload: json.RawMessage(`{
+					"ref_type": "branch",
+					"ref": "feature/new-feature"
+				}`),
+			},
+			expected: "Created branch 'feature/new-feature' in user/repo",
+		},
+		{
+			name: "WatchEvent",
+			event: GitHubEvent{
+				Type:    "WatchEvent",
+				Repo:    Repo{Name: "facebook/react"},
+				Payload: json.RawMessage(`{}`),
+			},
+			expected: "Starred facebook/react",
+		},
+		{
+			name: "IssuesEvent with issue details",
+			event: GitHubEvent{
+				Type: "IssuesEvent",
+				Repo: Repo{Name: "user/repo"},
+				Payload: json.RawMessage(`{
+					"action": "opened",
+					"issue": {
+						"number": 42,
+						"title": "Bug: Something is broken",
+						"state": "open"
+					}
+				}`),
+			},
+			expected: "Opened issue #42 in user/repo: Bug: Something is broken",
+		},
+		{
+			name: "ForkEvent with forkee",
+			event: GitHubEvent{
+				Type: "ForkEvent",
+				Repo: Repo{Name: "original/repo"},
+				Payload: json.RawMessage(`{
+					"forkee": {
+						"full_name": "user/forked-repo"
+					}
+				}`),
+			},
+			expected: "Forked original/repo to user/forked-repo",
+		},
+		{
+			name: "PullRequestReviewEvent approved",
+			event: GitHubEvent{
+				Type: "PullRequestReviewEvent",
+				Repo: Repo{Name: "user/repo"},
+				Payload: json.RawMessage(`{
+					"action": "created",
+					"review": {"state": "approved"},
+					"pull_request": {"number": 12, "title": "Add feature"}
+				}`),
+			},
+			expected: "Reviewed pull request #12 in user/repo (approved)",
+		},
+		{
+			name: "PullRequestReviewEvent changes requested",
+			event: GitHubEvent{
+				Type: "PullRequestReviewEvent",
+				Repo: Repo{Name: "user/repo"},
+				Payload: json.RawMessage(`{
+					"review": {"state": "CHANGES_REQUESTED"},
+					"pull_request": {"number": 12}
+				}`),
+			},
+			expected: "Reviewed pull request #12 in user/repo (changes requested)",
+		},
+		{
+			name: "PullRequestReviewCommentEvent",
+			event: GitHubEvent{
+				Type: "PullRequestReviewCommentEvent",
+				Repo: Repo{Name: "user/repo"},
+				Payload: json.RawMessage(`{
+					"action": "created",
+					"comment": {"body": "Nit"},
+					"pull_request": {"number": 5}
+				}`),
+			},
+			expected: "Commented on review of pull request #5 in user/repo",
+		},
+		{
+			name: "PullRequestReviewThreadEvent",
+			event: GitHubEvent{
+				Type: "PullRequestReviewThreadEvent",
+				Repo: Repo{Name: "user/repo"},
+				Payload: json.RawMessage(`{
+					"action": "resolved",
+					"pull_request": {"number": 5}
+				}`),
+			},
+			expected: "Resolved a review thread on pull request #5 in user/repo",
+		},
+		{
+			name: "GollumEvent single page",
+			event: GitHubEvent{
+				Type: "GollumEvent",
+				Repo: Repo{Name: "user/repo"},
+				Payload: json.RawMessage(`{
+					"pages": [{"page_name": "Home", "title": "Home", "action": "created"}]
+				}`),
+			},
+			expected: "Created wiki page 'Home' in user/repo",
+		},
+		{
+			name: "GollumEvent page name without title",
+			event: GitHubEvent{
+				Type: "GollumEvent",
+				Repo: Repo{Name: "user/repo"},
+				Payload: json.RawMessage(`{
+					"pages": [{"page_name": "Setup-Guide", "action": "edited"}]
+				}`),
+			},
+			expected: "Edited wiki page 'Setup-Guide' in user/repo",
+		},
+		{
+			name: "GollumEvent multiple pages",
+			event: GitHubEvent{
+				Type: "GollumEvent",
+				Repo: Repo{Name: "user/repo"},
+				Payload: json.RawMessage(`{
+					"pages": [
+						{"title": "Home", "action": "edited"},
+						{"title": "Setup", "action": "created"}
+					]
+				}`),
+			},
+			expected: "Updated 2 wiki pages in user/repo",
+		},
+		{
+			name: "CommitCommentEvent",
+			event: GitHubEvent{
+				Type: "CommitCommentEvent",
+				Repo: Repo{Name: "user/repo"},
+				Payload: json.RawMessage(`{
+					"comment": {"commit_id": "abc1234def5678", "body": "LGTM"}
+				}`),
+			},
+			expected: "Commented on commit abc1234 in user/repo",
+		},
+		{
+			name: "SponsorshipEvent",
+			event: GitHubEvent{
+				Type: "SponsorshipEvent",
+				Payload: json.RawMessage(`{
+					"action": "created",
+					"sponsorship": {"sponsorable": {"login": "octocat"}, "tier": {"name": "$5"}}
+				}`),
+			},
+			expected: "Created sponsorship of octocat",
+		},
+		{
+			name: "DeploymentEvent",
+			event: GitHubEvent{
+				Type: "DeploymentEvent",
+				Repo: Repo{Name: "user/repo"},
+				Payload: json.RawMessage(`{
+					"deployment": {"ref": "main", "environment": "production"}
+				}`),
+			},
+			expected: "Deployed main to production in user/repo",
+		},
+		{
+			name: "DeploymentStatusEvent",
+			event: GitHubEvent{
+				Type: "DeploymentStatusEvent",
+				Repo: Repo{Name: "user/repo"},
+				Payload: json.RawMessage(`{
+					"deployment_status": {"state": "success"},
+					"deployment": {"environment": "staging"}
+				}`),
+			},
+			expected: "Deployment to staging in user/repo: success",
+		},
+		{
+			name: "StatusEvent",
+			event: GitHubEvent{
+				Type: "StatusEvent",
+				Repo: Repo{Name: "user/repo"},
+				Payload: json.RawMessage(`{
+					"sha": "abc1234def", "state": "failure", "context": "ci/build"
+				}`),
+			},
+			expected: "Commit abc1234 in user/repo is failure (ci/build)",
+		},
+		{
+			name: "CheckRunEvent",
+			event: GitHubEvent{
+				Type: "CheckRunEvent",
+				Repo: Repo{Name: "user/repo"},
+				Payload: json.RawMessage(`{
+					"action": "completed",
+					"check_run": {"name": "lint", "status": "completed", "conclusion": "success"}
+				}`),
+			},
+			expected: "Check run 'lint' in user/repo: success",
+		},
+		{
+			name: "Unknown event type",
+			event: GitHubEvent{
+				Type:    "UnknownEvent",
+				Repo:    Repo{Name: "user/repo"},
+				Payload: json.RawMessage(`{}`),
+			},
+			expected: "UnknownEvent in user/repo",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.event.FormatDescription()
+			if result != tt.expected {
+				t.Errorf("FormatDescription() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTitleCase(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"opened", "Opened"},
+		{"closed", "Closed"},
+		{"UPPERCASE", "Uppercase"},
+		{"", ""},
+		{"a", "A"},
+		{"ABC", "Abc"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := titleCase(tt.input)
+			if result != tt.expected {
+				t.Errorf("titleCase(%q) = %v, want %v", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestPushPayload_GetBranch(t *testing.T) {
+	tests := []struct {
+		name     string
+		payload  PushPayload
+		expected string
+	}{
+		{
+			name:     "main branch",
+			payload:  PushPayload{Ref: "refs/heads/main"},
+			expected: "main",
+		},
+		{
+			name:     "feature branch",
+			payload:  PushPayload{Ref: "refs/heads/feature/new-feature"},
+			expected: "feature/new-feature",
+		},
+		{
+			name:     "branch without prefix",
+			payload:  PushPayload{Ref: "develop"},
+			expected: "develop",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.payload.GetBranch()
+			if result != tt.expected {
+				t.Errorf("GetBranch() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCommit_GetShortSHA(t *testing.T) {
+	tests := []struct {
+		name     string
+		commit   Commit
+		expected string
+	}{
+		{
+			name:     "normal SHA",
+			commit:   Commit{SHA: "abc123def456ghi789"},
+			expected: "abc123d",
+		},
+		{
+			name:     "short SHA",
+			commit:   Commit{SHA: "abc"},
+			expected: "abc",
+		},
+		{
+			name:     "empty SHA",
+			commit:   Commit{SHA: ""},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.commit.GetShortSHA()
+			if result != tt.expected {
+				t.Errorf("GetShortSHA() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestCommit_GetFirstLine(t *testing.T) {
+	tests := []struct {
+		name     string
+		commit   Commit
+		expected string
+	}{
+		{
+			name:     "single line message",
+			commit:   Commit{Message: "Fix bug in parser"},
+			expected: "Fix bug in parser",
+		},
+		{
+			name:     "multi-line message",
+			commit:   Commit{Message: "Fix bug in parser\n\nThis fixes issue #123"},
+			expected: "Fix bug in parser",
+		},
+		{
+			name:     "empty message",
+			commit:   Commit{Message: ""},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.commit.GetFirstLine()
+			if result != tt.expected {
+				t.Errorf("GetFirstLine() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTruncateMessage(t *testing.T) {
+	tests := []struct {
+		name      string
+		message   string
+		maxLength int
+		expected  string
+	}{
+		{
+			name:      "short message",
+			message:   "Short",
+			maxLength: 10,
+			expected:  "Short",
+		},
+		{
+			name:      "exact length",
+			message:   "Exact ten!",
+			maxLength: 10,
+			expected:  "Exact ten!",
+		},
+		{
+			name:      "long message",
+			message:   "This is a very long message that should be truncated",
+			maxLength: 20,
+			expected:  "This is a very lo...",
+		},
+		{
+			name:      "very short max",
+			message:   "Hello",
+			maxLength: 3,
+			expected:  "...",
+		},
+		{
+			name:      "multi-byte characters",
+			message:   "リリースノート 🎉🎉🎉 更新",
+			maxLength: 10,
+			expected:  "リリースノート...",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := TruncateMessage(tt.message, tt.maxLength)
+			if result != tt.expected {
+				t.Errorf("TruncateMessage(%q, %d) = %v, want %v",
+					tt.message, tt.maxLength, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGitHubEvent_ParsedPayload(t *testing.T) {
+	t.Run("typed per event type", func(t *testing.T) {
+		tests := []struct {
+			event    GitHubEvent
+			expected any
+		}{
+			{GitHubEvent{Type: "PushEvent", Payload: json.RawMessage(`{"size":1}`)}, &PushPayload{}},
+			{GitHubEvent{Type: "DeleteEvent", Payload: json.RawMessage(`{}`)}, &CreatePayload{}},
+			{GitHubEvent{Type: "IssueCommentEvent", Payload: json.RawMessage(`{}`)}, &IssuesPayload{}},
+			{GitHubEvent{Type: "WatchEvent", Payload: json.RawMessage(`{}`)}, nil},
+		}
+		for _, tt := range tests {
+			payload, err := tt.event.ParsedPayload()
+			if err != nil {
+				t.Fatalf("%s: ParsedPayload() error = %v", tt.event.Type, err)
+			}
+			if fmt.Sprintf("%T", payload) != fmt.Sprintf("%T", tt.expected) {
+				t.Errorf("%s: ParsedPayload() = %T, want %T", tt.event.Type, payload, tt.expected)
+			}
+		}
+	})
+
+	t.Run("decoded once and shared by copies", func(t *testing.T) {
+		var event GitHubEvent
+		if err := json.Unmarshal([]byte(`{"type":"PushEvent","payload":{"size":3}}`), &event); err != nil {
+			t.Fatal(err)
+		}
+		copied := event
+
+		first, _ := event.ParsedPayload()
+		// A decoded event is not re-parsed, so a later change is not seen
+		event.Payload = json.RawMessage(`{"size":9}`)
+		second, _ := copied.ParsedPayload()
+
+		if first != second {
+			t.Error("Copies of a decoded event should share the parsed payload")
+		}
+		if second.(*PushPayload).Size != 3 {
+			t.Errorf("Size = %d, want 3", second.(*PushPayload).Size)
+		}
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		event := GitHubEvent{Type: "PushEvent", Payload: json.RawMessage(`{`)}
+		if _, err := event.ParsedPayload(); err == nil {
+			t.Error("Expected an error for a malformed payload")
+		}
+		if description := event.FormatDescription(); description != "PushEvent in " {
+			t.Errorf("FormatDescription() = %q, want the generic fallback", description)
+		}
+	})
+
+	t.Run("mismatched field", func(t *testing.T) {
+		event := GitHubEvent{ID: "42", Type: "PushEvent", Payload: json.RawMessage(`{"size":"many"}`)}
+		_, err := event.ParsedPayload()
+		var payloadErr *PayloadError
+		if !errors.As(err, &payloadErr) || payloadErr.EventID != "42" || payloadErr.Field != "size" {
+			t.Fatalf("ParsedPayload() error = %#v, want a PayloadError for field size of event 42", err)
+		}
+		want := "event 42: PushEvent payload field size is a string, want int"
+		if err.Error() != want {
+			t.Errorf("Error() = %q, want %q", err.Error(), want)
+		}
+	})
+}
+
+func TestGetCommitDetails(t *testing.T) {
+	t.Run("PushEvent returns commits", func(t *testing.T) {
+		event := GitHubEvent{
+			Type: "PushEvent",
+			Payload: json.RawMessage(`{
+				"commits": [
+					{"sha": "abc123", "message": "commit 1"},
+					{"sha": "def456", "message": "commit 2"}
+				]
+			}`),
+		}
+
+		commits, err := event.GetCommitDetails()
+		if err != nil {
+			t.Fatalf("GetCommitDetails() error = %v", err)
+		}
+
+		if len(commits) != 2 {
+			t.Errorf("GetCommitDetails() returned %d commits, want 2", len(commits))
+		}
+	})
+
+	t.Run("non-PushEvent returns error", func(t *testing.T) {
+		event := GitHubEvent{
+			Type:    "IssuesEvent",
+			Payload: json.RawMessage(`{}`),
+		}
+
+		_, err := event.GetCommitDetails()
+		if err == nil {
+			t.Error("GetCommitDetails() should return error for non-PushEvent")
+		}
+	})
+}
+
+func TestDescriptionFields(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    GitHubEvent
+		expected map[string]string
+	}{
+		{
+			name: "PushEvent",
+			event: GitHubEvent{
+				Type:    "PushEvent",
+				Repo:    Repo{Name: "user/repo"},
+				Payload: json.RawMessage(`{"size": 3, "ref": "refs/heads/develop"}`),
+			},
+			expected: map[string]string{"repo": "user/repo", "branch": "develop", "size": "3"},
+		},
+		{
+			name: "IssuesEvent",
+			event: GitHubEvent{
+				Type: "IssuesEvent",
+				Repo: Repo{Name: "user/repo"},
+				Payload: json.RawMessage(`{
+					"action": "opened",
+					"issue": {"number": 42, "title": "Bug"}
+				}`),
+			},
+			expected: map[string]string{
+				"repo":   "user/repo",
+				"action": "opened",
+				"number": "42",
+				"title":  "Bug",
+			},
+		},
+		{
+			name: "WatchEvent",
+			event: GitHubEvent{
+				Type:    "WatchEvent",
+				Repo:    Repo{Name: "user/repo"},
+				Payload: json.RawMessage(`{}`),
+			},
+			expected: map[string]string{"repo": "user/repo"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields := tt.event.DescriptionFields()
+			if len(fields) != len(tt.expected) {
+				t.Errorf("DescriptionFields() = %v, want %v", fields, tt.expected)
+			}
+			for key, value := range tt.expected {
+				if fields[key] != value {
+					t.Errorf("fields[%s] = %v, want %v", key, fields[key], value)
+				}
+			}
+		})
+	}
+}
+
+func TestGitHubEvent_Action(t *testing.T) {
+	tests := []struct {
+		name     string
+		event    GitHubEvent
+		expected string
+	}{
+		{
+			name:     "opened pull request",
+			event:    GitHubEvent{Type: "PullRequestEvent", Payload: json.RawMessage(`{"action": "opened"}`)},
+			expected: "opened",
+		},
+		{
+			name: "merged pull request",
+			event: GitHubEvent{
+				Type:    "PullRequestEvent",
+				Payload: json.RawMessage(`{"action": "closed", "pull_request": {"merged": true}}`),
+			},
+			expected: "merged",
+		},
+		{
+			name: "closed without merging",
+			event: GitHubEvent{
+				Type:    "PullRequestEvent",
+				Payload: json.RawMessage(`{"action": "closed", "pull_request": {"merged": false}}`),
+			},
+			expected: "closed",
+		},
+		{
+			name:     "published release",
+			event:    GitHubEvent{Type: "ReleaseEvent", Payload: json.RawMessage(`{"action": "published"}`)},
+			expected: "published",
+		},
+		{
+			name:     "event without an action",
+			event:    GitHubEvent{Type: "PushEvent", Payload: json.RawMessage(`{"size": 1}`)},
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.event.Action(); got != tt.expected {
+				t.Errorf("Action() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetAvailableEventTypes(t *testing.T) {
+	types := GetAvailableEventTypes()
+
+	// Check some expected types exist
+	expectedTypes := []EventType{
+		EventTypePush,
+		EventTypeCreate,
+		EventTypeIssues,
+		EventTypePullRequest,
+	}
+
+	for _, expectedType := range expectedTypes {
+		if _, exists := types[expectedType]; !exists {
+			t.Errorf("GetAvailableEventTypes() missing %s", expectedType)
+		}
+	}
+
+	// Verify all entries have descriptions
+	for eventType, description := range types {
+		if description == "" {
+			t.Errorf("Event type %s has empty description", eventType)
+		}
+	}
+}
+
+func TestResolveEventType(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"push", "PushEvent"},
+		{"PR", "PullRequestEvent"},
+		{"issue", "IssuesEvent"},
+		{"star", "WatchEvent"},
+		{"fork", "ForkEvent"},
+		{"release", "ReleaseEvent"},
+		{"PushEvent", "PushEvent"},
+		{"unknown", "unknown"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := ResolveEventType(tt.name); got != tt.expected {
+			t.Errorf("ResolveEventType(%q) = %q, want %q", tt.name, got, tt.expected)
+		}
+	}
+
+	// Every alias must point at a listed event type
+	types := GetAvailableEventTypes()
+	for alias, eventType := range GetEventTypeAliases() {
+		if _, exists := types[eventType]; !exists {
+			t.Errorf("alias %s points at unknown type %s", alias, eventType)
+		}
+	}
+}
+
+func TestPayloadParsing(t *testing.T) {
+	// Test PushPayload parsing
+	pushJSON := `{
+		"size": 2,
+		"commits": [
+			{"sha": "abc123", "message": "First commit", "author": {"name": "John", "email": "john@example.com"}},
+			{"sha": "def456", "message": "Second commit", "author": {"name": "Jane", "email": "jane@example.com"}}
+		],
+		"ref": "refs/heads/main"
+	}`
+
+	var pushPayload PushPayload
+	err := json.Unmarshal([]byte(pushJSON), &pushPayload)
+	if err != nil {
+		t.Fatalf("Failed to parse PushPayload: %v", err)
+	}
+
+	if pushPayload.Size != 2 {
+		t.Errorf("PushPayload.Size = %v, want %v", pushPayload.Size, 2)
+	}
+
+	if len(pushPayload.Commits) != 2 {
+		t.Errorf("PushPayload.Commits length = %v, want %v", len(pushPayload.Commits), 2)
+	}
+
+	if pushPayload.Ref != "refs/heads/main" {
+		t.Errorf("PushPayload.Ref = %v, want %v", pushPayload.Ref, "refs/heads/main")
+	}
+}
+
+// Benchmark for event formatting
+func BenchmarkFormatDescription(b *testing.B) {
+	event := GitHubEvent{
+		Type: "PushEvent",
+		Repo: Repo{Name: "user/repo"},
+		Payload: json.RawMessage(`{
+			"size": 3,
+			"commits": [
+				{"sha": "abc123", "message": "commit 1"},
+				{"sha": "def456", "message": "commit 2"},
+				{"sha": "ghi789", "message": "commit 3"}
+			],
+			"ref": "refs/heads/main"
+		}`),
+		CreatedAt: time.Now(),
+	}
+
+	for i := 0; i < b.N; i++ {
+		_ = event.FormatDescription()
+	}
+}
+
+func TestMergeEvents(t *testing.T) {
+	base := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	event := func(id string, offset time.Duration, repo string) GitHubEvent {
+		return GitHubEvent{ID: id, CreatedAt: base.Add(offset), Repo: Repo{Name: repo}}
+	}
+
+	tests := []struct {
+		name     string
+		lists    [][]GitHubEvent
+		expected []string
+	}{
+		{
+			name:     "empty",
+			lists:    nil,
+			expected: []string{},
+		},
+		{
+			name: "duplicates across lists keep the first copy",
+			lists: [][]GitHubEvent{
+				{event("2", time.Hour, "fresh"), event("1", 0, "fresh")},
+				{event("1", 0, "cached"), event("0", -time.Hour, "cached")},
+			},
+			expected: []string{"2", "1", "0"},
+		},
+		{
+			name: "out of order deliveries are sorted newest first",
+			lists: [][]GitHubEvent{
+				{event("1", 0, "a"), event("3", 2*time.Hour, "a"), event("2", time.Hour, "a")},
+			},
+			expected: []string{"3", "2", "1"},
+		},
+		{
+			name: "equal timestamps fall back to numeric ID order",
+			lists: [][]GitHubEvent{
+				{event("9", 0, "a"), event("10", 0, "a"), event("100", 0, "a")},
+			},
+			expected: []string{"100", "10", "9"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged := MergeEvents(tt.lists...)
+			got := make([]string, len(merged))
+			for i, e := range merged {
+				got[i] = e.ID
+			}
+			if !reflect.DeepEqual(got, tt.expected) {
+				t.Errorf("MergeEvents() = %v, want %v", got, tt.expected)
+			}
+			for _, e := range merged {
+				if e.ID == "1" && e.Repo.Name != "fresh" && len(tt.lists) > 1 {
+					t.Errorf("event 1 came from %s, want fresh", e.Repo.Name)
+				}
+			}
+		})
+	}
+}
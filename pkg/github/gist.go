@@ -0,0 +1,74 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Gists - A user's public gists, which the events feed no longer reports
+
+// GistRepository fetches the gists a user owns
+type GistRepository interface {
+	FetchGists(username string) ([]Gist, error)
+}
+
+// Gist is a gist as listed by /users/{user}/gists
+type Gist struct {
+	ID          string              `json:"id"`
+	Description string              `json:"description"`
+	HTMLURL     string              `json:"html_url"`
+	Public      bool                `json:"public"`
+	Files       map[string]GistFile `json:"files"`
+	CreatedAt   time.Time           `json:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at"`
+}
+
+// GistFile is one file of a gist
+type GistFile struct {
+	Filename string `json:"filename"`
+	Language string `json:"language"`
+	Size     int    `json:"size"`
+}
+
+// FetchGists returns the user's most recently created gists, one page of
+// them sized by SetPerPage
+func (r *GitHubAPIRepository) FetchGists(username string) ([]Gist, error) {
+	url := fmt.Sprintf("%s/users/%s/gists", r.baseURL, username)
+	if r.perPage > 0 {
+		url += "?per_page=" + strconv.Itoa(r.perPage)
+	}
+
+	var gists []Gist
+	err := r.withRetry(context.Background(), func() error {
+		resp, err := r.get(url)
+		if err != nil {
+			return err
+		}
+		switch resp.StatusCode {
+		case 200:
+		case 404:
+			return r.userNotFound(context.Background(), username)
+		case 401:
+			return ErrUnauthorized
+		case 403:
+			return newRateLimitError(resp.Header, r.clock.Now())
+		default:
+			return statusError(resp.StatusCode)
+		}
+		if err := json.Unmarshal(resp.Body, &gists); err != nil {
+			return &RepositoryError{
+				Code:    ErrInvalidResponse.Code,
+				Message: ErrInvalidResponse.Message,
+				Err:     err,
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return gists, nil
+}
@@ -0,0 +1,54 @@
+package github
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGitHubAPIRepository_FetchGists(t *testing.T) {
+	var path, query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, query = r.URL.Path, r.URL.RawQuery
+		if r.URL.Path == "/users/ghost/gists" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte(`[{
+			"id": "aa5a315d",
+			"description": "Hello world",
+			"html_url": "https://gist.github.com/aa5a315d",
+			"public": true,
+			"files": {"hello.go": {"filename": "hello.go", "language": "Go", "size": 42}},
+			"created_at": "2024-01-02T03:04:05Z",
+			"updated_at": "2024-01-03T03:04:05Z"
+		}]`))
+	}))
+	defer server.Close()
+
+	repo := NewGitHubAPIRepository(WithBaseURL(server.URL))
+	repo.SetPerPage(10)
+
+	gists, err := repo.FetchGists("octocat")
+	if err != nil {
+		t.Fatalf("FetchGists() error = %v", err)
+	}
+	if path != "/users/octocat/gists" || query != "per_page=10" {
+		t.Errorf("Requested %s?%s, want /users/octocat/gists?per_page=10", path, query)
+	}
+	if len(gists) != 1 {
+		t.Fatalf("Expected 1 gist, got %d", len(gists))
+	}
+	gist := gists[0]
+	if gist.Description != "Hello world" || !gist.Public || gist.Files["hello.go"].Language != "Go" {
+		t.Errorf("Gist = %+v", gist)
+	}
+	if !gist.UpdatedAt.After(gist.CreatedAt) {
+		t.Errorf("UpdatedAt %v should follow CreatedAt %v", gist.UpdatedAt, gist.CreatedAt)
+	}
+
+	if _, err := repo.FetchGists("ghost"); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("FetchGists(ghost) error = %v, want ErrUserNotFound", err)
+	}
+}
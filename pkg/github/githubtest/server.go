@@ -0,0 +1,281 @@
+// Package githubtest runs a fake GitHub REST API, so code built on package
+// github can be tested against pagination, missing users, rate limits and
+// conditional requests without reaching api.github.com.
+package githubtest
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+// Fake GitHub API - Fixtures served over HTTP
+
+// DefaultRateLimit is how many requests a Server answers per window before
+// refusing them, as GitHub does for unauthenticated clients
+const DefaultRateLimit = 60
+
+// Server is a fake GitHub API. Point a repository at it with
+// github.WithBaseURL(server.URL).
+//
+// It serves /users/{user}/events and /users/{user}/received_events in pages
+// with Link headers, answers unknown users with 404 and past the 300 event
+// feed cap with 422, sends rate-limit headers and refuses requests with 403
+// once the limit is spent, and tags pages with an ETag, answering a matching
+// If-None-Match with 304. /user resolves tokens added with AddToken and
+// /search/users finds logins loosely
+// matching the query, enough for did-you-mean suggestions.
+type Server struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	events    map[string][]github.GitHubEvent // Newest first, by lowercased login
+	logins    map[string]string               // Login as added, by lowercased login
+	tokens    map[string]string               // Login, by token
+	perPage   int
+	limit     int
+	remaining int
+	reset     time.Time
+	requests  []string
+}
+
+// NewServer starts a Server with no users, closed when the test ends
+func NewServer(t testing.TB) *Server {
+	s := &Server{
+		events:    make(map[string][]github.GitHubEvent),
+		logins:    make(map[string]string),
+		tokens:    make(map[string]string),
+		perPage:   github.DefaultPageSize,
+		limit:     DefaultRateLimit,
+		remaining: DefaultRateLimit,
+		reset:     time.Now().Add(time.Hour).Truncate(time.Second),
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// AddUser adds a user whose feed is events, newest first; adding the same
+// login again appends to the feed
+func (s *Server) AddUser(login string, events ...github.GitHubEvent) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := strings.ToLower(login)
+	s.logins[key] = login
+	s.events[key] = append(s.events[key], events...)
+}
+
+// AddToken makes /user answer login for requests authenticated with token
+func (s *Server) AddToken(token, login string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.tokens[token] = login
+}
+
+// SetPerPage sets the page size used when a request has no per_page
+func (s *Server) SetPerPage(perPage int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.perPage = perPage
+}
+
+// SetRateLimit sets how many more requests are answered before 403s start
+func (s *Server) SetRateLimit(remaining int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.remaining = remaining
+}
+
+// Requests returns the path and query of every request received, in order
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+// serve routes a request after counting it against the rate limit
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, r.URL.RequestURI())
+
+	header := w.Header()
+	header.Set("X-RateLimit-Limit", strconv.Itoa(s.limit))
+	header.Set("X-RateLimit-Reset", strconv.FormatInt(s.reset.Unix(), 10))
+	if s.remaining <= 0 {
+		header.Set("X-RateLimit-Remaining", "0")
+		writeMessage(w, http.StatusForbidden, "API rate limit exceeded")
+		return
+	}
+	s.remaining--
+	header.Set("X-RateLimit-Remaining", strconv.Itoa(s.remaining))
+
+	if r.Method != http.MethodGet {
+		writeMessage(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	path := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case len(path) == 1 && path[0] == "user":
+		s.serveAuthenticated(w, r)
+	case len(path) == 2 && path[0] == "search" && path[1] == "users":
+		s.serveSearch(w, r)
+	case len(path) == 2 && path[0] == "users":
+		s.serveUser(w, path[1])
+	case len(path) == 3 && path[0] == "users" && (path[2] == github.FeedEvents || path[2] == github.FeedReceived):
+		s.serveEvents(w, r, path[1])
+	default:
+		writeMessage(w, http.StatusNotFound, "Not Found")
+	}
+}
+
+// serveEvents writes one page of a user's feed
+func (s *Server) serveEvents(w http.ResponseWriter, r *http.Request, login string) {
+	events, ok := s.events[strings.ToLower(login)]
+	if !ok {
+		writeMessage(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	events = events[:min(len(events), activityFeedCap)]
+
+	query := r.URL.Query()
+	perPage, page := s.perPage, 1
+	if value, err := strconv.Atoi(query.Get("per_page")); err == nil && value > 0 {
+		perPage = min(value, github.MaxPageSize)
+	}
+	if value, err := strconv.Atoi(query.Get("page")); err == nil && value > 0 {
+		page = value
+	}
+	start := (page - 1) * perPage
+	if start >= activityFeedCap {
+		writeMessage(w, http.StatusUnprocessableEntity, "In order to keep the API fast for everyone, pagination is limited for this resource.")
+		return
+	}
+
+	pages := max((len(events)+perPage-1)/perPage, 1)
+	link := func(page int, rel string) string {
+		return fmt.Sprintf(`<%s%s?per_page=%d&page=%d>; rel="%s"`, s.URL, r.URL.Path, perPage, page, rel)
+	}
+	var links []string
+	if page < pages {
+		links = append(links, link(page+1, "next"), link(pages, "last"))
+	}
+	if page > 1 {
+		links = append(links, link(1, "first"), link(page-1, "prev"))
+	}
+	if len(links) > 0 {
+		w.Header().Set("Link", strings.Join(links, ", "))
+	}
+
+	body, err := json.Marshal(events[min(start, len(events)):min(start+perPage, len(events))])
+	if err != nil {
+		writeMessage(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	if string(body) == "null" {
+		body = []byte("[]")
+	}
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:8]) + `"`
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(body)
+}
+
+// activityFeedCap is how far back GitHub pages through a feed
+const activityFeedCap = 300
+
+// serveUser writes a user's profile
+func (s *Server) serveUser(w http.ResponseWriter, login string) {
+	login, ok := s.logins[strings.ToLower(login)]
+	if !ok {
+		writeMessage(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	writeJSON(w, map[string]string{"login": login, "type": "User"})
+}
+
+// serveAuthenticated writes the profile of the user the token belongs to
+func (s *Server) serveAuthenticated(w http.ResponseWriter, r *http.Request) {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	login, ok := s.tokens[token]
+	if !ok {
+		writeMessage(w, http.StatusUnauthorized, "Requires authentication")
+		return
+	}
+	writeJSON(w, map[string]string{"login": login, "type": "User"})
+}
+
+// serveSearch writes the logins containing the q parameter or starting with
+// its first three letters, in alphabetical order
+func (s *Server) serveSearch(w http.ResponseWriter, r *http.Request) {
+	query := strings.ToLower(r.URL.Query().Get("q"))
+	type item struct {
+		Login string `json:"login"`
+	}
+	items := []item{}
+	for _, key := range slices.Sorted(maps.Keys(s.logins)) {
+		if query != "" && (strings.Contains(key, query) || strings.HasPrefix(key, query[:min(len(query), 3)])) {
+			items = append(items, item{Login: s.logins[key]})
+		}
+	}
+	writeJSON(w, map[string]any{"total_count": len(items), "items": items})
+}
+
+// writeJSON writes value as a 200 JSON response
+func writeJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(value)
+}
+
+// writeMessage writes an error response shaped like GitHub's
+func writeMessage(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]string{"message": message})
+}
+
+// NewEvent builds an event of eventType by login on repo with an empty payload
+func NewEvent(id, login, eventType, repo string, createdAt time.Time) github.GitHubEvent {
+	return github.GitHubEvent{
+		ID:        id,
+		Type:      eventType,
+		Actor:     github.Actor{Login: login, DisplayLogin: login},
+		Repo:      github.Repo{Name: repo, URL: "https://api.github.com/repos/" + repo},
+		Payload:   json.RawMessage(`{}`),
+		Public:    true,
+		CreatedAt: createdAt,
+	}
+}
+
+// Events builds count WatchEvents by login, newest first, one hour apart
+// and ending at latest, for filling a feed across several pages
+func Events(login string, count int, latest time.Time) []github.GitHubEvent {
+	events := make([]github.GitHubEvent, count)
+	for i := range events {
+		events[i] = NewEvent(
+			strconv.Itoa(count-i),
+			login,
+			"WatchEvent",
+			login+"/repo-"+strconv.Itoa(i%3),
+			latest.Add(-time.Duration(i)*time.Hour),
+		)
+	}
+	return events
+}
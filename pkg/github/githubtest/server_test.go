@@ -0,0 +1,190 @@
+package githubtest
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alnah/github-activity/pkg/github"
+)
+
+var latest = time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)
+
+func TestServer_Pagination(t *testing.T) {
+	server := NewServer(t)
+	server.AddUser("octocat", Events("octocat", 75, latest)...)
+
+	repo := github.NewGitHubAPIRepository(github.WithBaseURL(server.URL))
+	repo.SetMaxPages(0)
+	events, err := repo.FetchEvents("octocat")
+	if err != nil {
+		t.Fatalf("FetchEvents() error = %v", err)
+	}
+	if len(events) != 75 {
+		t.Fatalf("got %d events, want 75", len(events))
+	}
+	if events[0].ID != "75" || events[74].ID != "1" {
+		t.Errorf("events run %s..%s, want 75..1", events[0].ID, events[74].ID)
+	}
+	if got := len(server.Requests()); got != 3 {
+		t.Errorf("server got %d requests, want 3 pages: %v", got, server.Requests())
+	}
+}
+
+func TestServer_PerPage(t *testing.T) {
+	server := NewServer(t)
+	server.AddUser("octocat", Events("octocat", 5, latest)...)
+
+	resp, err := http.Get(server.URL + "/users/octocat/events?per_page=2&page=2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	link := resp.Header.Get("Link")
+	for _, want := range []string{`per_page=2&page=3>; rel="next"`, `page=3>; rel="last"`, `page=1>; rel="prev"`} {
+		if !strings.Contains(link, want) {
+			t.Errorf("Link = %q, want it to contain %q", link, want)
+		}
+	}
+}
+
+func TestServer_FeedCap(t *testing.T) {
+	server := NewServer(t)
+	server.AddUser("octocat", Events("octocat", 10, latest)...)
+
+	resp, err := http.Get(server.URL + "/users/octocat/events?per_page=100&page=4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusUnprocessableEntity {
+		t.Errorf("status = %d, want 422 past the feed cap", resp.StatusCode)
+	}
+}
+
+func TestServer_UserNotFound(t *testing.T) {
+	server := NewServer(t)
+	server.AddUser("octocat")
+
+	repo := github.NewGitHubAPIRepository(github.WithBaseURL(server.URL))
+	_, err := repo.FetchEvents("octocta")
+	if !errors.Is(err, github.ErrUserNotFound) {
+		t.Fatalf("FetchEvents() error = %v, want ErrUserNotFound", err)
+	}
+	if !strings.Contains(err.Error(), "did you mean octocat?") {
+		t.Errorf("Error = %q, want a suggestion from the search", err)
+	}
+}
+
+func TestServer_RateLimit(t *testing.T) {
+	server := NewServer(t)
+	server.AddUser("octocat", Events("octocat", 3, latest)...)
+	server.SetRateLimit(1)
+
+	repo := github.NewGitHubAPIRepository(github.WithBaseURL(server.URL))
+	if _, err := repo.FetchEvents("octocat"); err != nil {
+		t.Fatalf("first FetchEvents() error = %v", err)
+	}
+	if rateLimit, ok := repo.LastRateLimit(); !ok || rateLimit.Remaining != 0 {
+		t.Errorf("LastRateLimit() = %+v, %v, want 0 remaining", rateLimit, ok)
+	}
+
+	repo.Refresh()
+	_, err := repo.FetchEvents("octocat")
+	if !errors.Is(err, github.ErrRateLimitExceeded) {
+		t.Fatalf("second FetchEvents() error = %v, want ErrRateLimitExceeded", err)
+	}
+}
+
+func TestServer_ETag(t *testing.T) {
+	server := NewServer(t)
+	server.AddUser("octocat", Events("octocat", 3, latest)...)
+	url := server.URL + "/users/octocat/events"
+
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	etag := resp.Header.Get("ETag")
+	if etag == "" {
+		t.Fatal("no ETag on the first response")
+	}
+
+	conditional := func() int {
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+		req.Header.Set("If-None-Match", etag)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+		return resp.StatusCode
+	}
+	if got := conditional(); got != http.StatusNotModified {
+		t.Errorf("unchanged feed status = %d, want 304", got)
+	}
+
+	server.AddUser("octocat", NewEvent("0", "octocat", "ForkEvent", "octocat/hello", latest.Add(-72*time.Hour)))
+	if got := conditional(); got != http.StatusOK {
+		t.Errorf("changed feed status = %d, want 200", got)
+	}
+}
+
+func TestServer_ConditionalRequests(t *testing.T) {
+	server := NewServer(t)
+	server.AddUser("octocat", Events("octocat", 3, latest)...)
+
+	var statuses []int
+	repo := github.NewGitHubAPIRepository(github.WithBaseURL(server.URL))
+	repo.SetRecorder(func(response github.RecordedResponse) {
+		statuses = append(statuses, response.StatusCode)
+	})
+	fetch := func() []github.GitHubEvent {
+		t.Helper()
+		repo.Refresh()
+		events, err := repo.FetchEvents("octocat")
+		if err != nil {
+			t.Fatalf("FetchEvents() error = %v", err)
+		}
+		return events
+	}
+
+	if got := len(fetch()); got != 3 {
+		t.Fatalf("first fetch got %d events, want 3", got)
+	}
+	if got := len(fetch()); got != 3 {
+		t.Errorf("revalidated fetch got %d events, want the 3 cached", got)
+	}
+	if statuses[len(statuses)-1] != http.StatusNotModified {
+		t.Errorf("revalidated fetch status = %d, want 304", statuses[len(statuses)-1])
+	}
+
+	server.AddUser("octocat", NewEvent("0", "octocat", "ForkEvent", "octocat/hello", latest.Add(-72*time.Hour)))
+	if got := len(fetch()); got != 4 {
+		t.Errorf("fetch of a changed feed got %d events, want 4", got)
+	}
+	if statuses[len(statuses)-1] != http.StatusOK {
+		t.Errorf("changed feed status = %d, want 200", statuses[len(statuses)-1])
+	}
+}
+
+func TestServer_AuthenticatedUser(t *testing.T) {
+	server := NewServer(t)
+	server.AddToken("secret", "octocat")
+
+	repo := github.NewGitHubAPIRepository(github.WithBaseURL(server.URL))
+	repo.SetToken("secret")
+	login, err := repo.AuthenticatedUser()
+	if err != nil || login != "octocat" {
+		t.Errorf("AuthenticatedUser() = %q, %v, want octocat", login, err)
+	}
+
+	repo.SetToken("wrong")
+	if _, err := repo.AuthenticatedUser(); err == nil {
+		t.Error("AuthenticatedUser() with an unknown token succeeded")
+	}
+}
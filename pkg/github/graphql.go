@@ -0,0 +1,214 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// GraphQL Backend - Contribution counts beyond the events feed window
+
+// ContributionRepository fetches a user's contribution calendar and totals
+type ContributionRepository interface {
+	FetchContributions(username string, from, to time.Time) (*Contributions, error)
+}
+
+// Contributions are the totals GitHub counts on a user's profile
+type Contributions struct {
+	Username     string
+	From         time.Time
+	To           time.Time
+	Total        int
+	Commits      int
+	Issues       int
+	PullRequests int
+	Reviews      int
+	Repositories int
+	Private      int            // Contributions to private repositories, not broken down
+	Days         map[string]int // Contributions per YYYY-MM-DD
+}
+
+// contributionsQuery asks for the totals and calendar of one user
+const contributionsQuery = `query($login: String!, $from: DateTime!, $to: DateTime!) {
+  user(login: $login) {
+    contributionsCollection(from: $from, to: $to) {
+      totalCommitContributions
+      totalIssueContributions
+      totalPullRequestContributions
+      totalPullRequestReviewContributions
+      totalRepositoryContributions
+      restrictedContributionsCount
+      contributionCalendar {
+        totalContributions
+        weeks { contributionDays { date contributionCount } }
+      }
+    }
+  }
+}`
+
+// contributionsResponse is the GraphQL response to contributionsQuery
+type contributionsResponse struct {
+	Data struct {
+		User *struct {
+			ContributionsCollection struct {
+				TotalCommitContributions            int `json:"totalCommitContributions"`
+				TotalIssueContributions             int `json:"totalIssueContributions"`
+				TotalPullRequestContributions       int `json:"totalPullRequestContributions"`
+				TotalPullRequestReviewContributions int `json:"totalPullRequestReviewContributions"`
+				TotalRepositoryContributions        int `json:"totalRepositoryContributions"`
+				RestrictedContributionsCount        int `json:"restrictedContributionsCount"`
+				ContributionCalendar                struct {
+					TotalContributions int `json:"totalContributions"`
+					Weeks              []struct {
+						ContributionDays []struct {
+							Date              string `json:"date"`
+							ContributionCount int    `json:"contributionCount"`
+						} `json:"contributionDays"`
+					} `json:"weeks"`
+				} `json:"contributionCalendar"`
+			} `json:"contributionsCollection"`
+		} `json:"user"`
+	} `json:"data"`
+	Errors []struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// GraphQLRepository implements ContributionRepository with the GitHub
+// GraphQL API, which requires a token
+type GraphQLRepository struct {
+	client    *http.Client
+	endpoint  string
+	token     string
+	userAgent string
+}
+
+// NewGraphQLRepository returns a repository posting to endpoint
+func NewGraphQLRepository(endpoint, token string) *GraphQLRepository {
+	return &GraphQLRepository{
+		client:    &http.Client{Timeout: 10 * time.Second},
+		endpoint:  endpoint,
+		token:     token,
+		userAgent: "github-activity-cli",
+	}
+}
+
+// GraphQL returns a GraphQL repository sharing this repository's client,
+// token and server
+func (r *GitHubAPIRepository) GraphQL() *GraphQLRepository {
+	return &GraphQLRepository{
+		client:    r.client,
+		endpoint:  GraphQLEndpoint(r.baseURL),
+		token:     r.token,
+		userAgent: r.userAgent,
+	}
+}
+
+// GraphQLEndpoint returns the GraphQL URL for a REST API base URL. GitHub
+// Enterprise Server serves REST under /api/v3 and GraphQL at /api/graphql.
+func GraphQLEndpoint(apiURL string) string {
+	apiURL = strings.TrimRight(apiURL, "/")
+	if base, ok := strings.CutSuffix(apiURL, "/api/v3"); ok {
+		return base + "/api/graphql"
+	}
+	return apiURL + "/graphql"
+}
+
+// FetchContributions returns the user's contributions between from and to
+func (r *GraphQLRepository) FetchContributions(username string, from, to time.Time) (*Contributions, error) {
+	if r.token == "" {
+		return nil, &RepositoryError{
+			Code:    ErrUnauthorized.Code,
+			Message: "the GraphQL API requires a token; set GITHUB_TOKEN or run `gh auth login`",
+		}
+	}
+
+	request, err := json.Marshal(map[string]any{
+		"query": contributionsQuery,
+		"variables": map[string]string{
+			"login": username,
+			"from":  from.UTC().Format(time.RFC3339),
+			"to":    to.UTC().Format(time.RFC3339),
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode query: %w", err)
+	}
+
+	req, err := http.NewRequest("POST", r.endpoint, bytes.NewReader(request))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", r.userAgent)
+	req.Header.Set("Authorization", "Bearer "+r.token)
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return nil, networkError("failed to fetch contributions", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case 200:
+	case 401:
+		return nil, ErrUnauthorized
+	case 403:
+		return nil, newRateLimitError(resp.Header, time.Now())
+	default:
+		return nil, statusError(resp.StatusCode)
+	}
+
+	var body contributionsResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, &RepositoryError{
+			Code:    ErrInvalidResponse.Code,
+			Message: ErrInvalidResponse.Message,
+			Err:     err,
+		}
+	}
+	if len(body.Errors) > 0 {
+		messages := make([]string, 0, len(body.Errors))
+		for _, e := range body.Errors {
+			if e.Type == "NOT_FOUND" {
+				return nil, &RepositoryError{
+					Code:    ErrUserNotFound.Code,
+					Message: fmt.Sprintf("user '%s' not found", username),
+				}
+			}
+			messages = append(messages, e.Message)
+		}
+		return nil, &RepositoryError{Code: ErrAPIError.Code, Message: strings.Join(messages, "; ")}
+	}
+	if body.Data.User == nil {
+		return nil, &RepositoryError{
+			Code:    ErrUserNotFound.Code,
+			Message: fmt.Sprintf("user '%s' not found", username),
+		}
+	}
+
+	collection := body.Data.User.ContributionsCollection
+	contributions := &Contributions{
+		Username:     username,
+		From:         from,
+		To:           to,
+		Total:        collection.ContributionCalendar.TotalContributions,
+		Commits:      collection.TotalCommitContributions,
+		Issues:       collection.TotalIssueContributions,
+		PullRequests: collection.TotalPullRequestContributions,
+		Reviews:      collection.TotalPullRequestReviewContributions,
+		Repositories: collection.TotalRepositoryContributions,
+		Private:      collection.RestrictedContributionsCount,
+		Days:         make(map[string]int),
+	}
+	for _, week := range collection.ContributionCalendar.Weeks {
+		for _, day := range week.ContributionDays {
+			contributions.Days[day.Date] = day.ContributionCount
+		}
+	}
+	return contributions, nil
+}
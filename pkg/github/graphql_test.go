@@ -0,0 +1,90 @@
+package github
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGraphQLEndpoint(t *testing.T) {
+	tests := map[string]string{
+		"https://api.github.com":             "https://api.github.com/graphql",
+		"https://github.example.com/api/v3/": "https://github.example.com/api/graphql",
+		"http://localhost:8080":              "http://localhost:8080/graphql",
+	}
+	for apiURL, expected := range tests {
+		if got := GraphQLEndpoint(apiURL); got != expected {
+			t.Errorf("GraphQLEndpoint(%q) = %q, want %q", apiURL, got, expected)
+		}
+	}
+}
+
+func TestGraphQLRepository_FetchContributions(t *testing.T) {
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.Header.Get("Authorization") != "Bearer token" {
+			t.Errorf("Request = %s with %q", r.Method, r.Header.Get("Authorization"))
+		}
+		var request struct {
+			Query     string            `json:"query"`
+			Variables map[string]string `json:"variables"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(request.Query, "contributionsCollection") ||
+			request.Variables["from"] != "2024-01-01T00:00:00Z" {
+			t.Errorf("Unexpected query %+v", request)
+		}
+
+		switch request.Variables["login"] {
+		case "ghost":
+			_, _ = w.Write([]byte(`{"data":{"user":null},"errors":[{"type":"NOT_FOUND","message":"Could not resolve"}]}`))
+		case "broken":
+			_, _ = w.Write([]byte(`{"errors":[{"message":"Field 'x' doesn't exist"}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"data":{"user":{"contributionsCollection":{
+				"totalCommitContributions":40,
+				"totalIssueContributions":3,
+				"totalPullRequestContributions":5,
+				"totalPullRequestReviewContributions":7,
+				"totalRepositoryContributions":1,
+				"restrictedContributionsCount":2,
+				"contributionCalendar":{"totalContributions":58,"weeks":[
+					{"contributionDays":[{"date":"2024-03-01","contributionCount":4}]},
+					{"contributionDays":[{"date":"2024-03-08","contributionCount":0}]}
+				]}}}}}`))
+		}
+	}))
+	defer server.Close()
+
+	repo := NewGraphQLRepository(server.URL, "token")
+	contributions, err := repo.FetchContributions("octocat", from, to)
+	if err != nil {
+		t.Fatalf("FetchContributions() error = %v", err)
+	}
+	if contributions.Total != 58 || contributions.Commits != 40 || contributions.Reviews != 7 ||
+		contributions.Private != 2 {
+		t.Errorf("Contributions = %+v", contributions)
+	}
+	if contributions.Days["2024-03-01"] != 4 || len(contributions.Days) != 2 {
+		t.Errorf("Days = %v", contributions.Days)
+	}
+
+	if _, err := repo.FetchContributions("ghost", from, to); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("Unknown user error = %v, want %s", err, ErrUserNotFound.Code)
+	}
+	if _, err := repo.FetchContributions("broken", from, to); !errors.Is(err, ErrAPIError) ||
+		!strings.Contains(err.Error(), "doesn't exist") {
+		t.Errorf("Query error = %v, want %s with the message", err, ErrAPIError.Code)
+	}
+	if _, err := NewGraphQLRepository(server.URL, "").FetchContributions("octocat", from, to); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Missing token error = %v, want %s", err, ErrUnauthorized.Code)
+	}
+}
@@ -0,0 +1,47 @@
+package github
+
+import (
+	"io"
+	"log/slog"
+)
+
+// Logging - Structured events for embedders to route into their own logger
+
+// Logger receives structured events: a message naming the event and
+// alternating keys and values. *slog.Logger satisfies it, and other loggers
+// such as zap need a two-method adapter.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Warn(msg string, args ...any)
+}
+
+// Events the repository and service log
+const (
+	LogRequest      = "request"       // url, before each API call
+	LogResponse     = "response"      // url, status, bytes and the rate-limit state
+	LogCacheHit     = "cache hit"     // user, events
+	LogCacheMiss    = "cache miss"    // user
+	LogRetry        = "retry"         // attempt, error
+	LogFetched      = "fetched"       // user, events
+	LogFetchFailed  = "fetch failed"  // user, error
+	LogFeedCap      = "feed cap"      // user, page refused past the end of the feed
+	LogParseWarning = "parse warning" // event_id, type, error
+	LogSkippedEvent = "skipped event" // url, index, error for a page entry that is not an event
+)
+
+// nopLogger discards every event
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...any) {}
+func (nopLogger) Warn(string, ...any)  {}
+
+// NopLogger returns the default Logger, which discards everything
+func NopLogger() Logger {
+	return nopLogger{}
+}
+
+// NewTextLogger returns a Logger writing every event, debug ones included,
+// as key=value lines to w
+func NewTextLogger(w io.Writer) Logger {
+	return slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{Level: slog.LevelDebug}))
+}
@@ -0,0 +1,55 @@
+package github
+
+import (
+	"bytes"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// Embedders can pass a *slog.Logger as is
+var _ Logger = (*slog.Logger)(nil)
+
+// recordingLogger keeps the events it receives
+type recordingLogger struct {
+	events []string
+}
+
+func (l *recordingLogger) Debug(msg string, args ...any) { l.events = append(l.events, "debug "+msg) }
+
+func (l *recordingLogger) Warn(msg string, args ...any) { l.events = append(l.events, "warn "+msg) }
+
+func TestNopLogger(t *testing.T) {
+	logger := NopLogger()
+	logger.Debug(LogRequest, "url", "https://api.github.com")
+	logger.Warn(LogRetry, "attempt", 1)
+}
+
+func TestNewTextLogger(t *testing.T) {
+	var buf bytes.Buffer
+	NewTextLogger(&buf).Debug(LogCacheHit, "user", "octocat", "events", 3)
+	if !strings.Contains(buf.String(), `level=DEBUG msg="cache hit" user=octocat events=3`) {
+		t.Errorf("Text logger wrote %q", buf.String())
+	}
+}
+
+func TestWithRepositoryLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	logger := &recordingLogger{}
+	repo := NewGitHubAPIRepository(WithRepositoryLogger(logger))
+	repo.baseURL = server.URL
+	if _, err := repo.FetchEvents("octocat"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "debug cache miss,debug request,debug response"
+	if got := strings.Join(logger.events, ","); got != expected {
+		t.Errorf("Logged %s, want %s", got, expected)
+	}
+}
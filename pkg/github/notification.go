@@ -0,0 +1,109 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Notifications - The authenticated user's unread notifications
+
+// NotificationRepository lists and marks the authenticated user's
+// notifications, which requires a token
+type NotificationRepository interface {
+	FetchNotifications(limit int) ([]Notification, error)
+	MarkNotificationRead(id string) error
+}
+
+// Notification is a notification thread as listed by /notifications
+type Notification struct {
+	ID         string              `json:"id"`
+	Reason     string              `json:"reason"`
+	Unread     bool                `json:"unread"`
+	UpdatedAt  time.Time           `json:"updated_at"`
+	Subject    NotificationSubject `json:"subject"`
+	Repository NotificationRepo    `json:"repository"`
+}
+
+// NotificationSubject is the issue, pull request, release or other item a
+// notification is about
+type NotificationSubject struct {
+	Title string `json:"title"`
+	Type  string `json:"type"`
+}
+
+// NotificationRepo is the repository a notification belongs to
+type NotificationRepo struct {
+	FullName string `json:"full_name"`
+}
+
+// notificationStatus maps the status of a notifications request to an error
+func (r *GitHubAPIRepository) notificationStatus(resp *apiResponse, ok int) error {
+	switch resp.StatusCode {
+	case ok:
+		return nil
+	case 401:
+		return ErrUnauthorized
+	case 403:
+		return newRateLimitError(resp.Header, r.clock.Now())
+	default:
+		return statusError(resp.StatusCode)
+	}
+}
+
+// FetchNotifications returns the unread notifications, most recently updated
+// first, following pagination until limit are read; limit 0 reads them all
+func (r *GitHubAPIRepository) FetchNotifications(limit int) ([]Notification, error) {
+	url := r.baseURL + "/notifications"
+	if r.perPage > 0 {
+		url += "?per_page=" + strconv.Itoa(r.perPage)
+	}
+
+	var notifications []Notification
+	for url != "" && (limit <= 0 || len(notifications) < limit) {
+		var page []Notification
+		var next string
+		err := r.withRetry(context.Background(), func() error {
+			resp, err := r.get(url)
+			if err != nil {
+				return err
+			}
+			if err := r.notificationStatus(resp, 200); err != nil {
+				return err
+			}
+			if err := json.Unmarshal(resp.Body, &page); err != nil {
+				return &RepositoryError{
+					Code:    ErrInvalidResponse.Code,
+					Message: ErrInvalidResponse.Message,
+					Err:     err,
+				}
+			}
+			next = parseLink(resp.Header.Get("Link"), "next")
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, page...)
+		url = next
+	}
+
+	if limit > 0 && len(notifications) > limit {
+		notifications = notifications[:limit]
+	}
+	return notifications, nil
+}
+
+// MarkNotificationRead marks one notification thread as read
+func (r *GitHubAPIRepository) MarkNotificationRead(id string) error {
+	url := fmt.Sprintf("%s/notifications/threads/%s", r.baseURL, id)
+	return r.withRetry(context.Background(), func() error {
+		resp, err := r.request("PATCH", url, "application/vnd.github.v3+json")
+		if err != nil {
+			return err
+		}
+		return r.notificationStatus(resp, 205)
+	})
+}
@@ -0,0 +1,72 @@
+package github
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGitHubAPIRepository_FetchNotifications(t *testing.T) {
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		if auth == "" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`[
+			{"id": "1", "reason": "mention", "unread": true, "updated_at": "2024-01-02T03:04:05Z",
+			 "subject": {"title": "Fix the build", "type": "PullRequest"},
+			 "repository": {"full_name": "octo/repo"}},
+			{"id": "2", "reason": "subscribed", "unread": true,
+			 "subject": {"title": "v1.0", "type": "Release"},
+			 "repository": {"full_name": "octo/other"}}
+		]`))
+	}))
+	defer server.Close()
+
+	repo := NewGitHubAPIRepository(WithBaseURL(server.URL))
+	if _, err := repo.FetchNotifications(0); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("FetchNotifications() without a token error = %v, want ErrUnauthorized", err)
+	}
+
+	repo.SetToken("secret")
+	notifications, err := repo.FetchNotifications(1)
+	if err != nil {
+		t.Fatalf("FetchNotifications() error = %v", err)
+	}
+	if len(notifications) != 1 {
+		t.Fatalf("Expected 1 notification, got %d", len(notifications))
+	}
+	n := notifications[0]
+	if n.ID != "1" || n.Reason != "mention" || n.Subject.Title != "Fix the build" ||
+		n.Subject.Type != "PullRequest" || n.Repository.FullName != "octo/repo" {
+		t.Errorf("Notification = %+v", n)
+	}
+}
+
+func TestGitHubAPIRepository_MarkNotificationRead(t *testing.T) {
+	var method, path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method, path = r.Method, r.URL.Path
+		if r.URL.Path == "/notifications/threads/404" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusResetContent)
+	}))
+	defer server.Close()
+
+	repo := NewGitHubAPIRepository(WithBaseURL(server.URL))
+	repo.SetToken("secret")
+	if err := repo.MarkNotificationRead("42"); err != nil {
+		t.Fatalf("MarkNotificationRead() error = %v", err)
+	}
+	if method != "PATCH" || path != "/notifications/threads/42" {
+		t.Errorf("Requested %s %s, want PATCH /notifications/threads/42", method, path)
+	}
+	if err := repo.MarkNotificationRead("404"); err == nil {
+		t.Error("MarkNotificationRead() of a missing thread should fail")
+	}
+}
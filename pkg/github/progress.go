@@ -0,0 +1,18 @@
+package github
+
+import "time"
+
+// Progress - How a paginated fetch advances
+
+// FetchProgress is told how an API fetch advances
+type FetchProgress interface {
+	FetchStarted(username string)
+	PageFetched(done, pages int) // pages is 0 while the page count is unknown
+	FetchFinished()
+}
+
+// RetryProgress is implemented by a FetchProgress that wants to know when a
+// request waits before retrying, as GitHub's secondary rate limit asks
+type RetryProgress interface {
+	RetryWaiting(wait time.Duration)
+}
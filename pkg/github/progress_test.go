@@ -0,0 +1,83 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// recordingProgress keeps the progress calls of a fetch
+type recordingProgress struct {
+	mu     sync.Mutex
+	calls  []string
+	events int
+}
+
+func (p *recordingProgress) FetchStarted(username string) {
+	p.record("start " + username)
+}
+
+func (p *recordingProgress) PageFetched(done, pages int) {
+	p.record(fmt.Sprintf("%d/%d", done, pages))
+}
+
+func (p *recordingProgress) FetchFinished() {
+	p.record("finish")
+}
+
+func (p *recordingProgress) RetryWaiting(wait time.Duration) {
+	p.record("wait " + wait.String())
+}
+
+func (p *recordingProgress) record(call string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls = append(p.calls, call)
+}
+
+func TestGitHubAPIRepository_SetProgress(t *testing.T) {
+	const lastPage = 3
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+		if page < lastPage {
+			w.Header().Set("Link", fmt.Sprintf(
+				`<%[1]s%[2]s?page=%[3]d>; rel="next", <%[1]s%[2]s?page=%[4]d>; rel="last"`,
+				server.URL, r.URL.Path, page+1, lastPage,
+			))
+		}
+		_, _ = fmt.Fprintf(w, `[{"id": "%d"}]`, page)
+	}))
+	defer server.Close()
+
+	progress := &recordingProgress{}
+	repo := NewGitHubAPIRepository()
+	repo.baseURL = server.URL
+	repo.SetMaxPages(0)
+	repo.SetProgress(progress)
+
+	if _, err := repo.FetchEvents("octocat"); err != nil {
+		t.Fatalf("FetchEvents() error = %v", err)
+	}
+	expected := "start octocat,1/3,2/3,3/3,finish"
+	if got := strings.Join(progress.calls, ","); got != expected {
+		t.Errorf("Progress = %s, want %s", got, expected)
+	}
+
+	// Cached events need no progress line
+	progress.calls = nil
+	if _, err := repo.FetchEvents("octocat"); err != nil {
+		t.Fatal(err)
+	}
+	if len(progress.calls) != 0 {
+		t.Errorf("Progress reported for a cache hit: %v", progress.calls)
+	}
+}
@@ -0,0 +1,347 @@
+package github
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/alnah/github-activity/internal/messages"
+)
+
+// Event Renderers - Per-type descriptions behind FormatDescription
+
+// EventRenderer describes events of one type. An empty description falls
+// back to the generic "<type> in <repo>" one.
+type EventRenderer interface {
+	Describe(event *GitHubEvent) string
+}
+
+// EventRendererFunc adapts a function to the EventRenderer interface
+type EventRendererFunc func(event *GitHubEvent) string
+
+// Describe calls f(event)
+func (f EventRendererFunc) Describe(event *GitHubEvent) string {
+	return f(event)
+}
+
+// EventRendererRegistry maps event types to their renderers
+type EventRendererRegistry map[EventType]EventRenderer
+
+// eventRenderers is the registry FormatDescription looks up
+var eventRenderers = EventRendererRegistry{
+	EventTypePush:                     EventRendererFunc(describePush),
+	EventTypeCreate:                   EventRendererFunc(describeCreate),
+	EventTypeDelete:                   EventRendererFunc(describeDelete),
+	EventTypeIssues:                   EventRendererFunc(describeIssues),
+	EventTypePullRequest:              EventRendererFunc(describePullRequest),
+	EventTypePullRequestReview:        EventRendererFunc(describePullRequestReview),
+	EventTypePullRequestReviewComment: EventRendererFunc(describePullRequestReviewComment),
+	EventTypePullRequestReviewThread:  EventRendererFunc(describePullRequestReviewThread),
+	EventTypeGollum:                   EventRendererFunc(describeGollum),
+	EventTypeCommitComment:            EventRendererFunc(describeCommitComment),
+	EventTypeSponsorship:              EventRendererFunc(describeSponsorship),
+	EventTypeDeployment:               EventRendererFunc(describeDeployment),
+	EventTypeDeploymentStatus:         EventRendererFunc(describeDeploymentStatus),
+	EventTypeStatus:                   EventRendererFunc(describeStatus),
+	EventTypeCheckRun:                 EventRendererFunc(describeCheckRun),
+	EventTypeWatch:                    EventRendererFunc(describeWatch),
+	EventTypeFork:                     EventRendererFunc(describeFork),
+	EventTypeIssueComment:             EventRendererFunc(describeIssueComment),
+	EventTypePublic:                   EventRendererFunc(describePublic),
+	EventTypeMember:                   EventRendererFunc(describeMember),
+	EventTypeRelease:                  EventRendererFunc(describeRelease),
+}
+
+// RegisterEventRenderer adds or replaces the renderer for an event type.
+// Call it during initialization, before any event is described.
+func RegisterEventRenderer(eventType EventType, renderer EventRenderer) {
+	eventRenderers[eventType] = renderer
+}
+
+// Describe returns the registered description of event, or an empty string
+// when no renderer handles it
+func (r EventRendererRegistry) Describe(event *GitHubEvent) string {
+	renderer, ok := r[EventType(event.Type)]
+	if !ok {
+		return ""
+	}
+	return renderer.Describe(event)
+}
+
+// TemplateRenderer describes events with a user-supplied text/template.
+// The template sees DescriptionFields under capitalized names (.Repo,
+// .Branch, .Size, .RefType...), plus .Type, .Actor and the typed .Payload.
+type TemplateRenderer struct {
+	template *template.Template
+}
+
+// NewTemplateRenderer parses a description template
+func NewTemplateRenderer(text string) (*TemplateRenderer, error) {
+	tmpl, err := template.New("description").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid description template: %w", err)
+	}
+	return &TemplateRenderer{template: tmpl}, nil
+}
+
+// Describe executes the template. A template that fails, such as one naming
+// a field the event lacks, yields an empty description so the built-in one
+// is used instead.
+func (r *TemplateRenderer) Describe(event *GitHubEvent) string {
+	var description bytes.Buffer
+	if err := r.template.Execute(&description, descriptionData(event)); err != nil {
+		return ""
+	}
+	return strings.TrimSpace(description.String())
+}
+
+// descriptionData is what description templates are executed against
+func descriptionData(event *GitHubEvent) map[string]any {
+	data := map[string]any{
+		"Type":    event.Type,
+		"Actor":   event.Actor.Login,
+		"Payload": event.TypedPayload(),
+	}
+	for key, value := range event.DescriptionFields() {
+		data[templateFieldName(key)] = value
+	}
+	return data
+}
+
+// templateFieldName turns a description field such as "ref_type" into the
+// exported-looking name templates use, "RefType"
+func templateFieldName(key string) string {
+	parts := strings.Split(key, "_")
+	for i, part := range parts {
+		parts[i] = titleCase(part)
+	}
+	return strings.Join(parts, "")
+}
+
+// NewTemplateRenderers parses a description template per event type
+func NewTemplateRenderers(templates map[EventType]string) (EventRendererRegistry, error) {
+	renderers := make(EventRendererRegistry, len(templates))
+	for eventType, text := range templates {
+		renderer, err := NewTemplateRenderer(text)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", eventType, err)
+		}
+		renderers[eventType] = renderer
+	}
+	return renderers, nil
+}
+
+func describePush(e *GitHubEvent) string {
+	payload, ok := e.TypedPayload().(*PushPayload)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf(
+		"Pushed %s to %s (branch: %s)",
+		messages.Plural("commits", payload.Size),
+		e.Repo.Name,
+		payload.GetBranch(),
+	)
+}
+
+func describeCreate(e *GitHubEvent) string {
+	payload, ok := e.TypedPayload().(*CreatePayload)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("Created %s '%s' in %s", payload.RefType, payload.Ref, e.Repo.Name)
+}
+
+func describeDelete(e *GitHubEvent) string {
+	payload, ok := e.TypedPayload().(*CreatePayload)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("Deleted %s '%s' in %s", payload.RefType, payload.Ref, e.Repo.Name)
+}
+
+func describeIssues(e *GitHubEvent) string {
+	payload, ok := e.TypedPayload().(*IssuesPayload)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("%s issue #%d in %s: %s",
+		titleCase(payload.Action),
+		payload.Issue.Number,
+		e.Repo.Name,
+		payload.Issue.Title)
+}
+
+func describePullRequest(e *GitHubEvent) string {
+	payload, ok := e.TypedPayload().(*PullRequestPayload)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("%s pull request #%d in %s: %s",
+		titleCase(payload.GetAction()),
+		payload.PullRequest.Number,
+		e.Repo.Name,
+		payload.PullRequest.Title)
+}
+
+func describePullRequestReview(e *GitHubEvent) string {
+	payload, ok := e.TypedPayload().(*PullRequestReviewPayload)
+	if !ok {
+		return ""
+	}
+	if payload.Review.State != "" {
+		return fmt.Sprintf("Reviewed pull request #%d in %s (%s)",
+			payload.PullRequest.Number,
+			e.Repo.Name,
+			strings.ReplaceAll(strings.ToLower(payload.Review.State), "_", " "))
+	}
+	return fmt.Sprintf("Reviewed pull request #%d in %s", payload.PullRequest.Number, e.Repo.Name)
+}
+
+func describePullRequestReviewComment(e *GitHubEvent) string {
+	payload, ok := e.TypedPayload().(*PullRequestReviewCommentPayload)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf(
+		"Commented on review of pull request #%d in %s",
+		payload.PullRequest.Number,
+		e.Repo.Name,
+	)
+}
+
+func describePullRequestReviewThread(e *GitHubEvent) string {
+	payload, ok := e.TypedPayload().(*PullRequestReviewThreadPayload)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("%s a review thread on pull request #%d in %s",
+		titleCase(payload.Action),
+		payload.PullRequest.Number,
+		e.Repo.Name)
+}
+
+func describeGollum(e *GitHubEvent) string {
+	payload, ok := e.TypedPayload().(*GollumPayload)
+	if !ok || len(payload.Pages) == 0 {
+		return fmt.Sprintf("Updated the wiki in %s", e.Repo.Name)
+	}
+	if len(payload.Pages) == 1 {
+		page := payload.Pages[0]
+		return fmt.Sprintf("%s wiki page '%s' in %s",
+			titleCase(page.Action),
+			page.Name(),
+			e.Repo.Name)
+	}
+	return fmt.Sprintf("Updated %s in %s", messages.Plural("wiki_pages", len(payload.Pages)), e.Repo.Name)
+}
+
+func describeCommitComment(e *GitHubEvent) string {
+	payload, ok := e.TypedPayload().(*CommitCommentPayload)
+	if !ok || payload.Comment.CommitID == "" {
+		return fmt.Sprintf("Commented on a commit in %s", e.Repo.Name)
+	}
+	commit := Commit{SHA: payload.Comment.CommitID}
+	return fmt.Sprintf("Commented on commit %s in %s", commit.GetShortSHA(), e.Repo.Name)
+}
+
+func describeSponsorship(e *GitHubEvent) string {
+	payload, ok := e.TypedPayload().(*SponsorshipPayload)
+	if !ok || payload.Sponsorship.Sponsorable.Login == "" {
+		return "Updated a sponsorship"
+	}
+	return fmt.Sprintf("%s sponsorship of %s",
+		titleCase(payload.Action),
+		payload.Sponsorship.Sponsorable.Login)
+}
+
+func describeDeployment(e *GitHubEvent) string {
+	payload, ok := e.TypedPayload().(*DeploymentPayload)
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("Deployed %s to %s in %s",
+		payload.Deployment.Ref,
+		payload.Deployment.Environment,
+		e.Repo.Name)
+}
+
+func describeDeploymentStatus(e *GitHubEvent) string {
+	payload, ok := e.TypedPayload().(*DeploymentStatusPayload)
+	if !ok {
+		return ""
+	}
+	environment := payload.DeploymentStatus.Environment
+	if environment == "" {
+		environment = payload.Deployment.Environment
+	}
+	return fmt.Sprintf("Deployment to %s in %s: %s",
+		environment,
+		e.Repo.Name,
+		payload.DeploymentStatus.State)
+}
+
+func describeStatus(e *GitHubEvent) string {
+	payload, ok := e.TypedPayload().(*StatusPayload)
+	if !ok {
+		return ""
+	}
+	commit := Commit{SHA: payload.SHA}
+	return fmt.Sprintf("Commit %s in %s is %s (%s)",
+		commit.GetShortSHA(),
+		e.Repo.Name,
+		payload.State,
+		payload.Context)
+}
+
+func describeCheckRun(e *GitHubEvent) string {
+	payload, ok := e.TypedPayload().(*CheckRunPayload)
+	if !ok {
+		return ""
+	}
+	result := payload.CheckRun.Conclusion
+	if result == "" {
+		result = payload.CheckRun.Status
+	}
+	return fmt.Sprintf("Check run '%s' in %s: %s",
+		payload.CheckRun.Name,
+		e.Repo.Name,
+		result)
+}
+
+func describeWatch(e *GitHubEvent) string {
+	return fmt.Sprintf("Starred %s", e.Repo.Name)
+}
+
+func describeFork(e *GitHubEvent) string {
+	payload, ok := e.TypedPayload().(*ForkPayload)
+	if !ok || payload.Forkee.FullName == "" {
+		return fmt.Sprintf("Forked %s", e.Repo.Name)
+	}
+	return fmt.Sprintf("Forked %s to %s", e.Repo.Name, payload.Forkee.FullName)
+}
+
+func describeIssueComment(e *GitHubEvent) string {
+	payload, ok := e.TypedPayload().(*IssuesPayload)
+	if !ok {
+		return fmt.Sprintf("Commented on an issue in %s", e.Repo.Name)
+	}
+	return fmt.Sprintf("Commented on issue #%d in %s", payload.Issue.Number, e.Repo.Name)
+}
+
+func describePublic(e *GitHubEvent) string {
+	return fmt.Sprintf("Made %s public", e.Repo.Name)
+}
+
+func describeMember(e *GitHubEvent) string {
+	return fmt.Sprintf("Added a member to %s", e.Repo.Name)
+}
+
+func describeRelease(e *GitHubEvent) string {
+	payload, ok := e.TypedPayload().(*ReleasePayload)
+	if !ok {
+		return fmt.Sprintf("Created a release in %s", e.Repo.Name)
+	}
+	return fmt.Sprintf("Released %s in %s", payload.Release.TagName, e.Repo.Name)
+}
@@ -0,0 +1,92 @@
+package github
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRegisterEventRenderer(t *testing.T) {
+	const custom EventType = "DiscussionEvent"
+	original, builtin := eventRenderers[EventTypeWatch]
+	defer func() {
+		delete(eventRenderers, custom)
+		eventRenderers[EventTypeWatch] = original
+	}()
+
+	discussion := &GitHubEvent{
+		Type:    string(custom),
+		Repo:    Repo{Name: "user/repo"},
+		Payload: json.RawMessage(`{"action":"created"}`),
+	}
+	if got := discussion.FormatDescription(); got != "DiscussionEvent in user/repo" {
+		t.Errorf("Unregistered type = %q, want the generic description", got)
+	}
+
+	RegisterEventRenderer(custom, EventRendererFunc(func(e *GitHubEvent) string {
+		return "Started a discussion in " + e.Repo.Name
+	}))
+	if got := discussion.FormatDescription(); got != "Started a discussion in user/repo" {
+		t.Errorf("Custom renderer = %q", got)
+	}
+
+	if !builtin {
+		t.Fatal("Expected a built-in WatchEvent renderer")
+	}
+	RegisterEventRenderer(EventTypeWatch, EventRendererFunc(func(e *GitHubEvent) string {
+		return "Bookmarked " + e.Repo.Name
+	}))
+	watch := &GitHubEvent{Type: string(EventTypeWatch), Repo: Repo{Name: "user/repo"}}
+	if got := watch.FormatDescription(); got != "Bookmarked user/repo" {
+		t.Errorf("Replaced renderer = %q", got)
+	}
+}
+
+func TestEventRendererRegistry_Describe(t *testing.T) {
+	registry := EventRendererRegistry{
+		EventTypePush: EventRendererFunc(describePush),
+	}
+
+	// A payload that does not parse leaves the description to the caller
+	broken := &GitHubEvent{Type: string(EventTypePush), Payload: json.RawMessage(`{`)}
+	if got := registry.Describe(broken); got != "" {
+		t.Errorf("Describe(broken push) = %q, want empty", got)
+	}
+	if got := registry.Describe(&GitHubEvent{Type: string(EventTypeFork)}); got != "" {
+		t.Errorf("Describe(unregistered) = %q, want empty", got)
+	}
+}
+
+func TestTemplateRenderer_Describe(t *testing.T) {
+	push := &GitHubEvent{
+		Type:    string(EventTypePush),
+		Actor:   Actor{Login: "octocat"},
+		Repo:    Repo{Name: "user/repo"},
+		Payload: json.RawMessage(`{"ref":"refs/heads/main","size":3,"commits":[{"sha":"abc"}]}`),
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{"fields", "🚀 {{.Size}} commits → {{.Repo}}@{{.Branch}}", "🚀 3 commits → user/repo@main"},
+		{"typed payload", "{{.Actor}} pushed {{len .Payload.Commits}} to {{.Payload.Ref}}", "octocat pushed 1 to refs/heads/main"},
+		{"missing field", "{{.Title}} in {{.Repo}}", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			renderer, err := NewTemplateRenderer(tt.template)
+			if err != nil {
+				t.Fatalf("NewTemplateRenderer() error = %v", err)
+			}
+			if got := renderer.Describe(push); got != tt.expected {
+				t.Errorf("Describe() = %q, want %q", got, tt.expected)
+			}
+		})
+	}
+
+	if _, err := NewTemplateRenderers(map[EventType]string{EventTypePush: "{{.Size"}); err == nil {
+		t.Error("Expected an error for an unparsable template")
+	}
+}
@@ -0,0 +1,1388 @@
+// Package github fetches a user's public activity from the GitHub REST and
+// GraphQL APIs and models the events it returns.
+package github
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	neturl "net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Repository Layer - Handles data fetching and caching
+
+// EventRepository interface for fetching GitHub events
+type EventRepository interface {
+	FetchEvents(username string) ([]GitHubEvent, error)
+}
+
+// PagedEventRepository is an EventRepository that can also hand over events
+// one page at a time, newest first, so callers never hold the whole feed
+type PagedEventRepository interface {
+	EventRepository
+	// FetchEventPages calls page with each page of events until the feed
+	// ends, ctx is done, or page returns an error, which it returns as is
+	FetchEventPages(ctx context.Context, username string, page func([]GitHubEvent) error) error
+}
+
+// ContextEventRepository is an EventRepository whose fetches take the
+// caller's context, so their spans nest under the caller's span
+type ContextEventRepository interface {
+	EventRepository
+	FetchEventsContext(ctx context.Context, username string) ([]GitHubEvent, error)
+}
+
+// FetchWithContext fetches username's events from repository, passing ctx
+// along when the repository is a ContextEventRepository
+func FetchWithContext(ctx context.Context, repository EventRepository, username string) ([]GitHubEvent, error) {
+	if aware, ok := repository.(ContextEventRepository); ok {
+		return aware.FetchEventsContext(ctx, username)
+	}
+	return repository.FetchEvents(username)
+}
+
+// GitHubAPIRepository implements EventRepository using GitHub API
+type GitHubAPIRepository struct {
+	client    *http.Client
+	cache     *EventCache
+	userAgent string
+	token     string
+	baseURL   string
+	retry     RetryPolicy
+	recorder  func(RecordedResponse)
+	maxPages  int
+	perPage   int // Events per page; 0 leaves GitHub's default
+	feed      string
+	progress  FetchProgress
+	logger    Logger
+	tracer    Tracer
+	clock     Clock
+
+	mu        sync.Mutex
+	rateLimit *RateLimit
+
+	cacheMu  sync.Mutex            // Guards cache and pages when one repository serves concurrent requests
+	pages    map[string]cachedPage // Recently read events pages, revalidated with their ETag, by URL
+	pageUses uint64                // Orders pages by last use, to evict the least recent
+}
+
+// maxCachedPages bounds how many events pages are kept for revalidation, so
+// a long-running server asked about many users does not keep them all
+const maxCachedPages = 64
+
+// cachedPage is an events page kept with the ETag it was served with, so
+// refetching it can send If-None-Match and reuse it on 304 Not Modified,
+// which GitHub does not count against the rate limit
+type cachedPage struct {
+	etag   string
+	events []GitHubEvent
+	links  pageLinks
+	used   uint64
+}
+
+// cachedPage returns the page kept for url, marking it as just used
+func (r *GitHubAPIRepository) cachedPage(url string) (cachedPage, bool) {
+	r.cacheMu.Lock()
+	defer r.cacheMu.Unlock()
+	page, ok := r.pages[url]
+	if ok {
+		r.pageUses++
+		page.used = r.pageUses
+		r.pages[url] = page
+	}
+	return page, ok
+}
+
+// keepPage stores page for url, evicting the least recently used page once
+// maxCachedPages are kept
+func (r *GitHubAPIRepository) keepPage(url string, page cachedPage) {
+	r.cacheMu.Lock()
+	defer r.cacheMu.Unlock()
+	if r.pages == nil {
+		r.pages = make(map[string]cachedPage)
+	}
+	if _, ok := r.pages[url]; !ok && len(r.pages) >= maxCachedPages {
+		oldest := ""
+		for key, kept := range r.pages {
+			if oldest == "" || kept.used < r.pages[oldest].used {
+				oldest = key
+			}
+		}
+		delete(r.pages, oldest)
+	}
+	r.pageUses++
+	page.used = r.pageUses
+	r.pages[url] = page
+}
+
+// RecordedResponse is a raw API response captured for diagnostics
+type RecordedResponse struct {
+	URL        string
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// EventCache stores fetched events with TTL
+type EventCache struct {
+	data      []GitHubEvent
+	username  string
+	timestamp time.Time
+	ttl       time.Duration
+	clock     Clock // nil reads the wall clock
+}
+
+// RepositoryOption configures a GitHubAPIRepository
+type RepositoryOption func(*GitHubAPIRepository)
+
+// WithRepositoryLogger sets where requests, responses, retries and cache use
+// are logged
+func WithRepositoryLogger(logger Logger) RepositoryOption {
+	return func(r *GitHubAPIRepository) {
+		r.SetLogger(logger)
+	}
+}
+
+// WithRepositoryTracer sets the tracer that receives spans for fetches,
+// cache lookups and HTTP requests
+func WithRepositoryTracer(tracer Tracer) RepositoryOption {
+	return func(r *GitHubAPIRepository) {
+		r.SetTracer(tracer)
+	}
+}
+
+// WithRepositoryClock sets the clock the cache TTL, Retry-After and the
+// pauses between retries are measured with
+func WithRepositoryClock(clock Clock) RepositoryOption {
+	return func(r *GitHubAPIRepository) {
+		r.SetClock(clock)
+	}
+}
+
+// WithHTTPClient sends requests through client, e.g. one with a proxy,
+// custom transport or recording round tripper
+func WithHTTPClient(client *http.Client) RepositoryOption {
+	return func(r *GitHubAPIRepository) {
+		r.client = client
+	}
+}
+
+// WithTimeout sets the timeout of each request. It applies to the client in
+// place when the option runs, so give it after WithHTTPClient.
+func WithTimeout(timeout time.Duration) RepositoryOption {
+	return func(r *GitHubAPIRepository) {
+		client := *r.client
+		client.Timeout = timeout
+		r.client = &client
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request
+func WithUserAgent(userAgent string) RepositoryOption {
+	return func(r *GitHubAPIRepository) {
+		r.userAgent = userAgent
+	}
+}
+
+// WithBaseURL sets the API base URL, e.g. for GitHub Enterprise Server or a
+// test server
+func WithBaseURL(baseURL string) RepositoryOption {
+	return func(r *GitHubAPIRepository) {
+		r.SetBaseURL(baseURL)
+	}
+}
+
+// NewGitHubAPIRepository creates a new repository instance
+func NewGitHubAPIRepository(options ...RepositoryOption) *GitHubAPIRepository {
+	repository := &GitHubAPIRepository{
+		client: &http.Client{
+			Timeout: 10 * time.Second,
+		},
+		cache: &EventCache{
+			ttl: 5 * time.Minute,
+		},
+		userAgent: "github-activity-cli",
+		baseURL:   "https://api.github.com",
+		retry:     DefaultRetryPolicy(),
+		maxPages:  1,
+		feed:      FeedEvents,
+		logger:    NopLogger(),
+		tracer:    NopTracer(),
+		clock:     SystemClock(),
+	}
+	for _, option := range options {
+		option(repository)
+	}
+	return repository
+}
+
+// Event feeds a user exposes
+const (
+	FeedEvents   = "events"          // Events the user performed
+	FeedReceived = "received_events" // Events from people and repositories the user follows
+)
+
+// RetryPolicy controls how transient API failures are retried
+type RetryPolicy struct {
+	MaxRetries int
+	BaseDelay  time.Duration
+	MaxDelay   time.Duration
+}
+
+// DefaultRetryPolicy returns the retry policy used by new repositories
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries: 2,
+		BaseDelay:  500 * time.Millisecond,
+		MaxDelay:   5 * time.Second,
+	}
+}
+
+// Backoff returns the jittered delay before the given retry (starting at 1)
+func (p RetryPolicy) Backoff(retry int) time.Duration {
+	delay := p.BaseDelay << (retry - 1)
+	if delay <= 0 || delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	half := delay / 2
+	if half <= 0 {
+		return delay
+	}
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// SetMaxRetries sets how many times transient failures are retried
+func (r *GitHubAPIRepository) SetMaxRetries(retries int) {
+	r.retry.MaxRetries = retries
+}
+
+// DefaultPageSize is the number of events GitHub returns per page
+const DefaultPageSize = 30
+
+// MaxPageSize is the largest page GitHub serves through per_page
+const MaxPageSize = 100
+
+// SetPerPage sets how many events each page holds, up to MaxPageSize; 0
+// leaves GitHub's default of DefaultPageSize
+func (r *GitHubAPIRepository) SetPerPage(perPage int) {
+	perPage = min(max(perPage, 0), MaxPageSize)
+	if perPage != r.perPage {
+		r.cache.Clear()
+	}
+	r.perPage = perPage
+}
+
+// SetMaxPages sets how many pages of events are fetched; 0 fetches every page
+func (r *GitHubAPIRepository) SetMaxPages(pages int) {
+	if pages != r.maxPages {
+		r.cache.Clear()
+	}
+	r.maxPages = pages
+}
+
+// SetToken authenticates API requests, raising the rate limit and including
+// private events when a user views their own activity
+func (r *GitHubAPIRepository) SetToken(token string) {
+	if token != r.token {
+		r.cacheMu.Lock()
+		r.cache.Clear()
+		r.pages = nil
+		r.cacheMu.Unlock()
+	}
+	r.token = token
+}
+
+// HasToken reports whether API requests are authenticated
+func (r *GitHubAPIRepository) HasToken() bool {
+	return r.token != ""
+}
+
+// AuthenticatedUser returns the login the token belongs to
+func (r *GitHubAPIRepository) AuthenticatedUser() (string, error) {
+	body, err := r.FetchResource("/user")
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve the authenticated user: %w", err)
+	}
+	var user struct {
+		Login string `json:"login"`
+	}
+	if err := json.Unmarshal(body, &user); err != nil || user.Login == "" {
+		return "", fmt.Errorf("failed to resolve the authenticated user: unexpected response")
+	}
+	return user.Login, nil
+}
+
+// SetFeed selects which of the user's feeds FetchEvents reads
+func (r *GitHubAPIRepository) SetFeed(feed string) {
+	if feed != r.feed {
+		r.cache.Clear()
+	}
+	r.feed = feed
+}
+
+// SetBaseURL sets the API base URL, e.g. for GitHub Enterprise Server
+func (r *GitHubAPIRepository) SetBaseURL(baseURL string) {
+	r.baseURL = strings.TrimRight(baseURL, "/")
+}
+
+// SetCacheTTL sets how long fetched events are reused
+func (r *GitHubAPIRepository) SetCacheTTL(ttl time.Duration) {
+	r.cache.SetTTL(ttl)
+}
+
+// Refresh makes the next FetchEvents call hit the API even if the cache is fresh
+func (r *GitHubAPIRepository) Refresh() {
+	r.cacheMu.Lock()
+	defer r.cacheMu.Unlock()
+	r.cache.Expire()
+}
+
+// SetRecorder registers a callback receiving every raw API response
+func (r *GitHubAPIRepository) SetRecorder(recorder func(RecordedResponse)) {
+	r.recorder = recorder
+}
+
+// SetLogger sets where requests, responses, retries and cache use are
+// logged; nil discards them
+func (r *GitHubAPIRepository) SetLogger(logger Logger) {
+	if logger == nil {
+		logger = NopLogger()
+	}
+	r.logger = logger
+}
+
+// SetClock sets the clock the cache TTL, Retry-After and the pauses between
+// retries are measured with; nil uses the wall clock
+func (r *GitHubAPIRepository) SetClock(clock Clock) {
+	if clock == nil {
+		clock = SystemClock()
+	}
+	r.clock = clock
+	r.cache.SetClock(clock)
+}
+
+// SetTracer sets the tracer that receives spans for fetches, cache lookups
+// and HTTP requests; nil records nothing
+func (r *GitHubAPIRepository) SetTracer(tracer Tracer) {
+	if tracer == nil {
+		tracer = NopTracer()
+	}
+	r.tracer = tracer
+}
+
+// SetProgress registers where fetch progress is reported; nil reports nothing
+func (r *GitHubAPIRepository) SetProgress(progress FetchProgress) {
+	r.progress = progress
+}
+
+// LastRateLimit returns the rate-limit state from the most recent response, if any
+func (r *GitHubAPIRepository) LastRateLimit() (RateLimit, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.rateLimit == nil {
+		return RateLimit{}, false
+	}
+	return *r.rateLimit, true
+}
+
+// transientError marks failures that are worth retrying
+type transientError struct {
+	err  error
+	wait time.Duration // Least delay before the retry, as asked by Retry-After
+}
+
+func (e *transientError) Error() string {
+	return e.err.Error()
+}
+
+func (e *transientError) Unwrap() error {
+	return e.err
+}
+
+// isTransientNetworkError reports whether a transport error is likely temporary
+func isTransientNetworkError(err error) bool {
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// FetchEvents fetches events for a given username with caching. When a page
+// after the first fails, the earlier pages' events come with a
+// *PartialFetchError.
+func (r *GitHubAPIRepository) FetchEvents(username string) ([]GitHubEvent, error) {
+	return r.FetchEventsContext(context.Background(), username)
+}
+
+// FetchEventsContext is FetchEvents with its span started from ctx
+func (r *GitHubAPIRepository) FetchEventsContext(
+	ctx context.Context,
+	username string,
+) (events []GitHubEvent, err error) {
+	ctx, span := r.tracer.Start(ctx, SpanFetchEvents)
+	defer func() {
+		span.SetAttributes("user", username, "events", len(events))
+		endSpan(span, err)
+	}()
+
+	// Check cache first
+	if events, ok := r.cached(ctx, username); ok {
+		return events, nil
+	}
+
+	// Fetch from API
+	if r.progress != nil {
+		r.progress.FetchStarted(username)
+	}
+	events, err = r.fetchFromAPI(ctx, username)
+	if r.progress != nil {
+		r.progress.FetchFinished()
+	}
+	if err != nil {
+		// The pages read before a later one failed are returned, not cached
+		return events, err
+	}
+
+	return r.remember(username, events), nil
+}
+
+// cached returns username's events when the cache holds them and is fresh
+func (r *GitHubAPIRepository) cached(ctx context.Context, username string) ([]GitHubEvent, bool) {
+	_, span := r.tracer.Start(ctx, SpanCacheLookup)
+	defer span.End()
+
+	r.cacheMu.Lock()
+	valid := r.cache.IsValid(username)
+	events := r.cache.data
+	r.cacheMu.Unlock()
+
+	span.SetAttributes("user", username, "cache.hit", valid)
+	if !valid {
+		r.logger.Debug(LogCacheMiss, "user", username)
+		return nil, false
+	}
+	r.logger.Debug(LogCacheHit, "user", username, "events", len(events))
+	return events, true
+}
+
+// remember caches a complete fetch of username's feed and returns it
+func (r *GitHubAPIRepository) remember(username string, events []GitHubEvent) []GitHubEvent {
+	r.cacheMu.Lock()
+	defer r.cacheMu.Unlock()
+
+	// Keep older events from the previous fetch that have rolled off the feed
+	if r.cache.username == username {
+		events = MergeEvents(events, r.cache.data)
+	}
+
+	// Update cache
+	r.cache.Update(username, events)
+
+	return events
+}
+
+// FetchEventPages follows the feed's rel="next" links one page at a time,
+// up to the page cap. Events already seen on an earlier page, which shift
+// there as new events are published, are dropped. Fresh cached events are
+// passed as a single page, and the cache is only filled when page lets the
+// whole feed be read.
+func (r *GitHubAPIRepository) FetchEventPages(
+	ctx context.Context,
+	username string,
+	page func([]GitHubEvent) error,
+) (err error) {
+	ctx, span := r.tracer.Start(ctx, SpanFetchEvents)
+	fetched := 0
+	defer func() {
+		span.SetAttributes("user", username, "events", fetched)
+		endSpan(span, err)
+	}()
+
+	if events, ok := r.cached(ctx, username); ok {
+		fetched = len(events)
+		return page(events)
+	}
+
+	if r.progress != nil {
+		r.progress.FetchStarted(username)
+		defer r.progress.FetchFinished()
+	}
+
+	url := r.feedURL(username)
+	seen := make(map[string]bool)
+	var all []GitHubEvent
+	for number := 1; url != "" && (r.maxPages == 0 || number <= r.maxPages); number++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		events, links, err := r.fetchPageWithRetry(ctx, url, username)
+		if number > 1 && errors.Is(err, ErrFeedCapReached) {
+			r.logger.Debug(LogFeedCap, "user", username, "page", number)
+			break
+		}
+		if number > 1 && err != nil {
+			return &PartialFetchError{Page: number, Err: err}
+		}
+		if err != nil {
+			return err
+		}
+		fresh := make([]GitHubEvent, 0, len(events))
+		for _, event := range events {
+			if !seen[event.ID] {
+				seen[event.ID] = true
+				fresh = append(fresh, event)
+			}
+		}
+		r.logger.Debug(LogFetched, "user", username, "page", number, "events", len(fresh))
+		r.pageFetched(number, 0)
+		fetched += len(fresh)
+		if err := page(fresh); err != nil {
+			return err
+		}
+		all = append(all, fresh...)
+		url = links.next
+	}
+	r.remember(username, MergeEvents(all))
+	return nil
+}
+
+// feedURL returns the URL of the first page of username's feed; the Link
+// headers of later pages carry per_page along
+func (r *GitHubAPIRepository) feedURL(username string) string {
+	url := fmt.Sprintf("%s/users/%s/%s", r.baseURL, username, r.feed)
+	if r.perPage > 0 {
+		url += "?per_page=" + strconv.Itoa(r.perPage)
+	}
+	return url
+}
+
+// pageWorkers bounds how many event pages are fetched at once
+const pageWorkers = 4
+
+// fetchFromAPI performs the API call, retrying transient failures with backoff.
+// The first page tells how many pages exist; the rest are fetched concurrently.
+func (r *GitHubAPIRepository) fetchFromAPI(ctx context.Context, username string) ([]GitHubEvent, error) {
+	url := r.feedURL(username)
+
+	first, links, err := r.fetchPageWithRetry(ctx, url, username)
+	if err != nil {
+		return nil, err
+	}
+	if links.next == "" || r.maxPages == 1 {
+		return MergeEvents(first), nil
+	}
+
+	urls := pageURLs(links, r.maxPages)
+	if urls == nil {
+		// Without a rel="last" link the page count is unknown, so follow
+		// rel="next" one page at a time
+		r.pageFetched(1, 0)
+		return r.fetchSequentially(ctx, first, links.next, username)
+	}
+
+	var doneMu sync.Mutex
+	done := 1
+	r.pageFetched(done, len(urls)+1)
+
+	pages := make([][]GitHubEvent, len(urls))
+	errs := make([]error, len(urls))
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < pageWorkers && w < len(urls); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				pages[i], _, errs[i] = r.fetchPageWithRetry(ctx, urls[i], username)
+				doneMu.Lock()
+				done++
+				r.pageFetched(done, len(urls)+1)
+				doneMu.Unlock()
+			}
+		}()
+	}
+	for i := range urls {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	events := first
+	for i := range urls {
+		if errors.Is(errs[i], ErrFeedCapReached) {
+			r.logger.Debug(LogFeedCap, "user", username, "page", i+2)
+			break
+		}
+		if errs[i] != nil {
+			return MergeEvents(events), &PartialFetchError{Page: i + 2, Err: errs[i]}
+		}
+		events = append(events, pages[i]...)
+	}
+
+	// Events published while paging shift later pages, so the same event
+	// can show up twice
+	return MergeEvents(events), nil
+}
+
+// fetchSequentially follows rel="next" links after the first page
+func (r *GitHubAPIRepository) fetchSequentially(
+	ctx context.Context,
+	events []GitHubEvent,
+	url string,
+	username string,
+) ([]GitHubEvent, error) {
+	for page := 2; url != "" && (r.maxPages == 0 || page <= r.maxPages); page++ {
+		pageEvents, links, err := r.fetchPageWithRetry(ctx, url, username)
+		if errors.Is(err, ErrFeedCapReached) {
+			r.logger.Debug(LogFeedCap, "user", username, "page", page)
+			break
+		}
+		if err != nil {
+			return MergeEvents(events), &PartialFetchError{Page: page, Err: err}
+		}
+		events = append(events, pageEvents...)
+		url = links.next
+		r.pageFetched(page, 0)
+	}
+	return MergeEvents(events), nil
+}
+
+// pageFetched reports that done of pages pages have been fetched
+func (r *GitHubAPIRepository) pageFetched(done, pages int) {
+	if r.progress != nil {
+		r.progress.PageFetched(done, pages)
+	}
+}
+
+// fetchPageWithRetry fetches one page, retrying transient failures
+func (r *GitHubAPIRepository) fetchPageWithRetry(
+	ctx context.Context,
+	url string,
+	username string,
+) ([]GitHubEvent, pageLinks, error) {
+	var events []GitHubEvent
+	var links pageLinks
+	err := r.withRetry(ctx, func() error {
+		var err error
+		events, links, err = r.fetchPage(ctx, url, username)
+		return err
+	})
+	return events, links, err
+}
+
+// pageURLs lists the URLs of pages 2 through the last page, capped at
+// maxPages (0 = no cap). It returns nil when the last page is unknown.
+func pageURLs(links pageLinks, maxPages int) []string {
+	last, err := neturl.Parse(links.last)
+	if links.last == "" || err != nil {
+		return nil
+	}
+	lastPage, err := strconv.Atoi(last.Query().Get("page"))
+	if err != nil {
+		return nil
+	}
+	if maxPages > 0 && lastPage > maxPages {
+		lastPage = maxPages
+	}
+
+	urls := make([]string, 0, lastPage)
+	for page := 2; page <= lastPage; page++ {
+		query := last.Query()
+		query.Set("page", strconv.Itoa(page))
+		pageURL := *last
+		pageURL.RawQuery = query.Encode()
+		urls = append(urls, pageURL.String())
+	}
+	return urls
+}
+
+// withRetry runs fn, retrying it while it fails with a transient error. The
+// pauses between attempts end early with ctx's error when ctx is done.
+func (r *GitHubAPIRepository) withRetry(ctx context.Context, fn func() error) error {
+	var lastErr error
+	var wait time.Duration
+	for attempt := 0; attempt <= r.retry.MaxRetries; attempt++ {
+		if attempt > 0 {
+			if err := sleepContext(ctx, r.clock, max(r.retry.Backoff(attempt), wait)); err != nil {
+				return err
+			}
+		}
+
+		err := fn()
+		if err == nil {
+			return nil
+		}
+
+		var transient *transientError
+		if !errors.As(err, &transient) {
+			return err
+		}
+		lastErr, wait = transient.err, transient.wait
+		if attempt < r.retry.MaxRetries {
+			r.logger.Warn(LogRetry, "attempt", attempt+1, "error", lastErr, "wait", wait)
+			if progress, ok := r.progress.(RetryProgress); ok && wait > 0 {
+				progress.RetryWaiting(wait)
+			}
+		}
+	}
+
+	if r.retry.MaxRetries > 0 {
+		return fmt.Errorf("giving up after %d retries: %w", r.retry.MaxRetries, lastErr)
+	}
+	return lastErr
+}
+
+// apiResponse is an API response, read in full unless it was decoded as it
+// arrived
+type apiResponse struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte // nil when a decoder read it
+}
+
+// get performs a single GET request and reads the whole body
+func (r *GitHubAPIRepository) get(url string) (*apiResponse, error) {
+	return r.getAs(url, "application/vnd.github.v3+json")
+}
+
+// getAs is get asking for the accept media type, which some endpoints use
+// to add fields to their response
+func (r *GitHubAPIRepository) getAs(url, accept string) (*apiResponse, error) {
+	return r.request("GET", url, accept)
+}
+
+// request performs a single request without a body and reads the whole
+// response body
+func (r *GitHubAPIRepository) request(method, url, accept string) (*apiResponse, error) {
+	return r.requestContext(context.Background(), method, url, accept, "", nil)
+}
+
+// requestContext is request traced as a child of ctx's span and cancelled
+// with ctx. A non-empty etag is sent as If-None-Match. A 200 response body
+// is handed to decode, when set, as it is downloaded rather than read into
+// memory first.
+func (r *GitHubAPIRepository) requestContext(
+	ctx context.Context,
+	method, url, accept, etag string,
+	decode func(body io.Reader),
+) (_ *apiResponse, err error) {
+	ctx, span := r.tracer.Start(ctx, SpanHTTPRequest)
+	span.SetAttributes("http.method", method, "http.url", url)
+	defer func() { endSpan(span, err) }()
+
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Add headers
+	req.Header.Set("Accept", accept)
+	req.Header.Set("Accept-Encoding", "gzip")
+	req.Header.Set("User-Agent", r.userAgent)
+	if r.token != "" {
+		req.Header.Set("Authorization", "Bearer "+r.token)
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	r.logger.Debug(LogRequest, "url", url)
+	httpResp, err := r.client.Do(req)
+	if err != nil {
+		r.logger.Warn(LogRequest, "url", url, "error", err)
+		return nil, networkError("failed to fetch data", err)
+	}
+	defer func() { _ = httpResp.Body.Close() }()
+	span.SetAttributes("http.status_code", httpResp.StatusCode)
+
+	// Asking for gzip ourselves turns off the transport's transparent
+	// decompression, which custom RoundTrippers may not have anyway
+	header := httpResp.Header
+	var compressed io.Reader = httpResp.Body
+	if strings.EqualFold(header.Get("Content-Encoding"), "gzip") {
+		unzipped, err := gzip.NewReader(httpResp.Body)
+		if err != nil && err != io.EOF {
+			return nil, networkError("failed to read response body", err)
+		}
+		if unzipped != nil {
+			defer func() { _ = unzipped.Close() }()
+			compressed = unzipped
+		} else {
+			compressed = http.NoBody
+		}
+		header = header.Clone()
+		header.Del("Content-Encoding")
+		header.Del("Content-Length")
+	}
+	reader := &bodyReader{r: compressed}
+
+	// Pages and enrichment lookups are fetched concurrently
+	r.mu.Lock()
+	recorder := r.recorder
+	r.mu.Unlock()
+
+	var body []byte
+	if decode != nil && httpResp.StatusCode == http.StatusOK && recorder == nil {
+		decode(reader)
+		// Drain what the decoder left so the connection can be reused
+		_, _ = io.Copy(io.Discard, reader)
+	} else {
+		body, _ = io.ReadAll(reader)
+	}
+	if reader.err != nil {
+		return nil, networkError("failed to read response body", reader.err)
+	}
+
+	if recorder != nil {
+		r.mu.Lock()
+		recorder(RecordedResponse{
+			URL:        url,
+			StatusCode: httpResp.StatusCode,
+			Header:     header.Clone(),
+			Body:       body,
+		})
+		r.mu.Unlock()
+		if decode != nil && httpResp.StatusCode == http.StatusOK {
+			decode(bytes.NewReader(body))
+		}
+	}
+
+	attrs := []any{"url", url, "status", httpResp.StatusCode, "bytes", reader.n}
+	if rateLimit, ok := parseRateLimit(httpResp.Header); ok {
+		attrs = append(attrs,
+			"rate_remaining", rateLimit.Remaining,
+			"rate_limit", rateLimit.Limit,
+			"rate_reset", rateLimit.Reset)
+		r.mu.Lock()
+		r.rateLimit = &rateLimit
+		r.mu.Unlock()
+	}
+	r.logger.Debug(LogResponse, attrs...)
+
+	if httpResp.StatusCode >= 500 {
+		return nil, &transientError{err: statusError(httpResp.StatusCode)}
+	}
+
+	return &apiResponse{
+		StatusCode: httpResp.StatusCode,
+		Header:     header,
+		Body:       body,
+	}, nil
+}
+
+// bodyReader counts the bytes read from a response body and keeps the first
+// read error, so a dropped connection is not mistaken for malformed JSON
+type bodyReader struct {
+	r   io.Reader
+	n   int
+	err error
+}
+
+func (b *bodyReader) Read(p []byte) (int, error) {
+	n, err := b.r.Read(p)
+	b.n += n
+	if err != nil && err != io.EOF && b.err == nil {
+		b.err = err
+	}
+	return n, err
+}
+
+// pageLinks holds the pagination URLs from a Link header
+type pageLinks struct {
+	next string
+	last string
+}
+
+// fetchPage fetches a single page of events and its pagination links. A page
+// read before is requested with its ETag and reused when GitHub answers 304.
+func (r *GitHubAPIRepository) fetchPage(
+	ctx context.Context,
+	url string,
+	username string,
+) ([]GitHubEvent, pageLinks, error) {
+	cached, hasCached := r.cachedPage(url)
+
+	var events []GitHubEvent
+	var skipped []skippedEvent
+	var decodeErr error
+	resp, err := r.requestContext(ctx, "GET", url, "application/vnd.github.v3+json", cached.etag, func(body io.Reader) {
+		events, skipped, decodeErr = decodeEvents(body)
+	})
+	if err != nil {
+		return nil, pageLinks{}, err
+	}
+
+	// Handle common HTTP errors
+	switch resp.StatusCode {
+	case 304:
+		if hasCached {
+			return slices.Clone(cached.events), cached.links, nil
+		}
+	case 404:
+		return nil, pageLinks{}, r.userNotFound(ctx, username)
+	case 401:
+		return nil, pageLinks{}, ErrUnauthorized
+	case 403, 429:
+		return nil, pageLinks{}, newRateLimitError(resp.Header, r.clock.Now())
+	case 422:
+		// GitHub refuses pages past the end of the feed it exposes
+		return nil, pageLinks{}, ErrFeedCapReached
+	}
+
+	if resp.StatusCode == 202 || errors.Is(decodeErr, errEmptyBody) {
+		return nil, pageLinks{}, &transientError{err: ErrDataPending}
+	}
+	if resp.StatusCode != 200 {
+		return nil, pageLinks{}, statusError(resp.StatusCode)
+	}
+
+	if decodeErr != nil {
+		return nil, pageLinks{}, &RepositoryError{
+			Code:    ErrInvalidResponse.Code,
+			Message: ErrInvalidResponse.Message,
+			Err:     decodeErr,
+		}
+	}
+	for _, skip := range skipped {
+		r.logger.Warn(LogSkippedEvent, "url", url, "index", skip.Index, "error", skip.Err)
+	}
+
+	link := resp.Header.Get("Link")
+	links := pageLinks{next: parseLink(link, "next"), last: parseLink(link, "last")}
+	if etag := resp.Header.Get("ETag"); etag != "" {
+		r.keepPage(url, cachedPage{etag: etag, events: slices.Clone(events), links: links})
+	}
+	return events, links, nil
+}
+
+// skippedEvent is an entry of an events page that could not be decoded
+type skippedEvent struct {
+	Index int // Position in the page
+	Err   error
+}
+
+// errEmptyBody is returned by decodeEvents for a response with no body at all,
+// which GitHub sends while it is still preparing a feed
+var errEmptyBody = errors.New("empty response body")
+
+// decodeEvents reads a page of events one entry at a time, so an entry that
+// does not decode is skipped and reported instead of losing the whole page.
+// Only a body that is not a JSON array is an error.
+func decodeEvents(body io.Reader) ([]GitHubEvent, []skippedEvent, error) {
+	decoder := json.NewDecoder(body)
+	if token, err := decoder.Token(); err == io.EOF {
+		return nil, nil, errEmptyBody
+	} else if err != nil {
+		return nil, nil, err
+	} else if token == nil {
+		return nil, nil, nil
+	} else if token != json.Delim('[') {
+		return nil, nil, fmt.Errorf("expected an array of events, got %v", token)
+	}
+
+	var events []GitHubEvent
+	var skipped []skippedEvent
+	for index := 0; decoder.More(); index++ {
+		var raw json.RawMessage
+		if err := decoder.Decode(&raw); err != nil {
+			return nil, nil, err
+		}
+		var event GitHubEvent
+		if err := json.Unmarshal(raw, &event); err != nil {
+			skipped = append(skipped, skippedEvent{Index: index, Err: err})
+			continue
+		}
+		events = append(events, event)
+	}
+	if _, err := decoder.Token(); err != nil {
+		return nil, nil, err
+	}
+	return events, skipped, nil
+}
+
+// parseLink extracts the URL with the given rel from a Link header
+func parseLink(link, rel string) string {
+	for _, part := range strings.Split(link, ",") {
+		target, params, ok := strings.Cut(strings.TrimSpace(part), ";")
+		if !ok || !strings.Contains(params, `rel="`+rel+`"`) {
+			continue
+		}
+		return strings.Trim(strings.TrimSpace(target), "<>")
+	}
+	return ""
+}
+
+// FetchResource fetches an arbitrary API path such as /repos/{owner}/{repo}
+func (r *GitHubAPIRepository) FetchResource(path string) ([]byte, error) {
+	var body []byte
+	err := r.withRetry(context.Background(), func() error {
+		resp, err := r.get(r.baseURL + path)
+		if err != nil {
+			return err
+		}
+		switch resp.StatusCode {
+		case 200:
+		case 202:
+			return &transientError{err: ErrDataPending}
+		case 401:
+			return ErrUnauthorized
+		case 403, 429:
+			return newRateLimitError(resp.Header, r.clock.Now())
+		default:
+			return fmt.Errorf("%s: %w", path, statusError(resp.StatusCode))
+		}
+		body = resp.Body
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// IsValid checks if the cache is valid for the given username
+func (c *EventCache) IsValid(username string) bool {
+	if c.username != username {
+		return false
+	}
+	return c.now().Sub(c.timestamp) < c.ttl
+}
+
+// Update updates the cache with new data
+func (c *EventCache) Update(username string, events []GitHubEvent) {
+	c.username = username
+	c.data = events
+	c.timestamp = c.now()
+}
+
+// SetClock sets the clock the TTL is measured with
+func (c *EventCache) SetClock(clock Clock) {
+	c.clock = clock
+}
+
+// now is the cache's current time
+func (c *EventCache) now() time.Time {
+	if c.clock == nil {
+		return time.Now()
+	}
+	return c.clock.Now()
+}
+
+// Clear clears the cache
+func (c *EventCache) Clear() {
+	c.username = ""
+	c.data = nil
+	c.timestamp = time.Time{}
+}
+
+// Expire marks the cached events stale while keeping them for merging
+func (c *EventCache) Expire() {
+	c.timestamp = time.Time{}
+}
+
+// SetTTL sets the cache TTL duration
+func (c *EventCache) SetTTL(ttl time.Duration) {
+	c.ttl = ttl
+}
+
+// DiskCache stores raw API responses on disk with a TTL, shared across runs
+type DiskCache struct {
+	dir   string
+	ttl   time.Duration
+	clock Clock
+}
+
+// NewDiskCache creates a disk cache rooted at dir
+func NewDiskCache(dir string, ttl time.Duration) *DiskCache {
+	return &DiskCache{
+		dir:   dir,
+		ttl:   ttl,
+		clock: SystemClock(),
+	}
+}
+
+// SetClock sets the clock entries are aged with
+func (c *DiskCache) SetClock(clock Clock) {
+	c.clock = clock
+}
+
+// DefaultCacheDir returns the per-user cache directory for the CLI
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate cache directory: %w", err)
+	}
+	return filepath.Join(dir, "github-activity"), nil
+}
+
+// path returns the file used to store the given key
+func (c *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// Get returns the cached data for key if present and not expired
+func (c *DiskCache) Get(key string) ([]byte, bool) {
+	path := c.path(key)
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, false
+	}
+	if c.ttl > 0 && c.clock.Now().Sub(info.ModTime()) >= c.ttl {
+		return nil, false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	return data, true
+}
+
+// Set stores data under key, replacing the file atomically
+func (c *DiskCache) Set(key string, data []byte) error {
+	if err := os.MkdirAll(c.dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(c.dir, "entry-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to create cache entry: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	return os.Rename(tmp.Name(), c.path(key))
+}
+
+// MockEventRepository is a mock implementation for testing
+type MockEventRepository struct {
+	events []GitHubEvent
+	err    error
+}
+
+// NewMockEventRepository creates a new mock repository
+func NewMockEventRepository(events []GitHubEvent, err error) *MockEventRepository {
+	return &MockEventRepository{
+		events: events,
+		err:    err,
+	}
+}
+
+// FetchEvents returns the mocked events or error
+func (m *MockEventRepository) FetchEvents(username string) ([]GitHubEvent, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.events, nil
+}
+
+// RepositoryError represents repository-specific errors
+type RepositoryError struct {
+	Code       string
+	Message    string
+	Err        error
+	RateLimit  *RateLimit
+	RetryAfter time.Duration // Pause asked for by a secondary rate limit
+}
+
+func (e *RepositoryError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %s (%v)", e.Code, e.Message, e.Err)
+	}
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+func (e *RepositoryError) Unwrap() error {
+	return e.Err
+}
+
+// Is matches any RepositoryError with the same code, so
+// errors.Is(err, ErrUserNotFound) holds whatever the message says
+func (e *RepositoryError) Is(target error) bool {
+	t, ok := target.(*RepositoryError)
+	return ok && t.Code == e.Code
+}
+
+// networkError wraps a transport failure as NETWORK_ERROR, marked for retry
+// when it looks temporary
+func networkError(message string, err error) error {
+	repoErr := &RepositoryError{Code: ErrNetworkError.Code, Message: message, Err: err}
+	if isTransientNetworkError(err) {
+		return &transientError{err: repoErr}
+	}
+	return repoErr
+}
+
+// statusError reports an unexpected HTTP status as API_ERROR
+func statusError(status int) *RepositoryError {
+	return &RepositoryError{
+		Code:    ErrAPIError.Code,
+		Message: fmt.Sprintf("API returned status code: %d", status),
+	}
+}
+
+// RateLimit holds the rate-limit state reported by the API
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// parseRateLimit reads the X-RateLimit-* headers; ok is false when they are absent
+func parseRateLimit(header http.Header) (RateLimit, bool) {
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return RateLimit{}, false
+	}
+
+	rateLimit := RateLimit{Remaining: remaining}
+	if limit, err := strconv.Atoi(header.Get("X-RateLimit-Limit")); err == nil {
+		rateLimit.Limit = limit
+	}
+	if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		rateLimit.Reset = time.Unix(reset, 0)
+	}
+	return rateLimit, true
+}
+
+// RetryAfterLimit is the longest Retry-After a request waits out by itself;
+// longer pauses are reported instead
+const RetryAfterLimit = time.Minute
+
+// parseRetryAfter reads a Retry-After header, in seconds or as an HTTP date
+func parseRetryAfter(header http.Header, now time.Time) (time.Duration, bool) {
+	value := strings.TrimSpace(header.Get("Retry-After"))
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0), true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		return max(at.Sub(now).Round(time.Second), 0), true
+	}
+	return 0, false
+}
+
+// newRateLimitError builds a RATE_LIMIT error that tells when the limit
+// resets. A Retry-After header marks a secondary rate limit, which is
+// retried after the pause when it is short and otherwise says how long to wait.
+func newRateLimitError(header http.Header, now time.Time) error {
+	if wait, ok := parseRetryAfter(header, now); ok {
+		err := &RepositoryError{
+			Code:       ErrRateLimitExceeded.Code,
+			Message:    fmt.Sprintf("secondary rate limit exceeded, retry in %s", wait),
+			RetryAfter: wait,
+		}
+		if wait <= RetryAfterLimit {
+			return &transientError{err: err, wait: wait}
+		}
+		return err
+	}
+
+	err := &RepositoryError{
+		Code:    ErrRateLimitExceeded.Code,
+		Message: ErrRateLimitExceeded.Message,
+	}
+
+	if rateLimit, ok := parseRateLimit(header); ok {
+		err.RateLimit = &rateLimit
+		if !rateLimit.Reset.IsZero() {
+			err.Message = fmt.Sprintf(
+				"%s, resets at %s",
+				err.Message,
+				rateLimit.Reset.Local().Format("15:04"),
+			)
+		}
+	}
+	return err
+}
+
+// PartialFetchError reports that a page after the first could not be
+// fetched. FetchEvents returns it with the events of the pages read before
+// it; FetchEventPages returns it once those pages have been passed on.
+type PartialFetchError struct {
+	Page int // The page that failed
+	Err  error
+}
+
+func (e *PartialFetchError) Error() string {
+	return fmt.Sprintf("page %d: %v", e.Page, e.Err)
+}
+
+func (e *PartialFetchError) Unwrap() error {
+	return e.Err
+}
+
+// Common repository errors
+var (
+	ErrUserNotFound = &RepositoryError{
+		Code:    "USER_NOT_FOUND",
+		Message: "User not found",
+	}
+	ErrRateLimitExceeded = &RepositoryError{
+		Code:    "RATE_LIMIT",
+		Message: "API rate limit exceeded",
+	}
+	ErrNetworkError = &RepositoryError{
+		Code:    "NETWORK_ERROR",
+		Message: "Network error occurred",
+	}
+	ErrUnauthorized = &RepositoryError{
+		Code:    "UNAUTHORIZED",
+		Message: "authentication required",
+	}
+	ErrAPIError = &RepositoryError{
+		Code:    "API_ERROR",
+		Message: "Unexpected API response",
+	}
+	ErrInvalidResponse = &RepositoryError{
+		Code:    "INVALID_RESPONSE",
+		Message: "failed to parse JSON",
+	}
+	ErrNotArchived = &RepositoryError{
+		Code:    "NOT_ARCHIVED",
+		Message: "No archived events",
+	}
+	ErrOffline = &RepositoryError{
+		Code:    "OFFLINE",
+		Message: "No stored events",
+	}
+	ErrFeedCapReached = &RepositoryError{
+		Code:    "FEED_CAP",
+		Message: "GitHub only exposes the latest 300 events",
+	}
+	// GitHub answers 202, or with an empty body, while it computes data it
+	// has not cached yet
+	ErrDataPending = &RepositoryError{
+		Code:    "DATA_PENDING",
+		Message: "GitHub is still preparing this data",
+	}
+)
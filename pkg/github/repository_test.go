@@ -0,0 +1,1342 @@
+package github
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestEventCache_IsValid(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC))
+	cache := &EventCache{
+		ttl:   5 * time.Minute,
+		clock: clock,
+	}
+
+	t.Run("empty cache is invalid", func(t *testing.T) {
+		if cache.IsValid("testuser") {
+			t.Error("Empty cache should be invalid")
+		}
+	})
+
+	t.Run("cache with different username is invalid", func(t *testing.T) {
+		cache.Update("user1", []GitHubEvent{})
+		if cache.IsValid("user2") {
+			t.Error("Cache should be invalid for different username")
+		}
+	})
+
+	t.Run("fresh cache is valid", func(t *testing.T) {
+		cache.Update("testuser", []GitHubEvent{})
+		if !cache.IsValid("testuser") {
+			t.Error("Fresh cache should be valid")
+		}
+	})
+
+	t.Run("expired cache is invalid", func(t *testing.T) {
+		cache.Update("testuser", []GitHubEvent{})
+		clock.Advance(6 * time.Minute)
+		if cache.IsValid("testuser") {
+			t.Error("Expired cache should be invalid")
+		}
+	})
+}
+
+func TestEventCache_Update(t *testing.T) {
+	cache := &EventCache{
+		ttl: 5 * time.Minute,
+	}
+
+	events := []GitHubEvent{
+		{ID: "1", Type: "PushEvent"},
+		{ID: "2", Type: "WatchEvent"},
+	}
+
+	cache.Update("testuser", events)
+
+	if cache.username != "testuser" {
+		t.Errorf("Cache username = %v, want %v", cache.username, "testuser")
+	}
+
+	if len(cache.data) != 2 {
+		t.Errorf("Cache data length = %v, want %v", len(cache.data), 2)
+	}
+
+	if cache.timestamp.IsZero() {
+		t.Error("Cache timestamp should be set")
+	}
+}
+
+func TestEventCache_Clear(t *testing.T) {
+	cache := &EventCache{
+		username:  "testuser",
+		data:      []GitHubEvent{{ID: "1"}},
+		timestamp: time.Now(),
+		ttl:       5 * time.Minute,
+	}
+
+	cache.Clear()
+
+	if cache.username != "" {
+		t.Error("Cache username should be empty after clear")
+	}
+
+	if cache.data != nil {
+		t.Error("Cache data should be nil after clear")
+	}
+
+	if !cache.timestamp.IsZero() {
+		t.Error("Cache timestamp should be zero after clear")
+	}
+}
+
+func TestEventCache_Expire(t *testing.T) {
+	cache := &EventCache{
+		username:  "testuser",
+		data:      []GitHubEvent{{ID: "1"}},
+		timestamp: time.Now(),
+		ttl:       5 * time.Minute,
+	}
+
+	cache.Expire()
+
+	if cache.IsValid("testuser") {
+		t.Error("Cache should be stale after expire")
+	}
+
+	if len(cache.data) != 1 {
+		t.Error("Cache data should be kept after expire")
+	}
+}
+
+func TestEventCache_SetTTL(t *testing.T) {
+	cache := &EventCache{}
+
+	newTTL := 10 * time.Minute
+	cache.SetTTL(newTTL)
+
+	if cache.ttl != newTTL {
+		t.Errorf("Cache TTL = %v, want %v", cache.ttl, newTTL)
+	}
+}
+
+func TestMockEventRepository(t *testing.T) {
+	t.Run("returns events when no error", func(t *testing.T) {
+		expectedEvents := []GitHubEvent{
+			{ID: "1", Type: "PushEvent"},
+			{ID: "2", Type: "IssuesEvent"},
+		}
+
+		repo := NewMockEventRepository(expectedEvents, nil)
+		events, err := repo.FetchEvents("testuser")
+		if err != nil {
+			t.Fatalf("FetchEvents() error = %v", err)
+		}
+
+		if len(events) != len(expectedEvents) {
+			t.Errorf("FetchEvents() returned %d events, want %d", len(events), len(expectedEvents))
+		}
+	})
+
+	t.Run("returns error when configured", func(t *testing.T) {
+		expectedErr := errors.New("test error")
+		repo := NewMockEventRepository(nil, expectedErr)
+
+		_, err := repo.FetchEvents("testuser")
+
+		if err != expectedErr {
+			t.Errorf("FetchEvents() error = %v, want %v", err, expectedErr)
+		}
+	})
+}
+
+func TestRepositoryError(t *testing.T) {
+	t.Run("error without wrapped error", func(t *testing.T) {
+		err := &RepositoryError{
+			Code:    "TEST_ERROR",
+			Message: "Test error message",
+		}
+
+		expected := "TEST_ERROR: Test error message"
+		if err.Error() != expected {
+			t.Errorf("Error() = %v, want %v", err.Error(), expected)
+		}
+
+		if err.Unwrap() != nil {
+			t.Error("Unwrap() should return nil when no wrapped error")
+		}
+	})
+
+	t.Run("error with wrapped error", func(t *testing.T) {
+		wrappedErr := errors.New("wrapped error")
+		err := &RepositoryError{
+			Code:    "TEST_ERROR",
+			Message: "Test error message",
+			Err:     wrappedErr,
+		}
+
+		expected := "TEST_ERROR: Test error message (wrapped error)"
+		if err.Error() != expected {
+			t.Errorf("Error() = %v, want %v", err.Error(), expected)
+		}
+
+		if err.Unwrap() != wrappedErr {
+			t.Error("Unwrap() should return the wrapped error")
+		}
+	})
+
+	t.Run("matches sentinels by code", func(t *testing.T) {
+		err := fmt.Errorf("context: %w", &RepositoryError{Code: ErrUserNotFound.Code, Message: "user 'x' not found"})
+		if !errors.Is(err, ErrUserNotFound) {
+			t.Error("errors.Is() should match ErrUserNotFound by code")
+		}
+		if errors.Is(err, ErrRateLimitExceeded) {
+			t.Error("errors.Is() should not match a different code")
+		}
+	})
+}
+
+func TestGitHubAPIRepository_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		expected *RepositoryError
+	}{
+		{"not found", http.StatusNotFound, "", ErrUserNotFound},
+		{"unauthorized", http.StatusUnauthorized, "", ErrUnauthorized},
+		{"rate limited", http.StatusForbidden, "", ErrRateLimitExceeded},
+		{"server error", http.StatusServiceUnavailable, "", ErrAPIError},
+		{"unexpected status", http.StatusTeapot, "", ErrAPIError},
+		{"invalid JSON", http.StatusOK, "{", ErrInvalidResponse},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			repo := NewGitHubAPIRepository()
+			repo.baseURL = server.URL
+			repo.retry = RetryPolicy{}
+
+			_, err := repo.FetchEvents("octocat")
+			if !errors.Is(err, tt.expected) {
+				t.Errorf("FetchEvents() error = %v, want %s", err, tt.expected.Code)
+			}
+		})
+	}
+
+	t.Run("network", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+
+		repo := NewGitHubAPIRepository()
+		repo.baseURL = server.URL
+		repo.retry = RetryPolicy{}
+
+		if _, err := repo.FetchEvents("octocat"); !errors.Is(err, ErrNetworkError) {
+			t.Errorf("FetchEvents() error = %v, want %s", err, ErrNetworkError.Code)
+		}
+	})
+}
+
+func TestNewGitHubAPIRepository(t *testing.T) {
+	repo := NewGitHubAPIRepository()
+
+	if repo.client == nil {
+		t.Error("Repository client should not be nil")
+	}
+
+	if repo.client.Timeout != 10*time.Second {
+		t.Errorf("Client timeout = %v, want %v", repo.client.Timeout, 10*time.Second)
+	}
+
+	if repo.cache == nil {
+		t.Error("Repository cache should not be nil")
+	}
+
+	if repo.cache.ttl != 5*time.Minute {
+		t.Errorf("Cache TTL = %v, want %v", repo.cache.ttl, 5*time.Minute)
+	}
+
+	if repo.userAgent != "github-activity-cli" {
+		t.Errorf("User agent = %v, want %v", repo.userAgent, "github-activity-cli")
+	}
+}
+
+// Integration test for caching behavior
+func TestGitHubAPIRepository_Caching(t *testing.T) {
+	// This would require mocking the HTTP client for a real test
+	// For now, we'll test the caching logic structure
+
+	repo := NewGitHubAPIRepository()
+
+	// Simulate cache update
+	testEvents := []GitHubEvent{
+		{ID: "1", Type: "PushEvent"},
+	}
+	repo.cache.Update("testuser", testEvents)
+
+	// Verify cache is used when valid
+	if !repo.cache.IsValid("testuser") {
+		t.Error("Cache should be valid immediately after update")
+	}
+
+	// Verify cache data
+	if len(repo.cache.data) != 1 {
+		t.Errorf("Cache should contain 1 event, got %d", len(repo.cache.data))
+	}
+}
+
+func TestGitHubAPIRepository_CachedPagesBounded(t *testing.T) {
+	repo := NewGitHubAPIRepository()
+	url := func(i int) string { return fmt.Sprintf("https://api.github.com/users/user%d/events", i) }
+
+	repo.keepPage(url(0), cachedPage{etag: `"0"`})
+	for i := 1; i <= maxCachedPages; i++ {
+		repo.keepPage(url(i), cachedPage{etag: fmt.Sprintf(`"%d"`, i)})
+		if i == maxCachedPages/2 {
+			// Revalidating the first page keeps it among the recently used
+			repo.cachedPage(url(0))
+		}
+	}
+
+	if len(repo.pages) != maxCachedPages {
+		t.Errorf("Kept %d pages, want %d", len(repo.pages), maxCachedPages)
+	}
+	if _, ok := repo.pages[url(0)]; !ok {
+		t.Error("Recently used page was evicted")
+	}
+	if _, ok := repo.pages[url(1)]; ok {
+		t.Error("Least recently used page was kept")
+	}
+
+	repo.SetToken("other")
+	if len(repo.pages) != 0 {
+		t.Errorf("Kept %d pages after the token changed, want none", len(repo.pages))
+	}
+}
+
+func TestGitHubAPIRepository_SetFeed(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		_, _ = w.Write([]byte(`[{"id":"1","type":"WatchEvent"}]`))
+	}))
+	defer server.Close()
+
+	repo := NewGitHubAPIRepository()
+	repo.baseURL = server.URL
+
+	if _, err := repo.FetchEvents("testuser"); err != nil {
+		t.Fatalf("FetchEvents() error = %v", err)
+	}
+	repo.SetFeed(FeedReceived)
+	if _, err := repo.FetchEvents("testuser"); err != nil {
+		t.Fatalf("FetchEvents() error = %v", err)
+	}
+
+	// Switching feeds must not serve the other feed's cached events
+	expected := []string{"/users/testuser/events", "/users/testuser/received_events"}
+	if !reflect.DeepEqual(paths, expected) {
+		t.Errorf("Requested %v, want %v", paths, expected)
+	}
+}
+
+func TestGitHubAPIRepository_AuthenticatedUser(t *testing.T) {
+	tests := []struct {
+		name        string
+		token       string
+		expected    string
+		expectError bool
+	}{
+		{"valid token", "secret", "octocat", false},
+		{"rejected token", "wrong", "", true},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/user" || r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_, _ = w.Write([]byte(`{"login":"octocat"}`))
+	}))
+	defer server.Close()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewGitHubAPIRepository()
+			repo.baseURL = server.URL
+			repo.SetToken(tt.token)
+
+			login, err := repo.AuthenticatedUser()
+			if tt.expectError {
+				if err == nil {
+					t.Error("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("AuthenticatedUser() error = %v", err)
+			}
+			if login != tt.expected {
+				t.Errorf("AuthenticatedUser() = %q, want %q", login, tt.expected)
+			}
+		})
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	tests := []struct {
+		retry int
+		min   time.Duration
+		max   time.Duration
+	}{
+		{1, 50 * time.Millisecond, 100 * time.Millisecond},
+		{2, 100 * time.Millisecond, 200 * time.Millisecond},
+		{3, 200 * time.Millisecond, 400 * time.Millisecond},
+		{10, 500 * time.Millisecond, time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("retry %d", tt.retry), func(t *testing.T) {
+			delay := policy.Backoff(tt.retry)
+			if delay < tt.min || delay > tt.max {
+				t.Errorf("Backoff(%d) = %v, want between %v and %v", tt.retry, delay, tt.min, tt.max)
+			}
+		})
+	}
+}
+
+func TestGitHubAPIRepository_Retry(t *testing.T) {
+	newRepository := func(url string, retries int) *GitHubAPIRepository {
+		repo := NewGitHubAPIRepository()
+		repo.baseURL = url
+		repo.retry = RetryPolicy{
+			MaxRetries: retries,
+			BaseDelay:  time.Millisecond,
+			MaxDelay:   time.Millisecond,
+		}
+		return repo
+	}
+
+	t.Run("retries server errors until success", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) < 3 {
+				w.WriteHeader(http.StatusBadGateway)
+				return
+			}
+			_, _ = fmt.Fprint(w, `[{"id": "1", "type": "PushEvent"}]`)
+		}))
+		defer server.Close()
+
+		events, err := newRepository(server.URL, 2).FetchEvents("testuser")
+		if err != nil {
+			t.Fatalf("FetchEvents() error = %v", err)
+		}
+		if len(events) != 1 {
+			t.Errorf("Got %d events, want 1", len(events))
+		}
+		if calls != 3 {
+			t.Errorf("Server called %d times, want 3", calls)
+		}
+	})
+
+	t.Run("gives up after max retries", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}))
+		defer server.Close()
+
+		_, err := newRepository(server.URL, 1).FetchEvents("testuser")
+		if err == nil || !strings.Contains(err.Error(), "giving up after 1 retries") {
+			t.Errorf("FetchEvents() error = %v, want giving up error", err)
+		}
+		if calls != 2 {
+			t.Errorf("Server called %d times, want 2", calls)
+		}
+	})
+
+	t.Run("retries while the data is being prepared", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch atomic.AddInt32(&calls, 1) {
+			case 1:
+				w.WriteHeader(http.StatusAccepted)
+			case 2:
+				// 200 with nothing in it
+			default:
+				_, _ = fmt.Fprint(w, `[{"id": "1", "type": "PushEvent"}]`)
+			}
+		}))
+		defer server.Close()
+
+		events, err := newRepository(server.URL, 2).FetchEvents("testuser")
+		if err != nil || len(events) != 1 {
+			t.Fatalf("FetchEvents() = %d events, %v; want 1 once the data is ready", len(events), err)
+		}
+	})
+
+	t.Run("reports data still being prepared", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusAccepted)
+		}))
+		defer server.Close()
+
+		repo := newRepository(server.URL, 1)
+		if _, err := repo.FetchEvents("testuser"); !errors.Is(err, ErrDataPending) {
+			t.Errorf("FetchEvents() error = %v, want ErrDataPending", err)
+		}
+		if _, err := repo.FetchResource("/repos/octo/repo/stats/contributors"); !errors.Is(err, ErrDataPending) {
+			t.Errorf("FetchResource() error = %v, want ErrDataPending", err)
+		}
+	})
+
+	t.Run("does not retry client errors", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// The not found error then searches for suggestions once
+			if strings.HasPrefix(r.URL.Path, "/users/") {
+				atomic.AddInt32(&calls, 1)
+			}
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer server.Close()
+
+		_, err := newRepository(server.URL, 3).FetchEvents("ghost")
+		if err == nil {
+			t.Fatal("Expected error for missing user")
+		}
+		if calls != 1 {
+			t.Errorf("Server called %d times, want 1", calls)
+		}
+	})
+}
+
+func TestDiskCache(t *testing.T) {
+	cache := NewDiskCache(t.TempDir(), time.Hour)
+
+	if _, ok := cache.Get("/repos/user/repo"); ok {
+		t.Error("Empty cache should miss")
+	}
+
+	if err := cache.Set("/repos/user/repo", []byte(`{"language": "Go"}`)); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+
+	data, ok := cache.Get("/repos/user/repo")
+	if !ok {
+		t.Fatal("Expected cache hit after Set()")
+	}
+	if string(data) != `{"language": "Go"}` {
+		t.Errorf("Get() = %s", data)
+	}
+
+	clock := NewFakeClock(time.Now())
+	cache.SetClock(clock)
+	clock.Advance(time.Hour)
+	if _, ok := cache.Get("/repos/user/repo"); ok {
+		t.Error("Expired entry should miss")
+	}
+}
+
+func TestGitHubAPIRepository_FetchResource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/user/repo" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = fmt.Fprint(w, `{"language": "Go"}`)
+	}))
+	defer server.Close()
+
+	repo := NewGitHubAPIRepository()
+	repo.baseURL = server.URL
+
+	data, err := repo.FetchResource("/repos/user/repo")
+	if err != nil {
+		t.Fatalf("FetchResource() error = %v", err)
+	}
+	if string(data) != `{"language": "Go"}` {
+		t.Errorf("FetchResource() = %s", data)
+	}
+
+	if _, err := repo.FetchResource("/repos/user/missing"); err == nil {
+		t.Error("Expected error for missing resource")
+	}
+}
+
+func TestParseRateLimit(t *testing.T) {
+	t.Run("headers present", func(t *testing.T) {
+		header := http.Header{}
+		header.Set("X-RateLimit-Limit", "60")
+		header.Set("X-RateLimit-Remaining", "0")
+		header.Set("X-RateLimit-Reset", "1705314600")
+
+		rateLimit, ok := parseRateLimit(header)
+		if !ok {
+			t.Fatal("Expected rate limit to be parsed")
+		}
+		if rateLimit.Limit != 60 || rateLimit.Remaining != 0 {
+			t.Errorf("RateLimit = %+v", rateLimit)
+		}
+		if !rateLimit.Reset.Equal(time.Unix(1705314600, 0)) {
+			t.Errorf("Reset = %v", rateLimit.Reset)
+		}
+	})
+
+	t.Run("headers absent", func(t *testing.T) {
+		if _, ok := parseRateLimit(http.Header{}); ok {
+			t.Error("Expected no rate limit without headers")
+		}
+	})
+}
+
+func TestGitHubAPIRepository_RateLimit(t *testing.T) {
+	reset := time.Now().Add(30 * time.Minute).Truncate(time.Second)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "60")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", fmt.Sprint(reset.Unix()))
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	repo := NewGitHubAPIRepository()
+	repo.baseURL = server.URL
+
+	_, err := repo.FetchEvents("testuser")
+
+	var repoErr *RepositoryError
+	if !errors.As(err, &repoErr) {
+		t.Fatalf("FetchEvents() error = %v, want RepositoryError", err)
+	}
+	if repoErr.Code != "RATE_LIMIT" {
+		t.Errorf("Code = %v, want RATE_LIMIT", repoErr.Code)
+	}
+	if repoErr.RateLimit == nil || !repoErr.RateLimit.Reset.Equal(reset) {
+		t.Errorf("RateLimit = %+v, want reset %v", repoErr.RateLimit, reset)
+	}
+	if !strings.Contains(repoErr.Message, "resets at "+reset.Format("15:04")) {
+		t.Errorf("Message = %q, want reset time", repoErr.Message)
+	}
+
+	rateLimit, ok := repo.LastRateLimit()
+	if !ok || rateLimit.Remaining != 0 {
+		t.Errorf("LastRateLimit() = %+v, %v", rateLimit, ok)
+	}
+}
+
+func TestParseLink(t *testing.T) {
+	header := `<https://api.github.com/user/1/events?page=2>; rel="next", ` +
+		`<https://api.github.com/user/1/events?page=10>; rel="last"`
+	tests := []struct {
+		name     string
+		link     string
+		rel      string
+		expected string
+	}{
+		{"next", header, "next", "https://api.github.com/user/1/events?page=2"},
+		{"last", header, "last", "https://api.github.com/user/1/events?page=10"},
+		{"last page", `<https://api.github.com/user/1/events?page=1>; rel="first"`, "next", ""},
+		{"no header", "", "next", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := parseLink(tt.link, tt.rel); result != tt.expected {
+				t.Errorf("parseLink(%q) = %v, want %v", tt.rel, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGitHubAPIRepository_Pagination(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page := r.URL.Query().Get("page")
+		if page == "" {
+			page = "1"
+		}
+		if page != "3" {
+			next := map[string]string{"1": "2", "2": "3"}[page]
+			w.Header().Set("Link", fmt.Sprintf(`<%s%s?page=%s>; rel="next"`, server.URL, r.URL.Path, next))
+		}
+		_, _ = fmt.Fprintf(w, `[{"id": "%s-a"}, {"id": "%s-b"}]`, page, page)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		maxPages int
+		expected int
+	}{
+		{"single page", 1, 2},
+		{"two pages", 2, 4},
+		{"every page", 0, 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := NewGitHubAPIRepository()
+			repo.baseURL = server.URL
+			repo.SetMaxPages(tt.maxPages)
+
+			events, err := repo.FetchEvents("testuser")
+			if err != nil {
+				t.Fatalf("FetchEvents() error = %v", err)
+			}
+			if len(events) != tt.expected {
+				t.Errorf("Got %d events, want %d", len(events), tt.expected)
+			}
+		})
+	}
+}
+
+func TestGitHubAPIRepository_ConcurrentPages(t *testing.T) {
+	const lastPage = 6
+	var inFlight, maxInFlight, failPage int32
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		current := atomic.AddInt32(&inFlight, 1)
+		defer atomic.AddInt32(&inFlight, -1)
+		for {
+			seen := atomic.LoadInt32(&maxInFlight)
+			if current <= seen || atomic.CompareAndSwapInt32(&maxInFlight, seen, current) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+		if int32(page) == atomic.LoadInt32(&failPage) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if page < lastPage {
+			w.Header().Set("Link", fmt.Sprintf(
+				`<%[1]s%[2]s?page=%[3]d>; rel="next", <%[1]s%[2]s?page=%[4]d>; rel="last"`,
+				server.URL, r.URL.Path, page+1, lastPage,
+			))
+		}
+		// Newer pages hold newer events
+		created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC).Add(-time.Duration(page) * time.Hour)
+		_, _ = fmt.Fprintf(w, `[{"id": "%d", "created_at": "%s"}]`, 100-page, created.Format(time.RFC3339))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		failPage int32
+		maxPages int
+		expected string
+		wantErr  bool
+	}{
+		{"every page", 0, 0, "99,98,97,96,95,94", false},
+		{"capped", 0, 3, "99,98,97", false},
+		{"failed page", 4, 0, "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			atomic.StoreInt32(&maxInFlight, 0)
+			atomic.StoreInt32(&failPage, tt.failPage)
+			repo := NewGitHubAPIRepository()
+			repo.baseURL = server.URL
+			repo.SetMaxPages(tt.maxPages)
+			repo.SetMaxRetries(0)
+
+			events, err := repo.fetchFromAPI(context.Background(), "testuser")
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("Expected error but got none")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("fetchFromAPI() error = %v", err)
+			}
+
+			ids := make([]string, len(events))
+			for i, event := range events {
+				ids[i] = event.ID
+			}
+			if got := strings.Join(ids, ","); got != tt.expected {
+				t.Errorf("events = %s, want %s", got, tt.expected)
+			}
+			if tt.maxPages == 0 && atomic.LoadInt32(&maxInFlight) < 2 {
+				t.Errorf("pages were fetched one at a time")
+			}
+		})
+	}
+}
+
+func TestGitHubAPIRepository_OverlappingPagesAndRefresh(t *testing.T) {
+	var server *httptest.Server
+	fetches := 0
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			// A new event shifted the feed, so page 2 repeats event 2
+			_, _ = fmt.Fprint(w, `[
+				{"id": "2", "created_at": "2024-01-01T11:00:00Z"},
+				{"id": "1", "created_at": "2024-01-01T10:00:00Z"}
+			]`)
+			return
+		}
+		fetches++
+		w.Header().Set("Link", fmt.Sprintf(`<%s%s?page=2>; rel="next"`, server.URL, r.URL.Path))
+		if fetches == 1 {
+			_, _ = fmt.Fprint(w, `[{"id": "3", "created_at": "2024-01-01T12:00:00Z"},
+				{"id": "2", "created_at": "2024-01-01T11:00:00Z"}]`)
+			return
+		}
+		_, _ = fmt.Fprint(w, `[{"id": "4", "created_at": "2024-01-01T13:00:00Z"}]`)
+	}))
+	defer server.Close()
+
+	repo := NewGitHubAPIRepository()
+	repo.baseURL = server.URL
+	repo.SetMaxPages(0)
+
+	ids := func(events []GitHubEvent) string {
+		parts := make([]string, len(events))
+		for i, event := range events {
+			parts[i] = event.ID
+		}
+		return strings.Join(parts, ",")
+	}
+
+	events, err := repo.FetchEvents("testuser")
+	if err != nil {
+		t.Fatalf("FetchEvents() error = %v", err)
+	}
+	if got := ids(events); got != "3,2,1" {
+		t.Errorf("first fetch = %s, want 3,2,1", got)
+	}
+
+	// A refresh keeps previously fetched events without duplicating them
+	repo.cache.Expire()
+	events, err = repo.FetchEvents("testuser")
+	if err != nil {
+		t.Fatalf("FetchEvents() error = %v", err)
+	}
+	if got := ids(events); got != "4,3,2,1" {
+		t.Errorf("refresh = %s, want 4,3,2,1", got)
+	}
+}
+
+func TestGitHubAPIRepository_SetLogger(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "60")
+		w.Header().Set("X-RateLimit-Remaining", "59")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	repo := NewGitHubAPIRepository()
+	repo.baseURL = server.URL
+	repo.SetLogger(NewTextLogger(&buf))
+
+	for i := 0; i < 2; i++ {
+		if _, err := repo.FetchEvents("octocat"); err != nil {
+			t.Fatalf("FetchEvents() error = %v", err)
+		}
+	}
+
+	for _, want := range []string{
+		`msg="cache miss" user=octocat`,
+		"msg=response url=" + server.URL + "/users/octocat/events status=200",
+		"rate_remaining=59 rate_limit=60",
+		`msg="cache hit" user=octocat events=0`,
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("Log missing %q:\n%s", want, buf.String())
+		}
+	}
+
+	repo.SetLogger(nil)
+	repo.Refresh()
+	if _, err := repo.FetchEvents("octocat"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// roundTripFunc adapts a function to http.RoundTripper
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestNewGitHubAPIRepository_Options(t *testing.T) {
+	var requested *http.Request
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requested = req
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     make(http.Header),
+			Body:       io.NopCloser(strings.NewReader(`[{"id":"1","type":"WatchEvent"}]`)),
+		}, nil
+	})
+
+	repo := NewGitHubAPIRepository(
+		WithHTTPClient(&http.Client{Transport: transport}),
+		WithTimeout(3*time.Second),
+		WithUserAgent("embedder/1.0"),
+		WithBaseURL("https://github.example.com/api/v3/"),
+	)
+	events, err := repo.FetchEvents("octocat")
+	if err != nil {
+		t.Fatalf("FetchEvents() error = %v", err)
+	}
+	if len(events) != 1 {
+		t.Errorf("Got %d events through the injected transport, want 1", len(events))
+	}
+	if requested.URL.String() != "https://github.example.com/api/v3/users/octocat/events" {
+		t.Errorf("Requested %s", requested.URL)
+	}
+	if requested.Header.Get("User-Agent") != "embedder/1.0" {
+		t.Errorf("User-Agent = %q", requested.Header.Get("User-Agent"))
+	}
+	if repo.client.Timeout != 3*time.Second {
+		t.Errorf("Timeout = %v, want 3s", repo.client.Timeout)
+	}
+
+	// The default client is not shared between repositories
+	a, b := NewGitHubAPIRepository(WithTimeout(time.Second)), NewGitHubAPIRepository()
+	if a.client == b.client || b.client.Timeout != 10*time.Second {
+		t.Error("WithTimeout changed another repository's client")
+	}
+}
+
+func TestGitHubAPIRepository_SetPerPage(t *testing.T) {
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		perPage  int
+		expected string
+	}{
+		{0, ""},
+		{5, "per_page=5"},
+		{500, "per_page=100"},
+	}
+
+	for _, tt := range tests {
+		t.Run(strconv.Itoa(tt.perPage), func(t *testing.T) {
+			repo := NewGitHubAPIRepository(WithBaseURL(server.URL))
+			repo.SetPerPage(tt.perPage)
+			if _, err := repo.FetchEvents("octocat"); err != nil {
+				t.Fatalf("FetchEvents() error = %v", err)
+			}
+			if query != tt.expected {
+				t.Errorf("query = %q, want %q", query, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGitHubAPIRepository_FetchEventPages(t *testing.T) {
+	var requests int
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+		if page < 3 {
+			w.Header().Set("Link", fmt.Sprintf(`<%s%s?page=%d>; rel="next"`, server.URL, r.URL.Path, page+1))
+		}
+		// Page 2 repeats the last event of page 1, as when a new event shifts the feed
+		bodies := []string{`[{"id": "6"}, {"id": "5"}]`, `[{"id": "5"}, {"id": "4"}]`, `[{"id": "3"}]`}
+		_, _ = w.Write([]byte(bodies[page-1]))
+	}))
+	defer server.Close()
+
+	repo := NewGitHubAPIRepository(WithBaseURL(server.URL))
+	repo.SetMaxPages(0)
+
+	var ids []string
+	err := repo.FetchEventPages(context.Background(), "octocat", func(events []GitHubEvent) error {
+		for _, event := range events {
+			ids = append(ids, event.ID)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("FetchEventPages() error = %v", err)
+	}
+	if got := strings.Join(ids, ","); got != "6,5,4,3" {
+		t.Errorf("Got events %s, want 6,5,4,3", got)
+	}
+
+	// The whole feed was read, so it is cached
+	requests = 0
+	if _, err := repo.FetchEvents("octocat"); err != nil || requests != 0 {
+		t.Errorf("Expected a cache hit, got %v after %d requests", err, requests)
+	}
+
+	stop := errors.New("stop")
+	repo.Refresh()
+	err = repo.FetchEventPages(context.Background(), "octocat", func([]GitHubEvent) error { return stop })
+	if !errors.Is(err, stop) || requests != 1 {
+		t.Errorf("Expected the callback error after one request, got %v after %d", err, requests)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := repo.FetchEventPages(ctx, "octocat", func([]GitHubEvent) error { return nil }); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled, got %v", err)
+	}
+}
+
+func TestGitHubAPIRepository_FeedCap(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page >= 2 {
+			// GitHub refuses pages past the events it exposes
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			_, _ = w.Write([]byte(`{"message": "In order to keep the API fast for everyone, pagination is limited for this resource."}`))
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<%s%s?page=2>; rel="next"`, server.URL, r.URL.Path))
+		_, _ = w.Write([]byte(`[{"id": "2"}, {"id": "1"}]`))
+	}))
+	defer server.Close()
+
+	repo := NewGitHubAPIRepository(WithBaseURL(server.URL))
+	repo.SetMaxPages(0)
+
+	events, err := repo.FetchEvents("octocat")
+	if err != nil || len(events) != 2 {
+		t.Errorf("FetchEvents() = %d events, %v; want the 2 events before the cap", len(events), err)
+	}
+
+	repo.Refresh()
+	count := 0
+	err = repo.FetchEventPages(context.Background(), "octocat", func(page []GitHubEvent) error {
+		count += len(page)
+		return nil
+	})
+	if err != nil || count != 2 {
+		t.Errorf("FetchEventPages() = %d events, %v; want the 2 events before the cap", count, err)
+	}
+}
+
+func TestGitHubAPIRepository_PartialFetch(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<%s%s?page=2>; rel="next"`, server.URL, r.URL.Path))
+		_, _ = w.Write([]byte(`[{"id": "2"}, {"id": "1"}]`))
+	}))
+	defer server.Close()
+
+	repo := NewGitHubAPIRepository(WithBaseURL(server.URL))
+	repo.SetMaxPages(0)
+
+	events, err := repo.FetchEvents("octocat")
+	var partial *PartialFetchError
+	if !errors.As(err, &partial) || partial.Page != 2 {
+		t.Fatalf("FetchEvents() error = %v, want a PartialFetchError for page 2", err)
+	}
+	if len(events) != 2 {
+		t.Errorf("FetchEvents() = %d events, want the 2 of the first page", len(events))
+	}
+	if repo.cache.IsValid("octocat") {
+		t.Error("A partial feed should not be cached")
+	}
+
+	count := 0
+	err = repo.FetchEventPages(context.Background(), "octocat", func(page []GitHubEvent) error {
+		count += len(page)
+		return nil
+	})
+	if !errors.As(err, &partial) || count != 2 {
+		t.Errorf("FetchEventPages() = %d events, %v; want the first page and a PartialFetchError", count, err)
+	}
+}
+
+func TestDecodeEvents(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantIDs     []string
+		wantSkipped []int
+		wantErr     bool
+	}{
+		{"well formed", `[{"id": "2"}, {"id": "1"}]`, []string{"2", "1"}, nil, false},
+		{"bad entries skipped", `[{"id": "3"}, {"id": 2}, "oops", {"id": "1", "created_at": "soon"}, {"id": "0"}]`,
+			[]string{"3", "0"}, []int{1, 2, 3}, false},
+		{"empty", `[]`, nil, nil, false},
+		{"null", `null`, nil, nil, false},
+		{"no body", ``, nil, nil, true},
+		{"not an array", `{"message": "Server Error"}`, nil, nil, true},
+		{"truncated", `[{"id": "1"}, {"id": `, nil, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			events, skipped, err := decodeEvents(strings.NewReader(tt.body))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("decodeEvents() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var ids []string
+			for _, event := range events {
+				ids = append(ids, event.ID)
+			}
+			var indexes []int
+			for _, skip := range skipped {
+				indexes = append(indexes, skip.Index)
+			}
+			if !reflect.DeepEqual(ids, tt.wantIDs) || !reflect.DeepEqual(indexes, tt.wantSkipped) {
+				t.Errorf("decodeEvents() = %v, skipped %v; want %v, skipped %v", ids, indexes, tt.wantIDs, tt.wantSkipped)
+			}
+		})
+	}
+}
+
+func TestGitHubAPIRepository_SkipsMalformedEvents(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"id": "2", "type": "WatchEvent"}, {"id": "1", "actor": "ghost"}]`))
+	}))
+	defer server.Close()
+
+	logger := &recordingLogger{}
+	repo := NewGitHubAPIRepository(WithBaseURL(server.URL), WithRepositoryLogger(logger))
+	repo.SetMaxPages(1)
+
+	events, err := repo.FetchEvents("octocat")
+	if err != nil {
+		t.Fatalf("FetchEvents() error = %v", err)
+	}
+	if len(events) != 1 || events[0].ID != "2" {
+		t.Errorf("FetchEvents() = %+v, want only event 2", events)
+	}
+	if !strings.Contains(strings.Join(logger.events, ","), "warn "+LogSkippedEvent) {
+		t.Errorf("Logged %v, want a skipped event warning", logger.events)
+	}
+}
+
+func TestGitHubAPIRepository_GzipResponses(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			t.Errorf("Accept-Encoding = %q, want gzip", r.Header.Get("Accept-Encoding"))
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		if strings.HasPrefix(r.URL.Path, "/users/") {
+			_, _ = zw.Write([]byte(`[{"id": "2"}, {"id": "1"}]`))
+		} else {
+			_, _ = zw.Write([]byte(`{"full_name": "octo/repo"}`))
+		}
+		_ = zw.Close()
+	}))
+	defer server.Close()
+
+	var recorded []RecordedResponse
+	repo := NewGitHubAPIRepository(WithBaseURL(server.URL))
+	repo.SetMaxPages(1)
+
+	events, err := repo.FetchEvents("octocat")
+	if err != nil || len(events) != 2 {
+		t.Fatalf("FetchEvents() = %d events, %v; want 2", len(events), err)
+	}
+
+	repo.SetRecorder(func(response RecordedResponse) { recorded = append(recorded, response) })
+	body, err := repo.FetchResource("/repos/octo/repo")
+	if err != nil || string(body) != `{"full_name": "octo/repo"}` {
+		t.Fatalf("FetchResource() = %q, %v; want the decompressed body", body, err)
+	}
+	if len(recorded) != 1 || recorded[0].Header.Get("Content-Encoding") != "" {
+		t.Errorf("Recorded %+v, want the decompressed response without Content-Encoding", recorded)
+	}
+}
+
+func TestGitHubAPIRepository_TruncatedPage(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Length", "100")
+		_, _ = w.Write([]byte(`[{"id": "1"}`))
+	}))
+	defer server.Close()
+
+	repo := NewGitHubAPIRepository(WithBaseURL(server.URL))
+	repo.SetMaxPages(1)
+	repo.SetMaxRetries(0)
+
+	_, err := repo.FetchEvents("octocat")
+	var repoErr *RepositoryError
+	if !errors.As(err, &repoErr) || repoErr.Code != ErrNetworkError.Code {
+		t.Errorf("FetchEvents() error = %v, want a network error for the cut off body", err)
+	}
+}
+
+// benchmarkPage is a full page of push events as the API returns it
+func benchmarkPage() []byte {
+	var page bytes.Buffer
+	page.WriteString("[")
+	for i := range 100 {
+		if i > 0 {
+			page.WriteString(",")
+		}
+		fmt.Fprintf(&page, `{"id": "%d", "type": "PushEvent", "actor": {"login": "octocat"},
+			"repo": {"name": "octo/repo"}, "created_at": "2024-01-15T10:30:00Z",
+			"payload": {"ref": "refs/heads/main", "size": 2, "commits": [
+				{"sha": "abc1234", "message": "Fix the build"}, {"sha": "def5678", "message": "Add tests"}]}}`, 1000-i)
+	}
+	page.WriteString("]")
+	return page.Bytes()
+}
+
+func BenchmarkDecodeEvents(b *testing.B) {
+	page := benchmarkPage()
+	b.SetBytes(int64(len(page)))
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, _, err := decodeEvents(bytes.NewReader(page)); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+	tests := []struct {
+		value  string
+		want   time.Duration
+		wantOK bool
+	}{
+		{"", 0, false},
+		{"30", 30 * time.Second, true},
+		{"0", 0, true},
+		{"-5", 0, true},
+		{now.Add(90 * time.Second).Format(http.TimeFormat), 90 * time.Second, true},
+		{now.Add(-time.Minute).Format(http.TimeFormat), 0, true},
+		{"soon", 0, false},
+	}
+	for _, tt := range tests {
+		header := http.Header{}
+		if tt.value != "" {
+			header.Set("Retry-After", tt.value)
+		}
+		got, ok := parseRetryAfter(header, now)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("parseRetryAfter(%q) = %v, %v; want %v, %v", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
+
+func TestGitHubAPIRepository_SecondaryRateLimit(t *testing.T) {
+	t.Run("waits and retries", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				w.Header().Set("Retry-After", "20")
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			_, _ = w.Write([]byte(`[{"id": "1"}]`))
+		}))
+		defer server.Close()
+
+		progress := &recordingProgress{}
+		start := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+		clock := NewFakeClock(start)
+		repo := NewGitHubAPIRepository(WithBaseURL(server.URL), WithRepositoryClock(clock))
+		repo.SetProgress(progress)
+
+		events, err := repo.FetchEvents("octocat")
+		if err != nil || len(events) != 1 {
+			t.Fatalf("FetchEvents() = %d events, %v; want 1 after the pause", len(events), err)
+		}
+		if slept := clock.Now().Sub(start); slept != 20*time.Second {
+			t.Errorf("Slept %v, want the 20s GitHub asked for", slept)
+		}
+		if !strings.Contains(strings.Join(progress.calls, ","), "wait 20s") {
+			t.Errorf("Progress = %v, want the wait reported", progress.calls)
+		}
+	})
+
+	t.Run("reports long pauses", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+			w.Header().Set("Retry-After", "300")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer server.Close()
+
+		start := time.Date(2024, 1, 15, 10, 30, 0, 0, time.UTC)
+		clock := NewFakeClock(start)
+		repo := NewGitHubAPIRepository(WithBaseURL(server.URL), WithRepositoryClock(clock))
+
+		_, err := repo.FetchEvents("octocat")
+		if !clock.Now().Equal(start) {
+			t.Error("A pause longer than RetryAfterLimit should not be waited out")
+		}
+		var repoErr *RepositoryError
+		if !errors.As(err, &repoErr) || !errors.Is(err, ErrRateLimitExceeded) || repoErr.RetryAfter != 5*time.Minute {
+			t.Fatalf("FetchEvents() error = %v, want RATE_LIMIT with a 5m pause", err)
+		}
+		if !strings.Contains(err.Error(), "secondary rate limit exceeded, retry in 5m0s") {
+			t.Errorf("Error = %q, want the exact pause", err)
+		}
+		if calls != 1 {
+			t.Errorf("Server called %d times, want 1", calls)
+		}
+	})
+}
+
+func TestGitHubAPIRepository_RetryWaitCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "30")
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	defer server.Close()
+
+	repo := NewGitHubAPIRepository(WithBaseURL(server.URL))
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := repo.FetchEventsContext(ctx, "octocat")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("FetchEventsContext() error = %v, want the context's deadline", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("Waited %v after the context was done", elapsed)
+	}
+}
@@ -0,0 +1,90 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	neturl "net/url"
+	"slices"
+	"strings"
+)
+
+// User Search - "Did you mean" suggestions for logins that do not exist
+
+// maxSuggestions is how many logins a not found error suggests
+const maxSuggestions = 3
+
+// searchCandidates is how many search results are ranked for suggestions
+const searchCandidates = 10
+
+// SuggestUsers returns up to three existing logins close to username, best
+// first. It is best effort: a search that fails, for instance on its own
+// tighter rate limit, suggests nothing.
+func (r *GitHubAPIRepository) SuggestUsers(ctx context.Context, username string) []string {
+	url := fmt.Sprintf("%s/search/users?q=%s&per_page=%d", r.baseURL, neturl.QueryEscape(username), searchCandidates)
+	resp, err := r.requestContext(ctx, "GET", url, "application/vnd.github.v3+json", "", nil)
+	if err != nil || resp.StatusCode != 200 {
+		return nil
+	}
+	var result struct {
+		Items []struct {
+			Login string `json:"login"`
+		} `json:"items"`
+	}
+	if err := json.Unmarshal(resp.Body, &result); err != nil {
+		return nil
+	}
+
+	var logins []string
+	for _, item := range result.Items {
+		if item.Login != "" && !strings.EqualFold(item.Login, username) {
+			logins = append(logins, item.Login)
+		}
+	}
+	// Closest spelling first; the search's own ranking breaks ties
+	target := strings.ToLower(username)
+	slices.SortStableFunc(logins, func(a, b string) int {
+		return editDistance(strings.ToLower(a), target) - editDistance(strings.ToLower(b), target)
+	})
+	return logins[:min(len(logins), maxSuggestions)]
+}
+
+// userNotFound is the USER_NOT_FOUND error for username, suggesting near
+// matches when the search API knows some
+func (r *GitHubAPIRepository) userNotFound(ctx context.Context, username string) *RepositoryError {
+	message := fmt.Sprintf("user '%s' not found", username)
+	if suggestions := r.SuggestUsers(ctx, username); len(suggestions) > 0 {
+		message += "; did you mean " + orList(suggestions) + "?"
+	}
+	return &RepositoryError{Code: ErrUserNotFound.Code, Message: message}
+}
+
+// orList joins items as "a", "a or b" or "a, b or c"
+func orList(items []string) string {
+	if len(items) < 2 {
+		return strings.Join(items, "")
+	}
+	return strings.Join(items[:len(items)-1], ", ") + " or " + items[len(items)-1]
+}
+
+// editDistance is the Levenshtein distance between a and b, in runes
+func editDistance(a, b string) int {
+	source, target := []rune(a), []rune(b)
+	previous := make([]int, len(target)+1)
+	current := make([]int, len(target)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(source); i++ {
+		current[0] = i
+		for j := 1; j <= len(target); j++ {
+			cost := 1
+			if source[i-1] == target[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(target)]
+}
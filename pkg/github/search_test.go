@@ -0,0 +1,93 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGitHubAPIRepository_UserNotFoundSuggestions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/search/users" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if got := r.URL.Query().Get("q"); got != "octocta" {
+			t.Errorf("q = %q, want octocta", got)
+		}
+		_, _ = w.Write([]byte(`{"items": [{"login": "octo-team"}, {"login": "Octocta"}, {"login": "octocat"},
+			{"login": "octocats"}, {"login": "octavia"}]}`))
+	}))
+	defer server.Close()
+
+	repo := NewGitHubAPIRepository(WithBaseURL(server.URL))
+	_, err := repo.FetchEvents("octocta")
+	if !errors.Is(err, ErrUserNotFound) {
+		t.Fatalf("FetchEvents() error = %v, want ErrUserNotFound", err)
+	}
+	// octo-team and octavia tie, so the search's order decides
+	want := "user 'octocta' not found; did you mean octocat, octocats or octo-team?"
+	if !strings.Contains(err.Error(), want) {
+		t.Errorf("Error = %q, want it to contain %q", err, want)
+	}
+}
+
+func TestGitHubAPIRepository_SuggestUsers(t *testing.T) {
+	t.Run("search unavailable", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer server.Close()
+
+		repo := NewGitHubAPIRepository(WithBaseURL(server.URL))
+		if got := repo.SuggestUsers(context.Background(), "ghost"); got != nil {
+			t.Errorf("SuggestUsers() = %v, want none", got)
+		}
+		if got := repo.userNotFound(context.Background(), "ghost").Message; got != "user 'ghost' not found" {
+			t.Errorf("Message = %q, want no suggestions", got)
+		}
+	})
+
+	t.Run("one match", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"items": [{"login": "torvalds"}]}`))
+		}))
+		defer server.Close()
+
+		repo := NewGitHubAPIRepository(WithBaseURL(server.URL))
+		if got := repo.SuggestUsers(context.Background(), "torvals"); !reflect.DeepEqual(got, []string{"torvalds"}) {
+			t.Errorf("SuggestUsers() = %v, want [torvalds]", got)
+		}
+	})
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"octocat", "octocat", 0},
+		{"octocta", "octocat", 2},
+		{"octocat", "octocats", 1},
+		{"", "abc", 3},
+		{"café", "cafe", 1},
+	}
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.want {
+			t.Errorf("editDistance(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestOrList(t *testing.T) {
+	for items, want := range map[string]string{"a": "a", "a,b": "a or b", "a,b,c": "a, b or c"} {
+		if got := orList(strings.Split(items, ",")); got != want {
+			t.Errorf("orList(%s) = %q, want %q", items, got, want)
+		}
+	}
+}
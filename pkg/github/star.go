@@ -0,0 +1,86 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Stars - Repositories a user has starred
+
+// StarRepository fetches the repositories a user has starred
+type StarRepository interface {
+	FetchStars(username string, limit int) ([]Star, error)
+}
+
+// Star is a starred repository and when it was starred
+type Star struct {
+	StarredAt time.Time   `json:"starred_at"`
+	Repo      StarredRepo `json:"repo"`
+}
+
+// StarredRepo is the subset of a starred repository shown by the stars
+// subcommand
+type StarredRepo struct {
+	FullName        string `json:"full_name"`
+	Description     string `json:"description"`
+	HTMLURL         string `json:"html_url"`
+	Language        string `json:"language"`
+	StargazersCount int    `json:"stargazers_count"`
+}
+
+// starMediaType makes /starred include when each repository was starred
+const starMediaType = "application/vnd.github.star+json"
+
+// FetchStars returns the repositories the user starred, most recent first,
+// following pagination until limit stars are read; limit 0 reads them all
+func (r *GitHubAPIRepository) FetchStars(username string, limit int) ([]Star, error) {
+	url := fmt.Sprintf("%s/users/%s/starred", r.baseURL, username)
+	if r.perPage > 0 {
+		url += "?per_page=" + strconv.Itoa(r.perPage)
+	}
+
+	var stars []Star
+	for url != "" && (limit <= 0 || len(stars) < limit) {
+		var page []Star
+		var next string
+		err := r.withRetry(context.Background(), func() error {
+			resp, err := r.getAs(url, starMediaType)
+			if err != nil {
+				return err
+			}
+			switch resp.StatusCode {
+			case 200:
+			case 404:
+				return r.userNotFound(context.Background(), username)
+			case 401:
+				return ErrUnauthorized
+			case 403:
+				return newRateLimitError(resp.Header, r.clock.Now())
+			default:
+				return statusError(resp.StatusCode)
+			}
+			if err := json.Unmarshal(resp.Body, &page); err != nil {
+				return &RepositoryError{
+					Code:    ErrInvalidResponse.Code,
+					Message: ErrInvalidResponse.Message,
+					Err:     err,
+				}
+			}
+			next = parseLink(resp.Header.Get("Link"), "next")
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		stars = append(stars, page...)
+		url = next
+	}
+
+	if limit > 0 && len(stars) > limit {
+		stars = stars[:limit]
+	}
+	return stars, nil
+}
@@ -0,0 +1,70 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestGitHubAPIRepository_FetchStars(t *testing.T) {
+	var requests int
+	var accept string
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/users/ghost/starred" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		requests++
+		accept = r.Header.Get("Accept")
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 0 {
+			page = 1
+		}
+		if page < 3 {
+			w.Header().Set("Link", fmt.Sprintf(`<%s%s?per_page=2&page=%d>; rel="next"`, server.URL, r.URL.Path, page+1))
+		}
+		_, _ = fmt.Fprintf(w, `[
+			{"starred_at": "2024-01-0%dT00:00:00Z", "repo": {"full_name": "o/r%da", "language": "Go", "stargazers_count": 7}},
+			{"starred_at": "2024-01-0%dT00:00:00Z", "repo": {"full_name": "o/r%db"}}
+		]`, page, page, page, page)
+	}))
+	defer server.Close()
+
+	repo := NewGitHubAPIRepository(WithBaseURL(server.URL))
+	repo.SetPerPage(2)
+
+	stars, err := repo.FetchStars("octocat", 3)
+	if err != nil {
+		t.Fatalf("FetchStars() error = %v", err)
+	}
+	if len(stars) != 3 || requests != 2 {
+		t.Errorf("Got %d stars in %d requests, want 3 in 2", len(stars), requests)
+	}
+	if accept != starMediaType {
+		t.Errorf("Accept = %q, want %q", accept, starMediaType)
+	}
+	first := stars[0]
+	if first.Repo.FullName != "o/r1a" || first.Repo.Language != "Go" || first.Repo.StargazersCount != 7 {
+		t.Errorf("First star = %+v", first)
+	}
+	if first.StarredAt.Day() != 1 {
+		t.Errorf("StarredAt = %v, want January 1st", first.StarredAt)
+	}
+
+	requests = 0
+	all, err := repo.FetchStars("octocat", 0)
+	if err != nil {
+		t.Fatalf("FetchStars() error = %v", err)
+	}
+	if len(all) != 6 || requests != 3 {
+		t.Errorf("Without a limit got %d stars in %d requests, want 6 in 3", len(all), requests)
+	}
+
+	if _, err := repo.FetchStars("ghost", 0); !errors.Is(err, ErrUserNotFound) {
+		t.Errorf("FetchStars(ghost) error = %v, want ErrUserNotFound", err)
+	}
+}
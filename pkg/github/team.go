@@ -0,0 +1,61 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Teams - Members of an organization's team
+
+// TeamRepository lists the members of an organization's team, which
+// requires a token that can see the team
+type TeamRepository interface {
+	FetchTeamMembers(org, team string) ([]string, error)
+}
+
+// FetchTeamMembers returns the logins of every member of the team with the
+// given slug, following pagination
+func (r *GitHubAPIRepository) FetchTeamMembers(org, team string) ([]string, error) {
+	url := fmt.Sprintf("%s/orgs/%s/teams/%s/members?per_page=%d", r.baseURL, org, team, MaxPageSize)
+
+	var logins []string
+	for url != "" {
+		var page []Actor
+		var next string
+		err := r.withRetry(context.Background(), func() error {
+			resp, err := r.get(url)
+			if err != nil {
+				return err
+			}
+			switch resp.StatusCode {
+			case 200:
+			case 404:
+				return fmt.Errorf("team '%s/%s' not found: %w", org, team, statusError(resp.StatusCode))
+			case 401:
+				return ErrUnauthorized
+			case 403:
+				return newRateLimitError(resp.Header, r.clock.Now())
+			default:
+				return statusError(resp.StatusCode)
+			}
+			if err := json.Unmarshal(resp.Body, &page); err != nil {
+				return &RepositoryError{
+					Code:    ErrInvalidResponse.Code,
+					Message: ErrInvalidResponse.Message,
+					Err:     err,
+				}
+			}
+			next = parseLink(resp.Header.Get("Link"), "next")
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, member := range page {
+			logins = append(logins, member.Login)
+		}
+		url = next
+	}
+	return logins, nil
+}
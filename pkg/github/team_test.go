@@ -0,0 +1,40 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestGitHubAPIRepository_FetchTeamMembers(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/orgs/octo/teams/backend/members":
+			if r.URL.Query().Get("page") == "" {
+				w.Header().Set("Link", fmt.Sprintf(`<%s%s?page=2>; rel="next"`, server.URL, r.URL.Path))
+				_, _ = w.Write([]byte(`[{"login": "alice"}, {"login": "bob"}]`))
+				return
+			}
+			_, _ = w.Write([]byte(`[{"login": "carol"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	repo := NewGitHubAPIRepository(WithBaseURL(server.URL))
+	members, err := repo.FetchTeamMembers("octo", "backend")
+	if err != nil {
+		t.Fatalf("FetchTeamMembers() error = %v", err)
+	}
+	if !reflect.DeepEqual(members, []string{"alice", "bob", "carol"}) {
+		t.Errorf("FetchTeamMembers() = %v, want alice, bob and carol", members)
+	}
+
+	if _, err := repo.FetchTeamMembers("octo", "ghosts"); err == nil {
+		t.Error("FetchTeamMembers() of a missing team should fail")
+	}
+}
@@ -0,0 +1,123 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// Tracing - Spans for embedders to route into OpenTelemetry or another tracer
+
+// Tracer starts spans around fetches, cache lookups, HTTP requests and
+// formatting. It is the part of OpenTelemetry's trace.Tracer this module
+// needs, so the module does not depend on OpenTelemetry and an adapter of a
+// few lines connects the two.
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Span is one traced operation
+type Span interface {
+	// SetAttributes records alternating keys and values, as Logger takes them
+	SetAttributes(attrs ...any)
+	RecordError(err error)
+	End()
+}
+
+// Spans the repository, service and formatters start
+const (
+	SpanFetchEvents = "github.fetch_events" // user, events
+	SpanCacheLookup = "github.cache_lookup" // user, cache.hit
+	SpanHTTPRequest = "github.http_request" // http.method, http.url, http.status_code
+	SpanFetch       = "activity.fetch"      // user, events, matching the service's query
+	SpanFormat      = "format.activities"   // format, activities
+)
+
+// nopTracer starts spans that record nothing
+type nopTracer struct{}
+
+func (nopTracer) Start(ctx context.Context, _ string) (context.Context, Span) {
+	return ctx, nopSpan{}
+}
+
+// nopSpan records nothing
+type nopSpan struct{}
+
+func (nopSpan) SetAttributes(...any) {}
+func (nopSpan) RecordError(error)    {}
+func (nopSpan) End()                 {}
+
+// NopTracer returns the default Tracer, which records nothing
+func NopTracer() Tracer {
+	return nopTracer{}
+}
+
+// RecordingTracer keeps every ended span in memory, for tests of code that
+// takes a Tracer
+type RecordingTracer struct {
+	mu    sync.Mutex
+	spans []RecordedSpan
+}
+
+// RecordedSpan is a span ended under a RecordingTracer
+type RecordedSpan struct {
+	Name       string
+	Parent     string // Name of the span started from, or ""
+	Attributes map[string]any
+	Err        error // Last error recorded
+}
+
+// recordingParent is the context key holding the current span's name
+type recordingParent struct{}
+
+// recordingSpan is a span being recorded
+type recordingSpan struct {
+	tracer *RecordingTracer
+	span   RecordedSpan
+}
+
+// NewRecordingTracer returns a tracer recording spans in memory
+func NewRecordingTracer() *RecordingTracer {
+	return &RecordingTracer{}
+}
+
+// Start begins a span whose parent is the span ctx was returned with
+func (t *RecordingTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	parent, _ := ctx.Value(recordingParent{}).(string)
+	span := &recordingSpan{
+		tracer: t,
+		span:   RecordedSpan{Name: name, Parent: parent, Attributes: make(map[string]any)},
+	}
+	return context.WithValue(ctx, recordingParent{}, name), span
+}
+
+// Spans returns the ended spans in the order they ended
+func (t *RecordingTracer) Spans() []RecordedSpan {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return append([]RecordedSpan(nil), t.spans...)
+}
+
+func (s *recordingSpan) SetAttributes(attrs ...any) {
+	for i := 0; i+1 < len(attrs); i += 2 {
+		s.span.Attributes[fmt.Sprint(attrs[i])] = attrs[i+1]
+	}
+}
+
+func (s *recordingSpan) RecordError(err error) {
+	s.span.Err = err
+}
+
+func (s *recordingSpan) End() {
+	s.tracer.mu.Lock()
+	defer s.tracer.mu.Unlock()
+	s.tracer.spans = append(s.tracer.spans, s.span)
+}
+
+// endSpan records err, if any, and ends span
+func endSpan(span Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+	}
+	span.End()
+}
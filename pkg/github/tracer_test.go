@@ -0,0 +1,84 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestGitHubAPIRepository_Tracing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[{"id": "1"}]`))
+	}))
+	defer server.Close()
+
+	tracer := NewRecordingTracer()
+	repo := NewGitHubAPIRepository(WithBaseURL(server.URL), WithRepositoryTracer(tracer))
+	repo.SetMaxPages(1)
+
+	for range 2 {
+		if _, err := repo.FetchEvents("octocat"); err != nil {
+			t.Fatalf("FetchEvents() error = %v", err)
+		}
+	}
+
+	spans := tracer.Spans()
+	want := []struct{ name, parent string }{
+		{SpanCacheLookup, SpanFetchEvents},
+		{SpanHTTPRequest, SpanFetchEvents},
+		{SpanFetchEvents, ""},
+		{SpanCacheLookup, SpanFetchEvents},
+		{SpanFetchEvents, ""},
+	}
+	if len(spans) != len(want) {
+		t.Fatalf("Recorded %d spans, want %d: %+v", len(spans), len(want), spans)
+	}
+	for i, w := range want {
+		if spans[i].Name != w.name || spans[i].Parent != w.parent {
+			t.Errorf("Span %d = %s under %q, want %s under %q", i, spans[i].Name, spans[i].Parent, w.name, w.parent)
+		}
+	}
+	if got := spans[0].Attributes["cache.hit"]; got != false {
+		t.Errorf("First lookup cache.hit = %v, want false", got)
+	}
+	if got := spans[3].Attributes["cache.hit"]; got != true {
+		t.Errorf("Second lookup cache.hit = %v, want true", got)
+	}
+	if got := spans[1].Attributes["http.status_code"]; got != http.StatusOK {
+		t.Errorf("http.status_code = %v, want 200", got)
+	}
+	if got := spans[2].Attributes["events"]; got != 1 {
+		t.Errorf("events = %v, want 1", got)
+	}
+}
+
+func TestGitHubAPIRepository_TracingRecordsErrors(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	tracer := NewRecordingTracer()
+	repo := NewGitHubAPIRepository(WithBaseURL(server.URL), WithRepositoryTracer(tracer))
+
+	_, err := repo.FetchEvents("ghost")
+	if err == nil {
+		t.Fatal("FetchEvents() succeeded, want an error")
+	}
+	spans := tracer.Spans()
+	last := spans[len(spans)-1]
+	if last.Name != SpanFetchEvents || !errors.Is(last.Err, err) {
+		t.Errorf("Last span = %s with %v, want %s with %v", last.Name, last.Err, SpanFetchEvents, err)
+	}
+}
+
+func TestNopTracer(t *testing.T) {
+	ctx := context.Background()
+	got, span := NopTracer().Start(ctx, SpanFetch)
+	span.SetAttributes("user", "octocat")
+	span.RecordError(errors.New("ignored"))
+	span.End()
+	if got != ctx {
+		t.Error("NopTracer should return the context unchanged")
+	}
+}